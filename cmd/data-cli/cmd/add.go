@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	dataadd "github.com/xbpk3t/docs-alfred/internal/data/add"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newAddCmd(dataPath *string) *cobra.Command {
+	var (
+		file, lookup, id string
+		name, author     string
+		readAt, publish  string
+		url, des         string
+		score            int
+		tags, sets       []string
+		dryRun           bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "add <domain>",
+		Short: "Append an item to a data domain file (books, movie, tv, music, diary, ntl)",
+		Long: `Append a correctly shaped item to a file of a data domain.
+
+The file is --file under the domain directory; movie and tv default to
+movie.yml and tv.yml. Its rule scope decides which fields are allowed. Before
+anything is written, the domain checker runs on the new item and duplicate
+detection on the directory; errors or duplicates reject the item.
+
+--lookup reads a local TSV or CSV dump (an IMDb title.basics file, an ISBN
+export, ...) and fills the fields not given on the command line from the row
+matching --id or, without it, --name.`,
+		Example: `  data-cli add books --file history --name 万历十五年 --author 黄仁宇 --score 5 --read-at 2026-10-01
+  data-cli add movie --id tt0111161 --lookup title.basics.tsv --score 5 --dry-run`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			var fields []dataadd.Field
+			addField := func(key, value string) {
+				if value != "" {
+					fields = append(fields, dataadd.Field{Key: key, Value: value})
+				}
+			}
+			addField("name", name)
+			addField("author", author)
+			if cmd.Flags().Changed("score") {
+				addField("score", strconv.Itoa(score))
+			}
+			addField("readAt", readAt)
+			addField("publishAt", publish)
+			addField("tags", strings.Join(tags, ","))
+			addField("url", url)
+			addField("des", des)
+			for _, set := range sets {
+				key, value, ok := strings.Cut(set, "=")
+				if !ok || strings.TrimSpace(key) == "" {
+					return errors.New("--set expects key=value, got " + strconv.Quote(set))
+				}
+				addField(strings.TrimSpace(key), value)
+			}
+
+			result, err := dataops.RunAdd(dataops.AddInput{
+				Domain: domain,
+				Path:   *dataPath,
+				File:   file,
+				Lookup: lookup,
+				ID:     id,
+				Fields: fields,
+				DryRun: dryRun,
+			})
+			if err != nil && !errors.Is(err, dataadd.ErrRejected) {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				if werr := output.WriteJSON(result); werr != nil {
+					return werr
+				}
+			} else if werr := writeOutput(dataadd.Report(result)); werr != nil {
+				return werr
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "File under the domain directory to append to (.yml may be omitted)")
+	cmd.Flags().StringVar(&name, "name", "", "Item name")
+	cmd.Flags().StringVar(&author, "author", "", "Item author")
+	cmd.Flags().IntVar(&score, "score", 0, "Score from 0 to 5")
+	cmd.Flags().StringVar(&readAt, "read-at", "", "Date finished (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&publish, "publish-at", "", "Publish year or date")
+	cmd.Flags().StringSliceVar(&tags, "tags", nil, "Comma-separated tags")
+	cmd.Flags().StringVar(&url, "url", "", "Item URL")
+	cmd.Flags().StringVar(&des, "des", "", "Description")
+	cmd.Flags().StringArrayVar(&sets, "set", nil, "Any other field allowed by the file's scope, as key=value (repeatable)")
+	cmd.Flags().StringVar(&lookup, "lookup", "", "Local TSV/CSV dump to fill missing fields from")
+	cmd.Flags().StringVar(&id, "id", "", "ISBN or IMDb id of the lookup row (default: match by --name)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check and print the item without writing it")
+
+	return cmd
+}
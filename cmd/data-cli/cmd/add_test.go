@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCmdAppendsItem(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"books/history.yml": "- name: 万历十五年\n  author: 黄仁宇\n",
+	})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"add", "books", "--path", root + "/books", "--file", "history",
+			"--name", "中国大历史", "--author", "黄仁宇", "--score", "0", "--set", "readTime=3h"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "history.yml:3")
+
+	raw, err := os.ReadFile(filepath.Join(root, "books", "history.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- name: 万历十五年\n  author: 黄仁宇\n- name: 中国大历史\n  author: 黄仁宇\n  score: 0\n  readTime: 3h\n", string(raw))
+}
+
+func TestAddCmdReportsDuplicate(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"books/history.yml": "- name: 万历十五年\n  author: 黄仁宇\n",
+	})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"add", "books", "--path", root + "/books", "--file", "history",
+			"--name", "万历十五年", "--author", "黄仁宇", "--format", "json"})
+
+		return cmd.Execute()
+	})
+	require.Error(t, err)
+	assert.Contains(t, out, `"nameAuthorDuplicates"`)
+}
+
+func TestAddCmdRejectsBadSet(t *testing.T) {
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"add", "movie", "--path", t.TempDir(), "--set", "novalue"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key=value")
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/internal/gh/enrich"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+type enrichFlags struct {
+	cachePath  string
+	ttl        time.Duration
+	staleAfter time.Duration
+	refresh    bool
+	fix        bool
+	dryRun     bool
+}
+
+func newEnrichCmd(dataPath *string) *cobra.Command {
+	var flags enrichFlags
+
+	cmd := &cobra.Command{
+		Use:   "enrich <domain>",
+		Short: "Enrich data with remote metadata (gh: GitHub repo liveness)",
+		Long: `Query the GitHub API for every repo URL under data/gh and record stars,
+last push, archived state and rename targets in an on-disk cache
+(default: gh-enrich.json under the docs-alfred cache dir).
+
+Dead, renamed, archived and stale repos are reported as issues; data-cli check
+gh reports the same issues from the cache without calling the API. --fix
+rewrites renamed URLs in place, leaving YAML comments and layout untouched.
+
+Set GITHUB_TOKEN (or GH_TOKEN) to lift the unauthenticated rate limit.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+			if domain != data.DomainGH {
+				return fmt.Errorf("data enrich %s is not supported", domain)
+			}
+
+			return runGHEnrich(cmd, *dataPath, &flags)
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.cachePath, "cache", "", "Status cache file (default: docs-alfred cache dir)")
+	cmd.Flags().DurationVar(&flags.ttl, "ttl", enrich.DefaultTTL, "Reuse cached statuses younger than this")
+	cmd.Flags().DurationVar(&flags.staleAfter, "stale-after", enrich.DefaultStaleAfter, "Report repos without a push for this long (saved in the cache for check gh)")
+	cmd.Flags().BoolVar(&flags.refresh, "refresh", false, "Query every repo, ignoring the cache TTL")
+	cmd.Flags().BoolVar(&flags.fix, "fix", false, "Rewrite renamed repo URLs in the YAML files")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Report without writing the cache or YAML files")
+
+	return cmd
+}
+
+func runGHEnrich(cmd *cobra.Command, dataPath string, flags *enrichFlags) error {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+
+	result, err := dataops.RunGHEnrich(context.Background(), dataops.GHEnrichInput{
+		Path:       dataPath,
+		CachePath:  flags.cachePath,
+		Token:      token,
+		TTL:        flags.ttl,
+		StaleAfter: flags.staleAfter,
+		Refresh:    flags.refresh,
+		Fix:        flags.fix,
+		DryRun:     flags.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if output.GetFormat(cmd) == output.FormatJSON {
+		if err := output.WriteJSON(result); err != nil {
+			return err
+		}
+	} else if err := writeOutput(formatEnrichResult(result)); err != nil {
+		return err
+	}
+	slog.Info("Data enrich finished", "domain", data.DomainGH, "repos", result.Repos,
+		"fetched", result.Fetched, "cached", result.Cached, "failed", result.Failed)
+
+	if checkutil.HasErrors(result.Issues) {
+		return fmt.Errorf("data enrich gh found %d missing repo(s)", result.Missing)
+	}
+
+	return nil
+}
+
+func formatEnrichResult(result *enrich.Result) string {
+	var b strings.Builder
+	report, _ := checkutil.ReportIssues(result.Issues, "data enrich gh")
+	b.WriteString(report)
+	fmt.Fprintf(&b, "summary: repos=%d fetched=%d cached=%d failed=%d missing=%d renamed=%d archived=%d stale=%d\n",
+		result.Repos, result.Fetched, result.Cached, result.Failed,
+		result.Missing, result.Renamed, result.Archived, result.Stale)
+	verb := "rewrote"
+	if result.DryRun {
+		verb = "dry-run: would rewrite"
+	}
+	for _, rw := range result.Rewrites {
+		fmt.Fprintf(&b, "%s %s:%d %s -> %s\n", verb, rw.File, rw.Line, rw.From, rw.To)
+	}
+
+	return b.String()
+}
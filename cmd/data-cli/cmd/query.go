@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	"github.com/xbpk3t/docs-alfred/internal/data/query"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+// queryTitleFields name a record in the table, first present wins.
+var queryTitleFields = []string{"name", "url", "date"}
+
+func newQueryCmd(dataPath *string) *cobra.Command {
+	var (
+		sortBy string
+		fields []string
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "query <expr>",
+		Short: "Query items across all data domains",
+		Long: `Filter the items of every data domain (books, movie, tv, music, diary, gh
+repos, goods items, ...) with whitespace-separated clauses, all of which must
+hold:
+
+  field=value    equal (case-insensitive; a,b,c matches any)
+  field!=value   not equal
+  field~=value   contains; lists match when any element does
+  field!~value   does not contain
+  field>=value   also <=, >, <; numbers by value, dates by prefix
+  word           any field contains word
+
+The pseudo field domain selects domains, e.g.
+
+  data-cli query 'domain=books score>=4 tags~=history publishAt>=2020'
+
+--path sets the data root (default: data).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := dataops.RunQuery(dataops.QueryInput{
+				Expr:  args[0],
+				Root:  *dataPath,
+				Sort:  sortBy,
+				Limit: limit,
+			})
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(result)
+			}
+
+			return writeOutput(formatQueryTable(result, fields))
+		},
+	}
+
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Sort by field, descending with a leading - (e.g. -score)")
+	cmd.Flags().StringSliceVar(&fields, "fields", nil, "Table columns (default: fields used by the query)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum items to show (0 = all)")
+
+	return cmd
+}
+
+func formatQueryTable(result *dataquery.Result, fields []string) string {
+	if len(fields) == 0 {
+		fields = slices.DeleteFunc(result.Query.Fields(), func(f string) bool {
+			return slices.Contains(queryTitleFields, f)
+		})
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	header := append([]string{"DOMAIN", "ITEM"}, upperAll(fields)...)
+	fmt.Fprintln(w, strings.Join(append(header, "LOCATION"), "\t"))
+	for _, rec := range result.Records {
+		row := []string{rec.Domain, queryTitle(&rec)}
+		for _, f := range fields {
+			row = append(row, queryCell(rec.Fields[f]))
+		}
+		row = append(row, fmt.Sprintf("%s:%d", rec.File, rec.Line))
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+	fmt.Fprintf(&b, "%d of %d item(s) matched (%d shown)\n", result.Matched, result.Scanned, len(result.Records))
+
+	return b.String()
+}
+
+func queryTitle(rec *dataquery.Record) string {
+	for _, f := range queryTitleFields {
+		if v := queryCell(rec.Fields[f]); v != "" {
+			return v
+		}
+	}
+
+	return "-"
+}
+
+func queryCell(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case []any:
+		parts := make([]string, 0, len(val))
+		for _, item := range val {
+			parts = append(parts, fmt.Sprint(item))
+		}
+
+		return strings.Join(parts, ",")
+	case map[string]any:
+		return "{…}"
+	}
+
+	return strings.ReplaceAll(fmt.Sprint(v), "\n", " ")
+}
+
+func upperAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.ToUpper(v)
+	}
+
+	return out
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dataquery "github.com/xbpk3t/docs-alfred/internal/data/query"
+)
+
+func TestQueryCmdPrintsTableAndJSON(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"books/history.yml": `- name: 万历十五年
+  score: 5
+  tags: [history, ming]
+- name: Sapiens
+  score: 3
+  tags: [history]
+`,
+		"gh/tool.yml": validGhYAML,
+	})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"query", "score>=4 tags~=history", "--path", root})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "DOMAIN  ITEM")
+	assert.Contains(t, out, "SCORE  TAGS")
+	assert.Contains(t, out, "万历十五年")
+	assert.Contains(t, out, "history,ming")
+	assert.NotContains(t, out, "Sapiens")
+	assert.Contains(t, out, "1 of 3 item(s) matched (1 shown)")
+
+	out, err = captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"query", "domain=gh", "--path", root, "--format", "json"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	var result dataquery.Result
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Len(t, result.Records, 1)
+	assert.Equal(t, "https://github.com/acme/tool", result.Records[0].Fields["url"])
+	assert.Equal(t, "tool", result.Records[0].Fields["type"])
+}
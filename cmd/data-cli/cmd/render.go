@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	datarender "github.com/xbpk3t/docs-alfred/internal/data/render"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newRenderCmd(dataPath *string) *cobra.Command {
+	var (
+		outDir, manifest   string
+		incremental, watch bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "render <domain>",
+		Short: "Render YAML data for a domain",
+		Long: `Render YAML data for a domain.
+
+--incremental keeps a content-hash manifest (default: data-render/manifest.json
+under the docs-alfred cache dir, see --manifest): outputs whose sources did not
+change are left alone, and for merged YAML domains only changed files are
+parsed again. It prints a per-file timing summary.
+
+--watch renders incrementally, then re-renders and re-checks the domain on
+every save until interrupted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+
+			if ve := fileutil.ValidateOutputPath(outDir); ve != nil {
+				return ve
+			}
+
+			input := dataops.DomainRenderInput{
+				Domain:       domain,
+				Path:         *dataPath,
+				OutDir:       outDir,
+				Incremental:  incremental || watch,
+				ManifestPath: manifest,
+			}
+			if watch {
+				return runRenderWatch(cmd, input)
+			}
+
+			result, err := dataops.RunDomainRender(input)
+			if err != nil {
+				return err
+			}
+
+			for _, f := range result.OutputFiles {
+				slog.Info("Rendered", "domain", string(domain), "output", f)
+			}
+			if !input.Incremental {
+				return nil
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(result)
+			}
+
+			return writeOutput(datarender.TimingSummary(result))
+		},
+	}
+
+	cmd.Flags().StringVar(&outDir, "output", "docs/public", "Output directory")
+	cmd.Flags().BoolVar(&incremental, "incremental", false, "Re-render only changed files, tracked in a content-hash manifest")
+	cmd.Flags().StringVar(&manifest, "manifest", "", "Render manifest file (default: docs-alfred cache dir)")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Re-render and re-check on every save until interrupted (implies --incremental)")
+
+	return cmd
+}
+
+func runRenderWatch(cmd *cobra.Command, input dataops.DomainRenderInput) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	jsonOutput := output.GetFormat(cmd) == output.FormatJSON
+
+	return dataops.WatchDomainRender(ctx, input, func(event *dataops.DomainRenderWatchEvent) {
+		if err := writeWatchEvent(input.Domain, event, jsonOutput); err != nil {
+			slog.Error("Write watch output failed", "error", err)
+		}
+	})
+}
+
+func writeWatchEvent(domain data.DataDomain, event *dataops.DomainRenderWatchEvent, jsonOutput bool) error {
+	if jsonOutput {
+		errMsg := ""
+		if event.Err != nil {
+			errMsg = event.Err.Error()
+		}
+
+		return output.WriteJSON(struct {
+			*dataops.DomainRenderWatchEvent
+			Error string `json:"error,omitempty"`
+		}{event, errMsg})
+	}
+
+	if event.Render != nil {
+		if err := writeOutput(datarender.TimingSummary(event.Render)); err != nil {
+			return err
+		}
+	}
+	if event.Check != nil {
+		report, _ := checkutil.ReportIssues(event.Check.Issues, "data check "+string(domain))
+		if err := writeOutput(report); err != nil {
+			return err
+		}
+	}
+	if event.Err != nil {
+		return writeOutput(fmt.Sprintf("❌ %v\n", event.Err))
+	}
+
+	return nil
+}
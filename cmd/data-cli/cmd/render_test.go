@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCmdIncrementalPrintsTimings(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"books/history.yml": "- name: 万历十五年\n",
+		"books/movie.yml":   "- name: 霸王别姬\n",
+	})
+	manifest := filepath.Join(t.TempDir(), "manifest.json")
+	outDir := t.TempDir()
+	render := func() string {
+		out, err := captureStdout(t, func() error {
+			cmd := newRootCmd()
+			cmd.SetArgs([]string{"render", "books", "--path", root + "/books", "--output", outDir,
+				"--incremental", "--manifest", manifest})
+
+			return cmd.Execute()
+		})
+		require.NoError(t, err)
+
+		return out
+	}
+
+	out := render()
+	assert.Contains(t, out, "books.yml: written")
+	assert.Contains(t, out, "2 files: 2 rendered")
+
+	out = render()
+	assert.Contains(t, out, "books.yml: up to date")
+	assert.Contains(t, out, "2 files: 2 cached")
+}
+
+func TestNewRenderCmdIncrementalFlags(t *testing.T) {
+	cmd := newRenderCmd(new(string))
+
+	for _, name := range []string{"incremental", "manifest", "watch"} {
+		require.NotNil(t, cmd.Flag(name), name)
+	}
+	assert.Equal(t, "false", cmd.Flag("watch").DefValue)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/internal/gh/goods"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newReportCmd(dataPath *string) *cobra.Command {
+	var nearing float64
+
+	cmd := &cobra.Command{
+		Use:   "report <domain>",
+		Short: "Report derived numbers of a domain (goods: lifecycle cost)",
+		Long: `Compute the lifecycle cost of goods: total cost of ownership (price minus
+resale endPrice), cost per day of use, resale recovery and active vs retired
+inventory value per tag.
+
+Items with a date are owned and those with an endDate are retired. The median
+use period of retired items of the same type is their typical lifespan;
+active items past --nearing of it are flagged with an expected end date.
+Prices that are not a one-off CNY amount are counted as unpriced.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+			if domain != data.DomainGoods {
+				return fmt.Errorf("data report %s is not supported", domain)
+			}
+
+			report, err := dataops.RunGoodsReport(dataops.GoodsReportInput{
+				Path:         *dataPath,
+				NearingRatio: nearing,
+			})
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(report)
+			}
+
+			return writeOutput(goods.ReportMarkdown(report))
+		},
+	}
+
+	cmd.Flags().Float64Var(&nearing, "nearing", goods.DefaultNearingRatio, "Flag active items past this share of their type's typical lifespan")
+
+	return cmd
+}
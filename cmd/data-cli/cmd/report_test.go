@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/gh/goods"
+)
+
+func TestReportCmdGoods(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"goods/edc.yml": `- type: 耳机
+  tag: EDC
+  using:
+    name: C60
+    price: ¥300
+    date: 2024-01-01
+  item:
+    - name: C50
+      price: ¥179
+      date: 2023-01-01
+      endDate: 2024-01-01
+      endPrice: ¥50
+`,
+	})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"report", "goods", "--path", root + "/goods", "--format", "json"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	var report goods.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Categories, 1)
+	assert.InDelta(t, 50, report.Categories[0].Resale, 0.001)
+	require.Len(t, report.Lifespans, 1)
+	assert.Equal(t, 365, report.Lifespans[0].MedianDays)
+
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"report", "books"})
+	require.ErrorContains(t, cmd.Execute(), "not supported")
+}
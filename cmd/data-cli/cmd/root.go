@@ -10,7 +10,6 @@ import (
 	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
 	"github.com/xbpk3t/docs-alfred/pkg/carboninit"
 	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
-	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
 	"github.com/xbpk3t/docs-alfred/pkg/output"
 	"github.com/xbpk3t/docs-alfred/pkg/schema"
 	"github.com/xbpk3t/docs-alfred/pkg/validator"
@@ -35,67 +34,57 @@ func newRootCmd() *cobra.Command {
 	rootCmd.PersistentFlags().StringVar(&dataPath, "path", "", "Override data directory")
 	output.FormatFlag(rootCmd, &format, output.FormatText, []string{output.FormatText, output.FormatJSON}, "Output format: text or json")
 
+	rootCmd.AddCommand(newAddCmd(&dataPath))
 	rootCmd.AddCommand(newRenderCmd(&dataPath))
 	rootCmd.AddCommand(newCheckCmd(&dataPath))
 	rootCmd.AddCommand(newDedupCmd(&dataPath))
 	rootCmd.AddCommand(newDumpCmd(&dataPath))
-	rootCmd.AddCommand(schema.SchemaCmd(rootCmd))
+	rootCmd.AddCommand(newEnrichCmd(&dataPath))
+	rootCmd.AddCommand(newQueryCmd(&dataPath))
+	rootCmd.AddCommand(newReportCmd(&dataPath))
+	rootCmd.AddCommand(newStatsCmd(&dataPath))
+	schemaCmd := schema.SchemaCmd(rootCmd)
+	schemaCmd.AddCommand(newSchemaExportCmd())
+	rootCmd.AddCommand(schemaCmd)
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 
 	return rootCmd
 }
 
-func newRenderCmd(dataPath *string) *cobra.Command {
-	var outDir string
-
-	cmd := &cobra.Command{
-		Use:   "render <domain>",
-		Short: "Render YAML data for a domain",
-		Args:  cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			domain, err := parseDataDomainArg(args[0])
-			if err != nil {
-				return err
-			}
-
-			if ve := fileutil.ValidateOutputPath(outDir); ve != nil {
-				return ve
-			}
-
-			result, err := dataops.RunDomainRender(dataops.DomainRenderInput{
-				Domain: domain,
-				Path:   *dataPath,
-				OutDir: outDir,
-			})
-			if err != nil {
-				return err
-			}
-
-			for _, f := range result.OutputFiles {
-				slog.Info("Rendered", "domain", string(domain), "output", f)
-			}
-
-			return nil
-		},
-	}
-
-	cmd.Flags().StringVar(&outDir, "output", "docs/public", "Output directory")
-
-	return cmd
-}
-
 func newCheckCmd(dataPath *string) *cobra.Command {
-	var ruleScope string
+	var (
+		ruleScope   string
+		fix, dryRun bool
+		aliases     map[string]string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "check <domain>",
 		Short: "Check data validity for a domain",
-		Args:  cobra.ExactArgs(1),
+		Long: `Check data validity for a domain.
+
+--fix first applies safe mechanical fixes in place, keeping comments and
+ordering: trimmed quoted values, readAt/date normalised to YYYY-MM-DD, scores
+clamped to 0-5 and alias fields renamed (e.g. rating -> score; extend with
+--alias old=new). For gh it normalises topic.kind. --dry-run prints the
+fixes as a unified diff without writing.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			domain, err := parseDataDomainArg(args[0])
 			if err != nil {
 				return err
 			}
+			if fix || dryRun {
+				if err := runDomainFix(dataops.DomainFixInput{
+					Domain:    domain,
+					Path:      *dataPath,
+					RuleScope: ruleScope,
+					Aliases:   aliases,
+					DryRun:    dryRun,
+				}); err != nil {
+					return err
+				}
+			}
 
 			return runDomainCheck(domain, *dataPath, ruleScope)
 		},
@@ -103,10 +92,29 @@ func newCheckCmd(dataPath *string) *cobra.Command {
 
 	cmd.Flags().StringVar(&ruleScope, "rule-scope", "", "Override structured data check rule scope")
 	_ = cmd.Flags().MarkHidden("rule-scope")
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply safe mechanical fixes before checking")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the --fix changes as a unified diff without writing")
+	cmd.Flags().StringToStringVar(&aliases, "alias", nil, "Extra field renames for --fix (old=new; old= drops a default)")
 
 	return cmd
 }
 
+func runDomainFix(input dataops.DomainFixInput) error {
+	result, err := dataops.RunDomainFix(input)
+	if err != nil {
+		return err
+	}
+	if input.DryRun {
+		return writeOutput(result.Diff)
+	}
+	if err := writeOutput(checkutil.ReportFixes(result)); err != nil {
+		return err
+	}
+	slog.Info("Data fix finished", "domain", input.Domain, "fixes", len(result.Fixes))
+
+	return nil
+}
+
 func runDomainCheck(domain data.DataDomain, dataPath, ruleScope string) error {
 	result, err := dataops.RunDomainCheck(dataops.DomainCheckInput{
 		Domain:    domain,
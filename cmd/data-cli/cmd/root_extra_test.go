@@ -192,6 +192,42 @@ func TestRunDomainCheckGhWithRuleScope(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewCheckCmdFixGhKind(t *testing.T) {
+	content := `- type: tool
+  topics:
+    - topic: overview
+      kind: Tools
+`
+	ghDir := writeGhFiles(t, map[string]string{"tool.yml": content})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"check", "gh", "--path", ghDir, "--dry-run"})
+
+		return cmd.Execute()
+	})
+	require.Error(t, err, "dry run leaves the invalid kind in place")
+	require.Contains(t, out, "-      kind: Tools\n+      kind: tools\n")
+	got, readErr := os.ReadFile(filepath.Join(ghDir, "tool.yml"))
+	require.NoError(t, readErr)
+	require.Equal(t, content, string(got))
+
+	out, err = captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"check", "gh", "--path", ghDir, "--fix"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	require.Contains(t, out, "tool.yml:4 kind: Tools -> tools")
+}
+
+func TestNewCheckCmdFixUnsupportedDomain(t *testing.T) {
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"check", "goods", "--path", t.TempDir(), "--fix"})
+	require.ErrorContains(t, cmd.Execute(), "not supported")
+}
+
 // ---------------------------------------------------------------------------
 // newDedupCmd – RunE paths
 // ---------------------------------------------------------------------------
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newSchemaExportCmd() *cobra.Command {
+	var ruleScope, outFile string
+
+	cmd := &cobra.Command{
+		Use:   "export <domain>",
+		Short: "Export the JSON Schema of a data domain for editors",
+		Long: `Emit a JSON Schema (draft-07) of a data domain's YAML files, derived from
+the same field tables, date patterns and enums data check uses. Point
+yaml-language-server at it with a modeline:
+
+  # yaml-language-server: $schema=../../schema/books.schema.json
+
+ntl holds several file kinds; pick one with --rule-scope jav|vg|movie.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+			s, err := dataops.RunSchemaExport(dataops.SchemaExportInput{Domain: domain, RuleScope: ruleScope})
+			if err != nil {
+				return err
+			}
+			if outFile == "" {
+				return output.WriteJSON(s)
+			}
+			if err := fileutil.AtomicWriteJSONFile(outFile, s, fileutil.FilePerm); err != nil {
+				return err
+			}
+			slog.Info("Exported schema", "domain", domain, "output", outFile)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&ruleScope, "rule-scope", "", "Field set of a structured domain (books, movie, music, diary, jav, vg)")
+	cmd.Flags().StringVar(&outFile, "output", "", "Write the schema to this file instead of stdout")
+
+	return cmd
+}
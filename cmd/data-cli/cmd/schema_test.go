@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
+)
+
+func TestSchemaExportCmd(t *testing.T) {
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"schema", "export", "books"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	var s jsonschema.Schema
+	require.NoError(t, json.Unmarshal([]byte(out), &s))
+	assert.Equal(t, jsonschema.Draft07, s.Schema)
+	assert.Contains(t, s.Definitions["item"].Properties, "readAt")
+
+	file := filepath.Join(t.TempDir(), "vg.schema.json")
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"schema", "export", "ntl", "--rule-scope", "vg", "--output", file})
+	require.NoError(t, cmd.Execute())
+	raw, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"platform"`)
+
+	cmd = newRootCmd()
+	cmd.SetArgs([]string{"schema", "export", "books", "--rule-scope", "nope"})
+	require.ErrorContains(t, cmd.Execute(), "unknown rule scope")
+}
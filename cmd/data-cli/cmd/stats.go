@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	dataops "github.com/xbpk3t/docs-alfred/internal/data/ops"
+	"github.com/xbpk3t/docs-alfred/internal/data/stats"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newStatsCmd(dataPath *string) *cobra.Command {
+	var year, top int
+
+	cmd := &cobra.Command{
+		Use:   "stats <domain>",
+		Short: "Yearly review of a personal data domain (books, movie, tv, diary, ntl)",
+		Long: `Compute items per month, score distribution, top authors and tags and
+reading time totals of a data domain, rendered as markdown or JSON.
+
+Items are dated by readAt, date or playAt. --year reviews one year and
+compares it with the year before; without it every item is included and the
+year-over-year table covers all dated years.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domain, err := parseDataDomainArg(args[0])
+			if err != nil {
+				return err
+			}
+			result, err := dataops.RunStats(dataops.StatsInput{
+				Domain: domain,
+				Path:   *dataPath,
+				Year:   year,
+				Top:    top,
+			})
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(result)
+			}
+
+			return writeOutput(datastats.Markdown(result))
+		},
+	}
+
+	cmd.Flags().IntVar(&year, "year", 0, "Review one year and compare it with the year before (0 = all years)")
+	cmd.Flags().IntVar(&top, "top", datastats.DefaultTop, "Length of the top authors and tags lists")
+
+	return cmd
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCmdPrintsReview(t *testing.T) {
+	root := writeGhFiles(t, map[string]string{
+		"books/history.yml": `- name: 万历十五年
+  author: 黄仁宇
+  score: 5
+  readAt: "2021-03-04"
+- name: Sapiens
+  score: 3
+  readAt: "2020-07-01"
+`,
+	})
+
+	out, err := captureStdout(t, func() error {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"stats", "books", "--year", "2021", "--path", root + "/books"})
+
+		return cmd.Execute()
+	})
+	require.NoError(t, err)
+	assert.Contains(t, out, "books · 2021")
+	assert.Contains(t, out, "黄仁宇")
+	assert.Contains(t, out, "Items vs 2020")
+}
@@ -185,6 +185,21 @@ func TestApplyWikiFlagOverridesNoOp(t *testing.T) {
 	assert.Equal(t, 20000, cfg.Wiki.MaxContentSize)
 }
 
+func TestApplyWikiFlagOverridesAIModeAndBatch(t *testing.T) {
+	cfg := &wikiuc.Config{AI: wikiuc.AIConfig{Mode: "live"}}
+	flags := &wikiFlags{aiMode: "replay", aiFixtures: "/tmp/fixtures", batchSize: 4}
+	applyWikiFlagOverrides(cfg, flags)
+	assert.Equal(t, "replay", cfg.AI.Mode)
+	assert.Equal(t, "/tmp/fixtures", cfg.AI.Fixtures)
+	assert.Equal(t, 4, cfg.Wiki.Batch.Size)
+}
+
+func TestCheckWikiAIMode(t *testing.T) {
+	assert.NoError(t, checkWikiAIMode(""))
+	assert.NoError(t, checkWikiAIMode("record"))
+	assert.Error(t, checkWikiAIMode("mock"))
+}
+
 func TestFormatWikiTextResultOK(t *testing.T) {
 	result := &wikiuc.Result{
 		Name: "wiki add",
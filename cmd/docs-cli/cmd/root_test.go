@@ -40,7 +40,7 @@ func TestWikiCommandOwnsWikiActions(t *testing.T) {
 
 	require.Equal(t, wikiCommandName, wikiCmd.Name())
 	require.False(t, wikiCmd.HasAvailableFlags())
-	requireCommandNames(t, wikiCmd.Commands(), []string{"add", "compact", "digest", "digest-local", wikiAuditCommandName, wikiCheckCommandName})
+	requireCommandNames(t, wikiCmd.Commands(), []string{"add", "compact", "digest", "digest-local", wikiAuditCommandName, wikiCheckCommandName, wikiLedgerCommandName, wikiReclassifyCommandName, wikiRelatedCommandName, wikiExportCommandName, wikiImportCommandName, wikiRetryCommandName, wikiMoveCommandName, wikiMergeCommandName})
 	require.Nil(t, wikiCmd.Flags().Lookup("digest"))
 }
 
@@ -56,6 +56,8 @@ func TestWikiAddCommandFlags(t *testing.T) {
 	require.NotNil(t, f.Lookup("wiki-root"))
 	require.NotNil(t, wikiAddCmd.InheritedFlags().Lookup("format"))
 	require.NotNil(t, f.Lookup("dry-run"))
+	require.NotNil(t, f.Lookup("force"))
+	require.NotNil(t, f.Lookup("merge"))
 }
 
 func TestWikiDigestCommand(t *testing.T) {
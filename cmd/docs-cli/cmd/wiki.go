@@ -6,13 +6,22 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	carbon "github.com/dromara/carbon/v2"
 	"github.com/spf13/cobra"
 	workspaceuc "github.com/xbpk3t/docs-alfred/internal/docs/check"
 	wikiuc "github.com/xbpk3t/docs-alfred/internal/docs/ingest"
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
 	wikicompact "github.com/xbpk3t/docs-alfred/internal/docs/wiki/compact"
+	wikiexport "github.com/xbpk3t/docs-alfred/internal/docs/wiki/export"
+	wikirelated "github.com/xbpk3t/docs-alfred/internal/docs/wiki/related"
+	wikirestructure "github.com/xbpk3t/docs-alfred/internal/docs/wiki/restructure"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
 	"github.com/xbpk3t/docs-alfred/pkg/ai"
 	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
 	"github.com/xbpk3t/docs-alfred/pkg/cmdutil"
@@ -24,18 +33,31 @@ type wikiFlags struct {
 	config         string
 	wikiRoot       string
 	model          string
+	aiMode         string
+	aiFixtures     string
 	auditPaths     []string
 	maxContentSize int
+	batchSize      int
 	dryRun         bool
 	changedOnly    bool
+	force          bool
+	merge          bool
 }
 
 const (
-	wikiCommandName        = "wiki"
-	wikiDigestCommandName  = "digest"
-	wikiAuditCommandName   = "audit"
-	wikiCheckCommandName   = "check"
-	wikiCompactCommandName = "compact"
+	wikiCommandName           = "wiki"
+	wikiDigestCommandName     = "digest"
+	wikiAuditCommandName      = "audit"
+	wikiCheckCommandName      = "check"
+	wikiCompactCommandName    = "compact"
+	wikiLedgerCommandName     = "ledger"
+	wikiReclassifyCommandName = "reclassify"
+	wikiRelatedCommandName    = "related"
+	wikiExportCommandName     = "export"
+	wikiImportCommandName     = "import"
+	wikiRetryCommandName      = "retry"
+	wikiMoveCommandName       = "mv"
+	wikiMergeCommandName      = "merge"
 )
 
 func newWikiCmd() *cobra.Command {
@@ -58,9 +80,17 @@ and entry type (repo_eval/deep_dive/inbox). Writes structured entries.`,
 	cmd.AddCommand(newWikiAddCmd())
 	cmd.AddCommand(newWikiDigestCmd())
 	cmd.AddCommand(newWikiDigestLocalCmd())
+	cmd.AddCommand(newWikiImportCmd())
+	cmd.AddCommand(newWikiRetryCmd())
 	cmd.AddCommand(newWikiAuditCmd())
 	cmd.AddCommand(newWikiCheckCmd())
 	cmd.AddCommand(newWikiCompactCmd())
+	cmd.AddCommand(newWikiLedgerCmd())
+	cmd.AddCommand(newWikiReclassifyCmd())
+	cmd.AddCommand(newWikiRelatedCmd())
+	cmd.AddCommand(newWikiExportCmd())
+	cmd.AddCommand(newWikiRestructureCmd(wikiMoveCommandName))
+	cmd.AddCommand(newWikiRestructureCmd(wikiMergeCommandName))
 
 	return cmd
 }
@@ -72,6 +102,9 @@ func newWikiAddCmd() *cobra.Command {
 		Short: "Classify and summarize explicit URLs into wiki",
 		Args:  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkWikiAIMode(flags.aiMode); err != nil {
+				return err
+			}
 			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
 			if err != nil {
 				return fmt.Errorf("load config: %w", err)
@@ -83,6 +116,8 @@ func newWikiAddCmd() *cobra.Command {
 				Config: cfg,
 				URLs:   args,
 				DryRun: flags.dryRun,
+				Force:  flags.force,
+				Merge:  flags.merge,
 			})
 			if err != nil {
 				return err
@@ -103,6 +138,9 @@ func newWikiDigestCmd() *cobra.Command {
 		Short: "Digest wiki/inbox.md URLs and flush handled lines",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkWikiAIMode(flags.aiMode); err != nil {
+				return err
+			}
 			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
 			if err != nil {
 				return fmt.Errorf("load config: %w", err)
@@ -113,6 +151,62 @@ func newWikiDigestCmd() *cobra.Command {
 			result, err := wikiuc.RunDigest(context.Background(), wikiuc.DigestInput{
 				Config: cfg,
 				DryRun: flags.dryRun,
+				Force:  flags.force,
+				Merge:  flags.merge,
+			})
+			if err != nil {
+				return err
+			}
+
+			return writeWikiResult(result, output.GetFormat(cmd))
+		},
+	}
+	addWikiFlags(cmd, &flags)
+	cmd.Flags().IntVar(&flags.batchSize, "batch-size", 0, "Classify up to N short inbox items per AI request (overrides wiki.batch.size)")
+
+	return cmd
+}
+
+func newWikiImportCmd() *cobra.Command {
+	var (
+		flags        wikiFlags
+		importFormat string
+		progressPath string
+		restart      bool
+	)
+	cmd := &cobra.Command{
+		Use:   wikiImportCommandName + " <file>",
+		Short: "Digest URLs from browser bookmarks or a read-later export",
+		Long: `Digest every URL of a bookmark or read-later export like inbox entries.
+
+Supported exports: browser bookmark HTML (netscape), Pocket, Instapaper and
+Readwise CSV, any CSV with a url column, and plain URL lists (urls). The
+format is detected unless --type is given. The folder and tags a URL was
+filed under are passed to the classifier as a topic hint.
+
+Progress is saved after every chunk, so re-running the same file resumes
+where an interrupted import stopped; --restart starts over.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkWikiAIMode(flags.aiMode); err != nil {
+				return err
+			}
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			resolveWikiAPIKey(cfg)
+			applyWikiFlagOverrides(cfg, &flags)
+
+			result, err := wikiuc.RunImport(context.Background(), wikiuc.ImportInput{
+				Config:       cfg,
+				Path:         args[0],
+				Format:       importFormat,
+				ProgressPath: progressPath,
+				DryRun:       flags.dryRun,
+				Force:        flags.force,
+				Merge:        flags.merge,
+				Restart:      restart,
 			})
 			if err != nil {
 				return err
@@ -122,10 +216,104 @@ func newWikiDigestCmd() *cobra.Command {
 		},
 	}
 	addWikiFlags(cmd, &flags)
+	cmd.Flags().IntVar(&flags.batchSize, "batch-size", 0, "Classify up to N short items per AI request (overrides wiki.batch.size)")
+	cmd.Flags().StringVar(&importFormat, "type", "",
+		"Export format: netscape, pocket, instapaper, readwise, csv or urls (default: detect)")
+	cmd.Flags().StringVar(&progressPath, "progress", "", "Progress file path (default: per-file cache entry)")
+	cmd.Flags().BoolVar(&restart, "restart", false, "Ignore saved progress and import every URL again")
 
 	return cmd
 }
 
+func newWikiRetryCmd() *cobra.Command {
+	var (
+		flags       wikiFlags
+		kinds       []string
+		olderThan   string
+		maxAttempts int
+	)
+	cmd := &cobra.Command{
+		Use:   wikiRetryCommandName,
+		Short: "Digest URLs from the digest failure logs again",
+		Long: `Digest the URLs logged in digest-*-error.jsonl again.
+
+URLs that succeed, or were digested since they failed, are removed from the
+failure logs. A URL that fails again waits wiki.retry.backoffHours, twice as
+long after every further attempt, and is reported as a permanent failure
+after wiki.retry.maxAttempts attempts. Attempts are kept in digest-retry.json
+under the wiki root.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkWikiAIMode(flags.aiMode); err != nil {
+				return err
+			}
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return fmt.Errorf("invalid --older-than: %w", err)
+			}
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			resolveWikiAPIKey(cfg)
+			applyWikiFlagOverrides(cfg, &flags)
+
+			result, err := wikiuc.RunRetry(context.Background(), wikiuc.RetryInput{
+				Config:      cfg,
+				Kinds:       kinds,
+				OlderThan:   age,
+				MaxAttempts: maxAttempts,
+				DryRun:      flags.dryRun,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := writeCommandOutput(output.GetFormat(cmd), &CommandOutput{
+				Name:    result.Name,
+				OK:      result.OK(),
+				Summary: result.Summary(),
+				Actions: result.Actions(),
+				Results: result,
+			}, formatWikiTextResult(result.Result)); err != nil {
+				return err
+			}
+			if !result.OK() {
+				return fmt.Errorf("%s failed", result.Name)
+			}
+
+			return nil
+		},
+	}
+	addWikiFlags(cmd, &flags)
+	// URLs already in the ledger count as resolved, so re-digest modes do not apply.
+	_ = cmd.Flags().MarkHidden("force")
+	_ = cmd.Flags().MarkHidden("merge")
+	cmd.Flags().StringSliceVar(&kinds, "kind", nil,
+		"Failure kinds to retry: "+strings.Join(wikiuc.RetryKinds, ", ")+" (default: all)")
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Only retry failures at least this old (e.g. 1d, 12h)")
+	cmd.Flags().IntVar(&maxAttempts, "max-attempts", 0, "Give up after N attempts (overrides wiki.retry.maxAttempts)")
+
+	return cmd
+}
+
+// parseAge parses a Go duration or a whole number of days ("3d").
+func parseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("want Nd or a duration like 12h, got %q", s)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
 func newWikiDigestLocalCmd() *cobra.Command {
 	var flags struct {
 		config   string
@@ -203,12 +391,18 @@ func newWikiCheckCmd() *cobra.Command {
 	var flags struct {
 		ghRoot   string
 		wikiRoot string
+		fix      bool
+		dryRun   bool
 	}
 	cmd := &cobra.Command{
 		Use:   wikiCheckCommandName,
 		Short: "Check wiki/data/gh folder structure consistency",
-		Long:  `Check that wiki/ and data/gh/ have matching folder structures.`,
-		Args:  cobra.NoArgs,
+		Long: `Check that wiki/ and data/gh/ have matching folder structures.
+
+Stray topic dirs whose name matches exactly one data/gh topic get a proposed
+wiki mv/merge, which --fix applies. A stray dir that may be a renamed topic
+is only reported; check it and run wiki mv yourself.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			result, err := workspaceuc.RunWikiCheck(workspaceuc.WikiCheckInput{
 				GhRoot:   flags.ghRoot,
@@ -217,6 +411,21 @@ func newWikiCheckCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			var actions []string
+			if flags.fix && len(result.TopicMoves) > 0 {
+				if actions, err = applyWikiTopicMoves(flags.wikiRoot, result.TopicMoves, flags.dryRun); err != nil {
+					return err
+				}
+				if !flags.dryRun {
+					// Report what is left after the fixes.
+					if result, err = workspaceuc.RunWikiCheck(workspaceuc.WikiCheckInput{
+						GhRoot:   flags.ghRoot,
+						WikiRoot: flags.wikiRoot,
+					}); err != nil {
+						return err
+					}
+				}
+			}
 			textDetails := fmt.Sprintf("summary: expected=%d actual=%d missing=%d extra=%d\n",
 				len(result.ExpectedWikiDirs), len(result.ActualWikiDirs),
 				len(result.MissingWikiDirs), len(result.ExtraWikiDirs))
@@ -224,6 +433,7 @@ func newWikiCheckCmd() *cobra.Command {
 				Name:    "wiki check",
 				Issues:  result.Issues,
 				Summary: result.Summary(),
+				Actions: actions,
 			}, textDetails); err != nil {
 				return err
 			}
@@ -236,6 +446,324 @@ func newWikiCheckCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&flags.ghRoot, "gh-root", "data/gh", "data/gh path")
 	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "wiki", "wiki path")
+	cmd.Flags().BoolVar(&flags.fix, "fix", false, "Apply the proposed topic moves and merges of exact-name matches")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "With --fix, report the changes without writing")
+
+	return cmd
+}
+
+// applyWikiTopicMoves runs the moves proposed by wiki check and returns their
+// actions. Guessed renames are skipped.
+func applyWikiTopicMoves(wikiRoot string, moves []workspaceuc.TopicMove, dryRun bool) ([]string, error) {
+	var actions []string
+	for _, move := range moves {
+		if move.Guess {
+			actions = append(actions, fmt.Sprintf("skipped possible rename %s -> %s (not a name match)", move.From, move.To))
+
+			continue
+		}
+		opts := wikirestructure.Options{WikiRoot: wikiRoot, From: move.From, To: move.To, DryRun: dryRun}
+		run := wikirestructure.Move
+		if move.Merge {
+			run = wikirestructure.Merge
+		}
+		result, err := run(opts)
+		if err != nil {
+			return actions, fmt.Errorf("fix %s: %w", move.From, err)
+		}
+		actions = append(actions, result.Actions()...)
+	}
+
+	return actions, nil
+}
+
+func newWikiRestructureCmd(name string) *cobra.Command {
+	var flags struct {
+		config       string
+		wikiRoot     string
+		dryRun       bool
+		allowUnknown bool
+	}
+	run, short, long := wikirestructure.Move, "Rename a wiki topic",
+		`Move topic dir <old> to <new> (folder/type/topic paths), which must not exist.`
+	if name == wikiMergeCommandName {
+		run, short, long = wikirestructure.Merge, "Merge one wiki topic into another",
+			`Merge topic dir <from> into <into> and remove <from>. summary.md date
+sections are combined; other pages that exist in both get <from>'s body appended.`
+	}
+	cmd := &cobra.Command{
+		Use:   name + " <from> <to>",
+		Short: short,
+		Long: long + `
+
+The topic title in frontmatter, related-entry lines and relative links in
+every wiki page, the URL ledger and the digest logs are rewritten to the new
+path. The target must be a data/gh topic unless --allow-unknown is set.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			opts := wikirestructure.Options{
+				WikiRoot: cfg.Wiki.WikiRoot,
+				From:     strings.Trim(args[0], "/"),
+				To:       strings.Trim(args[1], "/"),
+				DryRun:   flags.dryRun,
+			}
+			if !flags.allowUnknown {
+				opts.ValidTopicPaths = wikiwrite.LoadValidTopicPaths(cfg.Wiki.WikiRoot)
+			}
+			result, err := run(opts)
+			if err != nil {
+				return err
+			}
+
+			return writeCommandOutput(output.GetFormat(cmd), &CommandOutput{
+				Name:    "wiki " + name,
+				OK:      true,
+				Summary: result.Summary(),
+				Results: result,
+				Actions: result.Actions(),
+			}, "")
+		},
+	}
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "Config file path")
+	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "", "Wiki root directory (overrides config)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Report the changes without writing")
+	cmd.Flags().BoolVar(&flags.allowUnknown, "allow-unknown", false, "Allow a target topic that is not in data/gh")
+
+	return cmd
+}
+
+func newWikiReclassifyCmd() *cobra.Command {
+	var flags wikiFlags
+	var since, topic string
+	cmd := &cobra.Command{
+		Use:   wikiReclassifyCommandName,
+		Short: "Replay classification and writing from the fetch cache",
+		Long: `Classify and write cached page content again, without fetching.
+
+Every successful fetch is cached per normalized URL (wiki.cache). Use this after
+classification failures or prompt changes: --since selects URLs fetched on or
+after a date, --topic selects URLs whose ledger entry lives under a topic path.
+URLs already in the wiki are updated in place rather than duplicated.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := wikiuc.ReclassifyInput{Topic: topic, DryRun: flags.dryRun}
+			if since != "" {
+				c := carbon.ParseByLayout(since, carbon.DateLayout)
+				if c.HasError() || c.IsInvalid() {
+					return fmt.Errorf("invalid --since %q (want YYYY-MM-DD)", since)
+				}
+				input.Since = c.StdTime()
+			}
+			if input.Since.IsZero() && topic == "" {
+				return errors.New("--since or --topic is required")
+			}
+			if err := checkWikiAIMode(flags.aiMode); err != nil {
+				return err
+			}
+
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			resolveWikiAPIKey(cfg)
+			applyWikiFlagOverrides(cfg, &flags)
+			input.Config = cfg
+
+			result, err := wikiuc.RunReclassify(context.Background(), input)
+			if err != nil {
+				return err
+			}
+
+			return writeWikiResult(result, output.GetFormat(cmd))
+		},
+	}
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "Config file path")
+	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "", "Wiki root directory (overrides config)")
+	cmd.Flags().StringVar(&flags.model, "model", "", "AI model override (e.g. deepseek-v3)")
+	cmd.Flags().IntVar(&flags.maxContentSize, "max-content-size", 0, "Max content chars sent to AI (default 20000)")
+	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Classify without writing files")
+	cmd.Flags().StringVar(&since, "since", "", "Replay URLs fetched on or after this date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&topic, "topic", "", "Replay URLs already written under this topic path")
+	addWikiAIFlags(cmd, &flags)
+
+	return cmd
+}
+
+func newWikiRelatedCmd() *cobra.Command {
+	var flags struct {
+		config   string
+		wikiRoot string
+		limit    int
+	}
+	cmd := &cobra.Command{
+		Use:   wikiRelatedCommandName + " <file|url>",
+		Short: "List wiki entries similar to a file or an already-digested URL",
+		Long: `List wiki entries whose summaries overlap with a markdown file or URL.
+
+Entries are ranked by TF-IDF cosine similarity over summary overviews, key
+points and tags of every topic summary.md. A URL must already be in the wiki;
+a file is matched by its whole text.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			index, err := wikirelated.Build(cfg.Wiki.WikiRoot)
+			if err != nil {
+				return err
+			}
+			index.MinScore = cfg.Wiki.Related.MinScore
+
+			matches, err := queryRelated(index, args[0], flags.limit)
+			if err != nil {
+				return err
+			}
+			out := &CommandOutput{
+				Name:    "wiki related",
+				OK:      true,
+				Summary: map[string]any{"query": args[0], "matches": len(matches), "indexed": index.Len()},
+				Results: matches,
+			}
+
+			return writeCommandOutput(output.GetFormat(cmd), out, formatRelatedText(args[0], matches))
+		},
+	}
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "Config file path")
+	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "", "Wiki root directory (overrides config)")
+	cmd.Flags().IntVar(&flags.limit, "limit", 5, "Maximum number of related entries")
+
+	return cmd
+}
+
+// queryRelated matches an indexed URL by its stored entry, or a file by its text.
+func queryRelated(index *wikirelated.Index, target string, limit int) ([]wikirelated.Match, error) {
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		matches, ok := index.Similar(target, limit)
+		if !ok {
+			return nil, fmt.Errorf("%s is not in any wiki summary", target)
+		}
+
+		return matches, nil
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", target, err)
+	}
+
+	return index.Query(string(data), limit, ""), nil
+}
+
+func formatRelatedText(target string, matches []wikirelated.Match) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "wiki related: %d match(es) for %s\n", len(matches), target)
+	for _, m := range matches {
+		fmt.Fprintf(&b, "  %.3f  %s  %s  %s\n", m.Score, m.TopicPath, m.Title, m.URL)
+	}
+
+	return b.String()
+}
+
+func newWikiExportCmd() *cobra.Command {
+	var flags struct {
+		config   string
+		wikiRoot string
+		outDir   string
+		format   string
+	}
+	cmd := &cobra.Command{
+		Use:   wikiExportCommandName,
+		Short: "Export the wiki as a static HTML site or a JSON dump",
+		Long: `Export every topic summary.md into a self-contained directory.
+
+--format html renders each topic to <topic>/index.html, adds index pages for
+every directory of the gh topic catalog and the wiki tree, a tag cloud under
+tags/ and a client-side search page. --format json writes wiki.json with every
+topic, entry and tag instead. Both formats write search-index.json.
+
+This command's --format selects the export format; the report is always text.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+
+			var actions []string
+			catalog, err := ghindex.LocalTopicCatalog(ghindex.LocalGHConfig{WikiRoot: cfg.Wiki.WikiRoot})
+			if err != nil {
+				actions = append(actions, fmt.Sprintf("gh topic catalog unavailable, index pages cover wiki topics only: %v", err))
+			}
+
+			result, err := wikiexport.Export(wikiexport.Options{
+				WikiRoot: cfg.Wiki.WikiRoot,
+				OutDir:   flags.outDir,
+				Format:   flags.format,
+				Catalog:  catalog,
+			})
+			if err != nil {
+				return err
+			}
+			out := &CommandOutput{
+				Name:    "wiki export",
+				OK:      true,
+				Summary: map[string]any{"format": result.Format, "topics": result.Topics, "entries": result.Entries, "tags": result.Tags},
+				Results: result,
+				Actions: actions,
+			}
+			text := fmt.Sprintf("wiki export: %d topic(s), %d entry(ies), %d tag(s), %d file(s) → %s (%s)\n",
+				result.Topics, result.Entries, result.Tags, len(result.Files), result.OutDir, result.Format)
+
+			return writeCommandOutput(outputFormatText, out, text)
+		},
+	}
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "Config file path")
+	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "", "Wiki root directory (overrides config)")
+	cmd.Flags().StringVarP(&flags.outDir, "out", "o", "wiki-site", "Output directory")
+	cmd.Flags().StringVar(&flags.format, output.FormatFlagName, wikiexport.FormatHTML, "Export format: html or json")
+
+	return cmd
+}
+
+func newWikiLedgerCmd() *cobra.Command {
+	var flags struct {
+		config   string
+		wikiRoot string
+	}
+	cmd := &cobra.Command{
+		Use:   wikiLedgerCommandName,
+		Short: "Rebuild the URL ledger from existing wiki summaries",
+		Long: `Rebuild wiki/url-ledger.json by scanning every topic summary.md.
+
+wiki add and wiki digest consult the ledger to skip URLs that were already
+digested (--force re-digests, --merge updates the existing entry). The ledger
+is built automatically on first use and kept up to date by later runs; rebuild
+it after editing or moving summaries by hand.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := wikiuc.LoadConfig(flags.config, flags.wikiRoot)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			ledger, err := wikiwrite.BuildURLLedger(cfg.Wiki.WikiRoot)
+			if err != nil {
+				return err
+			}
+			if err := ledger.Save(); err != nil {
+				return fmt.Errorf("save url ledger: %w", err)
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "wiki ledger: indexed %d url(s) -> %s\n",
+				ledger.Len(), filepath.Join(cfg.Wiki.WikiRoot, wikiwrite.LedgerFilename))
+
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&flags.config, "config", "c", "", "Config file path")
+	cmd.Flags().StringVar(&flags.wikiRoot, "wiki-root", "", "Wiki root directory (overrides config)")
 
 	return cmd
 }
@@ -246,6 +774,7 @@ type wikiCompactFlags struct {
 	model            string
 	topHot           int
 	topNotice        int
+	topHighlight     int
 	bulkLogThreshold int
 	minDeltaChars    int
 	minDeltaLines    int
@@ -253,6 +782,7 @@ type wikiCompactFlags struct {
 	createIssue      bool
 	dryRun           bool
 	skipAI           bool
+	readerDigest     bool
 }
 
 func newWikiCompactCmd() *cobra.Command {
@@ -268,7 +798,9 @@ Schedule is week-based, controlled by compact.schedule in the config (default 1
 This command never writes blog or log.md. Compact still means you write type:blog manually.
 
 Default is dry print (no side effects). Pass --send-mail (RESEND_TOKEN + compact.send.resend.mailTo) and/or --create-issue (LINEAR_API_KEY + compact.send.linear.teamKey).
-Brand from compact.title (From, mail subject prefix, issue title). Each run always creates a new Linear issue ({title} [YYYY-MM-DD]); no dedup against open issues.`,
+Brand from compact.title (From, mail subject prefix, issue title). Each run always creates a new Linear issue ({title} [YYYY-MM-DD]); no dedup against open issues.
+
+--reader-digest sends a reader-facing digest instead: the wiki entries added in the same schedule window, grouped by topic with their overview and verdict, and the best-rated entries (quality ≥ 4/5) listed as top picks. It needs neither git history nor AI.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runWikiCompact(cmd, &flags)
@@ -285,6 +817,8 @@ Brand from compact.title (From, mail subject prefix, issue title). Each run alwa
 	cmd.Flags().BoolVar(&flags.createIssue, "create-issue", false, "Create a new Linear issue with the compact report")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Print result; do not send mail or create issue")
 	cmd.Flags().BoolVar(&flags.skipAI, "skip-ai", false, "Skip AI (hot list only; for offline debug)")
+	cmd.Flags().BoolVar(&flags.readerDigest, "reader-digest", false, "Deliver new wiki entries of the window instead of compact notices")
+	cmd.Flags().IntVar(&flags.topHighlight, "top-highlight", 5, "Max top picks in the reader digest")
 	cmd.Flags().StringVar(&flags.model, "model", "", "AI model override")
 
 	return cmd
@@ -305,7 +839,11 @@ func runWikiCompact(cmd *cobra.Command, flags *wikiCompactFlags) error {
 		return err
 	}
 
-	result, err := wikicompact.RunCompact(context.Background(), opts)
+	run := wikicompact.RunCompact
+	if flags.readerDigest {
+		run = wikicompact.RunReaderDigest
+	}
+	result, err := run(context.Background(), opts)
 	// Always print bodies/status even when delivery partially failed.
 	if printErr := printCompactResult(cmd.OutOrStdout(), result, flags); printErr != nil {
 		if err != nil {
@@ -345,6 +883,7 @@ func buildCompactOptions(cfg *wikiuc.Config, flags *wikiCompactFlags) (*wikicomp
 		},
 		TopHot:           flags.topHot,
 		TopNotice:        flags.topNotice,
+		TopHighlight:     flags.topHighlight,
 		BulkLogThreshold: flags.bulkLogThreshold,
 		MinDeltaChars:    flags.minDeltaChars,
 		MinDeltaLines:    flags.minDeltaLines,
@@ -487,6 +1026,31 @@ func applyWikiFlagOverrides(cfg *wikiuc.Config, flags *wikiFlags) {
 	if flags.maxContentSize > 0 {
 		cfg.Wiki.MaxContentSize = flags.maxContentSize
 	}
+	if flags.aiMode != "" {
+		cfg.AI.Mode = flags.aiMode
+	}
+	if flags.aiFixtures != "" {
+		cfg.AI.Fixtures = flags.aiFixtures
+	}
+	if flags.batchSize > 0 {
+		cfg.Wiki.Batch.Size = flags.batchSize
+	}
+}
+
+// checkWikiAIMode rejects an --ai-mode the classifier does not know.
+func checkWikiAIMode(mode string) error {
+	switch mode {
+	case "", wikiclassify.AIModeLive, wikiclassify.AIModeRecord, wikiclassify.AIModeReplay:
+		return nil
+	default:
+		return fmt.Errorf("invalid --ai-mode %q (want live, record or replay)", mode)
+	}
+}
+
+// addWikiAIFlags registers the chat backend flags shared by classifying commands.
+func addWikiAIFlags(cmd *cobra.Command, flags *wikiFlags) {
+	cmd.Flags().StringVar(&flags.aiMode, "ai-mode", "", "AI backend: live, record (save responses as fixtures) or replay (fixtures + fetch cache, offline)")
+	cmd.Flags().StringVar(&flags.aiFixtures, "ai-fixtures", "", "Directory of recorded AI fixtures (overrides config)")
 }
 
 func addWikiFlags(cmd *cobra.Command, flags *wikiFlags) {
@@ -495,6 +1059,10 @@ func addWikiFlags(cmd *cobra.Command, flags *wikiFlags) {
 	cmd.Flags().StringVar(&flags.model, "model", "", "AI model override (e.g. deepseek-v3)")
 	cmd.Flags().IntVar(&flags.maxContentSize, "max-content-size", 0, "Max content chars sent to AI (default 20000)")
 	cmd.Flags().BoolVar(&flags.dryRun, "dry-run", false, "Run fetch/classify without writing files or flushing inbox")
+	cmd.Flags().BoolVar(&flags.force, "force", false, "Re-digest URLs already in the URL ledger and append a new entry")
+	cmd.Flags().BoolVar(&flags.merge, "merge", false, "Re-digest URLs already in the URL ledger and update their existing entry")
+	cmd.MarkFlagsMutuallyExclusive("force", "merge")
+	addWikiAIFlags(cmd, flags)
 }
 
 func writeWikiResult(result *wikiuc.Result, format string) error {
@@ -540,10 +1108,10 @@ func formatWikiTextResult(result *wikiuc.Result) string {
 	}
 	fmt.Fprintf(&out, "%s %s\n", result.Name, status)
 	fmt.Fprintf(&out,
-		"summary: processed=%v succeeded=%v handledFailures=%v unhandledFailures=%v "+
+		"summary: processed=%v succeeded=%v handledFailures=%v unhandledFailures=%v skippedDuplicates=%v "+
 			"written=%v flushed=%v wouldFlush=%v dryRun=%v\n",
 		summary["processed"], summary["succeeded"], summary["handledFailures"], summary["unhandledFailures"],
-		summary["written"], summary["flushed"], summary["wouldFlush"], summary["dryRun"])
+		summary["skippedDuplicates"], summary["written"], summary["flushed"], summary["wouldFlush"], summary["dryRun"])
 	for i := range result.URLResults {
 		item := &result.URLResults[i]
 		fmt.Fprintf(&out, "%s %s", item.Status, item.URL)
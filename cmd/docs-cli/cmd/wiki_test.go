@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	workspaceuc "github.com/xbpk3t/docs-alfred/internal/docs/check"
 	wikiuc "github.com/xbpk3t/docs-alfred/internal/docs/ingest"
+	wikirelated "github.com/xbpk3t/docs-alfred/internal/docs/wiki/related"
 	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
 )
 
@@ -490,6 +494,24 @@ func TestWikiCheck_PassedWithEmptyDirs(t *testing.T) {
 	assert.Contains(t, out, "summary:")
 }
 
+func TestApplyWikiTopicMovesSkipsGuessedRenames(t *testing.T) {
+	wikiRoot := t.TempDir()
+	for _, dir := range []string{"desktop/CLI/editor", "desktop/GUI/term"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(wikiRoot, filepath.FromSlash(dir)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(wikiRoot, filepath.FromSlash(dir), "summary.md"), []byte("# x\n"), 0o600))
+	}
+
+	actions, err := applyWikiTopicMoves(wikiRoot, []workspaceuc.TopicMove{
+		{From: "desktop/CLI/editor", To: "desktop/GUI/editor"},
+		{From: "desktop/GUI/term", To: "desktop/GUI/terminal", Guess: true},
+	}, false)
+	require.NoError(t, err)
+	assert.DirExists(t, filepath.Join(wikiRoot, "desktop", "GUI", "editor"))
+	assert.DirExists(t, filepath.Join(wikiRoot, "desktop", "GUI", "term"))
+	assert.NoDirExists(t, filepath.Join(wikiRoot, "desktop", "GUI", "terminal"))
+	assert.Contains(t, actions, "skipped possible rename desktop/GUI/term -> desktop/GUI/terminal (not a name match)")
+}
+
 // --- wiki root command ---
 
 func TestWikiRootCommand_ShowsHelpWithArgs(t *testing.T) {
@@ -516,3 +538,27 @@ func TestDigestLocalCommand_RequiresFromDir(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "--from-dir is required")
 }
+
+// --- related command ---
+
+func TestQueryRelatedByURLAndFile(t *testing.T) {
+	index := wikirelated.NewIndex()
+	index.Add(wikirelated.Document{URL: "https://example.com/raft", Title: "Raft", TopicPath: "db/consensus/raft"}, "raft consensus replicated log")
+	index.Add(wikirelated.Document{URL: "https://example.com/paxos", Title: "Paxos", TopicPath: "db/consensus/paxos"}, "paxos consensus replicated log")
+
+	matches, err := queryRelated(index, "https://example.com/raft", 5)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "Paxos", matches[0].Title)
+
+	_, err = queryRelated(index, "https://example.com/unknown", 5)
+	require.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "note.md")
+	require.NoError(t, os.WriteFile(file, []byte("notes on raft log replication"), 0o600))
+	matches, err = queryRelated(index, file, 5)
+	require.NoError(t, err)
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "Raft", matches[0].Title)
+	assert.Contains(t, formatRelatedText(file, matches), "db/consensus/raft")
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/xbpk3t/docs-alfred/cmd/gh-alfred/internal/presenter"
@@ -35,6 +38,8 @@ func newRootCmd() *cobra.Command {
 	output.FormatFlag(rootCmd, &format, output.FormatText, []string{output.FormatText, output.FormatJSON}, "Output format: text or json")
 
 	rootCmd.AddCommand(newSearchCmd())
+	rootCmd.AddCommand(newOpenCmd())
+	rootCmd.AddCommand(newHistoryCmd())
 	rootCmd.AddCommand(newSyncCmd())
 	rootCmd.AddCommand(newExportCmd())
 	rootCmd.AddCommand(newValidateCmd())
@@ -51,7 +56,17 @@ func newSearchCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "search [query]",
 		Short: "Search GitHub repositories from remote gh.yml for Alfred",
-		Args:  cobra.MaximumNArgs(1),
+		Long: `Search GitHub repositories from remote gh.yml for Alfred.
+
+Free-text terms match repo names with typo and subsequence tolerance, then
+tag, type, topic and description; every term must match. Field operators
+narrow the results and may be negated with a leading "-":
+
+  tag:langs type:golang topic:orm nix:yes doc:no
+
+Matches rank by relevance, then by repo score and how often and how recently
+the repo was opened (see gh-alfred open and gh-alfred history).`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := ""
 			if len(args) > 0 {
@@ -88,6 +103,94 @@ func newSearchCmd() *cobra.Command {
 	return cmd
 }
 
+func newOpenCmd() *cobra.Command {
+	var cachePath string
+
+	cmd := &cobra.Command{
+		Use:   "open <arg>",
+		Short: "Record an actioned search result and print its arg for Open URL",
+		Long: `Record the repo behind an actioned Alfred result in the usage history kept
+alongside the gh.yml cache, then print the arg unchanged and without a
+trailing newline so the workflow can hand it to Open URL. Docs and nixpkgs
+links pass through unrecorded.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := usecase.RunOpen(usecase.OpenInput{CachePath: cachePath, Arg: args[0]})
+			if err != nil {
+				// Recording is best effort; the repo must still open.
+				fmt.Fprintf(os.Stderr, "record history: %v\n", err)
+
+				return writeArg(args[0])
+			}
+
+			return writeArg(result.Arg)
+		},
+	}
+
+	cmd.Flags().StringVar(&cachePath, "cache", ghindex.DefaultConfigPath, "Local cache path (history is stored alongside)")
+
+	return cmd
+}
+
+func newHistoryCmd() *cobra.Command {
+	var cachePath string
+	var reset bool
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history [repo...]",
+		Short: "Show or reset the usage history that ranks search results",
+		Long: `Show repos by frecency (use count decayed by age), highest first.
+--reset forgets the given repos (owner/name or GitHub URL), or all repos when
+none are given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && !reset {
+				return errors.New("repo arguments require --reset")
+			}
+			result, err := usecase.RunHistory(usecase.HistoryInput{
+				CachePath: cachePath,
+				Reset:     reset,
+				Repos:     args,
+				Limit:     limit,
+			})
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(result)
+			}
+
+			return writeOutput(formatHistory(result, reset))
+		},
+	}
+
+	cmd.Flags().StringVar(&cachePath, "cache", ghindex.DefaultConfigPath, "Local cache path (history is stored alongside)")
+	cmd.Flags().BoolVar(&reset, "reset", false, "Forget the given repos, or all repos")
+	cmd.Flags().IntVar(&limit, "limit", 20, "Maximum repos to show (0 = all)")
+
+	return cmd
+}
+
+func formatHistory(result *usecase.HistoryResult, reset bool) string {
+	var b strings.Builder
+	if reset {
+		fmt.Fprintf(&b, "Removed %d repo(s) from %s\n", result.Removed, result.Path)
+	}
+	if len(result.Items) == 0 {
+		b.WriteString("No usage history")
+
+		return b.String()
+	}
+	for i, item := range result.Items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%6.2f  %3dx  %s  %s", item.Frecency, item.Count, item.LastUsed.Format(time.DateOnly), item.Repo)
+	}
+
+	return b.String()
+}
+
 func newSyncCmd() *cobra.Command {
 	var configURL, cachePath string
 
@@ -176,6 +279,14 @@ func writeOutput(s string) error {
 	return err
 }
 
+// writeArg prints s as is: Alfred passes stdout to Open URL as {query}, so a
+// trailing newline would end up in the URL.
+func writeArg(s string) error {
+	_, err := os.Stdout.WriteString(s)
+
+	return err
+}
+
 func runSearchOutput(repos ghindex.Repos, docsURL, query string, cmd *cobra.Command) error {
 	format := output.GetFormat(cmd)
 	if format == output.FormatJSON {
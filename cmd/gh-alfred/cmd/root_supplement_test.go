@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -257,3 +258,55 @@ func TestSearchCmdRunE_NoArgsDefaultQuery(t *testing.T) {
 	got := stdout()
 	require.Contains(t, got, "acme/tool")
 }
+
+func TestOpenCmd_WritesArgWithoutNewline(t *testing.T) {
+	const arg = "https://github.com/acme/tool?tab=readme"
+	stdout := captureStdout(t)
+	root := newRootCmd()
+	root.SetArgs([]string{"open", arg, "--cache", filepath.Join(t.TempDir(), "gh.yml")})
+	require.NoError(t, root.Execute())
+	require.Equal(t, []byte(arg), []byte(stdout()))
+}
+
+func TestOpenAndHistoryCmds_RecordAndRankRepos(t *testing.T) {
+	cacheDir := t.TempDir()
+	cacheFile := filepath.Join(cacheDir, "gh.yml")
+	require.NoError(t, os.WriteFile(cacheFile, []byte(`- type: tool
+  tag: dev
+  repo:
+    - url: https://github.com/acme/tool-a
+    - url: https://github.com/acme/tool-b
+`), 0o600))
+
+	for _, arg := range []string{"https://github.com/acme/tool-b", "https://docs.lucc.dev/x"} {
+		stdout := captureStdout(t)
+		root := newRootCmd()
+		root.SetArgs([]string{"open", arg, "--cache", cacheFile})
+		require.NoError(t, root.Execute())
+		require.Equal(t, arg, stdout())
+	}
+	require.FileExists(t, filepath.Join(cacheDir, "gh-history.json"))
+
+	stdout := captureStdout(t)
+	root := newRootCmd()
+	root.SetArgs([]string{"search", "tool", "--cache", cacheFile, "--format", "json"})
+	require.NoError(t, root.Execute())
+	got := stdout()
+	require.Less(t, strings.Index(got, "acme/tool-b"), strings.Index(got, "acme/tool-a"))
+
+	stdout = captureStdout(t)
+	root = newRootCmd()
+	root.SetArgs([]string{"history", "--cache", cacheFile})
+	require.NoError(t, root.Execute())
+	require.Contains(t, stdout(), "1x")
+
+	stdout = captureStdout(t)
+	root = newRootCmd()
+	root.SetArgs([]string{"history", "--reset", "acme/tool-b", "--cache", cacheFile})
+	require.NoError(t, root.Execute())
+	require.Contains(t, stdout(), "Removed 1 repo(s)")
+
+	root = newRootCmd()
+	root.SetArgs([]string{"history", "acme/tool-b", "--cache", cacheFile})
+	require.ErrorContains(t, root.Execute(), "require --reset")
+}
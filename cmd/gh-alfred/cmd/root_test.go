@@ -18,7 +18,7 @@ func TestRootCommandOwnsAlfredActions(t *testing.T) {
 	root := newRootCmd()
 
 	require.Equal(t, "gh-alfred", root.Name())
-	requireCommandNames(t, root.Commands(), []string{"export", "schema", "search", "sync", "validate"})
+	requireCommandNames(t, root.Commands(), []string{"export", "history", "open", "schema", "search", "sync", "validate"})
 }
 
 // TestWorkflowPlist_Metadata locks Alfred gallery fields to the shared
@@ -58,7 +58,8 @@ func TestAkJSON_Metadata(t *testing.T) {
 }
 
 // TestWorkflowPlist_ActionGraph locks the thin-executor design:
-// one Open URL (mods share it via JSON) + one Clipboard on ⌘.
+// one Open URL (mods share it via JSON) behind the gh-alfred open history
+// recorder + one Clipboard on ⌘.
 func TestWorkflowPlist_ActionGraph(t *testing.T) {
 	if runtime.GOOS != "darwin" {
 		t.Skip("plutil only on darwin")
@@ -72,7 +73,7 @@ func TestWorkflowPlist_ActionGraph(t *testing.T) {
 	require.NoError(t, json.Unmarshal(out, &data))
 
 	objects, _ := data["objects"].([]any)
-	var openURLUIDs, clipboardUIDs, scriptFilterUIDs []string
+	var openURLUIDs, clipboardUIDs, scriptFilterUIDs, scriptUIDs []string
 	for _, rawObj := range objects {
 		obj, _ := rawObj.(map[string]any)
 		uid, _ := obj["uid"].(string)
@@ -84,16 +85,21 @@ func TestWorkflowPlist_ActionGraph(t *testing.T) {
 			clipboardUIDs = append(clipboardUIDs, uid)
 		case strings.Contains(typ, "scriptfilter"):
 			scriptFilterUIDs = append(scriptFilterUIDs, uid)
+		case strings.Contains(typ, "action.script"):
+			scriptUIDs = append(scriptUIDs, uid)
+			cfg, _ := obj["config"].(map[string]any)
+			assert.Contains(t, cfg["script"], "gh-alfred open")
 		}
 	}
 	require.Len(t, openURLUIDs, 1, "exactly one Open URL executor")
 	require.Len(t, clipboardUIDs, 1, "exactly one Clipboard executor")
 	require.Len(t, scriptFilterUIDs, 1)
+	require.Len(t, scriptUIDs, 1, "exactly one history recorder")
 
 	sfUID := scriptFilterUIDs[0]
 	conns, _ := data["connections"].(map[string]any)
 	sfConns, _ := conns[sfUID].([]any)
-	require.Len(t, sfConns, 2, "SF should only fan out to Open URL + Clipboard")
+	require.Len(t, sfConns, 2, "SF should only fan out to the recorder + Clipboard")
 
 	const modNone = 0
 	const modCmd = 1048576 // ⌘
@@ -103,8 +109,8 @@ func TestWorkflowPlist_ActionGraph(t *testing.T) {
 		dest, _ := c["destinationuid"].(string)
 		mods := int(asFloat(c["modifiers"]))
 		switch dest {
-		case openURLUIDs[0]:
-			assert.Equal(t, modNone, mods, "Open URL must be default (no modifier) connection")
+		case scriptUIDs[0]:
+			assert.Equal(t, modNone, mods, "Recorder must be default (no modifier) connection")
 			sawOpen = true
 		case clipboardUIDs[0]:
 			assert.Equal(t, modCmd, mods, "Clipboard must be on cmd modifier")
@@ -115,6 +121,11 @@ func TestWorkflowPlist_ActionGraph(t *testing.T) {
 	}
 	assert.True(t, sawOpen)
 	assert.True(t, sawClip)
+
+	scriptConns, _ := conns[scriptUIDs[0]].([]any)
+	require.Len(t, scriptConns, 1, "recorder should only hand off to Open URL")
+	c, _ := scriptConns[0].(map[string]any)
+	assert.Equal(t, openURLUIDs[0], c["destinationuid"])
 }
 
 func asFloat(v any) float64 {
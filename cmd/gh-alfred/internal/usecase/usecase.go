@@ -35,7 +35,88 @@ func RunSearch(input SearchInput) (*SearchResult, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &SearchResult{Repos: manager.Filter(input.Query)}, nil
+	// A broken history only costs the ranking boost, never the search.
+	opts := ghindex.SearchOptions{}
+	if history, err := ghindex.LoadHistory(manager.HistoryPath()); err != nil {
+		slog.Warn("Ignoring gh-alfred history", "error", err)
+	} else {
+		opts.Recent = history.Scores(time.Now())
+	}
+
+	return &SearchResult{Repos: manager.Search(input.Query, opts)}, nil
+}
+
+// OpenInput holds input for recording an actioned Alfred result.
+type OpenInput struct {
+	CachePath string
+	Arg       string
+}
+
+// OpenResult holds the recorded repo, empty when Arg is not a GitHub repo.
+type OpenResult struct {
+	Arg  string
+	Repo string
+}
+
+// RunOpen records Arg in the usage history kept alongside the cache, so
+// later searches rank it higher. Args that are not GitHub repos (docs or
+// nixpkgs links) pass through unrecorded.
+func RunOpen(input OpenInput) (*OpenResult, error) {
+	history, err := ghindex.LoadHistory(ghindex.HistoryPathFor(input.CachePath))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &OpenResult{Arg: input.Arg}
+	repo, ok := history.Record(input.Arg, time.Now())
+	if !ok {
+		return result, nil
+	}
+	if err := history.Save(); err != nil {
+		return nil, err
+	}
+	result.Repo = repo
+
+	return result, nil
+}
+
+// HistoryInput holds input for inspecting or resetting the usage history.
+type HistoryInput struct {
+	CachePath string
+	// Reset removes Repos from the history, or everything when Repos is empty.
+	Reset bool
+	Repos []string
+	Limit int
+}
+
+// HistoryResult holds the usage history, ranked by frecency.
+type HistoryResult struct {
+	Path    string                `json:"path"`
+	Items   []ghindex.HistoryItem `json:"items"`
+	Removed int                   `json:"removed,omitempty"`
+}
+
+// RunHistory lists the usage history, or resets it with Reset.
+func RunHistory(input HistoryInput) (*HistoryResult, error) {
+	path := ghindex.HistoryPathFor(input.CachePath)
+	history, err := ghindex.LoadHistory(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HistoryResult{Path: path}
+	if input.Reset {
+		result.Removed = history.Reset(input.Repos...)
+		if err := history.Save(); err != nil {
+			return nil, err
+		}
+	}
+	result.Items = history.Ranked(time.Now())
+	if input.Limit > 0 && len(result.Items) > input.Limit {
+		result.Items = result.Items[:input.Limit]
+	}
+
+	return result, nil
 }
 
 // SyncInput holds input for Alfred cache sync.
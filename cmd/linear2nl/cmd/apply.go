@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+// issueMutator is the subset of *linear.Client used to apply plan actions.
+type issueMutator interface {
+	MoveIssue(ctx context.Context, identifier, stateName string) (*linear.Issue, error)
+	UpdateIssue(ctx context.Context, id string, in *linear.UpdateIssueInput) (*linear.Issue, error)
+	CreateComment(ctx context.Context, issueID, body string) (*linear.Comment, error)
+	CreateIssue(ctx context.Context, in *linear.CreateIssueInput) (*linear.Issue, error)
+	ResolveTeamID(ctx context.Context, teamKey string) (string, error)
+	ViewerID(ctx context.Context) (string, error)
+}
+
+// planMutation is a validated plan action bound to the issue it changes.
+type planMutation struct {
+	action internal.PlanActionJSON
+	issue  linear.IssueDetail
+	from   string
+	to     string
+}
+
+// applyPlanActions shows the planned mutations as a diff, then applies them
+// after confirmation. --dry-run stops after the diff; --yes skips the prompt.
+func applyPlanActions(cfg *internal.Config, actions []internal.PlanActionJSON, details []linear.IssueDetail, opts reportOptions) error {
+	muts, skipped := planMutations(actions, details)
+	for _, s := range skipped {
+		slog.Warn("skipping plan action", "reason", s)
+	}
+	if len(muts) == 0 {
+		slog.Info("no plan actions to apply")
+
+		return nil
+	}
+
+	fmt.Print(formatMutationDiff(muts)) //nolint:forbidigo // diff is shown before confirmation
+	if opts.dryRun {
+		slog.Info("dry-run: no changes applied", "actions", len(muts))
+
+		return nil
+	}
+	if !opts.yes && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("Apply %d change(s) to Linear?", len(muts))) {
+		slog.Info("aborted, no changes applied")
+
+		return nil
+	}
+
+	applied, err := applyMutations(context.Background(), newLinearClient(cfg), muts)
+	slog.Info("plan actions applied", "applied", applied, "total", len(muts))
+
+	return err
+}
+
+// planMutations validates AI actions against the current issues. Actions that
+// reference unknown issues, carry no usable value or would not change anything
+// are returned as skip reasons instead.
+func planMutations(actions []internal.PlanActionJSON, details []linear.IssueDetail) ([]planMutation, []string) {
+	byID := lo.KeyBy(details, func(d linear.IssueDetail) string { return d.Identifier })
+
+	var muts []planMutation
+	var skipped []string
+	for _, a := range actions {
+		issue, ok := byID[a.Identifier]
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s %s: unknown issue", a.Identifier, a.Type))
+			continue
+		}
+
+		m := planMutation{action: a, issue: issue}
+		var reason string
+		switch a.Type {
+		case internal.PlanActionMove:
+			m.from, m.to = issue.StateName, strings.TrimSpace(a.State)
+			if m.to == "" || m.to == m.from {
+				reason = "no state change"
+			}
+		case internal.PlanActionPriority:
+			if a.Priority == nil || *a.Priority < 0 || *a.Priority > 4 {
+				reason = "priority must be 0-4"
+				break
+			}
+			m.from, m.to = internal.PriorityName(issue.Priority), internal.PriorityName(float64(*a.Priority))
+			if float64(*a.Priority) == issue.Priority {
+				reason = "priority unchanged"
+			}
+		case internal.PlanActionEstimate:
+			if a.Estimate == nil || *a.Estimate < 0 {
+				reason = "estimate must be >= 0"
+				break
+			}
+			m.from, m.to = internal.FormatEstimate(issue.Estimate), internal.FormatEstimate(*a.Estimate)
+			if *a.Estimate == issue.Estimate {
+				reason = "estimate unchanged"
+			}
+		case internal.PlanActionComment:
+			m.to = strings.TrimSpace(a.Body)
+			if m.to == "" {
+				reason = "empty comment"
+			}
+		case internal.PlanActionSplit:
+			titles := lo.Compact(lo.Map(a.Titles, func(t string, _ int) string { return strings.TrimSpace(t) }))
+			m.action.Titles = titles
+			m.to = strings.Join(titles, "; ")
+			if len(titles) == 0 {
+				reason = "no sub-issue titles"
+			}
+		default:
+			reason = "unsupported action type"
+		}
+		if reason != "" {
+			skipped = append(skipped, fmt.Sprintf("%s %s: %s", a.Identifier, a.Type, reason))
+			continue
+		}
+		muts = append(muts, m)
+	}
+
+	return muts, skipped
+}
+
+// formatMutationDiff renders planned mutations one per line, diff-style.
+func formatMutationDiff(muts []planMutation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Planned Linear changes (%d):\n", len(muts))
+	for _, m := range muts {
+		fmt.Fprintf(&b, "\n%s %s\n", m.issue.Identifier, m.issue.Title)
+		switch m.action.Type {
+		case internal.PlanActionComment:
+			fmt.Fprintf(&b, "  + comment: %s\n", firstLine(m.to))
+		case internal.PlanActionSplit:
+			for _, t := range m.action.Titles {
+				fmt.Fprintf(&b, "  + sub-issue: %s\n", t)
+			}
+		default:
+			fmt.Fprintf(&b, "  - %s: %s\n  + %s: %s\n", m.action.Type, m.from, m.action.Type, m.to)
+		}
+		if m.action.Reason != "" {
+			fmt.Fprintf(&b, "  # %s\n", m.action.Reason)
+		}
+	}
+
+	return b.String()
+}
+
+// applyMutations runs every mutation, continuing past failures.
+// It returns the number applied and the joined errors.
+func applyMutations(ctx context.Context, client issueMutator, muts []planMutation) (int, error) {
+	var errs []error
+	teamIDs := map[string]string{}
+	var viewerID string
+	applied := 0
+
+	for _, m := range muts {
+		id := m.issue.Identifier
+		var err error
+		switch m.action.Type {
+		case internal.PlanActionMove:
+			_, err = client.MoveIssue(ctx, id, m.to)
+		case internal.PlanActionPriority:
+			_, err = client.UpdateIssue(ctx, id, &linear.UpdateIssueInput{Priority: *m.action.Priority, Estimate: -1})
+		case internal.PlanActionEstimate:
+			_, err = client.UpdateIssue(ctx, id, &linear.UpdateIssueInput{Priority: -1, Estimate: *m.action.Estimate})
+		case internal.PlanActionComment:
+			_, err = client.CreateComment(ctx, id, m.to)
+		case internal.PlanActionSplit:
+			err = splitIssue(ctx, client, &m, teamIDs, &viewerID)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", id, m.action.Type, err))
+			continue
+		}
+		applied++
+		slog.Info("applied plan action", "issue", id, "type", m.action.Type)
+	}
+
+	return applied, errors.Join(errs...)
+}
+
+// splitIssue creates one sub-issue per title under m.issue, assigned to the viewer.
+func splitIssue(ctx context.Context, client issueMutator, m *planMutation, teamIDs map[string]string, viewerID *string) error {
+	teamID, ok := teamIDs[m.issue.TeamKey]
+	if !ok {
+		var err error
+		if teamID, err = client.ResolveTeamID(ctx, m.issue.TeamKey); err != nil {
+			return err
+		}
+		teamIDs[m.issue.TeamKey] = teamID
+	}
+	if *viewerID == "" {
+		id, err := client.ViewerID(ctx)
+		if err != nil {
+			return err
+		}
+		*viewerID = id
+	}
+
+	parentID := m.issue.ID
+	if parentID == "" {
+		parentID = m.issue.Identifier
+	}
+	for _, title := range m.action.Titles {
+		if _, err := client.CreateIssue(ctx, &linear.CreateIssueInput{
+			TeamID:     teamID,
+			Title:      title,
+			ParentID:   parentID,
+			AssigneeID: *viewerID,
+			Priority:   -1,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// confirm asks a yes/no question on out and reads the answer from in.
+// Anything but "y"/"yes" (including EOF) is a no.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+
+	return answer == "y" || answer == "yes"
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+
+	return line
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+// fakeMutator records calls instead of talking to Linear.
+type fakeMutator struct {
+	failOn string
+	calls  []string
+}
+
+func (f *fakeMutator) record(call string) error {
+	f.calls = append(f.calls, call)
+	if f.failOn != "" && strings.HasPrefix(call, f.failOn) {
+		return errors.New("boom")
+	}
+
+	return nil
+}
+
+func (f *fakeMutator) MoveIssue(_ context.Context, id, state string) (*linear.Issue, error) {
+	return &linear.Issue{}, f.record("move " + id + " " + state)
+}
+
+func (f *fakeMutator) UpdateIssue(_ context.Context, id string, in *linear.UpdateIssueInput) (*linear.Issue, error) {
+	return &linear.Issue{}, f.record(fmt.Sprintf("update %s p=%d e=%g", id, in.Priority, in.Estimate))
+}
+
+func (f *fakeMutator) CreateComment(_ context.Context, id, body string) (*linear.Comment, error) {
+	return &linear.Comment{}, f.record("comment " + id + " " + body)
+}
+
+func (f *fakeMutator) CreateIssue(_ context.Context, in *linear.CreateIssueInput) (*linear.Issue, error) {
+	return &linear.Issue{}, f.record("create " + in.TeamID + " " + in.ParentID + " " + in.AssigneeID + " " + in.Title)
+}
+
+func (f *fakeMutator) ResolveTeamID(_ context.Context, key string) (string, error) {
+	return "team-" + key, f.record("team " + key)
+}
+
+func (f *fakeMutator) ViewerID(context.Context) (string, error) {
+	return "me", f.record("viewer")
+}
+
+func ptr[T any](v T) *T { return &v }
+
+var applyDetails = []linear.IssueDetail{
+	{ID: "uuid-1", Identifier: "LUC-1", Title: "One", StateName: "Todo", TeamKey: "LUC", Priority: 3, Estimate: 2},
+	{ID: "uuid-2", Identifier: "LUC-2", Title: "Two", StateName: "In Progress", TeamKey: "LUC"},
+}
+
+func TestPlanMutations(t *testing.T) {
+	muts, skipped := planMutations([]internal.PlanActionJSON{
+		{Identifier: "LUC-1", Type: internal.PlanActionMove, State: "In Progress", Reason: "today"},
+		{Identifier: "LUC-1", Type: internal.PlanActionPriority, Priority: ptr(2)},
+		{Identifier: "LUC-1", Type: internal.PlanActionEstimate, Estimate: ptr(5.0)},
+		{Identifier: "LUC-2", Type: internal.PlanActionComment, Body: " note "},
+		{Identifier: "LUC-2", Type: internal.PlanActionSplit, Titles: []string{"a", " ", "b"}},
+		// Skipped:
+		{Identifier: "LUC-9", Type: internal.PlanActionMove, State: "Done"},
+		{Identifier: "LUC-2", Type: internal.PlanActionMove, State: "In Progress"},
+		{Identifier: "LUC-1", Type: internal.PlanActionPriority, Priority: ptr(3)},
+		{Identifier: "LUC-1", Type: internal.PlanActionPriority, Priority: ptr(9)},
+		{Identifier: "LUC-1", Type: internal.PlanActionEstimate},
+		{Identifier: "LUC-1", Type: internal.PlanActionComment},
+		{Identifier: "LUC-1", Type: internal.PlanActionSplit},
+		{Identifier: "LUC-1", Type: "delete"},
+	}, applyDetails)
+
+	require.Len(t, muts, 5)
+	assert.Equal(t, "Todo", muts[0].from)
+	assert.Equal(t, "In Progress", muts[0].to)
+	assert.Equal(t, "Medium", muts[1].from)
+	assert.Equal(t, "High", muts[1].to)
+	assert.Equal(t, "5", muts[2].to)
+	assert.Equal(t, "note", muts[3].to)
+	assert.Equal(t, []string{"a", "b"}, muts[4].action.Titles)
+
+	assert.Len(t, skipped, 8)
+	assert.Contains(t, skipped[0], "unknown issue")
+	assert.Contains(t, skipped[7], "unsupported")
+}
+
+func TestFormatMutationDiff(t *testing.T) {
+	muts, _ := planMutations([]internal.PlanActionJSON{
+		{Identifier: "LUC-1", Type: internal.PlanActionMove, State: "In Progress", Reason: "today"},
+		{Identifier: "LUC-2", Type: internal.PlanActionComment, Body: "line1\nline2"},
+		{Identifier: "LUC-2", Type: internal.PlanActionSplit, Titles: []string{"a"}},
+	}, applyDetails)
+
+	out := formatMutationDiff(muts)
+	assert.Contains(t, out, "Planned Linear changes (3)")
+	assert.Contains(t, out, "  - move: Todo\n  + move: In Progress")
+	assert.Contains(t, out, "# today")
+	assert.Contains(t, out, "+ comment: line1\n")
+	assert.Contains(t, out, "+ sub-issue: a")
+}
+
+func TestApplyMutations(t *testing.T) {
+	muts, _ := planMutations([]internal.PlanActionJSON{
+		{Identifier: "LUC-1", Type: internal.PlanActionMove, State: "Done"},
+		{Identifier: "LUC-1", Type: internal.PlanActionPriority, Priority: ptr(1)},
+		{Identifier: "LUC-1", Type: internal.PlanActionEstimate, Estimate: ptr(3.0)},
+		{Identifier: "LUC-2", Type: internal.PlanActionComment, Body: "hi"},
+		{Identifier: "LUC-2", Type: internal.PlanActionSplit, Titles: []string{"a", "b"}},
+		{Identifier: "LUC-1", Type: internal.PlanActionSplit, Titles: []string{"c"}},
+	}, applyDetails)
+
+	f := &fakeMutator{}
+	applied, err := applyMutations(context.Background(), f, muts)
+	require.NoError(t, err)
+	assert.Equal(t, 6, applied)
+	assert.Equal(t, []string{
+		"move LUC-1 Done",
+		"update LUC-1 p=1 e=-1",
+		"update LUC-1 p=-1 e=3",
+		"comment LUC-2 hi",
+		"team LUC",
+		"viewer",
+		"create team-LUC uuid-2 me a",
+		"create team-LUC uuid-2 me b",
+		"create team-LUC uuid-1 me c",
+	}, f.calls)
+}
+
+func TestApplyMutationsContinuesAfterFailure(t *testing.T) {
+	muts, _ := planMutations([]internal.PlanActionJSON{
+		{Identifier: "LUC-1", Type: internal.PlanActionMove, State: "Done"},
+		{Identifier: "LUC-2", Type: internal.PlanActionComment, Body: "hi"},
+	}, applyDetails)
+
+	f := &fakeMutator{failOn: "move"}
+	applied, err := applyMutations(context.Background(), f, muts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LUC-1 move: boom")
+	assert.Equal(t, 1, applied)
+}
+
+func TestConfirm(t *testing.T) {
+	var out strings.Builder
+	assert.True(t, confirm(strings.NewReader("y\n"), &out, "Apply?"))
+	assert.Equal(t, "Apply? [y/N] ", out.String())
+	assert.True(t, confirm(strings.NewReader("YES"), &out, "Apply?"))
+	assert.False(t, confirm(strings.NewReader("n\n"), &out, "Apply?"))
+	assert.False(t, confirm(strings.NewReader(""), &out, "Apply?"))
+}
+
+func TestApplyPlanActionsDryRunDoesNotMutate(t *testing.T) {
+	cfg := &internal.Config{}
+	err := applyPlanActions(cfg, []internal.PlanActionJSON{
+		{Identifier: "LUC-1", Type: internal.PlanActionMove, State: "Done"},
+	}, applyDetails, reportOptions{apply: true, dryRun: true})
+	require.NoError(t, err)
+}
+
+func TestDecodePlanJSONActions(t *testing.T) {
+	plan := decodePlanJSON(`{"reviews":[],"actions":[{"identifier":"LUC-1","type":"priority","priority":2},{"identifier":"LUC-2","type":"split","titles":["a"]}]}`)
+	require.NotNil(t, plan)
+	require.Len(t, plan.Actions, 2)
+	require.NotNil(t, plan.Actions[0].Priority)
+	assert.Equal(t, 2, *plan.Actions[0].Priority)
+	assert.Equal(t, []string{"a"}, plan.Actions[1].Titles)
+
+	assert.Nil(t, decodePlanJSON("nope"))
+}
+
+func TestNewMorningCmdApplyFlags(t *testing.T) {
+	cmd := newMorningCmd()
+	assert.NotNil(t, cmd.Flags().Lookup("apply"))
+	assert.NotNil(t, cmd.Flags().ShorthandLookup("y"))
+	assert.Nil(t, newEveningCmd().Flags().Lookup("apply"))
+}
@@ -307,7 +307,7 @@ func TestWriteOutputErrorPath(t *testing.T) {
 }
 
 func TestNewReportCmdConfigError(t *testing.T) {
-	cmd := newReportCmd("test", "test cmd", func(cfg *internal.Config, dryRun bool) error {
+	cmd := newReportCmd("test", "test cmd", func(cfg *internal.Config, opts reportOptions) error {
 		return nil
 	})
 	cmd.SetArgs([]string{"--config", "/nonexistent/path/to/config.yml"})
@@ -492,13 +492,13 @@ func TestBuildMorningPlanAINotConfigured(t *testing.T) {
 
 	aiClient := internal.NewAIProvider(internal.AIConfig{APIKey: ""})
 
-	plans := buildMorningPlan(aiClient, nil)
+	plans, _ := buildMorningPlan(aiClient, nil)
 	assert.Nil(t, plans)
 }
 
 func TestBuildMorningPlanEmptyDetails(t *testing.T) {
 	aiClient := internal.NewAIProvider(internal.AIConfig{APIKey: "sk-test"})
-	plans := buildMorningPlan(aiClient, nil)
+	plans, _ := buildMorningPlan(aiClient, nil)
 	assert.Nil(t, plans)
 }
 
@@ -518,7 +518,7 @@ func TestBuildMorningPlanAIValidResponse(t *testing.T) {
 		{Identifier: "LUC-1", Title: "Task", Description: "desc"},
 	}
 
-	plans := buildMorningPlan(aiClient, details)
+	plans, _ := buildMorningPlan(aiClient, details)
 	require.NotNil(t, plans)
 	require.Contains(t, plans, "LUC-1")
 	assert.Contains(t, plans["LUC-1"], "ctx")
@@ -539,7 +539,7 @@ func TestBuildMorningPlanAIEmptyResponse(t *testing.T) {
 		{Identifier: "LUC-1", Title: "Task", Description: "desc"},
 	}
 
-	plans := buildMorningPlan(aiClient, details)
+	plans, _ := buildMorningPlan(aiClient, details)
 	assert.Nil(t, plans)
 }
 
@@ -558,7 +558,7 @@ func TestBuildMorningPlanAIInvalidJSON(t *testing.T) {
 		{Identifier: "LUC-1", Title: "Task", Description: "desc"},
 	}
 
-	plans := buildMorningPlan(aiClient, details)
+	plans, _ := buildMorningPlan(aiClient, details)
 	assert.Nil(t, plans)
 }
 
@@ -1213,7 +1213,7 @@ func TestBuildMorningPlanMultipleIssues(t *testing.T) {
 		{Identifier: "LUC-2", Title: "Task 2", Description: "desc2"},
 	}
 
-	plans := buildMorningPlan(aiClient, details)
+	plans, _ := buildMorningPlan(aiClient, details)
 	require.NotNil(t, plans)
 	require.Contains(t, plans, "LUC-1")
 	require.Contains(t, plans, "LUC-2")
@@ -21,16 +21,24 @@ func newEveningCmd() *cobra.Command {
 	return newReportCmd("evening", "Send evening report with today's accomplishments", runEvening)
 }
 
-func runEvening(cfg *internal.Config, dryRun bool) error {
-	ctx := context.Background()
-	client := linear.NewClient(cfg.Linear.APIKey, cfg.Linear.TeamKeys)
+func runEvening(cfg *internal.Config, opts reportOptions) error {
+	dryRun := opts.dryRun
 	aiClient := internal.NewAIProvider(cfg.AI)
 
 	todayStart := carbon.Yesterday().StartOfDay().StdTime()
 
-	eq, err := queryEveningData(ctx, client, todayStart)
-	if err != nil {
-		return err
+	var eq eveningQueryData
+	var err error
+	if opts.fromSnapshot {
+		eq, err = eveningDataFromSnapshot(internal.NewSnapshotStore(cfg.Snapshot.Dir), todayStart)
+		if err != nil {
+			return fmt.Errorf("load snapshot: %w", err)
+		}
+	} else {
+		eq, err = queryEveningData(context.Background(), newLinearClient(cfg), todayStart)
+		if err != nil {
+			return err
+		}
 	}
 
 	completed, changes, inProgress, updatedDetails := eq.completed, eq.changes, eq.inProgress, eq.updatedDetails
@@ -37,7 +37,7 @@ Default: JSON, last 2 days, written to linear2nl_export_<date>.json.`,
 			since := carbon.Now().SubDays(days).StartOfDay().StdTime()
 			slog.Info("fetching issues", "since", since.Format(time.RFC3339), "days", days)
 
-			client := linear.NewClient(cfg.Linear.APIKey, cfg.Linear.TeamKeys)
+			client := newLinearClient(cfg)
 			details, err := client.GetUpdatedIssuesWithDetails(context.Background(), since)
 			if err != nil {
 				return err
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+)
+
+func newIssueCmd() *cobra.Command {
+	var cfgFile string
+
+	cmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Create, update, move and comment on Linear issues",
+	}
+
+	cmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "cmd/linear2nl/linear2nl.yml", "config file path")
+	cmd.AddCommand(newIssueCreateCmd(&cfgFile))
+	cmd.AddCommand(newIssueUpdateCmd(&cfgFile))
+	cmd.AddCommand(newIssueMoveCmd(&cfgFile))
+	cmd.AddCommand(newIssueCommentCmd(&cfgFile))
+
+	return cmd
+}
+
+func newIssueCreateCmd(cfgFile *string) *cobra.Command {
+	var (
+		team, title, description, state, parent string
+		priority                                int
+		unassigned                              bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an issue (assigned to you unless --unassigned)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, client, err := loadIssueClient(*cfgFile)
+			if err != nil {
+				return err
+			}
+			if team == "" && len(cfg.Linear.TeamKeys) > 0 {
+				team = cfg.Linear.TeamKeys[0]
+			}
+			if team == "" {
+				return fmt.Errorf("--team is required when linear.teamKeys is empty")
+			}
+
+			ctx := context.Background()
+			in := &linear.CreateIssueInput{Title: title, Description: description, Priority: priority, ParentID: parent}
+			if in.TeamID, err = client.ResolveTeamID(ctx, team); err != nil {
+				return err
+			}
+			if state != "" {
+				if in.StateID, err = client.ResolveStateID(ctx, in.TeamID, state); err != nil {
+					return err
+				}
+			}
+			if !unassigned {
+				if in.AssigneeID, err = client.ViewerID(ctx); err != nil {
+					return err
+				}
+			}
+
+			issue, err := client.CreateIssue(ctx, in)
+			if err != nil {
+				return err
+			}
+
+			return printIssue(cmd, "created", issue)
+		},
+	}
+
+	cmd.Flags().StringVar(&team, "team", "", "team key (default: first linear.teamKeys entry)")
+	cmd.Flags().StringVar(&title, "title", "", "issue title")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "issue description (Markdown)")
+	cmd.Flags().StringVar(&state, "state", "", "initial workflow state name (default: team default)")
+	cmd.Flags().StringVar(&parent, "parent", "", "parent issue id or identifier, creates a sub-issue")
+	cmd.Flags().IntVar(&priority, "priority", -1, "priority 0-4 (0 none, 1 urgent … 4 low)")
+	cmd.Flags().BoolVar(&unassigned, "unassigned", false, "do not assign the issue to yourself")
+	_ = cmd.MarkFlagRequired("title")
+
+	return cmd
+}
+
+func newIssueUpdateCmd(cfgFile *string) *cobra.Command {
+	var (
+		title, description string
+		priority           int
+		estimate           float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update <identifier>",
+		Short: "Update title, description, priority or estimate of an issue",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := &linear.UpdateIssueInput{Title: title, Description: description, Priority: priority, Estimate: estimate}
+			if in.IsEmpty() {
+				return fmt.Errorf("nothing to update: set --title, --description, --priority or --estimate")
+			}
+
+			_, client, err := loadIssueClient(*cfgFile)
+			if err != nil {
+				return err
+			}
+			issue, err := client.UpdateIssue(context.Background(), args[0], in)
+			if err != nil {
+				return err
+			}
+
+			return printIssue(cmd, "updated", issue)
+		},
+	}
+
+	cmd.Flags().StringVar(&title, "title", "", "new title")
+	cmd.Flags().StringVarP(&description, "description", "d", "", "new description (Markdown)")
+	cmd.Flags().IntVar(&priority, "priority", -1, "priority 0-4 (0 none, 1 urgent … 4 low)")
+	cmd.Flags().Float64Var(&estimate, "estimate", -1, "estimate in points")
+
+	return cmd
+}
+
+func newIssueMoveCmd(cfgFile *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "move <identifier> <state>",
+		Short: "Move an issue to a workflow state of its team",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, client, err := loadIssueClient(*cfgFile)
+			if err != nil {
+				return err
+			}
+			issue, err := client.MoveIssue(context.Background(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			return printIssue(cmd, "moved", issue)
+		},
+	}
+}
+
+func newIssueCommentCmd(cfgFile *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "comment <identifier> <body...>",
+		Short: "Add a Markdown comment to an issue",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, client, err := loadIssueClient(*cfgFile)
+			if err != nil {
+				return err
+			}
+			comment, err := client.CreateComment(context.Background(), args[0], strings.Join(args[1:], " "))
+			if err != nil {
+				return err
+			}
+
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(map[string]any{
+					"issue":     args[0],
+					"body":      comment.Body,
+					"createdAt": comment.CreatedAt,
+				})
+			}
+			fmt.Printf("commented on %s\n", args[0]) //nolint:forbidigo // CLI result
+
+			return nil
+		},
+	}
+}
+
+func loadIssueClient(cfgFile string) (*internal.Config, *linear.Client, error) {
+	cfg, err := internal.LoadConfig(cfgFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, newLinearClient(cfg), nil
+}
+
+func printIssue(cmd *cobra.Command, verb string, issue *linear.Issue) error {
+	if output.GetFormat(cmd) == output.FormatJSON {
+		return output.WriteJSON(map[string]any{
+			"identifier": issue.Identifier,
+			"title":      issue.Title,
+			"state":      issue.StateName,
+			"priority":   issue.Priority,
+			"url":        issue.URL,
+		})
+	}
+	fmt.Printf("%s %s %s [%s] %s\n", verb, issue.Identifier, issue.Title, issue.StateName, issue.URL) //nolint:forbidigo // CLI result
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIssueCmdHasSubcommands(t *testing.T) {
+	cmd := newIssueCmd()
+	names := make([]string, 0, len(cmd.Commands()))
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"create", "update", "move", "comment"}, names)
+}
+
+func TestIssueCreateRequiresTitle(t *testing.T) {
+	cmd := newIssueCmd()
+	cmd.SetArgs([]string{"create", "--config", "/nonexistent/config.yml"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "title")
+}
+
+func TestIssueUpdateRequiresField(t *testing.T) {
+	cmd := newIssueCmd()
+	cmd.SetArgs([]string{"update", "LUC-1", "--config", "/nonexistent/config.yml"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to update")
+}
+
+func TestIssueMoveArgs(t *testing.T) {
+	cmd := newIssueCmd()
+	cmd.SetArgs([]string{"move", "LUC-1"})
+	require.Error(t, cmd.Execute())
+}
+
+func TestIssueCommentConfigError(t *testing.T) {
+	cmd := newIssueCmd()
+	cmd.SetArgs([]string{"comment", "LUC-1", "hello", "world", "--config", "/nonexistent/config.yml"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read config")
+}
@@ -15,17 +15,26 @@ import (
 )
 
 func newMorningCmd() *cobra.Command {
-	return newReportCmd("morning", "Send morning report with today's tasks", runMorning)
+	var apply, yes bool
+
+	cmd := newReportCmd("morning", "Send morning report with today's tasks", func(cfg *internal.Config, opts reportOptions) error {
+		opts.apply, opts.yes = apply, yes
+
+		return runMorning(cfg, opts)
+	})
+	cmd.Flags().BoolVar(&apply, "apply", false, "apply the AI plan's suggested actions (move/priority/estimate/comment/split) to Linear after confirmation")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "with --apply, skip the confirmation prompt")
+
+	return cmd
 }
 
-func runMorning(cfg *internal.Config, dryRun bool) error {
-	ctx := context.Background()
-	client := linear.NewClient(cfg.Linear.APIKey, cfg.Linear.TeamKeys)
+func runMorning(cfg *internal.Config, opts reportOptions) error {
+	dryRun := opts.dryRun
 	aiClient := internal.NewAIProvider(cfg.AI)
 
-	details, err := client.GetActiveIssuesWithDetails(ctx)
+	details, err := morningDetails(cfg, opts.fromSnapshot)
 	if err != nil {
-		return fmt.Errorf("query active issues with details: %w", err)
+		return err
 	}
 
 	if len(details) == 0 {
@@ -44,7 +53,7 @@ func runMorning(cfg *internal.Config, dryRun bool) error {
 	}
 
 	// AI plan (single stage).
-	plans := buildMorningPlan(aiClient, issueDetails)
+	plans, actions := buildMorningPlan(aiClient, issueDetails)
 
 	now := carbon.Now()
 	dateStr := now.ToDateString()
@@ -82,28 +91,70 @@ func runMorning(cfg *internal.Config, dryRun bool) error {
 
 	subject := fmt.Sprintf("Linear 今日任务 · %s %s", dateStr, dayOfWeek)
 
-	return sendOrWrite(cfg, subject, htmlBody, "morning", dryRun)
+	if err := sendOrWrite(cfg, subject, htmlBody, "morning", dryRun); err != nil {
+		return err
+	}
+	if opts.apply {
+		return applyPlanActions(cfg, actions, details, opts)
+	}
+
+	return nil
+}
+
+// morningDetails loads the open issues for the morning report,
+// either live from Linear or from the latest local snapshot.
+func morningDetails(cfg *internal.Config, fromSnapshot bool) ([]linear.IssueDetail, error) {
+	if fromSnapshot {
+		snap, err := internal.NewSnapshotStore(cfg.Snapshot.Dir).Latest()
+		if err != nil {
+			return nil, fmt.Errorf("load snapshot: %w", err)
+		}
+		slog.Info("reading from snapshot", "takenAt", snap.TakenAt)
+
+		return activeDetailsFromSnapshot(snap), nil
+	}
+
+	details, err := newLinearClient(cfg).GetActiveIssuesWithDetails(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("query active issues with details: %w", err)
+	}
+
+	return details, nil
 }
 
 // buildMorningPlan generates per-issue plan using AI.
-// Returns a map of identifier → rendered markdown.
-func buildMorningPlan(aiClient *internal.AIProvider, details []internal.IssueDetail) map[string]string {
+// Returns a map of identifier → rendered markdown and the suggested actions.
+func buildMorningPlan(aiClient *internal.AIProvider, details []internal.IssueDetail) (map[string]string, []internal.PlanActionJSON) {
 	if len(details) == 0 || !aiClient.IsConfigured() {
-		return nil
+		return nil, nil
 	}
 
 	raw := aiClient.MorningPlan(details)
 	if raw == "" {
 		slog.Warn("AI returned empty response")
 
-		return nil
+		return nil, nil
 	}
 	slog.Info("AI raw response preview", "len", len(raw), "raw", raw[:min(len(raw), 2000)])
 
-	return parsePlanJSON(raw)
+	plan := decodePlanJSON(raw)
+	if plan == nil {
+		return nil, nil
+	}
+
+	return renderPlanReviews(plan), plan.Actions
 }
 
 func parsePlanJSON(raw string) map[string]string {
+	plan := decodePlanJSON(raw)
+	if plan == nil {
+		return nil
+	}
+
+	return renderPlanReviews(plan)
+}
+
+func decodePlanJSON(raw string) *internal.PlanJSON {
 	var result internal.PlanJSON
 	if err := json.Unmarshal([]byte(raw), &result); err != nil {
 		slog.Warn("failed to parse AI plan JSON", "error", err)
@@ -111,6 +162,10 @@ func parsePlanJSON(raw string) map[string]string {
 		return nil
 	}
 
+	return &result
+}
+
+func renderPlanReviews(result *internal.PlanJSON) map[string]string {
 	plans := make(map[string]string, len(result.Reviews))
 	for _, r := range result.Reviews { //nolint:dupl // structurally similar to evening but different types/headings
 		var sections []md.ReviewSection
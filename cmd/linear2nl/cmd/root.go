@@ -18,11 +18,20 @@ import (
 	"github.com/xbpk3t/docs-alfred/pkg/validator"
 )
 
+// reportOptions are the flags shared by the morning/evening reports.
+// apply and yes are set by morning only.
+type reportOptions struct {
+	dryRun       bool
+	fromSnapshot bool
+	apply        bool
+	yes          bool
+}
+
 // newReportCmd creates a cobra command for a report subcommand (morning/evening).
 // It consolidates the shared flag setup and config-loading logic.
-func newReportCmd(use, short string, runFunc func(*internal.Config, bool) error) *cobra.Command {
+func newReportCmd(use, short string, runFunc func(*internal.Config, reportOptions) error) *cobra.Command {
 	var cfgFile string
-	var dryRun bool
+	var opts reportOptions
 
 	cmd := &cobra.Command{
 		Use:   use,
@@ -33,12 +42,13 @@ func newReportCmd(use, short string, runFunc func(*internal.Config, bool) error)
 				return err
 			}
 
-			return runFunc(cfg, dryRun)
+			return runFunc(cfg, opts)
 		},
 	}
 
 	cmd.Flags().StringVarP(&cfgFile, "config", "c", "cmd/linear2nl/linear2nl.yml", "config file path")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print HTML to stdout instead of sending email")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print HTML to stdout instead of sending email")
+	cmd.Flags().BoolVar(&opts.fromSnapshot, "from-snapshot", false, "read issues from the latest local snapshot instead of the Linear API")
 
 	return cmd
 }
@@ -58,6 +68,10 @@ func Execute() {
 Subcommands:
   morning   Send morning report with today's tasks
   evening   Send evening report with today's accomplishments
+  weekly    Send weekly (or --period month) retrospective report
+  snapshot  Save, list and diff local issue snapshots
+  issue     Create, update, move and comment on issues
+  sync      Sync issues with GitHub (sync github)
   export    Export Linear issues as JSON or Markdown
   review    AI review for a closed GitHub issue`,
 	}
@@ -66,6 +80,10 @@ Subcommands:
 
 	rootCmd.AddCommand(newMorningCmd())
 	rootCmd.AddCommand(newEveningCmd())
+	rootCmd.AddCommand(newWeeklyCmd())
+	rootCmd.AddCommand(newSnapshotCmd())
+	rootCmd.AddCommand(newIssueCmd())
+	rootCmd.AddCommand(newSyncCmd())
 	rootCmd.AddCommand(newExportCmd())
 	rootCmd.AddCommand(newReviewCmd())
 	rootCmd.AddCommand(schema.SchemaCmd(rootCmd))
@@ -79,6 +97,13 @@ Subcommands:
 
 // --- Shared helpers ---
 
+// newLinearClient builds a Linear client with pagination settings from config.
+func newLinearClient(cfg *internal.Config) *linear.Client {
+	return linear.NewClient(cfg.Linear.APIKey, cfg.Linear.TeamKeys,
+		linear.WithPageSize(cfg.Linear.PageSize),
+		linear.WithMaxItems(cfg.Linear.MaxItems))
+}
+
 func toIssueViews(issues []linear.Issue) []internal.IssueView {
 	return lo.Map(issues, func(iss linear.Issue, _ int) internal.IssueView {
 		return internal.IssueView{
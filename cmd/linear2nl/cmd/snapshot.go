@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	carbon "github.com/dromara/carbon/v2"
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+	"github.com/xbpk3t/docs-alfred/pkg/output"
+	"golang.org/x/sync/errgroup"
+)
+
+func newSnapshotCmd() *cobra.Command {
+	var cfgFile string
+
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save a local snapshot of Linear issues",
+		Long: `Capture open issues plus issues updated in the last snapshot.days days
+into the local snapshot store (snapshot.dir, default the docs-alfred cache).
+
+Snapshots feed "snapshot diff" and "morning/evening --from-snapshot",
+so reports can run offline and changes between emails are visible.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := internal.LoadConfig(cfgFile)
+			if err != nil {
+				return err
+			}
+
+			return runSnapshot(cmd.Context(), cfg)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "cmd/linear2nl/linear2nl.yml", "config file path")
+	cmd.AddCommand(newSnapshotListCmd(&cfgFile))
+	cmd.AddCommand(newSnapshotDiffCmd(&cfgFile))
+
+	return cmd
+}
+
+func newSnapshotListCmd(cfgFile *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List stored snapshots",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := internal.LoadConfig(*cfgFile)
+			if err != nil {
+				return err
+			}
+
+			infos, err := internal.NewSnapshotStore(cfg.Snapshot.Dir).List()
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(infos)
+			}
+			for _, info := range infos {
+				fmt.Printf("%s\t%s\n", info.Name, carbon.CreateFromStdTime(info.TakenAt).ToDateTimeString()) //nolint:forbidigo // CLI listing
+			}
+
+			return nil
+		},
+	}
+}
+
+func newSnapshotDiffCmd(cfgFile *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [older] [newer]",
+		Short: "Show changes between two snapshots",
+		Long: `Report new, closed, reopened, removed, re-stated, reprioritised and
+re-estimated issues between two snapshots.
+
+Without arguments the two most recent snapshots are compared; with one
+argument that snapshot is compared against the latest.`,
+		Args: cobra.MaximumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := internal.LoadConfig(*cfgFile)
+			if err != nil {
+				return err
+			}
+
+			diff, err := diffStoredSnapshots(internal.NewSnapshotStore(cfg.Snapshot.Dir), args)
+			if err != nil {
+				return err
+			}
+			if output.GetFormat(cmd) == output.FormatJSON {
+				return output.WriteJSON(diff)
+			}
+			fmt.Print(formatSnapshotDiff(diff)) //nolint:forbidigo // CLI report
+
+			return nil
+		},
+	}
+}
+
+func runSnapshot(ctx context.Context, cfg *internal.Config) error {
+	client := newLinearClient(cfg)
+	store := internal.NewSnapshotStore(cfg.Snapshot.Dir)
+
+	since := carbon.Now().SubDays(cfg.Snapshot.Days).StartOfDay().StdTime()
+	snap, err := takeSnapshot(ctx, client, since)
+	if err != nil {
+		return err
+	}
+
+	info, err := store.Save(snap)
+	if err != nil {
+		return err
+	}
+	slog.Info("snapshot saved", "path", info.Path, "issues", len(snap.Issues))
+
+	removed, err := store.Prune(cfg.Snapshot.Keep)
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		slog.Info("old snapshots pruned", "removed", removed, "keep", cfg.Snapshot.Keep)
+	}
+
+	return nil
+}
+
+// takeSnapshot captures open issues and issues updated since the given time,
+// deduplicated by identifier (open issues win).
+func takeSnapshot(ctx context.Context, client *linear.Client, since time.Time) (*internal.Snapshot, error) {
+	g, ctx := errgroup.WithContext(ctx)
+
+	var active, updated []linear.IssueDetail
+	g.Go(func() error {
+		var err error
+		active, err = client.GetActiveIssuesWithDetails(ctx)
+
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		updated, err = client.GetUpdatedIssuesWithDetails(ctx, since)
+
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	issues := lo.UniqBy(append(active, updated...), func(d linear.IssueDetail) string {
+		return d.Identifier
+	})
+
+	return &internal.Snapshot{TakenAt: time.Now().UTC(), Issues: issues}, nil
+}
+
+// diffStoredSnapshots resolves 0–2 snapshot names to a diff (see snapshot diff --help).
+func diffStoredSnapshots(store *internal.SnapshotStore, args []string) (*internal.SnapshotDiff, error) {
+	if len(args) == 2 {
+		older, err := store.Load(args[0])
+		if err != nil {
+			return nil, err
+		}
+		newer, err := store.Load(args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return internal.DiffSnapshots(older, newer), nil
+	}
+
+	infos, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, internal.ErrNoSnapshot
+	}
+	newer, err := store.Load(infos[len(infos)-1].Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		older, err := store.Load(args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return internal.DiffSnapshots(older, newer), nil
+	}
+	if len(infos) < 2 {
+		return nil, fmt.Errorf("need at least two snapshots to diff, have %d", len(infos))
+	}
+	older, err := store.Load(infos[len(infos)-2].Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.DiffSnapshots(older, newer), nil
+}
+
+func formatSnapshotDiff(d *internal.SnapshotDiff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Snapshot diff · %s → %s\n",
+		carbon.CreateFromStdTime(d.From).ToDateTimeString(),
+		carbon.CreateFromStdTime(d.To).ToDateTimeString())
+	if d.IsEmpty() {
+		b.WriteString("\nNo changes.\n")
+
+		return b.String()
+	}
+
+	groups := []struct {
+		title   string
+		changes []internal.IssueChange
+	}{
+		{"🆕 New", d.New},
+		{"✅ Closed", d.Closed},
+		{"↩️ Reopened", d.Reopened},
+		{"🚪 Removed", d.Removed},
+		{"🔄 State", d.StateChanged},
+		{"🎯 Priority", d.Reprioritised},
+		{"📐 Estimate", d.Reestimated},
+	}
+	for _, g := range groups {
+		if len(g.changes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s (%d)\n", g.title, len(g.changes))
+		for _, c := range g.changes {
+			switch {
+			case c.From == "":
+				fmt.Fprintf(&b, "  %s %s [%s]\n", c.Identifier, c.Title, c.To)
+			case c.To == "":
+				fmt.Fprintf(&b, "  %s %s [%s]\n", c.Identifier, c.Title, c.From)
+			default:
+				fmt.Fprintf(&b, "  %s %s: %s → %s\n", c.Identifier, c.Title, c.From, c.To)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// --- Snapshot-backed report data ---
+
+// activeDetailsFromSnapshot mirrors the morning API filter:
+// open issues that are not in backlog.
+func activeDetailsFromSnapshot(snap *internal.Snapshot) []linear.IssueDetail {
+	return lo.Filter(snap.Issues, func(d linear.IssueDetail, _ int) bool {
+		return d.StateType != "completed" && d.StateType != "canceled" && d.StateType != "backlog"
+	})
+}
+
+// eveningDataFromSnapshot rebuilds the evening query data from the latest
+// snapshot. State changes come from diffing it against the last snapshot taken
+// before since, so they carry no exact timestamp.
+func eveningDataFromSnapshot(store *internal.SnapshotStore, since time.Time) (eveningQueryData, error) {
+	latest, err := store.Latest()
+	if err != nil {
+		return eveningQueryData{}, err
+	}
+	slog.Info("reading from snapshot", "takenAt", latest.TakenAt)
+
+	var changes []linear.StateChange
+	base, err := store.LatestBefore(since)
+	switch {
+	case err == nil:
+		byID := lo.KeyBy(latest.Issues, func(d linear.IssueDetail) string { return d.Identifier })
+		for _, c := range internal.DiffSnapshots(base, latest).StateChanged {
+			d := byID[c.Identifier]
+			changes = append(changes, linear.StateChange{
+				IssueIdentifier: c.Identifier,
+				IssueTitle:      c.Title,
+				FromState:       c.From,
+				ToState:         c.To,
+				CreatedAt:       d.UpdatedAt,
+				TeamName:        d.TeamName,
+				TeamKey:         d.TeamKey,
+				URL:             d.URL,
+			})
+		}
+	case errors.Is(err, internal.ErrNoSnapshot):
+		slog.Warn("no snapshot before window start, state changes unavailable", "since", since)
+	default:
+		return eveningQueryData{}, err
+	}
+
+	var eq eveningQueryData
+	eq.changes = changes
+	for i := range latest.Issues {
+		d := &latest.Issues[i]
+		if d.StateType == "started" {
+			eq.inProgress = append(eq.inProgress, detailToIssue(d))
+		}
+		if d.StateType == "completed" && afterOrEqual(d.CompletedAt, since) {
+			eq.completed = append(eq.completed, detailToIssue(d))
+		}
+		if afterOrEqual(d.UpdatedAt, since) {
+			eq.updatedDetails = append(eq.updatedDetails, *d)
+		}
+	}
+
+	return eq, nil
+}
+
+func detailToIssue(d *linear.IssueDetail) linear.Issue {
+	return linear.Issue{
+		Title:       d.Title,
+		Identifier:  d.Identifier,
+		StateName:   d.StateName,
+		StateType:   d.StateType,
+		TeamName:    d.TeamName,
+		TeamKey:     d.TeamKey,
+		URL:         d.URL,
+		UpdatedAt:   d.UpdatedAt,
+		CompletedAt: d.CompletedAt,
+		Priority:    d.Priority,
+	}
+}
+
+func afterOrEqual(ts string, t time.Time) bool {
+	if ts == "" {
+		return false
+	}
+	parsed, err := time.Parse(time.RFC3339, ts)
+
+	return err == nil && !parsed.Before(t)
+}
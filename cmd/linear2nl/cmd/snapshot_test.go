@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+func mustSaveSnapshot(t *testing.T, store *internal.SnapshotStore, at string, issues ...linear.IssueDetail) {
+	t.Helper()
+
+	takenAt, err := time.Parse(time.RFC3339, at)
+	require.NoError(t, err)
+	_, err = store.Save(&internal.Snapshot{TakenAt: takenAt, Issues: issues})
+	require.NoError(t, err)
+}
+
+func TestNewSnapshotCmdHasSubcommands(t *testing.T) {
+	cmd := newSnapshotCmd()
+	assert.Equal(t, "snapshot", cmd.Use)
+	assert.NotNil(t, cmd.PersistentFlags().Lookup("config"))
+
+	names := make([]string, 0, len(cmd.Commands()))
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"list", "diff"}, names)
+}
+
+func TestReportCmdHasFromSnapshotFlag(t *testing.T) {
+	assert.NotNil(t, newMorningCmd().Flags().Lookup("from-snapshot"))
+	assert.NotNil(t, newEveningCmd().Flags().Lookup("from-snapshot"))
+}
+
+func TestDiffStoredSnapshots(t *testing.T) {
+	store := internal.NewSnapshotStore(t.TempDir())
+
+	_, err := diffStoredSnapshots(store, nil)
+	require.ErrorIs(t, err, internal.ErrNoSnapshot)
+
+	mustSaveSnapshot(t, store, "2026-06-01T08:00:00Z", linear.IssueDetail{Identifier: "ENG-1", StateName: "Todo", StateType: "unstarted"})
+	_, err = diffStoredSnapshots(store, nil)
+	require.Error(t, err)
+
+	mustSaveSnapshot(t, store, "2026-06-02T08:00:00Z", linear.IssueDetail{Identifier: "ENG-1", StateName: "Done", StateType: "completed"})
+	mustSaveSnapshot(t, store, "2026-06-03T08:00:00Z",
+		linear.IssueDetail{Identifier: "ENG-1", StateName: "Done", StateType: "completed"},
+		linear.IssueDetail{Identifier: "ENG-2", StateName: "Todo", StateType: "unstarted"})
+
+	d, err := diffStoredSnapshots(store, nil)
+	require.NoError(t, err)
+	assert.Len(t, d.New, 1)
+	assert.Empty(t, d.Closed)
+
+	d, err = diffStoredSnapshots(store, []string{"20260601T080000Z"})
+	require.NoError(t, err)
+	assert.Len(t, d.Closed, 1)
+	assert.Len(t, d.New, 1)
+
+	d, err = diffStoredSnapshots(store, []string{"20260601T080000Z", "20260602T080000Z"})
+	require.NoError(t, err)
+	assert.Len(t, d.Closed, 1)
+	assert.Empty(t, d.New)
+}
+
+func TestFormatSnapshotDiff(t *testing.T) {
+	empty := formatSnapshotDiff(&internal.SnapshotDiff{})
+	assert.Contains(t, empty, "No changes.")
+
+	out := formatSnapshotDiff(&internal.SnapshotDiff{
+		New:           []internal.IssueChange{{Identifier: "ENG-2", Title: "Fresh", To: "Todo"}},
+		Reprioritised: []internal.IssueChange{{Identifier: "ENG-1", Title: "Ship", From: "Low", To: "High"}},
+	})
+	assert.Contains(t, out, "🆕 New (1)")
+	assert.Contains(t, out, "ENG-2 Fresh [Todo]")
+	assert.Contains(t, out, "ENG-1 Ship: Low → High")
+	assert.NotContains(t, out, "Closed")
+}
+
+func TestActiveDetailsFromSnapshot(t *testing.T) {
+	got := activeDetailsFromSnapshot(&internal.Snapshot{Issues: []linear.IssueDetail{
+		{Identifier: "A", StateType: "started"},
+		{Identifier: "B", StateType: "completed"},
+		{Identifier: "C", StateType: "backlog"},
+		{Identifier: "D", StateType: "unstarted"},
+	}})
+	require.Len(t, got, 2)
+	assert.Equal(t, "A", got[0].Identifier)
+	assert.Equal(t, "D", got[1].Identifier)
+}
+
+func TestEveningDataFromSnapshot(t *testing.T) {
+	store := internal.NewSnapshotStore(t.TempDir())
+	since, _ := time.Parse(time.RFC3339, "2026-06-02T00:00:00Z")
+
+	_, err := eveningDataFromSnapshot(store, since)
+	require.ErrorIs(t, err, internal.ErrNoSnapshot)
+
+	mustSaveSnapshot(t, store, "2026-06-01T20:00:00Z",
+		linear.IssueDetail{Identifier: "ENG-1", StateName: "In Progress", StateType: "started"},
+		linear.IssueDetail{Identifier: "ENG-2", StateName: "Todo", StateType: "unstarted"})
+	mustSaveSnapshot(t, store, "2026-06-02T20:00:00Z",
+		linear.IssueDetail{Identifier: "ENG-1", StateName: "Done", StateType: "completed", TeamName: "Eng",
+			CompletedAt: "2026-06-02T10:00:00Z", UpdatedAt: "2026-06-02T10:00:00Z"},
+		linear.IssueDetail{Identifier: "ENG-2", StateName: "In Progress", StateType: "started",
+			UpdatedAt: "2026-06-02T11:00:00Z"})
+
+	eq, err := eveningDataFromSnapshot(store, since)
+	require.NoError(t, err)
+	require.Len(t, eq.completed, 1)
+	assert.Equal(t, "ENG-1", eq.completed[0].Identifier)
+	require.Len(t, eq.inProgress, 1)
+	assert.Equal(t, "ENG-2", eq.inProgress[0].Identifier)
+	assert.Len(t, eq.updatedDetails, 2)
+	require.Len(t, eq.changes, 2)
+	assert.Equal(t, "In Progress", eq.changes[0].FromState)
+	assert.Equal(t, "Done", eq.changes[0].ToState)
+	assert.Equal(t, "Eng", eq.changes[0].TeamName)
+}
+
+func TestEveningDataFromSnapshotWithoutBase(t *testing.T) {
+	store := internal.NewSnapshotStore(t.TempDir())
+	mustSaveSnapshot(t, store, "2026-06-02T20:00:00Z",
+		linear.IssueDetail{Identifier: "ENG-1", StateName: "Done", StateType: "completed", CompletedAt: "2026-06-02T10:00:00Z"})
+
+	since, _ := time.Parse(time.RFC3339, "2026-06-02T00:00:00Z")
+	eq, err := eveningDataFromSnapshot(store, since)
+	require.NoError(t, err)
+	assert.Len(t, eq.completed, 1)
+	assert.Empty(t, eq.changes)
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// syncGitHubAPI is the subset of *internal.GitHubClient used by sync.
+type syncGitHubAPI interface {
+	ListIssuesSince(ctx context.Context, since time.Time) ([]internal.GitHubIssue, error)
+	GetIssue(ctx context.Context, number int) (*internal.GitHubIssue, error)
+	ListComments(ctx context.Context, number int) ([]internal.GitHubReviewComment, error)
+	SetIssueClosed(ctx context.Context, number int, closed bool, reason string) error
+	AddLabels(ctx context.Context, number int, labels []string) error
+	PostComment(ctx context.Context, number int, body string) error
+}
+
+// syncLinearAPI is the subset of *linear.Client used by sync.
+type syncLinearAPI interface {
+	GetUpdatedIssuesWithDetails(ctx context.Context, since time.Time) ([]linear.IssueDetail, error)
+	GetIssueByIdentifier(ctx context.Context, identifier string) (*linear.IssueDetail, error)
+	MoveIssueToStateType(ctx context.Context, identifier, stateType string) (*linear.Issue, error)
+	AddIssueLabels(ctx context.Context, issueID string, names []string) ([]string, error)
+	CreateComment(ctx context.Context, issueID, body string) (*linear.Comment, error)
+}
+
+type syncOptions struct {
+	owner     string
+	repo      string
+	direction string
+	linkFile  string
+	fields    []string
+	days      int
+	dryRun    bool
+}
+
+func newSyncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Sync Linear issues with other trackers",
+	}
+	cmd.AddCommand(newSyncGitHubCmd())
+
+	return cmd
+}
+
+func newSyncGitHubCmd() *cobra.Command {
+	var (
+		cfgFile string
+		opts    syncOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "github",
+		Short: "Sync Linear issues with GitHub issues",
+		Long: `Link Linear and GitHub issues and mirror state, labels and comments.
+
+Links are discovered from Linear identifiers (e.g. LUC-12) in GitHub issue
+titles/bodies and from GitHub issue URLs in Linear descriptions, then stored
+in a local link map (github.sync.linkFile) so repeated runs are idempotent.
+
+State maps open ↔ unstarted/started and closed ↔ completed/canceled. With
+--direction both, the side that changed since the last sync wins; a link
+whose states differ before any state was synced is left alone until a run
+with an explicit --direction. Labels are only added, never removed; each
+comment is mirrored once.
+
+Requires GITHUB_TOKEN environment variable for authentication.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := internal.LoadConfig(cfgFile)
+			if err != nil {
+				return err
+			}
+			if err := resolveSyncOptions(cfg, cmd, &opts); err != nil {
+				return err
+			}
+
+			token := os.Getenv("GITHUB_TOKEN")
+			if token == "" {
+				return fmt.Errorf("GITHUB_TOKEN environment variable is required")
+			}
+
+			gh := internal.NewGitHubClient(token, opts.owner, opts.repo)
+
+			return runSyncGitHub(cmd.Context(), gh, newLinearClient(cfg), cfg.Linear.TeamKeys, &opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "cmd/linear2nl/linear2nl.yml", "config file path")
+	cmd.Flags().StringVar(&opts.owner, "owner", "", "GitHub repo owner (default: github.owner)")
+	cmd.Flags().StringVar(&opts.repo, "repo", "", "GitHub repo name (default: github.repo)")
+	cmd.Flags().StringVar(&opts.direction, "direction", "", "both, linear-to-github or github-to-linear (default: github.sync.direction)")
+	cmd.Flags().StringSliceVar(&opts.fields, "fields", nil, "fields to mirror: state, labels, comments (default: github.sync.fields)")
+	cmd.Flags().IntVar(&opts.days, "days", 0, "scan issues updated in the last N days for new links (default: github.sync.days)")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "print planned changes without applying them or saving links")
+
+	return cmd
+}
+
+// resolveSyncOptions fills unset flags from config and validates the result.
+func resolveSyncOptions(cfg *internal.Config, cmd *cobra.Command, opts *syncOptions) error {
+	sc := cfg.GitHub.Sync
+	opts.owner = lo.CoalesceOrEmpty(opts.owner, cfg.GitHub.Owner)
+	opts.repo = lo.CoalesceOrEmpty(opts.repo, cfg.GitHub.Repo)
+	opts.direction = lo.CoalesceOrEmpty(opts.direction, sc.Direction, internal.SyncBoth)
+	opts.linkFile = lo.CoalesceOrEmpty(sc.LinkFile, fileutil.CachePath("linear2nl/github-links.json"))
+	if !cmd.Flags().Changed("fields") {
+		opts.fields = sc.Fields
+	}
+	if opts.days <= 0 {
+		opts.days = sc.Days
+	}
+
+	if opts.owner == "" || opts.repo == "" {
+		return fmt.Errorf("--owner and --repo are required (or set github.owner/repo in config)")
+	}
+	if !slices.Contains([]string{internal.SyncBoth, internal.SyncLinearToGitHub, internal.SyncGitHubToLinear}, opts.direction) {
+		return fmt.Errorf("invalid --direction %q", opts.direction)
+	}
+	for _, f := range opts.fields {
+		if !slices.Contains([]string{internal.SyncFieldState, internal.SyncFieldLabels, internal.SyncFieldComments}, f) {
+			return fmt.Errorf("invalid sync field %q (want state, labels or comments)", f)
+		}
+	}
+
+	return nil
+}
+
+func runSyncGitHub(ctx context.Context, gh syncGitHubAPI, lin syncLinearAPI, teamKeys []string, opts *syncOptions) error {
+	links, err := internal.LoadLinkMap(opts.linkFile)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().AddDate(0, 0, -opts.days)
+	ghIssues, err := gh.ListIssuesSince(ctx, since)
+	if err != nil {
+		return err
+	}
+	linIssues, err := lin.GetUpdatedIssuesWithDetails(ctx, since)
+	if err != nil {
+		return err
+	}
+
+	added := discoverLinks(links, ghIssues, linIssues, teamKeys, opts.owner, opts.repo)
+	slog.Info("github sync links", "total", len(links.Links), "new", added)
+
+	byNumber := lo.KeyBy(ghIssues, func(i internal.GitHubIssue) int { return i.Number })
+	ids := lo.Keys(links.Links)
+	slices.Sort(ids)
+
+	var errs []error
+	applied := 0
+	for _, id := range ids {
+		n, err := syncLink(ctx, gh, lin, links.Links[id], byNumber, opts)
+		applied += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s ↔ #%d: %w", id, links.Links[id].IssueNumber, err))
+		}
+	}
+
+	if opts.dryRun {
+		slog.Info("dry-run: no changes applied, link map not saved")
+
+		return errors.Join(errs...)
+	}
+	slog.Info("github sync done", "links", len(ids), "applied", applied, "errors", len(errs))
+	if err := links.Save(opts.linkFile); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// discoverLinks adds links found in GitHub titles/bodies and Linear descriptions.
+func discoverLinks(links *internal.LinkMap, ghIssues []internal.GitHubIssue, linIssues []linear.IssueDetail, teamKeys []string, owner, repo string) int {
+	added := 0
+	for i := range ghIssues {
+		if id := findLinearID(ghIssues[i].Title+"\n"+ghIssues[i].Body, teamKeys); id != "" && links.Link(id, ghIssues[i].Number) {
+			added++
+		}
+	}
+
+	urlRe := regexp.MustCompile(`github\.com/` + regexp.QuoteMeta(owner) + `/` + regexp.QuoteMeta(repo) + `/issues/(\d+)`)
+	for i := range linIssues {
+		m := urlRe.FindStringSubmatch(linIssues[i].Description)
+		if m == nil {
+			continue
+		}
+		if number, err := strconv.Atoi(m[1]); err == nil && links.Link(linIssues[i].Identifier, number) {
+			added++
+		}
+	}
+
+	return added
+}
+
+// findLinearID returns the first Linear identifier in s, restricted to teamKeys when set.
+func findLinearID(s string, teamKeys []string) string {
+	for _, m := range linearIDRe.FindAllString(s, -1) {
+		key, _, _ := strings.Cut(m, "-")
+		if len(teamKeys) == 0 || slices.Contains(teamKeys, key) {
+			return m
+		}
+	}
+
+	return ""
+}
+
+// syncLink plans and (unless dry-run) applies the ops for one link.
+// It returns the number of ops applied.
+func syncLink(ctx context.Context, gh syncGitHubAPI, lin syncLinearAPI, link *internal.SyncLink, byNumber map[int]internal.GitHubIssue, opts *syncOptions) (int, error) {
+	li, err := lin.GetIssueByIdentifier(ctx, link.Identifier)
+	if err != nil {
+		return 0, err
+	}
+	gi, ok := byNumber[link.IssueNumber]
+	if !ok {
+		fetched, err := gh.GetIssue(ctx, link.IssueNumber)
+		if err != nil {
+			return 0, err
+		}
+		gi = *fetched
+	}
+
+	linSide := internal.SyncSide{
+		Closed: isLinearClosed(li.StateType),
+		Labels: li.Labels,
+		Comments: lo.Map(li.Comments, func(c linear.Comment, _ int) internal.SyncComment {
+			return internal.SyncComment{Author: c.UserName, CreatedAt: c.CreatedAt, Body: c.Body}
+		}),
+	}
+	ghSide := internal.SyncSide{Closed: gi.Closed(), Labels: gi.Labels}
+	if slices.Contains(opts.fields, internal.SyncFieldComments) {
+		comments, err := gh.ListComments(ctx, link.IssueNumber)
+		if err != nil {
+			return 0, err
+		}
+		ghSide.Comments = lo.Map(comments, func(c internal.GitHubReviewComment, _ int) internal.SyncComment {
+			return internal.SyncComment{Author: c.UserName, CreatedAt: c.CreatedAt, Body: c.Body}
+		})
+	}
+
+	ops := internal.PlanSync(link, linSide, ghSide, opts.direction, opts.fields)
+	if slices.Contains(opts.fields, internal.SyncFieldState) && linSide.Closed != ghSide.Closed &&
+		!slices.ContainsFunc(ops, func(op internal.SyncOp) bool { return op.Field == internal.SyncFieldState }) {
+		slog.Warn("state differs with no prior sync, skipped; rerun with --direction to pick a side",
+			"issue", link.Identifier, "number", link.IssueNumber, "linearClosed", linSide.Closed, "githubClosed", ghSide.Closed)
+	}
+	if opts.dryRun {
+		for _, op := range ops {
+			fmt.Printf("%s ↔ #%d  %s\n", link.Identifier, link.IssueNumber, op) //nolint:forbidigo // dry-run plan
+		}
+
+		return 0, nil
+	}
+
+	if slices.Contains(opts.fields, internal.SyncFieldState) && linSide.Closed == ghSide.Closed {
+		link.Record(internal.SyncOp{Field: internal.SyncFieldState, Close: linSide.Closed})
+	}
+	applied := 0
+	var errs []error
+	for _, op := range ops {
+		if err := applySyncOp(ctx, gh, lin, link, li, &gi, op); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", op, err))
+			continue
+		}
+		link.Record(op)
+		applied++
+	}
+	link.SyncedAt = time.Now().UTC()
+
+	return applied, errors.Join(errs...)
+}
+
+func applySyncOp(ctx context.Context, gh syncGitHubAPI, lin syncLinearAPI, link *internal.SyncLink, li *linear.IssueDetail, gi *internal.GitHubIssue, op internal.SyncOp) error {
+	if op.Target == internal.SyncTargetGitHub {
+		switch op.Field {
+		case internal.SyncFieldState:
+			reason := "completed"
+			if li.StateType == "canceled" {
+				reason = "not_planned"
+			}
+
+			return gh.SetIssueClosed(ctx, link.IssueNumber, op.Close, reason)
+		case internal.SyncFieldLabels:
+			return gh.AddLabels(ctx, link.IssueNumber, op.Labels)
+		default:
+			return gh.PostComment(ctx, link.IssueNumber, op.Comment)
+		}
+	}
+
+	switch op.Field {
+	case internal.SyncFieldState:
+		stateType := "unstarted"
+		if op.Close {
+			stateType = "completed"
+			if gi.StateReason == "not_planned" {
+				stateType = "canceled"
+			}
+		}
+		_, err := lin.MoveIssueToStateType(ctx, link.Identifier, stateType)
+
+		return err
+	case internal.SyncFieldLabels:
+		missing, err := lin.AddIssueLabels(ctx, link.Identifier, op.Labels)
+		if len(missing) > 0 {
+			slog.Warn("labels not found in Linear, skipped", "issue", link.Identifier, "labels", missing)
+		}
+
+		return err
+	default:
+		_, err := lin.CreateComment(ctx, link.Identifier, op.Comment)
+
+		return err
+	}
+}
+
+func isLinearClosed(stateType string) bool {
+	return stateType == "completed" || stateType == "canceled"
+}
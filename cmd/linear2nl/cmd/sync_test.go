@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+// fakeGitHub is an in-memory syncGitHubAPI.
+type fakeGitHub struct {
+	issues   map[int]*internal.GitHubIssue
+	comments map[int][]internal.GitHubReviewComment
+	closeErr error
+	calls    []string
+}
+
+func (f *fakeGitHub) ListIssuesSince(context.Context, time.Time) ([]internal.GitHubIssue, error) {
+	out := make([]internal.GitHubIssue, 0, len(f.issues))
+	for _, i := range f.issues {
+		out = append(out, *i)
+	}
+
+	return out, nil
+}
+
+func (f *fakeGitHub) GetIssue(_ context.Context, n int) (*internal.GitHubIssue, error) {
+	i, ok := f.issues[n]
+	if !ok {
+		return nil, fmt.Errorf("no issue %d", n)
+	}
+
+	return i, nil
+}
+
+func (f *fakeGitHub) ListComments(_ context.Context, n int) ([]internal.GitHubReviewComment, error) {
+	return f.comments[n], nil
+}
+
+func (f *fakeGitHub) SetIssueClosed(_ context.Context, n int, closed bool, reason string) error {
+	f.calls = append(f.calls, fmt.Sprintf("gh close #%d %v %s", n, closed, reason))
+	if f.closeErr != nil {
+		return f.closeErr
+	}
+	f.issues[n].State = map[bool]string{true: "closed", false: "open"}[closed]
+
+	return nil
+}
+
+func (f *fakeGitHub) AddLabels(_ context.Context, n int, labels []string) error {
+	f.calls = append(f.calls, fmt.Sprintf("gh labels #%d %v", n, labels))
+	f.issues[n].Labels = append(f.issues[n].Labels, labels...)
+
+	return nil
+}
+
+func (f *fakeGitHub) PostComment(_ context.Context, n int, body string) error {
+	f.calls = append(f.calls, fmt.Sprintf("gh comment #%d", n))
+	f.comments[n] = append(f.comments[n], internal.GitHubReviewComment{UserName: "bot", Body: body})
+
+	return nil
+}
+
+// fakeLinear is an in-memory syncLinearAPI.
+type fakeLinear struct {
+	issues map[string]*linear.IssueDetail
+	calls  []string
+}
+
+func (f *fakeLinear) GetUpdatedIssuesWithDetails(context.Context, time.Time) ([]linear.IssueDetail, error) {
+	out := make([]linear.IssueDetail, 0, len(f.issues))
+	for _, i := range f.issues {
+		out = append(out, *i)
+	}
+
+	return out, nil
+}
+
+func (f *fakeLinear) GetIssueByIdentifier(_ context.Context, id string) (*linear.IssueDetail, error) {
+	i, ok := f.issues[id]
+	if !ok {
+		return nil, fmt.Errorf("no issue %s", id)
+	}
+	cp := *i
+
+	return &cp, nil
+}
+
+func (f *fakeLinear) MoveIssueToStateType(_ context.Context, id, stateType string) (*linear.Issue, error) {
+	f.calls = append(f.calls, "linear move "+id+" "+stateType)
+	f.issues[id].StateType = stateType
+
+	return &linear.Issue{}, nil
+}
+
+func (f *fakeLinear) AddIssueLabels(_ context.Context, id string, names []string) ([]string, error) {
+	f.calls = append(f.calls, fmt.Sprintf("linear labels %s %v", id, names))
+	f.issues[id].Labels = append(f.issues[id].Labels, names...)
+
+	return nil, nil
+}
+
+func (f *fakeLinear) CreateComment(_ context.Context, id, body string) (*linear.Comment, error) {
+	f.calls = append(f.calls, "linear comment "+id)
+	f.issues[id].Comments = append(f.issues[id].Comments, linear.Comment{UserName: "bot", Body: body})
+
+	return &linear.Comment{}, nil
+}
+
+func newSyncFixtures() (*fakeGitHub, *fakeLinear) {
+	gh := &fakeGitHub{
+		issues: map[int]*internal.GitHubIssue{
+			1: {Number: 1, Title: "[LUC-1] Fix login", State: "open", Labels: []string{"bug"}},
+			2: {Number: 2, Title: "Unrelated UTF-8 handling", State: "open"},
+		},
+		comments: map[int][]internal.GitHubReviewComment{
+			1: {{UserName: "bob", CreatedAt: "2026-06-02T00:00:00Z", Body: "repro attached"}},
+		},
+	}
+	lin := &fakeLinear{issues: map[string]*linear.IssueDetail{
+		"LUC-1": {Identifier: "LUC-1", StateType: "completed", Labels: []string{"bug", "auth"},
+			Comments: []linear.Comment{{UserName: "Ann", CreatedAt: "2026-06-01T00:00:00Z", Body: "fixed in main"}}},
+		"LUC-2": {Identifier: "LUC-2", StateType: "started", Description: "see https://github.com/o/r/issues/3"},
+	}}
+	gh.issues[3] = &internal.GitHubIssue{Number: 3, Title: "Docs", State: "closed", StateReason: "not_planned"}
+
+	return gh, lin
+}
+
+func TestRunSyncGitHubIsIdempotent(t *testing.T) {
+	gh, lin := newSyncFixtures()
+	opts := &syncOptions{
+		owner: "o", repo: "r", direction: internal.SyncBoth, days: 30,
+		linkFile: filepath.Join(t.TempDir(), "links.json"),
+		fields:   []string{internal.SyncFieldState, internal.SyncFieldLabels, internal.SyncFieldComments},
+	}
+
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	// Both links disagree on state with nothing synced yet: left alone.
+	assert.ElementsMatch(t, []string{
+		"gh labels #1 [auth]",
+		"gh comment #1",
+	}, gh.calls)
+	assert.ElementsMatch(t, []string{"linear comment LUC-1"}, lin.calls)
+
+	links, err := internal.LoadLinkMap(opts.linkFile)
+	require.NoError(t, err)
+	require.Len(t, links.Links, 2)
+	assert.Equal(t, 1, links.Links["LUC-1"].IssueNumber)
+	assert.Equal(t, 3, links.Links["LUC-2"].IssueNumber)
+	assert.False(t, links.Links["LUC-1"].StateSynced)
+	assert.Len(t, links.Links["LUC-1"].Mirrored, 2)
+
+	gh.calls, lin.calls = nil, nil
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	assert.Empty(t, gh.calls)
+	assert.Empty(t, lin.calls)
+}
+
+func TestRunSyncGitHubFollowsGitHubChange(t *testing.T) {
+	gh, lin := newSyncFixtures()
+	opts := &syncOptions{
+		owner: "o", repo: "r", direction: internal.SyncLinearToGitHub, days: 30,
+		linkFile: filepath.Join(t.TempDir(), "links.json"),
+		fields:   []string{internal.SyncFieldState},
+	}
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	assert.ElementsMatch(t, []string{"gh close #1 true completed", "gh close #3 false completed"}, gh.calls)
+
+	// Reopened on GitHub after the first sync → Linear follows.
+	opts.direction = internal.SyncBoth
+	gh.issues[1].State = "open"
+	gh.calls, lin.calls = nil, nil
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	assert.Equal(t, []string{"linear move LUC-1 unstarted"}, lin.calls)
+	assert.Empty(t, gh.calls)
+}
+
+func TestRunSyncGitHubFailedOpKeepsLinkState(t *testing.T) {
+	gh, lin := newSyncFixtures()
+	opts := &syncOptions{
+		owner: "o", repo: "r", direction: internal.SyncBoth, days: 30,
+		linkFile: filepath.Join(t.TempDir(), "links.json"),
+		fields:   []string{internal.SyncFieldState},
+	}
+	// Agreed state: LUC-1 and #1 both open.
+	lin.issues["LUC-1"].StateType = "started"
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+
+	// Closed in Linear, but closing #1 fails.
+	lin.issues["LUC-1"].StateType = "completed"
+	gh.closeErr = fmt.Errorf("boom")
+	require.ErrorContains(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts), "boom")
+
+	links, err := internal.LoadLinkMap(opts.linkFile)
+	require.NoError(t, err)
+	assert.False(t, links.Links["LUC-1"].LinearClosed)
+	assert.False(t, links.Links["LUC-1"].GitHubClosed)
+
+	// The retry still closes GitHub instead of reopening Linear.
+	gh.closeErr, gh.calls, lin.calls = nil, nil, nil
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	assert.Equal(t, []string{"gh close #1 true completed"}, gh.calls)
+	assert.Empty(t, lin.calls)
+}
+
+func TestRunSyncGitHubDryRun(t *testing.T) {
+	gh, lin := newSyncFixtures()
+	opts := &syncOptions{
+		owner: "o", repo: "r", direction: internal.SyncBoth, days: 30, dryRun: true,
+		linkFile: filepath.Join(t.TempDir(), "links.json"),
+		fields:   []string{internal.SyncFieldState, internal.SyncFieldLabels},
+	}
+	require.NoError(t, runSyncGitHub(context.Background(), gh, lin, []string{"LUC"}, opts))
+	assert.Empty(t, gh.calls)
+	assert.Empty(t, lin.calls)
+	assert.NoFileExists(t, opts.linkFile)
+}
+
+func TestFindLinearID(t *testing.T) {
+	assert.Equal(t, "LUC-12", findLinearID("UTF-8 and LUC-12", []string{"LUC"}))
+	assert.Equal(t, "UTF-8", findLinearID("UTF-8 and LUC-12", nil))
+	assert.Empty(t, findLinearID("nothing here", nil))
+}
+
+func TestResolveSyncOptions(t *testing.T) {
+	cfg := &internal.Config{GitHub: internal.GitHubConfig{Owner: "o", Repo: "r", Sync: internal.GitHubSyncConfig{
+		Direction: internal.SyncLinearToGitHub, Fields: []string{"state"}, Days: 7,
+	}}}
+
+	cmd := newSyncGitHubCmd()
+	opts := syncOptions{}
+	require.NoError(t, resolveSyncOptions(cfg, cmd, &opts))
+	assert.Equal(t, internal.SyncLinearToGitHub, opts.direction)
+	assert.Equal(t, []string{"state"}, opts.fields)
+	assert.Equal(t, 7, opts.days)
+	assert.NotEmpty(t, opts.linkFile)
+
+	opts = syncOptions{direction: "sideways"}
+	require.ErrorContains(t, resolveSyncOptions(cfg, cmd, &opts), "invalid --direction")
+
+	require.NoError(t, cmd.Flags().Set("fields", "state,title"))
+	opts = syncOptions{fields: []string{"state", "title"}}
+	require.ErrorContains(t, resolveSyncOptions(cfg, cmd, &opts), `invalid sync field "title"`)
+
+	opts = syncOptions{}
+	require.ErrorContains(t, resolveSyncOptions(&internal.Config{}, newSyncGitHubCmd(), &opts), "--owner and --repo")
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	carbon "github.com/dromara/carbon/v2"
+	"github.com/spf13/cobra"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+	"github.com/xbpk3t/docs-alfred/pkg/md"
+)
+
+// Retrospective periods accepted by --period.
+const (
+	periodWeek  = "week"
+	periodMonth = "month"
+)
+
+func newWeeklyCmd() *cobra.Command {
+	var (
+		cfgFile string
+		period  string
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "weekly",
+		Short: "Send weekly or monthly retrospective report",
+		Long: `Aggregate the previous full week (Mon–Sun) or calendar month:
+completed issues, state-change throughput, cycle time (first started → completed),
+lead time (created → completed), carry-over items and per-team/per-label breakdowns.
+An AI retrospective is appended when AI is configured.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if period != periodWeek && period != periodMonth {
+				return fmt.Errorf("invalid --period %q (want %s or %s)", period, periodWeek, periodMonth)
+			}
+
+			cfg, err := internal.LoadConfig(cfgFile)
+			if err != nil {
+				return err
+			}
+
+			return runWeekly(cfg, period, dryRun)
+		},
+	}
+
+	cmd.Flags().StringVarP(&cfgFile, "config", "c", "cmd/linear2nl/linear2nl.yml", "config file path")
+	cmd.Flags().StringVar(&period, "period", periodWeek, "report period: week or month")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print HTML to stdout instead of sending email")
+
+	return cmd
+}
+
+func runWeekly(cfg *internal.Config, period string, dryRun bool) error {
+	ctx := context.Background()
+	client := newLinearClient(cfg)
+	aiClient := internal.NewAIProvider(cfg.AI)
+
+	start, end := retroWindow(period, carbon.Now())
+	slog.Info("building retrospective", "period", period, "start", start.ToDateString(), "end", end.ToDateString())
+
+	activity, err := client.GetIssueActivity(ctx, start.StdTime())
+	if err != nil {
+		return err
+	}
+	slog.Info("fetched issue activity", "count", len(activity))
+
+	report := internal.BuildRetroReport(activity, start.StdTime(), end.StdTime())
+	title := retroTitle(period, start, end)
+	stats := retroStats(report)
+	retro := buildRetrospective(aiClient, title, stats, report)
+
+	htmlBody, err := buildRetroHTML(title, stats, report, retro)
+	if err != nil {
+		return fmt.Errorf("render document: %w", err)
+	}
+
+	return sendOrWrite(cfg, "📊 "+title, htmlBody, period, dryRun)
+}
+
+// retroWindow returns the previous full period [start, end) relative to now:
+// last Monday–Sunday for week, the previous calendar month for month.
+func retroWindow(period string, now *carbon.Carbon) (start, end *carbon.Carbon) {
+	if period == periodMonth {
+		end = now.Copy().StartOfMonth()
+		return end.Copy().SubMonthNoOverflow(), end
+	}
+	end = now.Copy().SetWeekStartsAt(carbon.Monday).StartOfWeek()
+
+	return end.Copy().SubWeek(), end
+}
+
+func retroTitle(period string, start, end *carbon.Carbon) string {
+	last := end.Copy().SubDay().ToDateString()
+	if period == periodMonth {
+		return fmt.Sprintf("Linear 月报 · %s ~ %s", start.ToDateString(), last)
+	}
+
+	return fmt.Sprintf("Linear 周报 · %s ~ %s", start.ToDateString(), last)
+}
+
+// retroStats renders the headline numbers shared by the email and the AI prompt.
+func retroStats(r *internal.RetroReport) []md.StatItem {
+	return []md.StatItem{
+		{Label: "✅ 完成", Value: len(r.Completed)},
+		{Label: "▶️ 开始", Value: r.Started},
+		{Label: "🔄 状态变更", Value: r.Changes},
+		{Label: "📦 遗留", Value: len(r.CarryOver)},
+		{Label: "⏱ 周期时间 (中位/平均)", Value: internal.FormatDays(r.CycleTime.Median) + " / " + internal.FormatDays(r.CycleTime.Avg)},
+		{Label: "🚚 交付时间 (中位/平均)", Value: internal.FormatDays(r.LeadTime.Median) + " / " + internal.FormatDays(r.LeadTime.Avg)},
+	}
+}
+
+func buildRetroHTML(title string, stats []md.StatItem, r *internal.RetroReport, retro string) (string, error) {
+	doc := md.NewDocument()
+	doc.Add(md.NamedSection(title, md.StatsGrid(stats)))
+
+	if len(r.Completed) > 0 {
+		headers := []string{"ID", "Title", "Team", "Cycle", "Lead"}
+		var rows [][]string
+		for i := range r.Completed {
+			ri := &r.Completed[i]
+			rows = append(rows, []string{
+				md.Link(ri.Identifier, ri.URL),
+				ri.Title,
+				ri.TeamName,
+				internal.FormatDays(ri.CycleTime),
+				internal.FormatDays(ri.LeadTime),
+			})
+		}
+		doc.Add(md.NamedSection(fmt.Sprintf("✅ 完成 · %d", len(r.Completed)), md.Table(headers, rows)))
+	}
+
+	if len(r.Transitions) > 0 {
+		var rows [][]string
+		for _, t := range r.Transitions {
+			rows = append(rows, []string{t.Name, strconv.Itoa(t.Count)})
+		}
+		doc.Add(md.NamedSection("🔄 状态流转", md.Table([]string{"To", "Count"}, rows)))
+	}
+
+	if len(r.Teams) > 0 {
+		doc.Add(md.NamedSection("👥 团队", groupStatsTable("Team", r.Teams)))
+	}
+	if len(r.Labels) > 0 {
+		doc.Add(md.NamedSection("🏷 标签", groupStatsTable("Label", r.Labels)))
+	}
+
+	if len(r.CarryOver) > 0 {
+		headers := []string{"ID", "Title", "Status", "Team", "Age"}
+		var rows [][]string
+		for i := range r.CarryOver {
+			ri := &r.CarryOver[i]
+			rows = append(rows, []string{
+				md.Link(ri.Identifier, ri.URL),
+				ri.Title,
+				ri.StateName,
+				ri.TeamName,
+				internal.FormatDays(ri.Age),
+			})
+		}
+		doc.Add(md.NamedSection(fmt.Sprintf("📦 遗留 · %d", len(r.CarryOver)), md.Table(headers, rows)))
+	}
+
+	if retro != "" {
+		doc.Add(md.NamedSection("🧭 AI 复盘", &rawSection{content: retro}))
+	}
+
+	return doc.ToHTML()
+}
+
+func groupStatsTable(name string, groups []internal.GroupStats) md.Section {
+	var rows [][]string
+	for i := range groups {
+		rows = append(rows, []string{
+			groups[i].Name,
+			strconv.Itoa(groups[i].Completed),
+			strconv.Itoa(groups[i].CarryOver),
+			internal.FormatDays(groups[i].MedianCycle),
+		})
+	}
+
+	return md.Table([]string{name, "Completed", "Carry-over", "Median cycle"}, rows)
+}
+
+// buildRetrospective asks AI for a period retrospective.
+// Returns rendered markdown; empty when AI is unavailable or the reply is unusable.
+func buildRetrospective(aiClient *internal.AIProvider, title string, stats []md.StatItem, r *internal.RetroReport) string {
+	if !aiClient.IsConfigured() || (len(r.Completed) == 0 && len(r.CarryOver) == 0) {
+		return ""
+	}
+
+	lines := make([]string, 0, len(stats))
+	for _, s := range stats {
+		lines = append(lines, fmt.Sprintf("%s: %v", s.Label, s.Value))
+	}
+
+	raw := aiClient.Retrospective(title, lines, r)
+	if raw == "" {
+		slog.Warn("AI returned empty response")
+
+		return ""
+	}
+	slog.Info("AI raw response preview", "len", len(raw), "raw", raw[:min(len(raw), 2000)])
+
+	return parseRetroJSON(raw)
+}
+
+func parseRetroJSON(raw string) string {
+	var result internal.RetroJSON
+	if err := json.Unmarshal([]byte(raw), &result); err != nil {
+		slog.Warn("failed to parse AI retrospective JSON", "error", err)
+
+		return ""
+	}
+
+	var sections []md.ReviewSection
+	if len(result.Summary) > 0 {
+		sections = append(sections, md.ReviewSection{Heading: "总结", Items: result.Summary})
+	}
+	if len(result.Highlights) > 0 {
+		sections = append(sections, md.ReviewSection{Heading: "亮点", Items: result.Highlights})
+	}
+	if len(result.Risks) > 0 {
+		sections = append(sections, md.ReviewSection{Heading: "风险", Items: result.Risks})
+	}
+	if len(result.Actions) > 0 {
+		sections = append(sections, md.ReviewSection{Heading: "行动", Items: result.Actions})
+	}
+	if len(sections) == 0 {
+		return ""
+	}
+
+	return strings.TrimSpace(md.AIReviewItem(sections...).Markdown())
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	carbon "github.com/dromara/carbon/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/cmd/linear2nl/internal"
+)
+
+func TestNewWeeklyCmdHasFlags(t *testing.T) {
+	cmd := newWeeklyCmd()
+	assert.Equal(t, "weekly", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("config"))
+	assert.NotNil(t, cmd.Flags().Lookup("dry-run"))
+	f := cmd.Flags().Lookup("period")
+	require.NotNil(t, f)
+	assert.Equal(t, periodWeek, f.DefValue)
+}
+
+func TestNewWeeklyCmdRejectsUnknownPeriod(t *testing.T) {
+	cmd := newWeeklyCmd()
+	cmd.SetArgs([]string{"--period", "year"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --period")
+}
+
+func TestRetroWindowWeek(t *testing.T) {
+	// Wednesday.
+	now := carbon.Parse("2026-06-10 15:04:05", "UTC")
+	start, end := retroWindow(periodWeek, now)
+	assert.Equal(t, "2026-06-01 00:00:00", start.ToDateTimeString())
+	assert.Equal(t, "2026-06-08 00:00:00", end.ToDateTimeString())
+	assert.Equal(t, "Linear 周报 · 2026-06-01 ~ 2026-06-07", retroTitle(periodWeek, start, end))
+}
+
+func TestRetroWindowMonth(t *testing.T) {
+	now := carbon.Parse("2026-03-31 10:00:00", "UTC")
+	start, end := retroWindow(periodMonth, now)
+	assert.Equal(t, "2026-02-01 00:00:00", start.ToDateTimeString())
+	assert.Equal(t, "2026-03-01 00:00:00", end.ToDateTimeString())
+	assert.Equal(t, "Linear 月报 · 2026-02-01 ~ 2026-02-28", retroTitle(periodMonth, start, end))
+}
+
+func TestBuildRetroHTML(t *testing.T) {
+	r := &internal.RetroReport{
+		Completed:   []internal.RetroIssue{{Identifier: "LUC-1", Title: "Shipped", TeamName: "Eng", URL: "https://linear.app/1", CycleTime: 48 * time.Hour}},
+		CarryOver:   []internal.RetroIssue{{Identifier: "LUC-2", Title: "Stuck", StateName: "In Progress", Age: 72 * time.Hour}},
+		Transitions: []internal.GroupCount{{Name: "Done", Count: 1}},
+		Teams:       []internal.GroupStats{{Name: "Eng", Completed: 1}},
+		Labels:      []internal.GroupStats{{Name: "bug", CarryOver: 1}},
+	}
+
+	html, err := buildRetroHTML("Linear 周报", retroStats(r), r, "")
+	require.NoError(t, err)
+	assert.Contains(t, html, "Linear 周报")
+	assert.Contains(t, html, "LUC-1")
+	assert.Contains(t, html, "2.0d")
+	assert.Contains(t, html, "LUC-2")
+	assert.Contains(t, html, "bug")
+	assert.NotContains(t, html, "AI 复盘")
+}
+
+func TestParseRetroJSON(t *testing.T) {
+	got := parseRetroJSON(`{"highlights":["h1"],"risks":["r1"],"actions":["a1"],"summary":["s1"]}`)
+	assert.Contains(t, got, "h1")
+	assert.Contains(t, got, "r1")
+	assert.Contains(t, got, "a1")
+	assert.Contains(t, got, "s1")
+
+	assert.Empty(t, parseRetroJSON("not json"))
+	assert.Empty(t, parseRetroJSON(`{}`))
+}
+
+func TestBuildRetrospectiveAINotConfigured(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("LLM_AxonHub", "")
+	ai := internal.NewAIProvider(internal.AIConfig{})
+	r := &internal.RetroReport{Completed: []internal.RetroIssue{{Identifier: "LUC-1"}}}
+	assert.Empty(t, buildRetrospective(ai, "t", nil, r))
+}
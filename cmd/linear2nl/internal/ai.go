@@ -13,7 +13,7 @@ import (
 	"github.com/xbpk3t/docs-alfred/pkg/ai"
 )
 
-//go:embed prompts/plan.txt prompts/summary.txt
+//go:embed prompts/plan.txt prompts/summary.txt prompts/retro.txt
 var promptFiles embed.FS
 
 // PromptFiles is the embedded prompt filesystem, exported for use by review command.
@@ -31,6 +31,24 @@ type summaryPromptData struct {
 	Issues []IssueDetail
 }
 
+type retroPromptData struct {
+	Lang      string
+	Period    string
+	Stats     []string
+	Completed []retroPromptIssue
+	CarryOver []retroPromptIssue
+}
+
+type retroPromptIssue struct {
+	Identifier string
+	Title      string
+	TeamName   string
+	StateName  string
+	Labels     string
+	CycleTime  string
+	Age        string
+}
+
 // AIProvider wraps pkg/ai and prompt templates for report generation.
 type AIProvider struct {
 	clientCfg *ai.ClientConfig
@@ -58,7 +76,31 @@ func NewAIProvider(cfg AIConfig) *AIProvider {
 
 // PlanJSON is the expected JSON structure from the AI morning plan.
 type PlanJSON struct {
-	Reviews []PlanItemJSON `json:"reviews"`
+	Reviews []PlanItemJSON   `json:"reviews"`
+	Actions []PlanActionJSON `json:"actions"`
+}
+
+// Plan action types suggested by the AI morning plan (morning --apply).
+const (
+	PlanActionMove     = "move"
+	PlanActionPriority = "priority"
+	PlanActionEstimate = "estimate"
+	PlanActionComment  = "comment"
+	PlanActionSplit    = "split"
+)
+
+// PlanActionJSON is a single suggested mutation in the morning plan JSON response.
+// Only the field matching Type is used: State for move, Priority for priority,
+// Estimate for estimate, Body for comment, Titles (sub-issues) for split.
+type PlanActionJSON struct {
+	Priority   *int     `json:"priority,omitempty"`
+	Estimate   *float64 `json:"estimate,omitempty"`
+	Identifier string   `json:"identifier"`
+	Type       string   `json:"type"`
+	State      string   `json:"state,omitempty"`
+	Body       string   `json:"body,omitempty"`
+	Reason     string   `json:"reason,omitempty"`
+	Titles     []string `json:"titles,omitempty"`
 }
 
 // PlanItemJSON is a single issue item in the morning plan JSON response.
@@ -103,6 +145,60 @@ func (p *AIProvider) EveningDeepReview(issues []IssueDetail) string {
 	return p.chat(prompt)
 }
 
+// RetroJSON is the expected JSON structure from the AI retrospective.
+type RetroJSON struct {
+	Highlights []string `json:"highlights"`
+	Risks      []string `json:"risks"`
+	Actions    []string `json:"actions"`
+	Summary    []string `json:"summary"`
+}
+
+// Retrospective generates a period retrospective from an aggregated report.
+// stats are pre-rendered summary lines (e.g. "完成: 12").
+// Returns raw JSON string; empty if AI is unavailable or call fails.
+func (p *AIProvider) Retrospective(period string, stats []string, report *RetroReport) string {
+	data := retroPromptData{
+		Lang:      p.lang,
+		Period:    period,
+		Stats:     stats,
+		Completed: make([]retroPromptIssue, 0, len(report.Completed)),
+		CarryOver: make([]retroPromptIssue, 0, len(report.CarryOver)),
+	}
+	for i := range report.Completed {
+		data.Completed = append(data.Completed, toRetroPromptIssue(&report.Completed[i]))
+	}
+	for i := range report.CarryOver {
+		data.CarryOver = append(data.CarryOver, toRetroPromptIssue(&report.CarryOver[i]))
+	}
+
+	prompt, err := p.renderPrompt("prompts/retro.txt", data)
+	if err != nil {
+		slog.Warn("failed to render retrospective prompt", "error", err)
+
+		return ""
+	}
+
+	return p.chat(prompt)
+}
+
+func toRetroPromptIssue(ri *RetroIssue) retroPromptIssue {
+	out := retroPromptIssue{
+		Identifier: ri.Identifier,
+		Title:      ri.Title,
+		TeamName:   ri.TeamName,
+		StateName:  ri.StateName,
+		Labels:     strings.Join(ri.Labels, ", "),
+	}
+	if ri.CycleTime > 0 {
+		out.CycleTime = FormatDays(ri.CycleTime)
+	}
+	if ri.Age > 0 {
+		out.Age = FormatDays(ri.Age)
+	}
+
+	return out
+}
+
 // IsConfigured returns whether the AI client has an API key.
 func (p *AIProvider) IsConfigured() bool {
 	return p.clientCfg.APIKey != ""
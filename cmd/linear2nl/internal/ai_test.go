@@ -60,3 +60,28 @@ func TestRenderPromptWithInvalidTemplate(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "parse prompt")
 }
+
+func TestRetrospectiveReturnsEmptyWithoutKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("LLM_AxonHub", "")
+	p := NewAIProvider(AIConfig{APIKey: ""})
+	got := p.Retrospective("Linear 周报", nil, &RetroReport{})
+	assert.Empty(t, got)
+}
+
+func TestRenderRetroPrompt(t *testing.T) {
+	p := NewAIProvider(AIConfig{Language: "en"})
+	prompt, err := p.renderPrompt("prompts/retro.txt", retroPromptData{
+		Lang:      "en",
+		Period:    "Linear 周报 · 2026-06-01 ~ 2026-06-07",
+		Stats:     []string{"✅ 完成: 3"},
+		Completed: []retroPromptIssue{{Identifier: "LUC-1", Title: "Shipped", TeamName: "Eng", CycleTime: "2.0d"}},
+		CarryOver: []retroPromptIssue{{Identifier: "LUC-2", Title: "Stuck", StateName: "In Progress", Age: "9.0d"}},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, prompt, "2026-06-01 ~ 2026-06-07")
+	assert.Contains(t, prompt, "✅ 完成: 3")
+	assert.Contains(t, prompt, "LUC-1: Shipped")
+	assert.Contains(t, prompt, "周期: 2.0d")
+	assert.Contains(t, prompt, "LUC-2: Stuck")
+}
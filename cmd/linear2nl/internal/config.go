@@ -12,24 +12,50 @@ import (
 
 // Config is the top-level configuration for linear2nl.
 type Config struct {
-	GitHub  GitHubConfig  `koanf:"github"`
-	Theme   string        `default:"dark"  koanf:"theme" validate:"in:dark,light"`
-	Morning MorningConfig `koanf:"morning"`
-	AI      AIConfig      `koanf:"ai"`
-	Resend  ResendConfig  `koanf:"resend"`
-	Linear  LinearConfig  `koanf:"linear"`
+	GitHub   GitHubConfig   `koanf:"github"`
+	Theme    string         `default:"dark"  koanf:"theme" validate:"in:dark,light"`
+	Morning  MorningConfig  `koanf:"morning"`
+	AI       AIConfig       `koanf:"ai"`
+	Resend   ResendConfig   `koanf:"resend"`
+	Linear   LinearConfig   `koanf:"linear"`
+	Snapshot SnapshotConfig `koanf:"snapshot"`
 }
 
-// GitHubConfig holds GitHub API configuration for the review command.
+// GitHubConfig holds GitHub API configuration for the review and sync commands.
 type GitHubConfig struct {
-	Owner string `koanf:"owner"`
-	Repo  string `koanf:"repo"`
+	Owner string           `koanf:"owner"`
+	Repo  string           `koanf:"repo"`
+	Sync  GitHubSyncConfig `koanf:"sync"`
+}
+
+// GitHubSyncConfig controls `sync github`.
+// An empty LinkFile resolves to the docs-alfred cache directory; Days is how
+// far back updated issues are scanned for new links.
+type GitHubSyncConfig struct {
+	Direction string   `default:"both"                               koanf:"direction" validate:"in:both,linear-to-github,github-to-linear"`
+	LinkFile  string   `koanf:"linkFile"`
+	Fields    []string `default:"[\"state\",\"labels\",\"comments\"]" koanf:"fields"`
+	Days      int      `default:"30"                                 koanf:"days"      validate:"gte:1"`
 }
 
 // LinearConfig holds Linear API configuration.
+// PageSize and MaxItems control cursor pagination; zero uses the
+// internal/linear defaults.
 type LinearConfig struct {
 	APIKey   string   `koanf:"apiKey"   validate:"required"`
 	TeamKeys []string `koanf:"teamKeys"`
+	PageSize int      `koanf:"pageSize" validate:"gte:0|lte:250"`
+	MaxItems int      `koanf:"maxItems" validate:"gte:0"`
+}
+
+// SnapshotConfig controls the local issue snapshot store.
+// An empty Dir resolves to the docs-alfred cache directory. Days is the
+// recently-updated window captured alongside open issues so closures show up;
+// Keep is the number of snapshots retained after each save.
+type SnapshotConfig struct {
+	Dir  string `koanf:"dir"`
+	Days int    `default:"7"  koanf:"days" validate:"gte:1"`
+	Keep int    `default:"60" koanf:"keep" validate:"gte:1"`
 }
 
 // MorningConfig holds morning report configuration.
@@ -62,6 +62,37 @@ resend:
 	require.Equal(t, "yaml-resend-token", cfg.Resend.Token)
 }
 
+func TestLoadConfigPagination(t *testing.T) {
+	configPath := writeTestConfig(t, `
+linear:
+  apiKey: key
+  pageSize: 100
+  maxItems: 500
+resend:
+  token: token
+  mailTo: [me@example.com]
+`)
+
+	cfg, err := LoadConfig(configPath)
+	require.NoError(t, err)
+	require.Equal(t, 100, cfg.Linear.PageSize)
+	require.Equal(t, 500, cfg.Linear.MaxItems)
+}
+
+func TestLoadConfigPaginationRejectsOversizedPage(t *testing.T) {
+	configPath := writeTestConfig(t, `
+linear:
+  apiKey: key
+  pageSize: 1000
+resend:
+  token: token
+  mailTo: [me@example.com]
+`)
+
+	_, err := LoadConfig(configPath)
+	require.Error(t, err)
+}
+
 func writeTestConfig(t *testing.T, content string) string {
 	t.Helper()
 
@@ -0,0 +1,241 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// Sync directions accepted by github.sync.direction and --direction.
+const (
+	SyncBoth           = "both"
+	SyncLinearToGitHub = "linear-to-github"
+	SyncGitHubToLinear = "github-to-linear"
+)
+
+// Fields mirrored by the sync (github.sync.fields).
+const (
+	SyncFieldState    = "state"
+	SyncFieldLabels   = "labels"
+	SyncFieldComments = "comments"
+)
+
+// Sync operation targets.
+const (
+	SyncTargetGitHub = "github"
+	SyncTargetLinear = "linear"
+)
+
+// mirrorPrefix starts every comment written by the sync, so mirrored comments
+// are never mirrored back.
+const mirrorPrefix = "↪ mirrored from "
+
+// SyncLink is a Linear issue ↔ GitHub issue pair and the state last synced.
+// LinearClosed and GitHubClosed are only meaningful once StateSynced is set:
+// they hold the state both sides last agreed on.
+type SyncLink struct {
+	SyncedAt     time.Time `json:"syncedAt"`
+	Identifier   string    `json:"identifier"`
+	Mirrored     []string  `json:"mirrored,omitempty"` // fingerprints of comments already mirrored
+	IssueNumber  int       `json:"issueNumber"`
+	LinearClosed bool      `json:"linearClosed"`
+	GitHubClosed bool      `json:"githubClosed"`
+	StateSynced  bool      `json:"stateSynced,omitempty"`
+}
+
+// LinkMap is the persisted set of links, keyed by Linear identifier.
+type LinkMap struct {
+	Links map[string]*SyncLink `json:"links"`
+}
+
+// LoadLinkMap reads the link map at path; a missing file is an empty map.
+func LoadLinkMap(path string) (*LinkMap, error) {
+	m, err := fileutil.ReadJSONFile[LinkMap](path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &LinkMap{Links: map[string]*SyncLink{}}, nil
+		}
+
+		return nil, err
+	}
+	if m.Links == nil {
+		m.Links = map[string]*SyncLink{}
+	}
+
+	return &m, nil
+}
+
+// Save writes the link map to path.
+func (m *LinkMap) Save(path string) error {
+	if err := fileutil.EnsureFileDir(path); err != nil {
+		return fmt.Errorf("create link map dir: %w", err)
+	}
+
+	return fileutil.AtomicWriteJSONFile(path, m, fileutil.FilePermPrivate)
+}
+
+// ByNumber returns the link for a GitHub issue number, if any.
+func (m *LinkMap) ByNumber(number int) (*SyncLink, bool) {
+	for _, l := range m.Links {
+		if l.IssueNumber == number {
+			return l, true
+		}
+	}
+
+	return nil, false
+}
+
+// Link records identifier ↔ number unless either side is already linked.
+// It reports whether a new link was added.
+func (m *LinkMap) Link(identifier string, number int) bool {
+	if _, ok := m.Links[identifier]; ok {
+		return false
+	}
+	if _, ok := m.ByNumber(number); ok {
+		return false
+	}
+	m.Links[identifier] = &SyncLink{Identifier: identifier, IssueNumber: number}
+
+	return true
+}
+
+// SyncSide is one side of a link as the planner sees it.
+type SyncSide struct {
+	Labels   []string
+	Comments []SyncComment
+	Closed   bool
+}
+
+// SyncComment is a comment on either side.
+type SyncComment struct {
+	Author    string
+	CreatedAt string
+	Body      string
+}
+
+// SyncOp is one change to apply to Target.
+type SyncOp struct {
+	Target      string
+	Field       string
+	Labels      []string // labels to add
+	Comment     string   // rendered comment body
+	Fingerprint string   // source comment fingerprint, recorded once applied
+	Close       bool     // desired closed state
+}
+
+// String renders the op for dry-run output.
+func (o SyncOp) String() string {
+	switch o.Field {
+	case SyncFieldState:
+		if o.Close {
+			return o.Target + ": close"
+		}
+
+		return o.Target + ": reopen"
+	case SyncFieldLabels:
+		return o.Target + ": add labels " + strings.Join(o.Labels, ", ")
+	default:
+		line, _, _ := strings.Cut(o.Comment, "\n")
+
+		return o.Target + ": comment " + line
+	}
+}
+
+// PlanSync computes the operations that bring a link in sync.
+//
+// State follows direction; for "both" the side that changed since the state
+// last agreed wins. Without an agreed state to compare against, a mismatch is
+// left alone for an explicit direction to resolve.
+// Labels are only ever added, never removed. Comments are mirrored once each,
+// tracked by fingerprint in link.Mirrored.
+func PlanSync(link *SyncLink, lin, gh SyncSide, direction string, fields []string) []SyncOp {
+	var ops []SyncOp
+	toGitHub := direction != SyncGitHubToLinear
+	toLinear := direction != SyncLinearToGitHub
+
+	if slices.Contains(fields, SyncFieldState) && lin.Closed != gh.Closed {
+		switch {
+		case !toLinear:
+			ops = append(ops, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldState, Close: lin.Closed})
+		case !toGitHub:
+			ops = append(ops, SyncOp{Target: SyncTargetLinear, Field: SyncFieldState, Close: gh.Closed})
+		case !link.StateSynced:
+			// No agreed state to tell which side changed.
+		default:
+			linChanged := lin.Closed != link.LinearClosed
+			ghChanged := gh.Closed != link.GitHubClosed
+			switch {
+			case ghChanged && !linChanged:
+				ops = append(ops, SyncOp{Target: SyncTargetLinear, Field: SyncFieldState, Close: gh.Closed})
+			case linChanged && !ghChanged:
+				ops = append(ops, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldState, Close: lin.Closed})
+			}
+		}
+	}
+
+	if slices.Contains(fields, SyncFieldLabels) {
+		if toGitHub {
+			if add, _ := lo.Difference(lin.Labels, gh.Labels); len(add) > 0 {
+				ops = append(ops, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldLabels, Labels: add})
+			}
+		}
+		if toLinear {
+			if add, _ := lo.Difference(gh.Labels, lin.Labels); len(add) > 0 {
+				ops = append(ops, SyncOp{Target: SyncTargetLinear, Field: SyncFieldLabels, Labels: add})
+			}
+		}
+	}
+
+	if slices.Contains(fields, SyncFieldComments) {
+		if toGitHub {
+			ops = append(ops, mirrorComments(link, lin.Comments, SyncTargetGitHub, "Linear "+link.Identifier)...)
+		}
+		if toLinear {
+			ops = append(ops, mirrorComments(link, gh.Comments, SyncTargetLinear, fmt.Sprintf("GitHub #%d", link.IssueNumber))...)
+		}
+	}
+
+	return ops
+}
+
+func mirrorComments(link *SyncLink, comments []SyncComment, target, source string) []SyncOp {
+	var ops []SyncOp
+	for _, c := range comments {
+		if strings.HasPrefix(c.Body, mirrorPrefix) || strings.TrimSpace(c.Body) == "" {
+			continue
+		}
+		fp := commentFingerprint(c)
+		if slices.Contains(link.Mirrored, fp) {
+			continue
+		}
+		body := fmt.Sprintf("%s%s · %s · %s\n\n%s", mirrorPrefix, source, c.Author, c.CreatedAt, c.Body)
+		ops = append(ops, SyncOp{Target: target, Field: SyncFieldComments, Comment: body, Fingerprint: fp})
+	}
+
+	return ops
+}
+
+func commentFingerprint(c SyncComment) string {
+	sum := sha256.Sum256([]byte(c.Author + "\x00" + c.CreatedAt + "\x00" + c.Body))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// Record marks op as applied on the link. A state op leaves both sides in
+// agreement on op.Close.
+func (l *SyncLink) Record(op SyncOp) {
+	switch op.Field {
+	case SyncFieldState:
+		l.LinearClosed, l.GitHubClosed, l.StateSynced = op.Close, op.Close, true
+	case SyncFieldComments:
+		l.Mirrored = append(l.Mirrored, op.Fingerprint)
+	}
+}
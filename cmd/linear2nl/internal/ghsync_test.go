@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var allSyncFields = []string{SyncFieldState, SyncFieldLabels, SyncFieldComments}
+
+func TestLinkMapLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "links.json")
+
+	m, err := LoadLinkMap(path)
+	require.NoError(t, err)
+	assert.Empty(t, m.Links)
+
+	assert.True(t, m.Link("LUC-1", 10))
+	assert.False(t, m.Link("LUC-1", 11), "identifier already linked")
+	assert.False(t, m.Link("LUC-2", 10), "issue number already linked")
+	m.Links["LUC-1"].Mirrored = []string{"abc"}
+	require.NoError(t, m.Save(path))
+
+	loaded, err := LoadLinkMap(path)
+	require.NoError(t, err)
+	require.Contains(t, loaded.Links, "LUC-1")
+	assert.Equal(t, 10, loaded.Links["LUC-1"].IssueNumber)
+	assert.Equal(t, []string{"abc"}, loaded.Links["LUC-1"].Mirrored)
+
+	l, ok := loaded.ByNumber(10)
+	require.True(t, ok)
+	assert.Equal(t, "LUC-1", l.Identifier)
+}
+
+func TestPlanSyncStateFirstSyncMismatchNeedsDirection(t *testing.T) {
+	link := &SyncLink{Identifier: "LUC-1", IssueNumber: 1, SyncedAt: time.Now()}
+	assert.Empty(t, PlanSync(link, SyncSide{Closed: true}, SyncSide{Closed: false}, SyncBoth, []string{SyncFieldState}))
+
+	ops := PlanSync(link, SyncSide{Closed: true}, SyncSide{Closed: false}, SyncLinearToGitHub, []string{SyncFieldState})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldState, Close: true}, ops[0])
+}
+
+func TestPlanSyncStateChangedSideWins(t *testing.T) {
+	link := &SyncLink{Identifier: "LUC-1", IssueNumber: 1, SyncedAt: time.Now(), StateSynced: true}
+
+	// GitHub was closed since the last sync; Linear unchanged (open).
+	ops := PlanSync(link, SyncSide{}, SyncSide{Closed: true}, SyncBoth, []string{SyncFieldState})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncTargetLinear, ops[0].Target)
+	assert.True(t, ops[0].Close)
+
+	// Linear was closed since the last sync.
+	ops = PlanSync(link, SyncSide{Closed: true}, SyncSide{}, SyncBoth, []string{SyncFieldState})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncTargetGitHub, ops[0].Target)
+}
+
+func TestPlanSyncStateOneWay(t *testing.T) {
+	link := &SyncLink{Identifier: "LUC-1", IssueNumber: 1, SyncedAt: time.Now()}
+
+	ops := PlanSync(link, SyncSide{}, SyncSide{Closed: true}, SyncLinearToGitHub, []string{SyncFieldState})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldState, Close: false}, ops[0])
+
+	ops = PlanSync(link, SyncSide{Closed: true}, SyncSide{}, SyncGitHubToLinear, []string{SyncFieldState})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncOp{Target: SyncTargetLinear, Field: SyncFieldState, Close: false}, ops[0])
+
+	assert.Empty(t, PlanSync(link, SyncSide{Closed: true}, SyncSide{Closed: true}, SyncBoth, allSyncFields))
+}
+
+func TestPlanSyncLabels(t *testing.T) {
+	link := &SyncLink{Identifier: "LUC-1", IssueNumber: 1}
+	lin := SyncSide{Labels: []string{"bug", "infra"}}
+	gh := SyncSide{Labels: []string{"bug", "help wanted"}}
+
+	ops := PlanSync(link, lin, gh, SyncBoth, []string{SyncFieldLabels})
+	require.Len(t, ops, 2)
+	assert.Equal(t, SyncOp{Target: SyncTargetGitHub, Field: SyncFieldLabels, Labels: []string{"infra"}}, ops[0])
+	assert.Equal(t, SyncOp{Target: SyncTargetLinear, Field: SyncFieldLabels, Labels: []string{"help wanted"}}, ops[1])
+
+	ops = PlanSync(link, lin, gh, SyncLinearToGitHub, []string{SyncFieldLabels})
+	require.Len(t, ops, 1)
+	assert.Equal(t, SyncTargetGitHub, ops[0].Target)
+}
+
+func TestPlanSyncCommentsMirroredOnce(t *testing.T) {
+	link := &SyncLink{Identifier: "LUC-1", IssueNumber: 7}
+	lin := SyncSide{Comments: []SyncComment{{Author: "Ann", CreatedAt: "2026-06-01T00:00:00Z", Body: "from linear"}}}
+	gh := SyncSide{Comments: []SyncComment{
+		{Author: "bob", CreatedAt: "2026-06-02T00:00:00Z", Body: "from github"},
+		{Author: "bot", Body: mirrorPrefix + "Linear LUC-1 · Ann · x\n\nold"},
+		{Author: "bob", Body: "  "},
+	}}
+
+	ops := PlanSync(link, lin, gh, SyncBoth, []string{SyncFieldComments})
+	require.Len(t, ops, 2)
+	assert.Equal(t, SyncTargetGitHub, ops[0].Target)
+	assert.True(t, strings.HasPrefix(ops[0].Comment, mirrorPrefix+"Linear LUC-1 · Ann"))
+	assert.Contains(t, ops[0].Comment, "\n\nfrom linear")
+	assert.Equal(t, SyncTargetLinear, ops[1].Target)
+	assert.Contains(t, ops[1].Comment, "GitHub #7 · bob")
+	assert.Contains(t, ops[0].String(), "github: comment ↪ mirrored from")
+
+	for _, op := range ops {
+		link.Record(op)
+	}
+	assert.Empty(t, PlanSync(link, lin, gh, SyncBoth, []string{SyncFieldComments}))
+}
+
+func TestSyncLinkRecordState(t *testing.T) {
+	link := &SyncLink{}
+	link.Record(SyncOp{Field: SyncFieldState, Close: true})
+	assert.True(t, link.LinearClosed)
+	assert.True(t, link.GitHubClosed)
+	assert.True(t, link.StateSynced)
+
+	assert.Equal(t, "linear: reopen", SyncOp{Target: SyncTargetLinear, Field: SyncFieldState}.String())
+	assert.Equal(t, "github: add labels a, b", SyncOp{Target: SyncTargetGitHub, Field: SyncFieldLabels, Labels: []string{"a", "b"}}.String())
+}
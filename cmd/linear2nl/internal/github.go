@@ -98,6 +98,134 @@ func (g *GitHubClient) PostReviewComment(ctx context.Context, number int, body s
 	return nil
 }
 
+// GitHubIssue is a GitHub issue as seen by the sync command.
+type GitHubIssue struct {
+	UpdatedAt   time.Time
+	Title       string
+	Body        string
+	State       string // open or closed
+	StateReason string // completed, not_planned or reopened
+	URL         string
+	Labels      []string
+	Number      int
+}
+
+// Closed reports whether the issue is closed.
+func (i *GitHubIssue) Closed() bool {
+	return i.State == "closed"
+}
+
+// ListIssuesSince lists issues (not pull requests) in any state updated at or
+// after since, handling pagination. A zero since lists all issues.
+func (g *GitHubClient) ListIssuesSince(ctx context.Context, since time.Time) ([]GitHubIssue, error) {
+	var all []GitHubIssue
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Since:       since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		issues, resp, err := g.client.Issues.ListByRepo(ctx, g.owner, g.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list issues: %w", err)
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			all = append(all, toGitHubIssue(issue))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// GetIssue fetches a single issue.
+func (g *GitHubClient) GetIssue(ctx context.Context, number int) (*GitHubIssue, error) {
+	issue, _, err := g.client.Issues.Get(ctx, g.owner, g.repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("get issue #%d: %w", number, err)
+	}
+	gi := toGitHubIssue(issue)
+
+	return &gi, nil
+}
+
+// ListComments fetches all comments on an issue.
+func (g *GitHubClient) ListComments(ctx context.Context, number int) ([]GitHubReviewComment, error) {
+	comments, err := g.listAllComments(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("list comments for #%d: %w", number, err)
+	}
+
+	return comments, nil
+}
+
+// SetIssueClosed closes (reason "completed" or "not_planned") or reopens an issue.
+func (g *GitHubClient) SetIssueClosed(ctx context.Context, number int, closed bool, reason string) error {
+	req := &github.IssueRequest{State: github.Ptr("open")}
+	if closed {
+		req.State = github.Ptr("closed")
+		if reason != "" {
+			req.StateReason = github.Ptr(reason)
+		}
+	}
+	if _, _, err := g.client.Issues.Edit(ctx, g.owner, g.repo, number, req); err != nil {
+		return fmt.Errorf("set state of #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds labels to an issue; GitHub creates unknown labels on the fly.
+func (g *GitHubClient) AddLabels(ctx context.Context, number int, labels []string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	if _, _, err := g.client.Issues.AddLabelsToIssue(ctx, g.owner, g.repo, number, labels); err != nil {
+		return fmt.Errorf("add labels to #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+// PostComment posts a comment on the given issue.
+func (g *GitHubClient) PostComment(ctx context.Context, number int, body string) error {
+	_, _, err := g.client.Issues.CreateComment(ctx, g.owner, g.repo, number, &github.IssueComment{
+		Body: github.Ptr(body),
+	})
+	if err != nil {
+		return fmt.Errorf("post comment on #%d: %w", number, err)
+	}
+
+	return nil
+}
+
+func toGitHubIssue(issue *github.Issue) GitHubIssue {
+	gi := GitHubIssue{
+		Number:      issue.GetNumber(),
+		Title:       issue.GetTitle(),
+		Body:        issue.GetBody(),
+		State:       issue.GetState(),
+		StateReason: issue.GetStateReason(),
+		URL:         issue.GetHTMLURL(),
+		UpdatedAt:   issue.GetUpdatedAt().Time,
+		Labels:      make([]string, 0, len(issue.Labels)),
+	}
+	for _, l := range issue.Labels {
+		gi.Labels = append(gi.Labels, l.GetName())
+	}
+
+	return gi
+}
+
 // listAllComments fetches all comments for an issue, handling pagination.
 func (g *GitHubClient) listAllComments(ctx context.Context, number int) ([]GitHubReviewComment, error) {
 	var all []GitHubReviewComment
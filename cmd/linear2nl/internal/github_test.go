@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v70/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGitHubClient(t *testing.T, handler http.HandlerFunc) *GitHubClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(nil)
+	base, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client.BaseURL = base
+
+	return &GitHubClient{client: client, owner: "o", repo: "r"}
+}
+
+func TestListIssuesSinceSkipsPullRequests(t *testing.T) {
+	g := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/o/r/issues", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		_ = json.NewEncoder(w).Encode([]map[string]any{
+			{"number": 1, "title": "LUC-1 bug", "state": "closed", "state_reason": "not_planned",
+				"labels": []map[string]any{{"name": "bug"}}, "html_url": "https://github.com/o/r/issues/1"},
+			{"number": 2, "title": "PR", "state": "open", "pull_request": map[string]any{"url": "x"}},
+		})
+	})
+
+	issues, err := g.ListIssuesSince(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.True(t, issues[0].Closed())
+	assert.Equal(t, "not_planned", issues[0].StateReason)
+	assert.Equal(t, []string{"bug"}, issues[0].Labels)
+}
+
+func TestSetIssueClosed(t *testing.T) {
+	var body map[string]any
+	g := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_ = json.NewEncoder(w).Encode(map[string]any{"number": 3})
+	})
+
+	require.NoError(t, g.SetIssueClosed(context.Background(), 3, true, "completed"))
+	assert.Equal(t, map[string]any{"state": "closed", "state_reason": "completed"}, body)
+
+	body = nil
+	require.NoError(t, g.SetIssueClosed(context.Background(), 3, false, "completed"))
+	assert.Equal(t, map[string]any{"state": "open"}, body)
+}
+
+func TestAddLabelsNoop(t *testing.T) {
+	g := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request %s", r.URL.Path)
+	})
+	require.NoError(t, g.AddLabels(context.Background(), 1, nil))
+}
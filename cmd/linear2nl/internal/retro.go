@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+// Linear workflow state types used by the retrospective.
+const (
+	stateTypeStarted   = "started"
+	stateTypeUnstarted = "unstarted"
+	stateTypeCompleted = "completed"
+)
+
+// noLabel groups issues without any label in the per-label breakdown.
+const noLabel = "(none)"
+
+// RetroReport is the aggregated data for a weekly/monthly retrospective.
+type RetroReport struct {
+	Start       time.Time
+	End         time.Time
+	Completed   []RetroIssue
+	CarryOver   []RetroIssue
+	Transitions []GroupCount // state transitions in the window, by target state
+	Teams       []GroupStats
+	Labels      []GroupStats
+	CycleTime   DurationStats // first started → completed
+	LeadTime    DurationStats // created → completed
+	Started     int           // issues moved into a started state in the window
+	Changes     int           // total state transitions in the window
+}
+
+// RetroIssue is a single issue as shown in the retrospective.
+type RetroIssue struct {
+	Identifier  string
+	Title       string
+	TeamName    string
+	StateName   string
+	URL         string
+	CompletedAt string
+	Labels      []string
+	CycleTime   time.Duration // zero when the issue never entered a started state
+	LeadTime    time.Duration
+	Age         time.Duration // carry-over only: time since creation at window end
+}
+
+// GroupCount is a name with an occurrence count.
+type GroupCount struct {
+	Name  string
+	Count int
+}
+
+// GroupStats is the per-team or per-label breakdown row.
+type GroupStats struct {
+	Name         string
+	Completed    int
+	CarryOver    int
+	MedianCycle  time.Duration
+	cycleSamples []time.Duration
+}
+
+// DurationStats summarizes a set of durations.
+type DurationStats struct {
+	Count  int
+	Avg    time.Duration
+	Median time.Duration
+	Max    time.Duration
+}
+
+// BuildRetroReport aggregates issue activity for the half-open window [start, end).
+//
+// Completed issues are those whose completedAt falls in the window; carry-over
+// issues are still unstarted/started and were created before the window ended.
+// Cycle time runs from the first transition into a started state to completion.
+func BuildRetroReport(activity []linear.IssueActivity, start, end time.Time) *RetroReport {
+	r := &RetroReport{Start: start, End: end}
+	teams := map[string]*GroupStats{}
+	labels := map[string]*GroupStats{}
+	transitions := map[string]int{}
+	var cycles, leads []time.Duration
+
+	for i := range activity {
+		a := &activity[i]
+		for _, h := range a.History {
+			at, ok := parseTime(h.CreatedAt)
+			if !ok || !inWindow(at, start, end) || h.FromState == h.ToState {
+				continue
+			}
+			r.Changes++
+			transitions[h.ToState]++
+			if h.ToType == stateTypeStarted && h.FromType != stateTypeStarted {
+				r.Started++
+			}
+		}
+
+		created, _ := parseTime(a.CreatedAt)
+		completed, isDone := parseTime(a.CompletedAt)
+		issueLabels := a.Labels
+		if len(issueLabels) == 0 {
+			issueLabels = []string{noLabel}
+		}
+
+		switch {
+		case isDone && a.StateType == stateTypeCompleted && inWindow(completed, start, end):
+			ri := toRetroIssue(a)
+			if !created.IsZero() {
+				ri.LeadTime = completed.Sub(created)
+				leads = append(leads, ri.LeadTime)
+			}
+			if startedAt, ok := firstStarted(a.History); ok && !startedAt.After(completed) {
+				ri.CycleTime = completed.Sub(startedAt)
+				cycles = append(cycles, ri.CycleTime)
+			}
+			r.Completed = append(r.Completed, ri)
+
+			g := groupFor(teams, a.TeamName)
+			g.Completed++
+			g.addCycle(ri.CycleTime)
+			for _, l := range issueLabels {
+				lg := groupFor(labels, l)
+				lg.Completed++
+				lg.addCycle(ri.CycleTime)
+			}
+
+		case (a.StateType == stateTypeStarted || a.StateType == stateTypeUnstarted) && !created.IsZero() && created.Before(end):
+			ri := toRetroIssue(a)
+			ri.Age = end.Sub(created)
+			r.CarryOver = append(r.CarryOver, ri)
+
+			groupFor(teams, a.TeamName).CarryOver++
+			for _, l := range issueLabels {
+				groupFor(labels, l).CarryOver++
+			}
+		}
+	}
+
+	slices.SortFunc(r.Completed, func(a, b RetroIssue) int { return cmp.Compare(a.CompletedAt, b.CompletedAt) })
+	slices.SortFunc(r.CarryOver, func(a, b RetroIssue) int { return cmp.Compare(b.Age, a.Age) })
+
+	r.CycleTime = summarizeDurations(cycles)
+	r.LeadTime = summarizeDurations(leads)
+	r.Teams = sortedGroups(teams)
+	r.Labels = sortedGroups(labels)
+	for name, n := range transitions {
+		r.Transitions = append(r.Transitions, GroupCount{Name: name, Count: n})
+	}
+	slices.SortFunc(r.Transitions, func(a, b GroupCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+
+	return r
+}
+
+func toRetroIssue(a *linear.IssueActivity) RetroIssue {
+	return RetroIssue{
+		Identifier:  a.Identifier,
+		Title:       a.Title,
+		TeamName:    a.TeamName,
+		StateName:   a.StateName,
+		URL:         a.URL,
+		CompletedAt: a.CompletedAt,
+		Labels:      a.Labels,
+	}
+}
+
+// firstStarted returns the earliest transition into a started state.
+// Linear returns history newest-first, so every entry is inspected.
+func firstStarted(history []linear.StateTransition) (time.Time, bool) {
+	var first time.Time
+	for _, h := range history {
+		if h.ToType != stateTypeStarted {
+			continue
+		}
+		at, ok := parseTime(h.CreatedAt)
+		if !ok {
+			continue
+		}
+		if first.IsZero() || at.Before(first) {
+			first = at
+		}
+	}
+
+	return first, !first.IsZero()
+}
+
+func groupFor(groups map[string]*GroupStats, name string) *GroupStats {
+	g, ok := groups[name]
+	if !ok {
+		g = &GroupStats{Name: name}
+		groups[name] = g
+	}
+
+	return g
+}
+
+func (g *GroupStats) addCycle(d time.Duration) {
+	if d > 0 {
+		g.cycleSamples = append(g.cycleSamples, d)
+	}
+}
+
+func sortedGroups(groups map[string]*GroupStats) []GroupStats {
+	out := make([]GroupStats, 0, len(groups))
+	for _, g := range groups {
+		g.MedianCycle = summarizeDurations(g.cycleSamples).Median
+		g.cycleSamples = nil
+		out = append(out, *g)
+	}
+	slices.SortFunc(out, func(a, b GroupStats) int {
+		return cmp.Or(cmp.Compare(b.Completed, a.Completed), cmp.Compare(b.CarryOver, a.CarryOver), cmp.Compare(a.Name, b.Name))
+	})
+
+	return out
+}
+
+func summarizeDurations(ds []time.Duration) DurationStats {
+	if len(ds) == 0 {
+		return DurationStats{}
+	}
+	sorted := slices.Clone(ds)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return DurationStats{
+		Count:  len(sorted),
+		Avg:    total / time.Duration(len(sorted)),
+		Median: median,
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+func inWindow(t, start, end time.Time) bool {
+	return !t.Before(start) && t.Before(end)
+}
+
+// parseTime parses a Linear RFC 3339 timestamp; empty or malformed values report false.
+func parseTime(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// FormatDays renders a duration in days with one decimal (e.g. "2.5d"),
+// falling back to hours below one day.
+func FormatDays(d time.Duration) string {
+	if d <= 0 {
+		return "—"
+	}
+	if d < 24*time.Hour {
+		return fmt.Sprintf("%.1fh", d.Hours())
+	}
+
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+func mustTime(t *testing.T, s string) time.Time {
+	t.Helper()
+
+	v, err := time.Parse(time.RFC3339, s)
+	require.NoError(t, err)
+
+	return v
+}
+
+func TestBuildRetroReport(t *testing.T) {
+	start := mustTime(t, "2026-06-01T00:00:00Z")
+	end := mustTime(t, "2026-06-08T00:00:00Z")
+
+	activity := []linear.IssueActivity{
+		{
+			Identifier: "ENG-1", Title: "Done in window", TeamName: "Eng", StateType: "completed", StateName: "Done",
+			Labels:      []string{"bug"},
+			CreatedAt:   "2026-05-30T00:00:00Z",
+			CompletedAt: "2026-06-03T00:00:00Z",
+			History: []linear.StateTransition{
+				// Newest first, as Linear returns it.
+				{FromState: "In Progress", FromType: "started", ToState: "Done", ToType: "completed", CreatedAt: "2026-06-03T00:00:00Z"},
+				{FromState: "Todo", FromType: "unstarted", ToState: "In Progress", ToType: "started", CreatedAt: "2026-06-01T00:00:00Z"},
+			},
+		},
+		{
+			Identifier: "ENG-2", Title: "Done before window", TeamName: "Eng", StateType: "completed",
+			CreatedAt: "2026-05-01T00:00:00Z", CompletedAt: "2026-05-20T00:00:00Z",
+		},
+		{
+			Identifier: "OPS-1", Title: "Still open", TeamName: "Ops", StateType: "started", StateName: "In Progress",
+			CreatedAt: "2026-05-25T00:00:00Z",
+			History: []linear.StateTransition{
+				{FromState: "Todo", FromType: "unstarted", ToState: "In Progress", ToType: "started", CreatedAt: "2026-06-02T00:00:00Z"},
+			},
+		},
+		{
+			Identifier: "OPS-2", Title: "Created after window", TeamName: "Ops", StateType: "unstarted",
+			CreatedAt: "2026-06-10T00:00:00Z",
+		},
+	}
+
+	r := BuildRetroReport(activity, start, end)
+
+	require.Len(t, r.Completed, 1)
+	assert.Equal(t, "ENG-1", r.Completed[0].Identifier)
+	assert.Equal(t, 48*time.Hour, r.Completed[0].CycleTime)
+	assert.Equal(t, 96*time.Hour, r.Completed[0].LeadTime)
+
+	require.Len(t, r.CarryOver, 1)
+	assert.Equal(t, "OPS-1", r.CarryOver[0].Identifier)
+	assert.Equal(t, 14*24*time.Hour, r.CarryOver[0].Age)
+
+	assert.Equal(t, 3, r.Changes)
+	assert.Equal(t, 2, r.Started)
+	assert.Equal(t, []GroupCount{{Name: "In Progress", Count: 2}, {Name: "Done", Count: 1}}, r.Transitions)
+
+	assert.Equal(t, 1, r.CycleTime.Count)
+	assert.Equal(t, 48*time.Hour, r.CycleTime.Median)
+
+	require.Len(t, r.Teams, 2)
+	assert.Equal(t, GroupStats{Name: "Eng", Completed: 1, MedianCycle: 48 * time.Hour}, r.Teams[0])
+	assert.Equal(t, GroupStats{Name: "Ops", CarryOver: 1}, r.Teams[1])
+
+	require.Len(t, r.Labels, 2)
+	assert.Equal(t, "bug", r.Labels[0].Name)
+	assert.Equal(t, noLabel, r.Labels[1].Name)
+}
+
+func TestBuildRetroReportEmpty(t *testing.T) {
+	r := BuildRetroReport(nil, time.Now(), time.Now())
+	assert.Empty(t, r.Completed)
+	assert.Empty(t, r.CarryOver)
+	assert.Zero(t, r.CycleTime)
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	s := summarizeDurations([]time.Duration{4 * time.Hour, time.Hour, 3 * time.Hour, 2 * time.Hour})
+	assert.Equal(t, 4, s.Count)
+	assert.Equal(t, 150*time.Minute, s.Avg)
+	assert.Equal(t, 150*time.Minute, s.Median)
+	assert.Equal(t, 4*time.Hour, s.Max)
+
+	assert.Equal(t, DurationStats{}, summarizeDurations(nil))
+}
+
+func TestFirstStartedIgnoresLaterRestarts(t *testing.T) {
+	at, ok := firstStarted([]linear.StateTransition{
+		{ToType: "started", CreatedAt: "2026-06-05T00:00:00Z"},
+		{ToType: "unstarted", CreatedAt: "2026-06-04T00:00:00Z"},
+		{ToType: "started", CreatedAt: "2026-06-02T00:00:00Z"},
+	})
+	require.True(t, ok)
+	assert.Equal(t, mustTime(t, "2026-06-02T00:00:00Z"), at)
+
+	_, ok = firstStarted(nil)
+	assert.False(t, ok)
+}
+
+func TestFormatDays(t *testing.T) {
+	assert.Equal(t, "—", FormatDays(0))
+	assert.Equal(t, "5.0h", FormatDays(5*time.Hour))
+	assert.Equal(t, "1.5d", FormatDays(36*time.Hour))
+}
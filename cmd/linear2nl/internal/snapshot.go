@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// snapshotTimeLayout names snapshot files so lexical order is chronological.
+const (
+	snapshotTimeLayout = "20060102T150405Z"
+	snapshotExt        = ".json"
+)
+
+// ErrNoSnapshot is returned when the store holds no matching snapshot.
+var ErrNoSnapshot = errors.New("no snapshot found")
+
+// Snapshot is a point-in-time copy of the viewer's Linear issues.
+type Snapshot struct {
+	TakenAt time.Time            `json:"takenAt"`
+	Issues  []linear.IssueDetail `json:"issues"`
+}
+
+// SnapshotInfo identifies a stored snapshot without loading it.
+type SnapshotInfo struct {
+	TakenAt time.Time
+	Name    string
+	Path    string
+}
+
+// SnapshotStore persists snapshots as one JSON file per capture in Dir.
+type SnapshotStore struct {
+	Dir string
+}
+
+// NewSnapshotStore returns a store rooted at dir, defaulting to
+// the linear2nl/snapshots cache directory when dir is empty.
+func NewSnapshotStore(dir string) *SnapshotStore {
+	if dir == "" {
+		dir = fileutil.CachePath("linear2nl/snapshots")
+	}
+
+	return &SnapshotStore{Dir: dir}
+}
+
+// Save writes the snapshot and returns its info.
+func (s *SnapshotStore) Save(snap *Snapshot) (SnapshotInfo, error) {
+	if err := fileutil.EnsureDir(s.Dir); err != nil {
+		return SnapshotInfo{}, fmt.Errorf("create snapshot dir: %w", err)
+	}
+
+	takenAt := snap.TakenAt.UTC()
+	name := takenAt.Format(snapshotTimeLayout)
+	path := filepath.Join(s.Dir, name+snapshotExt)
+	if err := fileutil.AtomicWriteJSONFile(path, snap, fileutil.FilePermPrivate); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	return SnapshotInfo{TakenAt: takenAt, Name: name, Path: path}, nil
+}
+
+// List returns all stored snapshots, oldest first. A missing directory is an empty store.
+func (s *SnapshotStore) List() ([]SnapshotInfo, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read snapshot dir: %w", err)
+	}
+
+	var infos []SnapshotInfo
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), snapshotExt)
+		if e.IsDir() || !ok {
+			continue
+		}
+		takenAt, err := time.Parse(snapshotTimeLayout, name)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, SnapshotInfo{TakenAt: takenAt, Name: name, Path: filepath.Join(s.Dir, e.Name())})
+	}
+	slices.SortFunc(infos, func(a, b SnapshotInfo) int { return a.TakenAt.Compare(b.TakenAt) })
+
+	return infos, nil
+}
+
+// Load reads the snapshot with the given name (as shown by List).
+func (s *SnapshotStore) Load(name string) (*Snapshot, error) {
+	name = strings.TrimSuffix(filepath.Base(name), snapshotExt)
+	snap, err := fileutil.ReadJSONFile[Snapshot](filepath.Join(s.Dir, name+snapshotExt))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNoSnapshot, name)
+		}
+
+		return nil, err
+	}
+
+	return &snap, nil
+}
+
+// Latest loads the most recent snapshot.
+func (s *SnapshotStore) Latest() (*Snapshot, error) {
+	return s.LatestBefore(time.Time{})
+}
+
+// LatestBefore loads the most recent snapshot taken strictly before t.
+// A zero t means no upper bound.
+func (s *SnapshotStore) LatestBefore(t time.Time) (*Snapshot, error) {
+	infos, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(infos) - 1; i >= 0; i-- {
+		if t.IsZero() || infos[i].TakenAt.Before(t) {
+			return s.Load(infos[i].Name)
+		}
+	}
+
+	return nil, ErrNoSnapshot
+}
+
+// Prune removes the oldest snapshots so at most keep remain.
+// It returns the number of files removed; keep <= 0 removes nothing.
+func (s *SnapshotStore) Prune(keep int) (int, error) {
+	infos, err := s.List()
+	if err != nil || keep <= 0 || len(infos) <= keep {
+		return 0, err
+	}
+
+	removed := 0
+	for _, info := range infos[:len(infos)-keep] {
+		if err := os.Remove(info.Path); err != nil {
+			return removed, fmt.Errorf("remove snapshot %s: %w", info.Name, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+// Closed Linear state types.
+const stateTypeCanceled = "canceled"
+
+// SnapshotDiff is the set of changes between two snapshots.
+//
+// Closed issues moved into a completed/canceled state; Removed issues are
+// open in the older snapshot but absent from the newer one (unassigned,
+// moved to an ignored team, or closed outside the capture window).
+type SnapshotDiff struct {
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	New           []IssueChange `json:"new"`
+	Closed        []IssueChange `json:"closed"`
+	Reopened      []IssueChange `json:"reopened"`
+	Removed       []IssueChange `json:"removed"`
+	StateChanged  []IssueChange `json:"stateChanged"`
+	Reprioritised []IssueChange `json:"reprioritised"`
+	Reestimated   []IssueChange `json:"reestimated"`
+}
+
+// IssueChange is an issue with the before/after value of the changed field.
+// From is empty for New; To is empty for Removed.
+type IssueChange struct {
+	Identifier string `json:"identifier"`
+	Title      string `json:"title"`
+	TeamName   string `json:"teamName"`
+	URL        string `json:"url"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to,omitempty"`
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d *SnapshotDiff) IsEmpty() bool {
+	return len(d.New) == 0 && len(d.Closed) == 0 && len(d.Reopened) == 0 && len(d.Removed) == 0 &&
+		len(d.StateChanged) == 0 && len(d.Reprioritised) == 0 && len(d.Reestimated) == 0
+}
+
+// DiffSnapshots compares two snapshots. Either may be nil (treated as empty).
+// Every slice is sorted by identifier.
+func DiffSnapshots(older, newer *Snapshot) *SnapshotDiff {
+	if older == nil {
+		older = &Snapshot{}
+	}
+	if newer == nil {
+		newer = &Snapshot{}
+	}
+
+	d := &SnapshotDiff{From: older.TakenAt, To: newer.TakenAt}
+	before := make(map[string]*linear.IssueDetail, len(older.Issues))
+	for i := range older.Issues {
+		before[older.Issues[i].Identifier] = &older.Issues[i]
+	}
+	seen := make(map[string]bool, len(newer.Issues))
+
+	for i := range newer.Issues {
+		cur := &newer.Issues[i]
+		seen[cur.Identifier] = true
+
+		prev, ok := before[cur.Identifier]
+		if !ok {
+			d.New = append(d.New, issueChange(cur, "", cur.StateName))
+			continue
+		}
+
+		if prev.StateName != cur.StateName {
+			d.StateChanged = append(d.StateChanged, issueChange(cur, prev.StateName, cur.StateName))
+		}
+		switch {
+		case !isClosedState(prev.StateType) && isClosedState(cur.StateType):
+			d.Closed = append(d.Closed, issueChange(cur, prev.StateName, cur.StateName))
+		case isClosedState(prev.StateType) && !isClosedState(cur.StateType):
+			d.Reopened = append(d.Reopened, issueChange(cur, prev.StateName, cur.StateName))
+		}
+		if prev.Priority != cur.Priority {
+			d.Reprioritised = append(d.Reprioritised, issueChange(cur, PriorityName(prev.Priority), PriorityName(cur.Priority)))
+		}
+		if prev.Estimate != cur.Estimate {
+			d.Reestimated = append(d.Reestimated, issueChange(cur, FormatEstimate(prev.Estimate), FormatEstimate(cur.Estimate)))
+		}
+	}
+
+	for i := range older.Issues {
+		prev := &older.Issues[i]
+		if !seen[prev.Identifier] && !isClosedState(prev.StateType) {
+			d.Removed = append(d.Removed, issueChange(prev, prev.StateName, ""))
+		}
+	}
+
+	for _, s := range []*[]IssueChange{&d.New, &d.Closed, &d.Reopened, &d.Removed, &d.StateChanged, &d.Reprioritised, &d.Reestimated} {
+		slices.SortFunc(*s, func(a, b IssueChange) int { return cmp.Compare(a.Identifier, b.Identifier) })
+	}
+
+	return d
+}
+
+func issueChange(d *linear.IssueDetail, from, to string) IssueChange {
+	return IssueChange{
+		Identifier: d.Identifier,
+		Title:      d.Title,
+		TeamName:   d.TeamName,
+		URL:        d.URL,
+		From:       from,
+		To:         to,
+	}
+}
+
+func isClosedState(stateType string) bool {
+	return stateType == stateTypeCompleted || stateType == stateTypeCanceled
+}
+
+// PriorityName returns Linear's name for a numeric priority (0 = No priority).
+func PriorityName(p float64) string {
+	switch int(p) {
+	case 1:
+		return "Urgent"
+	case 2:
+		return "High"
+	case 3:
+		return "Medium"
+	case 4:
+		return "Low"
+	default:
+		return "No priority"
+	}
+}
+
+// FormatEstimate renders an estimate in points, "—" when unset.
+func FormatEstimate(e float64) string {
+	if e == 0 {
+		return "—"
+	}
+
+	return strconv.FormatFloat(e, 'f', -1, 64)
+}
@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/linear"
+)
+
+func saveSnapshot(t *testing.T, store *SnapshotStore, at string, issues ...linear.IssueDetail) SnapshotInfo {
+	t.Helper()
+
+	info, err := store.Save(&Snapshot{TakenAt: mustTime(t, at), Issues: issues})
+	require.NoError(t, err)
+
+	return info
+}
+
+func TestSnapshotStoreSaveListLoad(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+	saveSnapshot(t, store, "2026-06-02T08:00:00Z", linear.IssueDetail{Identifier: "ENG-2"})
+	first := saveSnapshot(t, store, "2026-06-01T08:00:00Z", linear.IssueDetail{Identifier: "ENG-1", Estimate: 3})
+	assert.Equal(t, "20260601T080000Z", first.Name)
+
+	// Foreign files are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir, "notes.json"), []byte("{}"), 0o600))
+
+	infos, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "20260601T080000Z", infos[0].Name)
+	assert.Equal(t, "20260602T080000Z", infos[1].Name)
+
+	snap, err := store.Load(first.Name + ".json")
+	require.NoError(t, err)
+	require.Len(t, snap.Issues, 1)
+	assert.InDelta(t, 3, snap.Issues[0].Estimate, 0)
+	assert.True(t, snap.TakenAt.Equal(mustTime(t, "2026-06-01T08:00:00Z")))
+
+	_, err = store.Load("20990101T000000Z")
+	require.ErrorIs(t, err, ErrNoSnapshot)
+}
+
+func TestSnapshotStoreLatest(t *testing.T) {
+	store := NewSnapshotStore(filepath.Join(t.TempDir(), "missing"))
+	_, err := store.Latest()
+	require.ErrorIs(t, err, ErrNoSnapshot)
+
+	saveSnapshot(t, store, "2026-06-01T08:00:00Z", linear.IssueDetail{Identifier: "ENG-1"})
+	saveSnapshot(t, store, "2026-06-02T08:00:00Z", linear.IssueDetail{Identifier: "ENG-2"})
+
+	latest, err := store.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-2", latest.Issues[0].Identifier)
+
+	before, err := store.LatestBefore(mustTime(t, "2026-06-02T08:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-1", before.Issues[0].Identifier)
+
+	_, err = store.LatestBefore(mustTime(t, "2026-06-01T00:00:00Z"))
+	require.ErrorIs(t, err, ErrNoSnapshot)
+}
+
+func TestSnapshotStorePrune(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+	for _, at := range []string{"2026-06-01T08:00:00Z", "2026-06-02T08:00:00Z", "2026-06-03T08:00:00Z"} {
+		saveSnapshot(t, store, at)
+	}
+
+	removed, err := store.Prune(2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+
+	infos, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "20260602T080000Z", infos[0].Name)
+
+	removed, err = store.Prune(0)
+	require.NoError(t, err)
+	assert.Zero(t, removed)
+}
+
+func TestNewSnapshotStoreDefaultDir(t *testing.T) {
+	store := NewSnapshotStore("")
+	assert.Contains(t, store.Dir, filepath.Join("linear2nl", "snapshots"))
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	older := &Snapshot{
+		TakenAt: mustTime(t, "2026-06-01T08:00:00Z"),
+		Issues: []linear.IssueDetail{
+			{Identifier: "ENG-1", Title: "Ship", StateName: "In Progress", StateType: "started", Priority: 3, Estimate: 2},
+			{Identifier: "ENG-2", Title: "Gone", StateName: "Todo", StateType: "unstarted"},
+			{Identifier: "ENG-3", Title: "Back", StateName: "Done", StateType: "completed"},
+			{Identifier: "ENG-4", Title: "Same", StateName: "Todo", StateType: "unstarted", Priority: 2},
+			{Identifier: "ENG-6", Title: "Closed long ago", StateName: "Done", StateType: "completed"},
+		},
+	}
+	newer := &Snapshot{
+		TakenAt: mustTime(t, "2026-06-02T08:00:00Z"),
+		Issues: []linear.IssueDetail{
+			{Identifier: "ENG-1", Title: "Ship", StateName: "Done", StateType: "completed", Priority: 1, Estimate: 5},
+			{Identifier: "ENG-3", Title: "Back", StateName: "Todo", StateType: "unstarted"},
+			{Identifier: "ENG-4", Title: "Same", StateName: "Todo", StateType: "unstarted", Priority: 2},
+			{Identifier: "ENG-5", Title: "Fresh", StateName: "Backlog", StateType: "backlog"},
+		},
+	}
+
+	d := DiffSnapshots(older, newer)
+	assert.False(t, d.IsEmpty())
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-5", Title: "Fresh", To: "Backlog"}}, d.New)
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-1", Title: "Ship", From: "In Progress", To: "Done"}}, d.Closed)
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-3", Title: "Back", From: "Done", To: "Todo"}}, d.Reopened)
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-2", Title: "Gone", From: "Todo"}}, d.Removed)
+	require.Len(t, d.StateChanged, 2)
+	assert.Equal(t, "ENG-1", d.StateChanged[0].Identifier)
+	assert.Equal(t, "ENG-3", d.StateChanged[1].Identifier)
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-1", Title: "Ship", From: "Medium", To: "Urgent"}}, d.Reprioritised)
+	assert.Equal(t, []IssueChange{{Identifier: "ENG-1", Title: "Ship", From: "2", To: "5"}}, d.Reestimated)
+}
+
+func TestDiffSnapshotsNil(t *testing.T) {
+	d := DiffSnapshots(nil, &Snapshot{Issues: []linear.IssueDetail{{Identifier: "ENG-1", StateName: "Todo"}}})
+	assert.Len(t, d.New, 1)
+	assert.True(t, DiffSnapshots(nil, nil).IsEmpty())
+}
+
+func TestPriorityNameAndEstimate(t *testing.T) {
+	assert.Equal(t, "No priority", PriorityName(0))
+	assert.Equal(t, "High", PriorityName(2))
+	assert.Equal(t, "—", FormatEstimate(0))
+	assert.Equal(t, "0.5", FormatEstimate(0.5))
+}
+
+func TestSnapshotTimesAreUTC(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+	loc := time.FixedZone("CST", 8*3600)
+	info, err := store.Save(&Snapshot{TakenAt: time.Date(2026, 6, 1, 8, 0, 0, 0, loc)})
+	require.NoError(t, err)
+	assert.Equal(t, "20260601T000000Z", info.Name)
+}
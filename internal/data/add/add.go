@@ -0,0 +1,416 @@
+// Package dataadd appends new items to the YAML files of the structured data
+// domains. The item is checked and deduplicated against the domain before
+// anything is written.
+package dataadd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+const (
+	fieldName      = "name"
+	fieldAlias     = "alias"
+	fieldAuthor    = "author"
+	fieldScore     = "score"
+	fieldPublishAt = "publishAt"
+	fieldTags      = "tags"
+	fieldURL       = "url"
+	fieldDes       = "des"
+	extYML         = ".yml"
+)
+
+// fieldOrder is the key order of written items; other fields follow in the
+// order they were given.
+var fieldOrder = []string{fieldName, fieldAlias, fieldAuthor, "cast", fieldScore, "readAt", "readTime", "playAt", "date", fieldPublishAt, fieldTags, "tag", fieldURL, fieldDes}
+
+var integer = regexp.MustCompile(`^-?\d+$`)
+
+// ErrRejected is returned when the new item fails the data check or
+// duplicates an existing item.
+var ErrRejected = errors.New("item rejected")
+
+// Field is one value of the new item. Tags are comma-separated.
+type Field struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Options controls Run.
+type Options struct {
+	// Dir is the domain directory.
+	Dir string
+	// File is the file under Dir to append to; .yml is added when missing.
+	// movie and tv default to movie.yml and tv.yml.
+	File string
+	// Lookup is a TSV or CSV dump whose matching row fills the fields not
+	// given in Fields.
+	Lookup string
+	// ID selects the Lookup row by ISBN or IMDb id instead of by name.
+	ID     string
+	Fields []Field
+	DryRun bool
+}
+
+// Result is the outcome of Run.
+type Result struct {
+	Duplicates *data.DuplicateReport `json:"duplicates,omitempty"`
+	File       string                `json:"file"`
+	// YAML is the text appended to File.
+	YAML   string            `json:"yaml"`
+	Fields []Field           `json:"fields"`
+	Issues []checkutil.Issue `json:"issues,omitempty"`
+	// Line is the first line of the new item in File.
+	Line     int  `json:"line"`
+	LookedUp bool `json:"lookedUp"`
+	DryRun   bool `json:"dryRun"`
+	// Rejected is set when Run returns ErrRejected; File is left untouched.
+	Rejected bool `json:"rejected"`
+}
+
+// Run appends an item to a file of domain. The file is resolved from the
+// domain directory and opts.File, and its rule scope from ResolveScope; the
+// item may only use fields of that scope. Errors of the data check on the
+// new lines, or duplicates of the new item, reject it with ErrRejected.
+func Run(domain data.DataDomain, opts Options) (*Result, error) {
+	spec, ok := data.SpecForDomain(domain)
+	if !ok {
+		return nil, fmt.Errorf("unknown data domain %q", domain)
+	}
+	if !spec.StructuredCheck {
+		return nil, fmt.Errorf("data add %s is not supported", domain)
+	}
+	file, err := targetFile(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	scope := data.ResolveScope(file, string(spec.RuleScope))
+
+	result := &Result{File: file, DryRun: opts.DryRun}
+	fields, err := itemFields(opts, scope, result)
+	if err != nil {
+		return nil, err
+	}
+	result.Fields = fields
+	result.YAML = renderItem(fields)
+
+	existing, perm, err := readExisting(file)
+	if err != nil {
+		return nil, err
+	}
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	result.Line = strings.Count(existing, "\n") + 1
+	content := existing + result.YAML
+
+	if err := validate(spec, opts.Dir, content, result); err != nil {
+		return result, err
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+	if err := fileutil.AtomicWriteFile(file, []byte(content), perm); err != nil {
+		return nil, fmt.Errorf("write %s: %w", file, err)
+	}
+	slog.Info("Added item", "domain", domain, "file", file, "line", result.Line)
+
+	return result, nil
+}
+
+// targetFile resolves opts.File under opts.Dir. Domains sharing a directory
+// are told apart by file name, so movie items go to movie.yml and books
+// items to any other file.
+func targetFile(spec data.DomainSpec, opts Options) (string, error) {
+	var owner data.DomainSpec
+	var siblings []data.DataDomain
+	for _, other := range data.DomainSpecs() {
+		if other.DefaultPath != spec.DefaultPath {
+			continue
+		}
+		if owner.Domain == "" {
+			owner = other
+		} else {
+			siblings = append(siblings, other.Domain)
+		}
+	}
+
+	name := opts.File
+	if name == "" {
+		if spec.Domain == owner.Domain {
+			return "", fmt.Errorf("data add %s needs --file (a file under %s)", spec.Domain, opts.Dir)
+		}
+		name = string(spec.Domain)
+	}
+	if strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("--file %q must be a file name under %s", name, opts.Dir)
+	}
+	if filepath.Ext(name) == "" {
+		name += extYML
+	}
+
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	switch {
+	case spec.Domain == owner.Domain && slices.Contains(siblings, data.DataDomain(stem)):
+		return "", fmt.Errorf("file %s holds %s items, not %s", name, stem, spec.Domain)
+	case spec.Domain != owner.Domain && stem != string(spec.Domain):
+		return "", fmt.Errorf("%s items belong in %s%s, not %s", spec.Domain, spec.Domain, extYML, name)
+	}
+
+	return filepath.Join(opts.Dir, name), nil
+}
+
+// itemFields merges opts.Fields with the lookup row and checks every key
+// against the allowed fields of scope.
+func itemFields(opts Options, scope data.RuleScope, result *Result) ([]Field, error) {
+	fields := slices.Clone(opts.Fields)
+	seen := map[string]bool{}
+	name := ""
+	for _, f := range fields {
+		if seen[f.Key] {
+			return nil, fmt.Errorf("field %s given twice", f.Key)
+		}
+		seen[f.Key] = true
+		if f.Key == fieldName {
+			name = f.Value
+		}
+	}
+
+	if opts.Lookup != "" {
+		found, ok, err := lookup(opts.Lookup, opts.ID, name)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case ok:
+			result.LookedUp = true
+		case opts.ID != "":
+			return nil, fmt.Errorf("no row of %s has id %s", opts.Lookup, opts.ID)
+		default:
+			slog.Warn("No lookup row matches the name", "lookup", opts.Lookup, "name", name)
+		}
+		allowed := data.AllowedFieldsForScope(scope)
+		for _, key := range slices.Sorted(maps.Keys(found)) {
+			if !seen[key] && allowed[key] {
+				fields = append(fields, Field{Key: key, Value: found[key]})
+				seen[key] = true
+			}
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil, errors.New("no fields given for the new item")
+	}
+	allowed := data.AllowedFieldsForScope(scope)
+	for _, f := range fields {
+		if !allowed[f.Key] {
+			return nil, fmt.Errorf("field %s is not allowed in %s files", f.Key, scope)
+		}
+	}
+
+	return fields, nil
+}
+
+// renderItem writes fields as one block sequence item in fieldOrder.
+func renderItem(fields []Field) string {
+	ordered := slices.Clone(fields)
+	rank := func(key string) int {
+		if i := slices.Index(fieldOrder, key); i >= 0 {
+			return i
+		}
+
+		return len(fieldOrder)
+	}
+	slices.SortStableFunc(ordered, func(a, b Field) int { return rank(a.Key) - rank(b.Key) })
+
+	var b strings.Builder
+	for i, f := range ordered {
+		prefix := "  "
+		if i == 0 {
+			prefix = "- "
+		}
+		fmt.Fprintf(&b, "%s%s: %s\n", prefix, f.Key, renderValue(f))
+	}
+
+	return b.String()
+}
+
+func renderValue(f Field) string {
+	switch f.Key {
+	case fieldTags:
+		var tags []string
+		for _, tag := range strings.Split(f.Value, ",") {
+			if tag = strings.TrimSpace(tag); tag == "" {
+				continue
+			}
+			if strings.ContainsAny(tag, `[]{},:#"'`) {
+				tag = strconv.Quote(tag)
+			}
+			tags = append(tags, tag)
+		}
+
+		return "[" + strings.Join(tags, ", ") + "]"
+	case fieldScore, fieldPublishAt:
+		if integer.MatchString(f.Value) || checkutil.DateFullPattern.MatchString(f.Value) {
+			return f.Value
+		}
+	case "readAt", "date", "playAt":
+		// Plain like the rest of the data files; the checker reads both.
+		if checkutil.DateFullPattern.MatchString(f.Value) {
+			return f.Value
+		}
+	}
+
+	return scalar(f.Value)
+}
+
+func scalar(s string) string {
+	if strings.Contains(s, "\n") {
+		return strconv.Quote(s)
+	}
+	out, err := yaml.Marshal(s)
+	if err != nil {
+		return strconv.Quote(s)
+	}
+
+	return strings.TrimSuffix(string(out), "\n")
+}
+
+func readExisting(file string) (string, os.FileMode, error) {
+	raw, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fileutil.FilePerm, nil
+	}
+	if err != nil {
+		return "", 0, fmt.Errorf("read %s: %w", file, err)
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", 0, fmt.Errorf("stat %s: %w", file, err)
+	}
+
+	return string(raw), info.Mode().Perm(), nil
+}
+
+// validate runs the data check and duplicate check on a copy of the domain
+// directory holding content in place of result.File. Only issues on the new
+// lines, file-level issues such as a parse error, and duplicates of the new
+// item count.
+func validate(spec data.DomainSpec, dir, content string, result *Result) error {
+	tmp, err := os.MkdirTemp("", "data-add-*")
+	if err != nil {
+		return fmt.Errorf("create temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	files, err := fileutil.ListYAMLFiles(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", file, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmp, filepath.Base(file)), raw, fileutil.FilePermPrivate); err != nil {
+			return fmt.Errorf("copy %s: %w", file, err)
+		}
+	}
+	candidate := filepath.Join(tmp, filepath.Base(result.File))
+	if err := os.WriteFile(candidate, []byte(content), fileutil.FilePermPrivate); err != nil {
+		return fmt.Errorf("write %s: %w", candidate, err)
+	}
+
+	check, err := data.RunStructuredDataCheck(tmp, string(spec.RuleScope))
+	if err != nil {
+		return err
+	}
+	for _, issue := range check.Issues {
+		// Parse errors carry no line: the append may have broken the file.
+		if issue.File == candidate && (issue.Line == 0 || issue.Line >= result.Line) {
+			issue.File = result.File
+			result.Issues = append(result.Issues, issue)
+		}
+	}
+
+	dups, err := data.RunDuplicateCheck(tmp)
+	if err != nil {
+		return err
+	}
+	result.Duplicates = newDuplicates(dups, result.Fields)
+
+	switch {
+	case checkutil.HasErrors(result.Issues):
+		result.Rejected = true
+
+		return fmt.Errorf("%w: data check failed for %s", ErrRejected, result.File)
+	case result.Duplicates != nil:
+		result.Rejected = true
+
+		return fmt.Errorf("%w: duplicates an existing item", ErrRejected)
+	}
+
+	return nil
+}
+
+// newDuplicates keeps the duplicate groups of the new item.
+func newDuplicates(report *data.DuplicateReport, fields []Field) *data.DuplicateReport {
+	values := map[string]string{}
+	for _, f := range fields {
+		values[f.Key] = f.Value
+	}
+
+	out := &data.DuplicateReport{}
+	for _, dup := range report.URLDuplicates {
+		if values[fieldURL] != "" && dup.URL == values[fieldURL] {
+			out.URLDuplicates = append(out.URLDuplicates, dup)
+		}
+	}
+	for _, dup := range report.NameAuthorDuplicates {
+		if values[fieldName] != "" && dup.Key == values[fieldName]+" | "+values[fieldAuthor] {
+			out.NameAuthorDuplicates = append(out.NameAuthorDuplicates, dup)
+		}
+	}
+	if len(out.URLDuplicates) == 0 && len(out.NameAuthorDuplicates) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+// Report renders result as text: where the item went, its YAML and the
+// check issues and duplicates found for it.
+func Report(result *Result) string {
+	var b strings.Builder
+	switch {
+	case result.Rejected:
+		fmt.Fprintf(&b, "Rejected for %s:%d\n", result.File, result.Line)
+	case result.DryRun:
+		fmt.Fprintf(&b, "Would append to %s:%d\n", result.File, result.Line)
+	default:
+		fmt.Fprintf(&b, "Appending to %s:%d\n", result.File, result.Line)
+	}
+	b.WriteString(result.YAML)
+	if len(result.Issues) > 0 {
+		report, _ := checkutil.ReportIssues(result.Issues, "data add check")
+		b.WriteString(report)
+	}
+	if result.Duplicates != nil {
+		b.WriteString(data.FormatDuplicateReport(result.Duplicates))
+	}
+
+	return b.String()
+}
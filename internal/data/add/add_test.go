@@ -0,0 +1,185 @@
+package dataadd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+)
+
+const historyYAML = `- name: 万历十五年
+  author: 黄仁宇
+  score: 5
+  readAt: 2021-03-04`
+
+func writeBooksDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "history.yml"), []byte(historyYAML), 0o644))
+
+	return dir
+}
+
+func TestRunAppendsItem(t *testing.T) {
+	dir := writeBooksDir(t)
+
+	result, err := Run(data.DomainBooks, Options{
+		Dir:  dir,
+		File: "history",
+		Fields: []Field{
+			{Key: "tags", Value: "历史, 明朝"},
+			{Key: "readAt", Value: "2026-10-01"},
+			{Key: "name", Value: "中国大历史"},
+			{Key: "score", Value: "4"},
+			{Key: "author", Value: "黄仁宇"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Line)
+	assert.Empty(t, result.Issues)
+	assert.Nil(t, result.Duplicates)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "history.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, historyYAML+`
+- name: 中国大历史
+  author: 黄仁宇
+  score: 4
+  readAt: 2026-10-01
+  tags: [历史, 明朝]
+`, string(raw))
+}
+
+func TestRunDryRunWritesNothing(t *testing.T) {
+	dir := writeBooksDir(t)
+
+	result, err := Run(data.DomainMovie, Options{Dir: dir, Fields: []Field{{Key: "name", Value: "霸王别姬"}}, DryRun: true})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "movie.yml"), result.File)
+	assert.Equal(t, 1, result.Line)
+	assert.Equal(t, "- name: 霸王别姬\n", result.YAML)
+	assert.NoFileExists(t, result.File)
+}
+
+func TestRunRejectsDuplicate(t *testing.T) {
+	dir := writeBooksDir(t)
+
+	result, err := Run(data.DomainBooks, Options{
+		Dir:    dir,
+		File:   "history.yml",
+		Fields: []Field{{Key: "name", Value: "万历十五年"}, {Key: "author", Value: "黄仁宇"}},
+	})
+	require.ErrorIs(t, err, ErrRejected)
+	require.NotNil(t, result.Duplicates)
+	require.Len(t, result.Duplicates.NameAuthorDuplicates, 1)
+	assert.Equal(t, "万历十五年 | 黄仁宇", result.Duplicates.NameAuthorDuplicates[0].Key)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "history.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, historyYAML, string(raw))
+}
+
+func TestRunRejectsCheckErrors(t *testing.T) {
+	dir := writeBooksDir(t)
+
+	result, err := Run(data.DomainBooks, Options{
+		Dir:    dir,
+		File:   "history",
+		Fields: []Field{{Key: "name", Value: "Sapiens"}, {Key: "score", Value: "9"}},
+	})
+	require.ErrorIs(t, err, ErrRejected)
+	require.NotEmpty(t, result.Issues)
+	assert.Equal(t, filepath.Join(dir, "history.yml"), result.Issues[0].File)
+	assert.GreaterOrEqual(t, result.Issues[0].Line, result.Line)
+}
+
+func TestRunRejectsUnparseableResult(t *testing.T) {
+	for name, existing := range map[string]string{
+		"flow sequence": "[]\n",
+		"mapping":       "name: x\n",
+		"block scalar":  "des: |\n  text",
+		"indented":      "  - name: a\n",
+		"flow unclosed": "- name: a\n  tags: [x,\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "history.yml")
+			require.NoError(t, os.WriteFile(file, []byte(existing), 0o644))
+
+			result, err := Run(data.DomainBooks, Options{Dir: dir, File: "history", Fields: []Field{{Key: "name", Value: "Sapiens"}}})
+			require.ErrorIs(t, err, ErrRejected)
+			assert.True(t, result.Rejected)
+			assert.True(t, strings.HasPrefix(Report(result), "Rejected for "+file))
+
+			raw, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.Equal(t, existing, string(raw))
+		})
+	}
+}
+
+func TestRunRejectsFieldsAndFiles(t *testing.T) {
+	dir := writeBooksDir(t)
+
+	tests := []struct {
+		name   string
+		domain data.DataDomain
+		opts   Options
+		errMsg string
+	}{
+		{"unknown field", data.DomainBooks, Options{File: "history", Fields: []Field{{Key: "bogus", Value: "x"}}}, "field bogus is not allowed"},
+		{"books without file", data.DomainBooks, Options{Fields: []Field{{Key: "name", Value: "x"}}}, "needs --file"},
+		{"books into movie file", data.DomainBooks, Options{File: "movie", Fields: []Field{{Key: "name", Value: "x"}}}, "holds movie items"},
+		{"tv into other file", data.DomainTV, Options{File: "shows", Fields: []Field{{Key: "name", Value: "x"}}}, "belong in tv.yml"},
+		{"nested file", data.DomainBooks, Options{File: "a/b", Fields: []Field{{Key: "name", Value: "x"}}}, "must be a file name"},
+		{"gh", data.DomainGH, Options{}, "not supported"},
+		{"twice", data.DomainBooks, Options{File: "history", Fields: []Field{{Key: "name", Value: "x"}, {Key: "name", Value: "y"}}}, "given twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Dir = dir
+			_, err := Run(tt.domain, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestRunFillsFromLookup(t *testing.T) {
+	dir := writeBooksDir(t)
+	basics := filepath.Join(t.TempDir(), "title.basics.tsv")
+	require.NoError(t, os.WriteFile(basics, []byte("tconst\ttitleType\tprimaryTitle\toriginalTitle\tstartYear\tgenres\n"+
+		"tt0106332\tmovie\tFarewell My Concubine\tBa wang bie ji\t1993\tDrama,Romance\n"+
+		"tt0111161\tmovie\tThe Shawshank Redemption\tThe Shawshank Redemption\t1994\tDrama\n"), 0o644))
+
+	result, err := Run(data.DomainMovie, Options{
+		Dir:    dir,
+		Lookup: basics,
+		ID:     "tt0111161",
+		Fields: []Field{{Key: "score", Value: "5"}},
+		DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, result.LookedUp)
+	assert.Equal(t, `- name: The Shawshank Redemption
+  score: 5
+  publishAt: 1994
+  tags: [Drama]
+  url: https://www.imdb.com/title/tt0111161/
+`, result.YAML)
+
+	_, err = Run(data.DomainMovie, Options{Dir: dir, Lookup: basics, ID: "tt0000001", DryRun: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no row")
+}
+
+func TestRenderValueQuotesWhenNeeded(t *testing.T) {
+	assert.Equal(t, `"2021"`, renderValue(Field{Key: "name", Value: "2021"}))
+	assert.Equal(t, "2021", renderValue(Field{Key: "publishAt", Value: "2021"}))
+	assert.Equal(t, `"a\nb"`, renderValue(Field{Key: "des", Value: "a\nb"}))
+	assert.Equal(t, `[c++, "a: b"]`, renderValue(Field{Key: "tags", Value: "c++,a: b,"}))
+}
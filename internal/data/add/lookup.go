@@ -0,0 +1,174 @@
+package dataadd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// imdbNull is the missing-value marker of IMDb TSV dumps.
+const imdbNull = `\N`
+
+// lookupColumns maps lowercase dump headers to item fields: Open Library and
+// Goodreads style book exports, IMDb title.basics and plain name/author
+// tables all work.
+var lookupColumns = map[string]string{
+	"name":             fieldName,
+	"title":            fieldName,
+	"primarytitle":     fieldName,
+	"originaltitle":    fieldAlias,
+	"alias":            fieldAlias,
+	"author":           fieldAuthor,
+	"authors":          fieldAuthor,
+	"director":         fieldAuthor,
+	"directors":        fieldAuthor,
+	"year":             fieldPublishAt,
+	"startyear":        fieldPublishAt,
+	"publishat":        fieldPublishAt,
+	"publish_year":     fieldPublishAt,
+	"publication_year": fieldPublishAt,
+	"genres":           fieldTags,
+	"tags":             fieldTags,
+	"subjects":         fieldTags,
+	"url":              fieldURL,
+	"des":              fieldDes,
+	"description":      fieldDes,
+}
+
+// lookupIDColumns hold the ids --id is matched against.
+var lookupIDColumns = []string{"id", "isbn", "isbn13", "isbn10", "tconst", "imdb", "imdb_id"}
+
+// imdbIDColumns hold IMDb title ids, which also give the item its url.
+var imdbIDColumns = []string{"tconst", "imdb", "imdb_id"}
+
+// errStop ends a row scan early.
+var errStop = errors.New("stop")
+
+// lookup streams the TSV or CSV dump at path and returns the fields of the
+// first row whose id column equals id or, without id, whose title equals
+// name. Dumps can be large, so rows are never held in memory.
+func lookup(path, id, name string) (map[string]string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("open lookup %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var (
+		header []string
+		found  map[string]string
+	)
+	err = scanRows(f, strings.EqualFold(filepath.Ext(path), ".tsv"), func(row []string) error {
+		if header == nil {
+			header = make([]string, len(row))
+			for i, col := range row {
+				header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff")))
+			}
+
+			return nil
+		}
+		if !rowMatches(header, row, id, name) {
+			return nil
+		}
+		found = rowFields(header, row)
+
+		return errStop
+	})
+	if err != nil && !errors.Is(err, errStop) {
+		return nil, false, fmt.Errorf("read lookup %s: %w", path, err)
+	}
+
+	return found, found != nil, nil
+}
+
+// scanRows calls fn for every row. TSV dumps such as IMDb's do not quote
+// fields, so they are split on tabs rather than read as CSV.
+func scanRows(r io.Reader, tsv bool, fn func([]string) error) error {
+	if tsv {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+		for scanner.Scan() {
+			if err := fn(strings.Split(strings.TrimSuffix(scanner.Text(), "\r"), "\t")); err != nil {
+				return err
+			}
+		}
+
+		return scanner.Err()
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
+
+func rowMatches(header, row []string, id, name string) bool {
+	for i, col := range header {
+		if i >= len(row) {
+			break
+		}
+		switch {
+		case id != "" && slices.Contains(lookupIDColumns, col):
+			if normalizeID(row[i]) == normalizeID(id) {
+				return true
+			}
+		case id == "" && lookupColumns[col] == fieldName:
+			if strings.EqualFold(strings.TrimSpace(row[i]), strings.TrimSpace(name)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func rowFields(header, row []string) map[string]string {
+	fields := map[string]string{}
+	for i, col := range header {
+		if i >= len(row) {
+			break
+		}
+		value := strings.TrimSpace(row[i])
+		if value == "" || value == imdbNull {
+			continue
+		}
+		if slices.Contains(imdbIDColumns, col) {
+			fields[fieldURL] = "https://www.imdb.com/title/" + value + "/"
+		}
+		field, ok := lookupColumns[col]
+		if !ok {
+			continue
+		}
+		if _, set := fields[field]; !set || field == fieldURL {
+			fields[field] = value
+		}
+	}
+	if fields[fieldAlias] != "" && fields[fieldAlias] == fields[fieldName] {
+		delete(fields, fieldAlias)
+	}
+
+	return fields
+}
+
+// normalizeID compares ISBNs without hyphens or spaces, and ids without
+// case.
+func normalizeID(id string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(id)))
+}
@@ -0,0 +1,53 @@
+package dataadd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCSVByISBNAndName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "books.csv")
+	require.NoError(t, os.WriteFile(path, []byte("\ufeffTitle,Author,ISBN13,Publication_Year,Description\n"+
+		"\"Sapiens, A Brief History\",Yuval Noah Harari,978-0-06-231609-7,2011,\"About \"\"us\"\"\"\n"+
+		"万历十五年,黄仁宇,9787108009821,1982,\n"), 0o644))
+
+	fields, ok, err := lookup(path, "9780062316097", "")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"name":      "Sapiens, A Brief History",
+		"author":    "Yuval Noah Harari",
+		"publishAt": "2011",
+		"des":       `About "us"`,
+	}, fields)
+
+	fields, ok, err = lookup(path, "", "万历十五年")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "黄仁宇", fields["author"])
+	assert.NotContains(t, fields, "des")
+
+	_, ok, err = lookup(path, "", "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLookupTSVSkipsNulls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "title.basics.tsv")
+	require.NoError(t, os.WriteFile(path, []byte("tconst\tprimaryTitle\toriginalTitle\tstartYear\tgenres\n"+
+		"tt0106332\tFarewell My Concubine\tBa wang bie ji\t\\N\tDrama,Romance\n"), 0o644))
+
+	fields, ok, err := lookup(path, "", "farewell my concubine")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"name":  "Farewell My Concubine",
+		"alias": "Ba wang bie ji",
+		"tags":  "Drama,Romance",
+		"url":   "https://www.imdb.com/title/tt0106332/",
+	}, fields)
+}
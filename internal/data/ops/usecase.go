@@ -1,14 +1,22 @@
 package dataops
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
+	"github.com/xbpk3t/docs-alfred/internal/data/add"
+	"github.com/xbpk3t/docs-alfred/internal/data/query"
 	"github.com/xbpk3t/docs-alfred/internal/data/render"
+	"github.com/xbpk3t/docs-alfred/internal/data/stats"
 	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/internal/gh/enrich"
 	"github.com/xbpk3t/docs-alfred/internal/gh/ghcheck"
 	"github.com/xbpk3t/docs-alfred/internal/gh/goods"
 	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
 )
 
 // DomainCheckInput holds input for domain data check.
@@ -16,6 +24,8 @@ type DomainCheckInput struct {
 	Domain    data.DataDomain
 	Path      string // empty = default for domain
 	RuleScope string // empty = default for domain
+	// EnrichCache is the gh liveness cache (empty = enrich.DefaultCachePath).
+	EnrichCache string
 }
 
 // DomainCheckResult holds the result of a domain data check.
@@ -36,9 +46,10 @@ func RunDomainCheck(input DomainCheckInput) (*DomainCheckResult, error) {
 }
 
 type domainCheckOptions struct {
-	path       string
-	scope      string
-	spec       data.DomainSpec
+	path        string
+	scope       string
+	enrichCache string
+	spec        data.DomainSpec
 }
 
 func resolveDomainCheckOptions(input DomainCheckInput) (domainCheckOptions, error) {
@@ -59,7 +70,7 @@ func resolveDomainCheckOptions(input DomainCheckInput) (domainCheckOptions, erro
 		}
 	}
 
-	return domainCheckOptions{spec: spec, path: path, scope: scope}, nil
+	return domainCheckOptions{spec: spec, path: path, scope: scope, enrichCache: input.EnrichCache}, nil
 }
 
 func runDomainCheckWithOptions(domain data.DataDomain, opts *domainCheckOptions) (*DomainCheckResult, error) {
@@ -68,8 +79,14 @@ func runDomainCheckWithOptions(domain data.DataDomain, opts *domainCheckOptions)
 		if err != nil {
 			return nil, err
 		}
+		// Liveness issues come from the last data-cli enrich gh run, with its
+		// --stale-after; check itself never calls the GitHub API.
+		liveness, err := enrich.CachedIssues(opts.path, opts.enrichCache, 0, time.Now())
+		if err != nil {
+			slog.Warn("Skipping gh liveness issues", "error", err)
+		}
 
-		return &DomainCheckResult{Issues: result.Issues}, nil
+		return &DomainCheckResult{Issues: append(result.Issues, liveness...)}, nil
 	}
 
 	if domain == data.DomainGoods {
@@ -112,6 +129,222 @@ func runYAMLParseOnlyDomainCheck(domain data.DataDomain, path string) (*DomainCh
 	return &DomainCheckResult{}, nil
 }
 
+// DomainFixInput holds input for data check --fix.
+type DomainFixInput struct {
+	// Aliases renames fields on top of data.DefaultFieldAliases.
+	Aliases   map[string]string
+	Domain    data.DataDomain
+	Path      string // empty = default for domain
+	RuleScope string // empty = default for domain
+	DryRun    bool
+}
+
+// RunDomainFix applies the safe mechanical fixes of a domain: structured
+// domains get trimmed values, normalised dates, clamped scores and alias
+// renames; gh gets normalised topic.kind values.
+func RunDomainFix(input DomainFixInput) (*checkutil.FixResult, error) {
+	opts, err := resolveDomainCheckOptions(DomainCheckInput{Domain: input.Domain, Path: input.Path, RuleScope: input.RuleScope})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Fixing domain", "domain", input.Domain, "path", opts.path, "dryRun", input.DryRun)
+
+	if input.Domain == data.DomainGH {
+		return ghcheck.RunFix(opts.path, input.DryRun)
+	}
+	if opts.spec.StructuredCheck {
+		return data.RunStructuredDataFix(opts.path, opts.scope, data.FixOptions{Aliases: input.Aliases, DryRun: input.DryRun})
+	}
+
+	return nil, fmt.Errorf("data check --fix %s is not supported", input.Domain)
+}
+
+// SchemaExportInput holds input for data schema export.
+type SchemaExportInput struct {
+	Domain data.DataDomain
+	// RuleScope overrides the field set of structured domains; ntl files
+	// use jav, vg or movie (the default).
+	RuleScope string
+}
+
+// schemaScopes are the rule scopes SchemaExportInput.RuleScope accepts.
+var schemaScopes = []data.RuleScope{data.ScopeBooks, data.ScopeMovie, data.ScopeMusic, data.ScopeDiary, data.ScopeJav, data.ScopeVG}
+
+// RunSchemaExport returns the JSON Schema of a domain's YAML files, built
+// from the same rules data check applies.
+func RunSchemaExport(input SchemaExportInput) (*jsonschema.Schema, error) {
+	spec, ok := data.SpecForDomain(input.Domain)
+	if !ok {
+		return nil, fmt.Errorf("unknown data domain %q", input.Domain)
+	}
+
+	switch {
+	case input.Domain == data.DomainGH:
+		return ghcheck.JSONSchema(), nil
+	case input.Domain == data.DomainGoods:
+		return goods.JSONSchema(), nil
+	case spec.StructuredCheck:
+		scope := data.ResolveScope("", string(spec.RuleScope))
+		if input.RuleScope != "" {
+			scope = data.RuleScope(input.RuleScope)
+			if !slices.Contains(schemaScopes, scope) {
+				return nil, fmt.Errorf("unknown rule scope %q", input.RuleScope)
+			}
+		}
+
+		return data.JSONSchema(scope), nil
+	}
+
+	return &jsonschema.Schema{
+		Schema:      jsonschema.Draft07,
+		Title:       "docs-alfred " + string(input.Domain) + " data",
+		Description: "only checked for valid YAML",
+	}, nil
+}
+
+// GHEnrichInput holds input for GitHub repo liveness enrichment.
+type GHEnrichInput struct {
+	Fetcher    enrich.Fetcher // nil = GitHub API with Token
+	Path       string         // empty = data/gh
+	CachePath  string         // empty = enrich.DefaultCachePath
+	Token      string
+	TTL        time.Duration
+	StaleAfter time.Duration
+	Refresh    bool
+	Fix        bool
+	DryRun     bool
+}
+
+// RunGHEnrich refreshes the liveness of every data/gh repo and reports
+// dead, renamed, archived and stale repos.
+func RunGHEnrich(ctx context.Context, input GHEnrichInput) (*enrich.Result, error) {
+	spec, _ := data.SpecForDomain(data.DomainGH)
+	path := input.Path
+	if path == "" {
+		path = spec.DefaultPath
+	}
+	fetcher := input.Fetcher
+	if fetcher == nil {
+		gh, err := enrich.NewGitHubFetcher(input.Token, "")
+		if err != nil {
+			return nil, err
+		}
+		fetcher = gh
+	}
+
+	return enrich.Run(ctx, enrich.Options{
+		Fetcher:    fetcher,
+		Root:       path,
+		CachePath:  input.CachePath,
+		TTL:        input.TTL,
+		StaleAfter: input.StaleAfter,
+		Refresh:    input.Refresh,
+		Fix:        input.Fix,
+		DryRun:     input.DryRun,
+	})
+}
+
+// QueryInput holds input for a cross-domain data query.
+type QueryInput struct {
+	Expr  string
+	Root  string // empty = dataquery.DefaultRoot
+	Sort  string
+	Limit int
+}
+
+// RunQuery filters the items of every data domain with a query expression.
+func RunQuery(input QueryInput) (*dataquery.Result, error) {
+	slog.Info("Querying data", "root", input.Root, "expr", input.Expr)
+
+	return dataquery.Run(input.Expr, dataquery.Options{
+		Root:  input.Root,
+		Sort:  input.Sort,
+		Limit: input.Limit,
+	})
+}
+
+// GoodsReportInput holds input for the goods lifecycle cost report.
+type GoodsReportInput struct {
+	Now          time.Time // zero = time.Now
+	Path         string    // empty = data/goods
+	NearingRatio float64   // 0 = goods.DefaultNearingRatio
+}
+
+// RunGoodsReport computes ownership cost, resale recovery, inventory value
+// and lifespan forecasts for the goods domain.
+func RunGoodsReport(input GoodsReportInput) (*goods.Report, error) {
+	path := input.Path
+	if path == "" {
+		spec, _ := data.SpecForDomain(data.DomainGoods)
+		path = spec.DefaultPath
+	}
+
+	slog.Info("Reporting goods lifecycle", "path", path)
+
+	return goods.RunReport(path, goods.ReportOptions{Now: input.Now, NearingRatio: input.NearingRatio})
+}
+
+// StatsInput holds input for a domain review.
+type StatsInput struct {
+	Domain data.DataDomain
+	Path   string // empty = default for domain
+	Year   int    // 0 = all years
+	Top    int
+}
+
+// RunStats computes counts, score distributions, top authors and tags and
+// year-over-year numbers for a personal data domain.
+func RunStats(input StatsInput) (*datastats.Result, error) {
+	spec, ok := data.SpecForDomain(input.Domain)
+	if !ok {
+		return nil, fmt.Errorf("unknown data domain %q", input.Domain)
+	}
+	path := input.Path
+	if path == "" {
+		path = spec.DefaultPath
+	}
+
+	slog.Info("Computing data stats", "domain", input.Domain, "path", path, "year", input.Year)
+
+	return datastats.Run(path, input.Domain, datastats.Options{Year: input.Year, Top: input.Top})
+}
+
+// AddInput holds input for appending an item to a domain file.
+type AddInput struct {
+	Domain data.DataDomain
+	Path   string // empty = default for domain
+	File   string // file under Path; movie and tv default to their own file
+	Lookup string // TSV/CSV dump filling the fields not given
+	ID     string // ISBN or IMDb id of the lookup row
+	Fields []dataadd.Field
+	DryRun bool
+}
+
+// RunAdd appends an item to a domain file after the data check and duplicate
+// check pass for it.
+func RunAdd(input AddInput) (*dataadd.Result, error) {
+	spec, ok := data.SpecForDomain(input.Domain)
+	if !ok {
+		return nil, fmt.Errorf("unknown data domain %q", input.Domain)
+	}
+	path := input.Path
+	if path == "" {
+		path = spec.DefaultPath
+	}
+
+	slog.Info("Adding data item", "domain", input.Domain, "path", path, "file", input.File, "dryRun", input.DryRun)
+
+	return dataadd.Run(input.Domain, dataadd.Options{
+		Dir:    path,
+		File:   input.File,
+		Lookup: input.Lookup,
+		ID:     input.ID,
+		Fields: input.Fields,
+		DryRun: input.DryRun,
+	})
+}
+
 // DomainDedupInput holds input for duplicate detection.
 type DomainDedupInput struct {
 	Domain data.DataDomain
@@ -162,6 +395,9 @@ type DomainRenderInput struct {
 	Path   string // empty = default for domain
 	OutDir string // empty = "docs/public"
 	Format string // empty = domain default
+	// Incremental re-renders only changed files, tracked in the manifest.
+	Incremental  bool
+	ManifestPath string // empty = datarender.DefaultManifestPath
 }
 
 // DomainRenderResult holds the result of a domain render.
@@ -169,9 +405,79 @@ type DomainRenderResult = datarender.DomainRenderResult
 
 // RunDomainRender renders a single domain's data into output files.
 func RunDomainRender(input DomainRenderInput) (*DomainRenderResult, error) {
+	cfg, err := domainRenderConfig(input)
+	if err != nil {
+		return nil, err
+	}
+	if input.Incremental {
+		if cfg.Manifest, err = datarender.LoadManifest(manifestPath(input)); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Info("Rendering domain", "domain", input.Domain, "src", cfg.Src, "outDir", cfg.OutDir, "format", cfg.Format, "incremental", input.Incremental)
+
+	result, err := datarender.RunDomainRender(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Manifest != nil {
+		if err := cfg.Manifest.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// DomainRenderWatchEvent is one incremental render and check of a watched
+// domain.
+type DomainRenderWatchEvent struct {
+	Err    error               `json:"-"`
+	Render *DomainRenderResult `json:"render,omitempty"`
+	Check  *DomainCheckResult  `json:"check,omitempty"`
+	// Changed lists the files whose changes triggered the run; it is empty
+	// for the first run.
+	Changed []string `json:"changed,omitempty"`
+}
+
+// WatchDomainRender renders and checks a domain incrementally, then again
+// after every save under its source until ctx is done. Each run is passed to
+// onRun; a failed render or check does not stop the watch.
+func WatchDomainRender(ctx context.Context, input DomainRenderInput, onRun func(*DomainRenderWatchEvent)) error {
+	cfg, err := domainRenderConfig(input)
+	if err != nil {
+		return err
+	}
+	if cfg.Manifest, err = datarender.LoadManifest(manifestPath(input)); err != nil {
+		return err
+	}
+
+	run := func(changed []string) {
+		if len(changed) > 0 {
+			slog.Info("Sources changed", "domain", input.Domain, "files", changed)
+		}
+		event := &DomainRenderWatchEvent{Changed: changed}
+		event.Render, event.Err = datarender.RunDomainRender(cfg)
+		if event.Err == nil {
+			event.Err = cfg.Manifest.Save()
+		}
+		if event.Err == nil {
+			event.Check, event.Err = RunDomainCheck(DomainCheckInput{Domain: input.Domain, Path: cfg.Src})
+		}
+		onRun(event)
+	}
+
+	slog.Info("Watching domain", "domain", input.Domain, "src", cfg.Src, "outDir", cfg.OutDir, "format", cfg.Format)
+	run(nil)
+
+	return datarender.Watch(ctx, cfg.Src, datarender.DefaultDebounce, run)
+}
+
+func domainRenderConfig(input DomainRenderInput) (datarender.DomainRenderConfig, error) {
 	spec, ok := data.SpecForDomain(input.Domain)
 	if !ok {
-		return nil, fmt.Errorf("unknown data domain %q", input.Domain)
+		return datarender.DomainRenderConfig{}, fmt.Errorf("unknown data domain %q", input.Domain)
 	}
 
 	src := input.Path
@@ -189,14 +495,20 @@ func RunDomainRender(input DomainRenderInput) (*DomainRenderResult, error) {
 		format = defaultRenderFormat(input.Domain)
 	}
 
-	slog.Info("Rendering domain", "domain", input.Domain, "src", src, "outDir", outDir, "format", format)
-
-	return datarender.RunDomainRender(datarender.DomainRenderConfig{
+	return datarender.DomainRenderConfig{
 		Domain: string(input.Domain),
 		Src:    src,
 		OutDir: outDir,
 		Format: format,
-	})
+	}, nil
+}
+
+func manifestPath(input DomainRenderInput) string {
+	if input.ManifestPath != "" {
+		return input.ManifestPath
+	}
+
+	return datarender.DefaultManifestPath()
 }
 
 func defaultRenderFormat(domain data.DataDomain) string {
@@ -50,6 +50,25 @@ func TestRunDomainCheck_GoodsDomain(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestRunDomainCheck_GHReportsCachedLiveness(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "network.yml"), []byte(`- type: network
+  topics:
+    - topic: proxy
+      kind: tools
+      repo:
+        - url: https://github.com/acme/gone
+`), 0644))
+	cachePath := filepath.Join(t.TempDir(), "gh-enrich.json")
+	require.NoError(t, os.WriteFile(cachePath, []byte(`{"repos":{"acme/gone":{"missing":true}}}`), 0644))
+
+	result, err := RunDomainCheck(DomainCheckInput{Domain: data.DomainGH, Path: tmpDir, EnrichCache: cachePath})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, 6, result.Issues[0].Line)
+	assert.Contains(t, result.Issues[0].Message, "repo not found")
+}
+
 func TestRunDomainDedup_UnknownDomain(t *testing.T) {
 	_, err := RunDomainDedup(DomainDedupInput{Domain: data.DataDomain("unknown")})
 	require.Error(t, err)
@@ -205,6 +224,29 @@ func TestRunDomainRender_Task(t *testing.T) {
 	assert.Len(t, result.OutputFiles, 1) // yaml only
 }
 
+func TestRunDomainRender_Incremental(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "data", "books")
+	require.NoError(t, os.MkdirAll(src, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "history.yml"), []byte("- name: 万历十五年\n"), 0644))
+	input := DomainRenderInput{
+		Domain:       data.DomainBooks,
+		Path:         src,
+		OutDir:       filepath.Join(tmpDir, "public"),
+		Incremental:  true,
+		ManifestPath: filepath.Join(tmpDir, "manifest.json"),
+	}
+
+	_, err := RunDomainRender(input)
+	require.NoError(t, err)
+	assert.FileExists(t, input.ManifestPath)
+
+	result, err := RunDomainRender(input)
+	require.NoError(t, err)
+	require.Len(t, result.Outputs, 1)
+	assert.True(t, result.Outputs[0].UpToDate)
+}
+
 func TestRunDomainRender_Defaults(t *testing.T) {
 	// Test with default path (which may not exist) - should error
 	_, err := RunDomainRender(DomainRenderInput{Domain: data.DomainGH})
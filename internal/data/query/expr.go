@@ -0,0 +1,327 @@
+// Package dataquery filters the items of every data domain with a small
+// field expression language, e.g. `domain=books score>=4 tags~=history`.
+package dataquery
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Op is a comparison operator of a query clause.
+type Op string
+
+const (
+	OpEq       Op = "="
+	OpNe       Op = "!="
+	OpGe       Op = ">="
+	OpLe       Op = "<="
+	OpGt       Op = ">"
+	OpLt       Op = "<"
+	OpContains Op = "~="
+	OpExcludes Op = "!~"
+)
+
+// ops are tried longest first so ">=" is not read as ">".
+var ops = []Op{OpGe, OpLe, OpNe, OpContains, OpExcludes, OpEq, OpGt, OpLt}
+
+// fieldDomain is the pseudo field holding the record's data domain.
+const fieldDomain = "domain"
+
+// datePrefix matches year, year-month and full dates, so readAt>=2020
+// compares against the year of 2021-03-04.
+var datePrefix = regexp.MustCompile(`^-?\d{1,4}(-\d{2}){0,2}$`)
+
+// Clause is one condition of a query. A clause without Field is a free-text
+// term matched against every string field.
+type Clause struct {
+	Field string `json:"field,omitempty"`
+	Op    Op     `json:"op,omitempty"`
+	Value string `json:"value"`
+}
+
+// Query is a conjunction of clauses.
+type Query struct {
+	Clauses []Clause `json:"clauses"`
+}
+
+// Parse reads a query expression: whitespace-separated clauses of the form
+// field<op>value, where op is one of = != >= <= > < ~= (contains) and !~
+// (does not contain). Values may be quoted; = and != accept comma-separated
+// alternatives. Tokens without an operator are free-text terms.
+func Parse(expr string) (*Query, error) {
+	tokens, err := splitTokens(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &Query{}
+	for _, token := range tokens {
+		clause, err := parseClause(token)
+		if err != nil {
+			return nil, err
+		}
+		q.Clauses = append(q.Clauses, clause)
+	}
+
+	return q, nil
+}
+
+func splitTokens(expr string) ([]string, error) {
+	var (
+		tokens []string
+		cur    strings.Builder
+		quote  rune
+		inTok  bool
+	)
+	for _, r := range expr {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+
+				continue
+			}
+			cur.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inTok = r, true
+		case unicode.IsSpace(r):
+			if inTok {
+				tokens = append(tokens, cur.String())
+				cur.Reset()
+				inTok = false
+			}
+		default:
+			cur.WriteRune(r)
+			inTok = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in query %q", expr)
+	}
+	if inTok {
+		tokens = append(tokens, cur.String())
+	}
+
+	return tokens, nil
+}
+
+func parseClause(token string) (Clause, error) {
+	idx, op := operatorIndex(token)
+	if idx < 0 {
+		return Clause{Value: token}, nil
+	}
+	field := token[:idx]
+	if field == "" {
+		return Clause{}, fmt.Errorf("query clause %q has no field", token)
+	}
+
+	return Clause{Field: field, Op: op, Value: token[idx+len(op):]}, nil
+}
+
+// operatorIndex finds the first operator after a field name made of letters,
+// digits and underscores.
+func operatorIndex(token string) (int, Op) {
+	for i, r := range token {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			continue
+		}
+		for _, op := range ops {
+			if strings.HasPrefix(token[i:], string(op)) {
+				return i, op
+			}
+		}
+
+		return -1, ""
+	}
+
+	return -1, ""
+}
+
+// Domains returns the domains named by positive domain= clauses, lower
+// cased, or nil when the query spans every domain.
+func (q *Query) Domains() []string {
+	var domains []string
+	for _, c := range q.Clauses {
+		if c.Field != fieldDomain || c.Op != OpEq {
+			continue
+		}
+		for _, d := range strings.Split(c.Value, ",") {
+			if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+				domains = append(domains, d)
+			}
+		}
+	}
+
+	return domains
+}
+
+// Fields returns the fields referenced by the query, in order, without the
+// domain pseudo field.
+func (q *Query) Fields() []string {
+	seen := map[string]bool{}
+	var fields []string
+	for _, c := range q.Clauses {
+		if c.Field == "" || c.Field == fieldDomain || seen[c.Field] {
+			continue
+		}
+		seen[c.Field] = true
+		fields = append(fields, c.Field)
+	}
+
+	return fields
+}
+
+// Match reports whether rec satisfies every clause.
+func (q *Query) Match(rec *Record) bool {
+	for _, c := range q.Clauses {
+		if !c.match(rec) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *Clause) match(rec *Record) bool {
+	if c.Field == "" {
+		return matchFreeText(rec, c.Value)
+	}
+
+	var values []string
+	if c.Field == fieldDomain {
+		values = []string{rec.Domain}
+	} else {
+		v, ok := rec.Fields[c.Field]
+		if !ok {
+			return c.Op == OpNe || c.Op == OpExcludes
+		}
+		values = ScalarStrings(v)
+	}
+
+	switch c.Op {
+	case OpNe:
+		return !anyValue(values, c.Value, OpEq)
+	case OpExcludes:
+		return !anyValue(values, c.Value, OpContains)
+	default:
+		return anyValue(values, c.Value, c.Op)
+	}
+}
+
+func anyValue(values []string, want string, op Op) bool {
+	alternatives := []string{want}
+	if op == OpEq {
+		alternatives = strings.Split(want, ",")
+	}
+	for _, v := range values {
+		for _, alt := range alternatives {
+			if compare(v, alt, op) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func compare(got, want string, op Op) bool {
+	switch op {
+	case OpEq:
+		return strings.EqualFold(got, want)
+	case OpContains:
+		return strings.Contains(strings.ToLower(got), strings.ToLower(want))
+	}
+
+	cmp, ok := order(got, want)
+	if !ok {
+		return false
+	}
+	switch op {
+	case OpGe:
+		return cmp >= 0
+	case OpLe:
+		return cmp <= 0
+	case OpGt:
+		return cmp > 0
+	case OpLt:
+		return cmp < 0
+	}
+
+	return false
+}
+
+// order compares got with want numerically when both are numbers, by date
+// prefix when both are dates (2021-03-04 vs 2020 compares the years), and
+// is undefined otherwise.
+func order(got, want string) (int, bool) {
+	gotNum, gotErr := strconv.ParseFloat(got, 64)
+	wantNum, wantErr := strconv.ParseFloat(want, 64)
+	if gotErr == nil && wantErr == nil {
+		switch {
+		case gotNum < wantNum:
+			return -1, true
+		case gotNum > wantNum:
+			return 1, true
+		}
+
+		return 0, true
+	}
+	if !datePrefix.MatchString(got) || !datePrefix.MatchString(want) {
+		return 0, false
+	}
+	if len(got) > len(want) {
+		got = got[:len(want)]
+	}
+
+	return strings.Compare(got, want), true
+}
+
+func matchFreeText(rec *Record, term string) bool {
+	for _, v := range rec.Fields {
+		for _, s := range ScalarStrings(v) {
+			if strings.Contains(strings.ToLower(s), strings.ToLower(term)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ScalarStrings flattens a field value, a scalar or a list of scalars, to
+// strings. Mappings and lists nested in a list are skipped.
+func ScalarStrings(v any) []string {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		var out []string
+		for _, item := range val {
+			switch item.(type) {
+			case []any, map[string]any:
+				continue
+			}
+			out = append(out, scalarString(item))
+		}
+
+		return out
+	case map[string]any:
+		return nil
+	}
+
+	return []string{scalarString(v)}
+}
+
+func scalarString(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	}
+
+	return fmt.Sprint(v)
+}
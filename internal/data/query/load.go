@@ -0,0 +1,375 @@
+package dataquery
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+// DefaultRoot is the data directory every DomainSpec.DefaultPath lives under.
+const DefaultRoot = "data"
+
+// ErrEmptyQuery is returned by Run for an expression without clauses.
+var ErrEmptyQuery = errors.New("empty query")
+
+// Record is one queryable item: a book, movie, album, diary day, gh repo or
+// goods item. Nested gh repos and goods items inherit the tag, type and
+// topic of their parents.
+type Record struct {
+	Fields map[string]any `json:"fields"`
+	Domain string         `json:"domain"`
+	File   string         `json:"file"`
+	Line   int            `json:"line"`
+}
+
+// Options controls Run.
+type Options struct {
+	// Root is the data directory (default: DefaultRoot).
+	Root string
+	// Sort orders the matches by a field, descending with a leading "-"
+	// (e.g. -score). Records missing the field sort last.
+	Sort string
+	// Limit caps the number of records returned (0 = all).
+	Limit int
+}
+
+// Result is the outcome of Run.
+type Result struct {
+	Query   *Query   `json:"query"`
+	Records []Record `json:"records"`
+	Scanned int      `json:"scanned"`
+	Matched int      `json:"matched"`
+}
+
+// Run parses expr and returns the matching records of every domain under
+// opts.Root. Only the domains named by domain= clauses are loaded.
+func Run(expr string, opts Options) (*Result, error) {
+	q, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(q.Clauses) == 0 {
+		return nil, ErrEmptyQuery
+	}
+	root := opts.Root
+	if root == "" {
+		root = DefaultRoot
+	}
+
+	records, err := Load(root, q.Domains()...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Query: q, Scanned: len(records)}
+	for i := range records {
+		if q.Match(&records[i]) {
+			result.Records = append(result.Records, records[i])
+		}
+	}
+	result.Matched = len(result.Records)
+	if opts.Sort != "" {
+		sortRecords(result.Records, opts.Sort)
+	}
+	if opts.Limit > 0 && len(result.Records) > opts.Limit {
+		result.Records = result.Records[:opts.Limit]
+	}
+
+	return result, nil
+}
+
+func sortRecords(records []Record, by string) {
+	field, desc := strings.TrimPrefix(by, "-"), strings.HasPrefix(by, "-")
+	key := func(rec *Record) (string, bool) {
+		if field == fieldDomain {
+			return rec.Domain, true
+		}
+		values := ScalarStrings(rec.Fields[field])
+		if len(values) == 0 {
+			return "", false
+		}
+
+		return values[0], true
+	}
+	slices.SortStableFunc(records, func(a, b Record) int {
+		ka, okA := key(&a)
+		kb, okB := key(&b)
+		switch {
+		case !okA || !okB:
+			return cmp.Compare(boolRank(okA), boolRank(okB))
+		case desc:
+			return compareValues(kb, ka)
+		}
+
+		return compareValues(ka, kb)
+	})
+}
+
+func boolRank(present bool) int {
+	if present {
+		return 0
+	}
+
+	return 1
+}
+
+// compareValues orders numbers and dates by value and everything else, as
+// well as dates equal up to the shorter one, by case-folded text.
+func compareValues(a, b string) int {
+	if c, ok := order(a, b); ok && c != 0 {
+		return c
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// Load reads the records of the given domains (all when none) under root.
+// Domains sharing a directory (books, movie and tv) are told apart by file
+// name: movie.yml and tv.yml belong to their domain, the rest to the first.
+// An unknown domain is an error.
+func Load(root string, domains ...string) ([]Record, error) {
+	for _, domain := range domains {
+		if !knownDomain(domain) {
+			return nil, fmt.Errorf("unknown data domain %q", domain)
+		}
+	}
+
+	var records []Record
+	for _, group := range domainGroups(domains) {
+		dir := filepath.Join(root, strings.TrimPrefix(strings.TrimPrefix(group[0].DefaultPath, DefaultRoot), "/"))
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		groupRecords, err := loadGroup(dir, group, domains)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, groupRecords...)
+	}
+
+	return records, nil
+}
+
+// LoadDir reads the records of one domain from dir, which replaces the
+// domain's DefaultPath.
+func LoadDir(dir string, domain data.DataDomain) ([]Record, error) {
+	groups := domainGroups([]string{string(domain)})
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("unknown data domain %q", domain)
+	}
+
+	return loadGroup(dir, groups[0], []string{string(domain)})
+}
+
+func loadGroup(dir string, group []data.DomainSpec, domains []string) ([]Record, error) {
+	files, err := fileutil.ListYAMLFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	for _, file := range files {
+		domain := domainForFile(group, file)
+		if len(domains) > 0 && !slices.Contains(domains, string(domain)) {
+			continue
+		}
+		fileRecords, err := loadFile(file, domain)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, fileRecords...)
+	}
+
+	return records, nil
+}
+
+// domainGroups groups the specs of the wanted domains by directory, keeping
+// the declaration order of DomainSpecs.
+func domainGroups(domains []string) [][]data.DomainSpec {
+	var (
+		groups [][]data.DomainSpec
+		byPath = map[string]int{}
+	)
+	for _, spec := range data.DomainSpecs() {
+		idx, ok := byPath[spec.DefaultPath]
+		if !ok {
+			idx = len(groups)
+			byPath[spec.DefaultPath] = idx
+			groups = append(groups, nil)
+		}
+		groups[idx] = append(groups[idx], spec)
+	}
+
+	if len(domains) == 0 {
+		return groups
+	}
+
+	return slices.DeleteFunc(groups, func(group []data.DomainSpec) bool {
+		return !slices.ContainsFunc(group, func(spec data.DomainSpec) bool {
+			return slices.Contains(domains, string(spec.Domain))
+		})
+	})
+}
+
+func knownDomain(domain string) bool {
+	return slices.ContainsFunc(data.DomainSpecs(), func(spec data.DomainSpec) bool {
+		return string(spec.Domain) == domain
+	})
+}
+
+func domainForFile(group []data.DomainSpec, file string) data.DataDomain {
+	stem := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	for _, spec := range group[1:] {
+		if string(spec.Domain) == stem {
+			return spec.Domain
+		}
+	}
+
+	return group[0].Domain
+}
+
+func loadFile(file string, domain data.DataDomain) ([]Record, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	if strings.TrimSpace(string(raw)) == "" {
+		return nil, nil
+	}
+	parsed, err := yamlparser.ParseBytes(raw, yamlparser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	l := loader{file: file, domain: string(domain)}
+	for _, doc := range parsed.Docs {
+		if doc == nil || doc.Body == nil {
+			continue
+		}
+		seq, ok := yamlutil.Sequence(doc.Body)
+		if !ok {
+			continue
+		}
+		for _, item := range seq.Values {
+			mapping, ok := yamlutil.Mapping(item)
+			if !ok {
+				continue
+			}
+			switch domain {
+			case data.DomainGH:
+				l.addGHCategory(mapping)
+			case data.DomainGoods:
+				l.addGoodsCategory(mapping)
+			default:
+				l.add(mapping, nil)
+			}
+		}
+	}
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	return l.records, nil
+}
+
+type loader struct {
+	err     error
+	file    string
+	domain  string
+	records []Record
+}
+
+// add records mapping with inherited parent fields underneath its own.
+func (l *loader) add(mapping *ast.MappingNode, inherited map[string]any) {
+	fields := make(map[string]any, len(mapping.Values)+len(inherited))
+	for k, v := range inherited {
+		fields[k] = v
+	}
+	for _, kv := range mapping.Values {
+		if kv == nil {
+			continue
+		}
+		key := yamlutil.KeyString(kv.Key)
+		if key == "" {
+			continue
+		}
+		var value any
+		if err := yaml.NodeToValue(kv.Value, &value); err != nil {
+			l.err = fmt.Errorf("%s:%d: decode %s: %w", l.file, yamlutil.NodeLine(kv.Value), key, err)
+
+			return
+		}
+		fields[key] = value
+	}
+	l.records = append(l.records, Record{Domain: l.domain, File: l.file, Line: yamlutil.NodeLine(mapping), Fields: fields})
+}
+
+// addGHCategory records the repos of a data/gh category and its topics.
+func (l *loader) addGHCategory(category *ast.MappingNode) {
+	inherited := scalarFields(category, "type", "tag")
+	l.addEach(yamlutil.MappingValue(category, "repo"), inherited)
+
+	topics, ok := yamlutil.Sequence(yamlutil.MappingValue(category, "topics"))
+	if !ok {
+		return
+	}
+	for _, rawTopic := range topics.Values {
+		topic, ok := yamlutil.Mapping(rawTopic)
+		if !ok {
+			continue
+		}
+		topicFields := scalarFields(topic, "topic", "kind")
+		for k, v := range inherited {
+			topicFields[k] = v
+		}
+		l.addEach(yamlutil.MappingValue(topic, "repo"), topicFields)
+	}
+}
+
+// addGoodsCategory records the using item and the item list of a goods
+// category; the using item is marked with using: true.
+func (l *loader) addGoodsCategory(category *ast.MappingNode) {
+	inherited := scalarFields(category, "type", "tag")
+	if using, ok := yamlutil.Mapping(yamlutil.MappingValue(category, "using")); ok {
+		usingFields := map[string]any{"using": true}
+		for k, v := range inherited {
+			usingFields[k] = v
+		}
+		l.add(using, usingFields)
+	}
+	l.addEach(yamlutil.MappingValue(category, "item"), inherited)
+}
+
+func (l *loader) addEach(node ast.Node, inherited map[string]any) {
+	seq, ok := yamlutil.Sequence(node)
+	if !ok {
+		return
+	}
+	for _, item := range seq.Values {
+		if mapping, ok := yamlutil.Mapping(item); ok {
+			l.add(mapping, inherited)
+		}
+	}
+}
+
+func scalarFields(mapping *ast.MappingNode, keys ...string) map[string]any {
+	fields := map[string]any{}
+	for _, key := range keys {
+		if s, ok := yamlutil.String(yamlutil.MappingValue(mapping, key)); ok {
+			fields[key] = s
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,149 @@
+package dataquery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDataTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"books/history.yml": `- name: 万历十五年
+  author: 黄仁宇
+  score: 5
+  publishAt: 1981
+  readAt: "2021-03-04"
+  tags: [history, ming]
+- name: Sapiens
+  author: Yuval Noah Harari
+  score: 3
+  publishAt: 2011
+  readAt: "2019-07-01"
+  tags: [history]
+`,
+		"books/movie.yml": `- name: 霸王别姬
+  score: 5
+  publishAt: 1993
+`,
+		"music/music-jazz.yml": `- name: Kind of Blue
+  author: Miles Davis
+  score: 5
+  publishAt: 1959
+`,
+		"gh/network.yml": `- type: network
+  tag: devops
+  repo:
+    - url: https://github.com/fatedier/frp
+      des: reverse proxy
+  topics:
+    - topic: 内网穿透工具
+      kind: tools
+      repo:
+        - url: https://github.com/ehang-io/nps
+`,
+		"goods/edc.yml": `- tag: EDC
+  type: knife
+  using:
+    name: Victorinox
+    price: "300"
+  item:
+    - name: Leatherman
+      price: "800"
+      endDate: "2024-01-01"
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func recordNames(records []Record) []string {
+	names := make([]string, 0, len(records))
+	for _, rec := range records {
+		name, _ := rec.Fields["name"].(string)
+		if name == "" {
+			name, _ = rec.Fields["url"].(string)
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func TestParse(t *testing.T) {
+	q, err := Parse(`domain=books score>=4 tags~=history name!~"war and peace" ming`)
+	require.NoError(t, err)
+	assert.Equal(t, []Clause{
+		{Field: "domain", Op: OpEq, Value: "books"},
+		{Field: "score", Op: OpGe, Value: "4"},
+		{Field: "tags", Op: OpContains, Value: "history"},
+		{Field: "name", Op: OpExcludes, Value: "war and peace"},
+		{Value: "ming"},
+	}, q.Clauses)
+	assert.Equal(t, []string{"books"}, q.Domains())
+	assert.Equal(t, []string{"score", "tags", "name"}, q.Fields())
+
+	_, err = Parse(`name="open`)
+	require.ErrorContains(t, err, "unterminated quote")
+	_, err = Parse(`>=4`)
+	require.ErrorContains(t, err, "has no field")
+}
+
+func TestRunFiltersAcrossDomains(t *testing.T) {
+	root := writeDataTree(t)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"domain=books score>=4 tags~=history publishAt>=1980", []string{"万历十五年"}},
+		{"readAt>=2020", []string{"万历十五年"}},
+		{"readAt<2020-01", []string{"Sapiens"}},
+		{"score=5", []string{"万历十五年", "霸王别姬", "Kind of Blue"}},
+		{"domain=movie,music", []string{"霸王别姬", "Kind of Blue"}},
+		{"domain=Movie,MUSIC", []string{"霸王别姬", "Kind of Blue"}},
+		{"domain!=books score=5", []string{"霸王别姬", "Kind of Blue"}},
+		{"tag=devops kind=tools", []string{"https://github.com/ehang-io/nps"}},
+		{"domain=gh proxy", []string{"https://github.com/fatedier/frp"}},
+		{"type=knife using=true", []string{"Victorinox"}},
+		{"domain=goods endDate!~2024", []string{"Victorinox"}},
+		{"author~=harari", []string{"Sapiens"}},
+		{"nosuchfield=1", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			result, err := Run(tt.expr, Options{Root: root})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, recordNames(result.Records))
+		})
+	}
+}
+
+func TestRunRejectsUnknownDomain(t *testing.T) {
+	_, err := Run("domain=book score=5", Options{Root: writeDataTree(t)})
+	require.ErrorContains(t, err, `unknown data domain "book"`)
+}
+
+func TestRunSortsAndLimits(t *testing.T) {
+	root := writeDataTree(t)
+
+	result, err := Run("publishAt>0", Options{Root: root, Sort: "-publishAt", Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Matched)
+	assert.Equal(t, []string{"Sapiens", "霸王别姬", "万历十五年"}, recordNames(result.Records))
+	assert.Equal(t, filepath.Join(root, "books", "history.yml"), result.Records[0].File)
+	assert.Equal(t, 7, result.Records[0].Line)
+	assert.Equal(t, "books", result.Records[0].Domain)
+
+	_, err = Run("  ", Options{Root: root})
+	require.ErrorIs(t, err, ErrEmptyQuery)
+}
@@ -0,0 +1,323 @@
+package datarender
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/render"
+)
+
+// Statuses of a source file in an incremental render.
+const (
+	// FileRendered files were new or changed and rendered on their own.
+	FileRendered = "rendered"
+	// FileCached files were unchanged and their cached fragment was reused.
+	FileCached = "cached"
+	// FileChanged files were new or changed in a domain rendered as a whole.
+	FileChanged = "changed"
+	// FileUnchanged files were unchanged in a domain rendered as a whole.
+	FileUnchanged = "unchanged"
+)
+
+// FileTiming is the time spent on one source file for one output: reading
+// and hashing, plus parsing and rendering when its fragment was rendered.
+type FileTiming struct {
+	File     string        `json:"file"`
+	Output   string        `json:"output"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"duration"`
+}
+
+// OutputTiming is the time spent on one output.
+type OutputTiming struct {
+	File     string        `json:"file"`
+	Duration time.Duration `json:"duration"`
+	// UpToDate outputs were left untouched because no source changed.
+	UpToDate bool `json:"upToDate"`
+}
+
+// source is a read and hashed source file.
+type source struct {
+	path string
+	rel  string
+	hash string
+	raw  []byte
+	took time.Duration
+}
+
+// renderIncremental renders one output, parsing only the sources whose hash
+// differs from cfg.Manifest. Generic YAML domains read from a directory are
+// rendered file by file and assembled from fragments; other domains are
+// rendered as a whole when any source changed. Either way the output is left
+// alone when no source changed and it still matches the manifest.
+func renderIncremental(cfg *DomainRenderConfig, src string, isSourceDir bool, proc *docProcessor, renderer render.Renderer, result *DomainRenderResult) error {
+	start := time.Now()
+	filename := proc.getOutputFilename(src)
+	outputPath, err := filepath.Abs(filepath.Join(proc.Dst, filename))
+	if err != nil {
+		return fmt.Errorf("get absolute path: %w", err)
+	}
+	key := renderKey(cfg.Domain, src, outputPath)
+	prev := cfg.Manifest.Renders[key]
+	if prev == nil {
+		prev = &RenderEntry{}
+	}
+
+	sources, err := readSources(src, isSourceDir)
+	if err != nil {
+		return err
+	}
+
+	next := &RenderEntry{Files: make(map[string]*SourceEntry, len(sources))}
+	var (
+		timings   []FileTiming
+		content   string
+		assembled bool
+	)
+	if yr, ok := fragmentRenderer(renderer, isSourceDir); ok {
+		timings, content, assembled, err = assemble(yr, proc.fileType, sources, prev, next)
+		if err != nil {
+			return err
+		}
+	}
+	if !assembled {
+		timings = wholeTimings(sources, prev, next)
+	}
+	for i := range timings {
+		timings[i].Output = filename
+	}
+	result.Files = append(result.Files, timings...)
+
+	changed := len(prev.Files) != len(next.Files) || slices.ContainsFunc(timings, func(t FileTiming) bool {
+		return t.Status == FileRendered || t.Status == FileChanged
+	})
+	if !changed && outputMatches(outputPath, prev.OutputHash) {
+		next.OutputHash = prev.OutputHash
+		cfg.Manifest.Renders[key] = next
+		result.Outputs = append(result.Outputs, OutputTiming{File: filename, UpToDate: true, Duration: time.Since(start)})
+
+		return nil
+	}
+
+	if assembled {
+		if err := proc.writeOutput(content, filename); err != nil {
+			return fmt.Errorf("write %s: %w", filename, err)
+		}
+		next.OutputHash = contentHash([]byte(content))
+	} else {
+		if err := renderWhole(cfg.Domain, src, isSourceDir, proc, renderer); err != nil {
+			return err
+		}
+		written, err := os.ReadFile(outputPath)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", outputPath, err)
+		}
+		next.OutputHash = contentHash(written)
+	}
+	cfg.Manifest.Renders[key] = next
+	result.Outputs = append(result.Outputs, OutputTiming{File: filename, Duration: time.Since(start)})
+
+	return nil
+}
+
+// readSources reads and hashes src, or every YAML file under it in the
+// order the whole-domain render merges them.
+func readSources(src string, isSourceDir bool) ([]source, error) {
+	paths := []string{src}
+	if isSourceDir {
+		var err error
+		if paths, err = fileutil.ListYAMLFilesRecursive(src); err != nil {
+			return nil, err
+		}
+	}
+
+	sources := make([]source, 0, len(paths))
+	for _, path := range paths {
+		start := time.Now()
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", path, err)
+		}
+		rel := filepath.Base(path)
+		if isSourceDir {
+			if rel, err = filepath.Rel(src, path); err != nil {
+				return nil, fmt.Errorf("relative path of %s: %w", path, err)
+			}
+		}
+		sources = append(sources, source{
+			path: path,
+			rel:  filepath.ToSlash(rel),
+			hash: contentHash(raw),
+			raw:  raw,
+			took: time.Since(start),
+		})
+	}
+
+	return sources, nil
+}
+
+// fragmentRenderer returns the renderer when a directory render equals the
+// concatenation of its per-file renders: the generic YAML renderer reading
+// one sequence per file.
+func fragmentRenderer(renderer render.Renderer, isSourceDir bool) (*render.YAMLRenderer, bool) {
+	yr, ok := renderer.(*render.YAMLRenderer)
+	if !ok || !isSourceDir {
+		return nil, false
+	}
+
+	return yr, yr.ParseMode == render.ParseSingle || yr.ParseMode == render.ParseFlatten
+}
+
+// assemble renders the changed sources and joins their fragments with the
+// cached ones. It reports false when a source cannot be rendered on its own,
+// e.g. a mapping or a multi-document file.
+func assemble(r *render.YAMLRenderer, ft fileType, sources []source, prev, next *RenderEntry) ([]FileTiming, string, bool, error) {
+	timings := make([]FileTiming, 0, len(sources))
+	var parts []string
+	for _, s := range sources {
+		start := time.Now()
+		status := FileCached
+		var fragment string
+		if old := prev.Files[s.rel]; old != nil && old.Hash == s.hash && old.Fragment != nil {
+			fragment = *old.Fragment
+		} else {
+			rendered, ok, err := renderFragment(r, ft, s.raw)
+			if err != nil {
+				return nil, "", false, fmt.Errorf("render %s: %w", s.path, err)
+			}
+			if !ok {
+				return nil, "", false, nil
+			}
+			fragment, status = rendered, FileRendered
+		}
+		next.Files[s.rel] = &SourceEntry{Hash: s.hash, Fragment: &fragment}
+		if fragment != "" {
+			parts = append(parts, fragment)
+		}
+		timings = append(timings, FileTiming{File: s.rel, Status: status, Duration: s.took + time.Since(start)})
+	}
+	if len(parts) == 0 {
+		return nil, "", false, nil
+	}
+	if ft == fileTypeJSON {
+		return timings, "[" + strings.Join(parts, ", ") + "]\n", true, nil
+	}
+
+	return timings, strings.Join(parts, ""), true, nil
+}
+
+// renderFragment renders one file: the YAML sequence items, or the JSON
+// array elements, it adds to the domain output. Empty and comment-only files
+// add nothing.
+func renderFragment(r *render.YAMLRenderer, ft fileType, raw []byte) (string, bool, error) {
+	if bytes.HasPrefix(raw, []byte("---")) || bytes.Contains(raw, []byte("\n---")) {
+		return "", false, nil
+	}
+	value, err := r.ParseData(raw)
+	if errors.Is(err, io.EOF) {
+		return "", true, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	if value == nil {
+		return "", true, nil
+	}
+	seq, ok := value.([]any)
+	if !ok {
+		return "", false, nil
+	}
+	if len(seq) == 0 {
+		return "", true, nil
+	}
+
+	out, err := yaml.Marshal(seq)
+	if err != nil {
+		return "", false, fmt.Errorf("marshal: %w", err)
+	}
+	if ft != fileTypeJSON {
+		return string(out), true, nil
+	}
+	jsonData, err := yaml.YAMLToJSON(out)
+	if err != nil {
+		return "", false, fmt.Errorf("convert to json: %w", err)
+	}
+	elements := strings.TrimSuffix(strings.TrimSpace(string(jsonData)), "]")
+
+	return strings.TrimPrefix(elements, "["), true, nil
+}
+
+// wholeTimings records the sources of a domain rendered as a whole.
+func wholeTimings(sources []source, prev, next *RenderEntry) []FileTiming {
+	clear(next.Files)
+	timings := make([]FileTiming, 0, len(sources))
+	for _, s := range sources {
+		status := FileChanged
+		if old := prev.Files[s.rel]; old != nil && old.Hash == s.hash {
+			status = FileUnchanged
+		}
+		next.Files[s.rel] = &SourceEntry{Hash: s.hash}
+		timings = append(timings, FileTiming{File: s.rel, Status: status, Duration: s.took})
+	}
+
+	return timings
+}
+
+func outputMatches(path, hash string) bool {
+	if hash == "" {
+		return false
+	}
+	raw, err := os.ReadFile(path)
+
+	return err == nil && contentHash(raw) == hash
+}
+
+// TimingSummary renders the timings of an incremental render: every output
+// with its source files, slowest first.
+func TimingSummary(result *DomainRenderResult) string {
+	var b strings.Builder
+	files := 0
+	for _, out := range result.Outputs {
+		state := "written"
+		if out.UpToDate {
+			state = "up to date"
+		}
+		fmt.Fprintf(&b, "%s: %s in %s\n", out.File, state, out.Duration.Round(time.Microsecond))
+
+		var outFiles []FileTiming
+		counts := map[string]int{}
+		for _, f := range result.Files {
+			if f.Output == out.File {
+				outFiles = append(outFiles, f)
+				counts[f.Status]++
+			}
+		}
+		slices.SortStableFunc(outFiles, func(a, b FileTiming) int { return int(b.Duration - a.Duration) })
+		for _, f := range outFiles {
+			fmt.Fprintf(&b, "  %-9s %10s  %s\n", f.Status, f.Duration.Round(time.Microsecond), f.File)
+		}
+
+		var parts []string
+		for _, status := range []string{FileRendered, FileCached, FileChanged, FileUnchanged} {
+			if counts[status] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+			}
+		}
+		if len(parts) > 0 {
+			fmt.Fprintf(&b, "  %d files: %s\n", len(outFiles), strings.Join(parts, ", "))
+		}
+		files += len(outFiles)
+	}
+	fmt.Fprintf(&b, "Total: %d outputs, %d source files in %s\n", len(result.Outputs), files, result.Duration.Round(time.Microsecond))
+
+	return b.String()
+}
@@ -0,0 +1,182 @@
+package datarender
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSources(t *testing.T, files map[string]string) string {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "books")
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return src
+}
+
+func loadTestManifest(t *testing.T) *Manifest {
+	t.Helper()
+	m, err := LoadManifest(filepath.Join(t.TempDir(), "manifest.json"))
+	require.NoError(t, err)
+
+	return m
+}
+
+func statuses(result *DomainRenderResult) map[string]string {
+	out := map[string]string{}
+	for _, f := range result.Files {
+		out[f.Output+" "+f.File] = f.Status
+	}
+
+	return out
+}
+
+func TestRunDomainRender_IncrementalMatchesFullRender(t *testing.T) {
+	src := writeSources(t, map[string]string{
+		"a.yml":     "- name: a\n  score: 5\n  readAt: 2021-03-04\n  tags: [x, y]\n- name: \"b: c\"\n  des: |\n    line1\n    line2\n",
+		"b.yml":     "# only comments\n",
+		"sub/c.yml": "- name: d\n  publishAt: 1994\n  record:\n    - date: 2024-01-01\n",
+	})
+	full, inc := t.TempDir(), t.TempDir()
+
+	_, err := RunDomainRender(DomainRenderConfig{Domain: "books", Src: src, OutDir: full, Format: "yaml,json"})
+	require.NoError(t, err)
+	result, err := RunDomainRender(DomainRenderConfig{Manifest: loadTestManifest(t), Domain: "books", Src: src, OutDir: inc, Format: "yaml,json"})
+	require.NoError(t, err)
+
+	for _, name := range []string{"books.yml", "books.json"} {
+		want, err := os.ReadFile(filepath.Join(full, name))
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(inc, name))
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), name)
+	}
+	assert.Equal(t, FileRendered, statuses(result)["books.json sub/c.yml"])
+	require.Len(t, result.Outputs, 2)
+	assert.False(t, result.Outputs[0].UpToDate)
+}
+
+func TestRunDomainRender_IncrementalRendersOnlyChangedFiles(t *testing.T) {
+	src := writeSources(t, map[string]string{
+		"a.yml": "- name: a\n",
+		"b.yml": "- name: b\n",
+	})
+	out := t.TempDir()
+	cfg := DomainRenderConfig{Manifest: loadTestManifest(t), Domain: "books", Src: src, OutDir: out, Format: "yaml"}
+
+	_, err := RunDomainRender(cfg)
+	require.NoError(t, err)
+
+	result, err := RunDomainRender(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"books.yml a.yml": FileCached, "books.yml b.yml": FileCached}, statuses(result))
+	assert.True(t, result.Outputs[0].UpToDate)
+
+	require.NoError(t, os.WriteFile(filepath.Join(src, "b.yml"), []byte("- name: b2\n"), 0o644))
+	result, err = RunDomainRender(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"books.yml a.yml": FileCached, "books.yml b.yml": FileRendered}, statuses(result))
+	assert.False(t, result.Outputs[0].UpToDate)
+
+	got, err := os.ReadFile(filepath.Join(out, "books.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- name: a\n- name: b2\n", string(got))
+
+	require.NoError(t, os.Remove(filepath.Join(src, "a.yml")))
+	_, err = RunDomainRender(cfg)
+	require.NoError(t, err)
+	got, err = os.ReadFile(filepath.Join(out, "books.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- name: b2\n", string(got))
+}
+
+func TestRunDomainRender_IncrementalRewritesTamperedOutput(t *testing.T) {
+	src := writeSources(t, map[string]string{"a.yml": "- name: a\n"})
+	out := t.TempDir()
+	cfg := DomainRenderConfig{Manifest: loadTestManifest(t), Domain: "books", Src: src, OutDir: out, Format: "yaml"}
+
+	_, err := RunDomainRender(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(out, "books.yml"), []byte("edited\n"), 0o644))
+
+	result, err := RunDomainRender(cfg)
+	require.NoError(t, err)
+	assert.False(t, result.Outputs[0].UpToDate)
+	assert.Equal(t, FileCached, statuses(result)["books.yml a.yml"])
+
+	got, err := os.ReadFile(filepath.Join(out, "books.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, "- name: a\n", string(got))
+}
+
+func TestRunDomainRender_IncrementalWholeDomain(t *testing.T) {
+	src := writeSources(t, map[string]string{
+		"edc.yml": "- type: 耳机\n  tag: EDC\n  item:\n    - name: C50\n      price: ¥179\n",
+	})
+	full, inc := t.TempDir(), t.TempDir()
+	cfg := DomainRenderConfig{Manifest: loadTestManifest(t), Domain: "goods", Src: src, OutDir: inc, Format: "json"}
+
+	_, err := RunDomainRender(DomainRenderConfig{Domain: "goods", Src: src, OutDir: full, Format: "json"})
+	require.NoError(t, err)
+	result, err := RunDomainRender(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"books.json edc.yml": FileChanged}, statuses(result))
+
+	want, err := os.ReadFile(filepath.Join(full, "books.json"))
+	require.NoError(t, err)
+	got, err := os.ReadFile(filepath.Join(inc, "books.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	result, err = RunDomainRender(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"books.json edc.yml": FileUnchanged}, statuses(result))
+	assert.True(t, result.Outputs[0].UpToDate)
+}
+
+func TestRunDomainRender_IncrementalFallsBackForMappings(t *testing.T) {
+	src := writeSources(t, map[string]string{"a.yml": "name: a\n"})
+	result, err := RunDomainRender(DomainRenderConfig{Manifest: loadTestManifest(t), Domain: "books", Src: src, OutDir: t.TempDir(), Format: "yaml"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"books.yml a.yml": FileChanged}, statuses(result))
+}
+
+func TestManifestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "manifest.json")
+	m, err := LoadManifest(path)
+	require.NoError(t, err)
+	src := writeSources(t, map[string]string{"a.yml": "- name: a\n"})
+	_, err = RunDomainRender(DomainRenderConfig{Manifest: m, Domain: "books", Src: src, OutDir: t.TempDir(), Format: "yaml"})
+	require.NoError(t, err)
+	require.NoError(t, m.Save())
+
+	loaded, err := LoadManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, m.Renders, loaded.Renders)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 0, "renders": {"x": {}}}`), 0o600))
+	loaded, err = LoadManifest(path)
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Renders)
+}
+
+func TestTimingSummary(t *testing.T) {
+	summary := TimingSummary(&DomainRenderResult{
+		Outputs: []OutputTiming{{File: "books.yml", UpToDate: true}},
+		Files: []FileTiming{
+			{File: "a.yml", Output: "books.yml", Status: FileCached},
+			{File: "b.yml", Output: "books.yml", Status: FileRendered, Duration: 2},
+		},
+	})
+	assert.Contains(t, summary, "books.yml: up to date")
+	assert.Contains(t, summary, "2 files: 1 rendered, 1 cached")
+	assert.Less(t, strings.Index(summary, "b.yml"), strings.Index(summary, "a.yml"))
+}
@@ -0,0 +1,84 @@
+package datarender
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// DefaultManifestFile is the render manifest under the docs-alfred cache dir.
+const DefaultManifestFile = "data-render/manifest.json"
+
+// manifestVersion is bumped whenever rendered output changes shape, so
+// fragments cached by an older build are never reused.
+const manifestVersion = 1
+
+// Manifest records the content hash of every rendered source file, and the
+// rendered fragment of files whose domain output is a concatenation of
+// per-file output, so unchanged files are not parsed again.
+type Manifest struct {
+	Renders map[string]*RenderEntry `json:"renders"`
+	path    string
+	Version int `json:"version"`
+}
+
+// RenderEntry is the state of one output: a domain, source, output
+// directory and format.
+type RenderEntry struct {
+	// Files are keyed by path relative to the source directory.
+	Files      map[string]*SourceEntry `json:"files"`
+	OutputHash string                  `json:"outputHash"`
+}
+
+// SourceEntry is the state of one source file.
+type SourceEntry struct {
+	// Fragment is the file's share of the output, set when the output is
+	// assembled from per-file fragments.
+	Fragment *string `json:"fragment,omitempty"`
+	Hash     string  `json:"hash"`
+}
+
+// DefaultManifestPath returns the render manifest under the docs-alfred
+// cache dir.
+func DefaultManifestPath() string {
+	return fileutil.CachePath(DefaultManifestFile)
+}
+
+// LoadManifest reads the manifest at path. A missing file, or one written by
+// another manifest version, is an empty manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	m, err := fileutil.ReadJSONFile[Manifest](path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load render manifest: %w", err)
+	}
+	if m.Version != manifestVersion || m.Renders == nil {
+		m = Manifest{Version: manifestVersion, Renders: map[string]*RenderEntry{}}
+	}
+	m.path = path
+
+	return &m, nil
+}
+
+// Save writes the manifest back to the path it was loaded from.
+func (m *Manifest) Save() error {
+	if err := fileutil.AtomicWriteJSONFile(m.path, m, fileutil.FilePermPrivate); err != nil {
+		return fmt.Errorf("save render manifest: %w", err)
+	}
+
+	return nil
+}
+
+func renderKey(domain, src, outputPath string) string {
+	return strings.Join([]string{domain, src, outputPath}, "|")
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
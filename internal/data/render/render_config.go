@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/xbpk3t/docs-alfred/internal/gh/goods"
@@ -24,19 +25,29 @@ const (
 
 // DomainRenderConfig holds configuration for rendering a single domain.
 type DomainRenderConfig struct {
-	Domain string
-	Src    string
-	OutDir string
-	Format string // "json", "yaml", "json,yaml"
+	// Manifest enables incremental rendering: outputs whose sources are
+	// unchanged are left alone and only changed files are parsed again. The
+	// caller loads and saves it.
+	Manifest *Manifest
+	Domain   string
+	Src      string
+	OutDir   string
+	Format   string // "json", "yaml", "json,yaml"
 }
 
 // DomainRenderResult holds the result of a domain render.
 type DomainRenderResult struct {
-	OutputFiles []string
+	OutputFiles []string `json:"outputFiles"`
+	// Outputs and Files time every output and every source file per output;
+	// they are set for incremental renders.
+	Outputs  []OutputTiming `json:"outputs,omitempty"`
+	Files    []FileTiming   `json:"files,omitempty"`
+	Duration time.Duration  `json:"duration"`
 }
 
 // RunDomainRender renders a single domain's data into the specified output formats.
 func RunDomainRender(cfg DomainRenderConfig) (*DomainRenderResult, error) {
+	start := time.Now()
 	src, err := filepath.Abs(cfg.Src)
 	if err != nil {
 		return nil, fmt.Errorf("get absolute path: %w", err)
@@ -55,7 +66,7 @@ func RunDomainRender(cfg DomainRenderConfig) (*DomainRenderResult, error) {
 		return nil, err
 	}
 
-	var outputFiles []string
+	result := &DomainRenderResult{}
 
 	for _, f := range formats {
 		f = strings.TrimSpace(f)
@@ -67,20 +78,36 @@ func RunDomainRender(cfg DomainRenderConfig) (*DomainRenderResult, error) {
 		proc := newDocProcessor(ft)
 		proc.Dst = cfg.OutDir
 
-		if cfg.Domain == "gh" && isSourceDir {
-			if err := processGithubDirDomain(src, ft, proc); err != nil {
-				return nil, fmt.Errorf("process gh dir: %w", err)
-			}
+		if cfg.Manifest != nil {
+			err = renderIncremental(&cfg, src, isSourceDir, proc, renderer, result)
 		} else {
-			if err := proc.processFile(src, renderer); err != nil {
-				return nil, fmt.Errorf("process %s: %w", ft, err)
-			}
+			err = renderWhole(cfg.Domain, src, isSourceDir, proc, renderer)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result.OutputFiles = append(result.OutputFiles, filepath.Join(cfg.OutDir, proc.getOutputFilename(src)))
+	}
+	result.Duration = time.Since(start)
+
+	return result, nil
+}
+
+// renderWhole reads every source of the domain and renders one output.
+func renderWhole(domain, src string, isSourceDir bool, proc *docProcessor, renderer render.Renderer) error {
+	if domain == "gh" && isSourceDir {
+		if err := processGithubDirDomain(src, proc.fileType, proc); err != nil {
+			return fmt.Errorf("process gh dir: %w", err)
 		}
 
-		outputFiles = append(outputFiles, filepath.Join(cfg.OutDir, proc.getOutputFilename(src)))
+		return nil
+	}
+	if err := proc.processFile(src, renderer); err != nil {
+		return fmt.Errorf("process %s: %w", proc.fileType, err)
 	}
 
-	return &DomainRenderResult{OutputFiles: outputFiles}, nil
+	return nil
 }
 
 // createRendererForDomain returns the appropriate renderer for a domain.
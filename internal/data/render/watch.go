@@ -0,0 +1,111 @@
+package datarender
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// DefaultDebounce is how long Watch waits for a burst of saves to settle;
+// editors often write a file several times per save.
+const DefaultDebounce = 200 * time.Millisecond
+
+// Watch calls fn with the YAML files changed under src, a directory or a
+// single file, after every burst of changes until ctx is done. Directories
+// created under src are watched as they appear.
+func Watch(ctx context.Context, src string, debounce time.Duration, fn func(changed []string)) error {
+	src, err := filepath.Abs(src)
+	if err != nil {
+		return fmt.Errorf("get absolute path: %w", err)
+	}
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	root, only := src, ""
+	if !isDir(src) {
+		// Editors replace files on save, so the directory is watched.
+		root, only = filepath.Dir(src), src
+	}
+	if err := watchTree(watcher, root, only == ""); err != nil {
+		return err
+	}
+
+	pending := map[string]bool{}
+	var fire <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if only == "" && event.Has(fsnotify.Create) && isDir(event.Name) {
+				if err := watchTree(watcher, event.Name, true); err != nil {
+					slog.Warn("Watch new directory failed", "dir", event.Name, "error", err)
+				}
+
+				continue
+			}
+			if event.Op == fsnotify.Chmod || !fileutil.IsYAMLFileName(event.Name) || (only != "" && event.Name != only) {
+				continue
+			}
+			pending[event.Name] = true
+			fire = time.After(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("Watch error", "error", err)
+		case <-fire:
+			fire = nil
+			changed := slices.Sorted(maps.Keys(pending))
+			clear(pending)
+			fn(changed)
+		}
+	}
+}
+
+// watchTree watches dir and, when recursive, its visible subdirectories.
+func watchTree(watcher *fsnotify.Watcher, dir string, recursive bool) error {
+	if !recursive {
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("watch %s: %w", path, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,52 @@
+package datarender
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchReportsChangedYAMLFiles(t *testing.T) {
+	src := writeSources(t, map[string]string{"a.yml": "- name: a\n"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan []string, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- Watch(ctx, src, 20*time.Millisecond, func(changed []string) { changes <- changed })
+	}()
+
+	// Give the watcher time to register before writing.
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(src, "notes.txt"), []byte("x"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a.yml"), []byte("- name: a2\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "sub", "b.yml"), []byte("- name: b\n"), 0o644))
+
+	var got []string
+	timeout := time.After(5 * time.Second)
+	for len(got) < 2 {
+		select {
+		case changed := <-changes:
+			for _, file := range changed {
+				if !slices.Contains(got, file) {
+					got = append(got, file)
+				}
+			}
+		case <-timeout:
+			t.Fatalf("no change reported, got %v", got)
+		}
+	}
+	assert.ElementsMatch(t, []string{filepath.Join(src, "a.yml"), filepath.Join(src, "sub", "b.yml")}, got)
+
+	cancel()
+	require.NoError(t, <-done)
+}
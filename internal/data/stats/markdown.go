@@ -0,0 +1,107 @@
+package datastats
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/xbpk3t/docs-alfred/pkg/md"
+)
+
+// Markdown renders the review as a markdown document.
+func Markdown(r *Result) string {
+	title := fmt.Sprintf("%s · all years", r.Domain)
+	if r.Year != 0 {
+		title = fmt.Sprintf("%s · %d", r.Domain, r.Year)
+	}
+
+	doc := md.NewDocument()
+	doc.Add(md.NamedSection(title, md.StatsGrid(summaryStats(r))))
+	if r.Summary.Count == 0 {
+		doc.AddEmpty("No dated items.")
+
+		return doc.Markdown()
+	}
+
+	doc.Add(md.NamedSection("Months", md.Table(
+		[]string{"Month", "Items"},
+		bucketRows(r.Months),
+	)))
+	doc.Add(md.NamedSection("Scores", md.Table(
+		[]string{"Score", "Items"},
+		bucketRows(r.Scores),
+	)))
+	if len(r.TopAuthors) > 0 {
+		doc.Add(md.NamedSection("Top authors", md.Table([]string{"Author", "Items"}, bucketRows(r.TopAuthors))))
+	}
+	if len(r.TopTags) > 0 {
+		doc.Add(md.NamedSection("Top tags", md.Table([]string{"Tag", "Items"}, bucketRows(r.TopTags))))
+	}
+	if len(r.Years) > 0 {
+		doc.Add(md.NamedSection("Year over year", md.Table(
+			[]string{"Year", "Items", "Δ", "Avg score", "Read hours"},
+			yearRows(r.Years),
+		)))
+	}
+
+	return doc.Markdown()
+}
+
+func summaryStats(r *Result) []md.StatItem {
+	stats := []md.StatItem{
+		{Label: "Items", Value: r.Summary.Count},
+		{Label: "Scored", Value: r.Summary.Scored},
+		{Label: "Avg score", Value: formatFloat(r.Summary.AvgScore)},
+		{Label: "Read hours", Value: formatFloat(r.Summary.ReadHours)},
+	}
+	if r.Previous != nil {
+		stats = append(stats,
+			md.StatItem{Label: fmt.Sprintf("Items vs %d", r.Previous.Year), Value: formatDelta(float64(r.Summary.Count - r.Previous.Count))},
+			md.StatItem{Label: fmt.Sprintf("Avg score vs %d", r.Previous.Year), Value: formatDelta(r.Summary.AvgScore - r.Previous.AvgScore)},
+			md.StatItem{Label: fmt.Sprintf("Read hours vs %d", r.Previous.Year), Value: formatDelta(r.Summary.ReadHours - r.Previous.ReadHours)},
+		)
+	} else {
+		stats = append(stats, md.StatItem{Label: "Undated", Value: r.Undated})
+	}
+
+	return stats
+}
+
+func bucketRows(buckets []Bucket) [][]string {
+	rows := make([][]string, 0, len(buckets))
+	for _, b := range buckets {
+		rows = append(rows, []string{b.Key, strconv.Itoa(b.Count)})
+	}
+
+	return rows
+}
+
+func yearRows(years []YearSummary) [][]string {
+	rows := make([][]string, 0, len(years))
+	for i, y := range years {
+		delta := ""
+		if i > 0 {
+			delta = formatDelta(float64(y.Count - years[i-1].Count))
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(y.Year),
+			strconv.Itoa(y.Count),
+			delta,
+			formatFloat(y.AvgScore),
+			formatFloat(y.ReadHours),
+		})
+	}
+
+	return rows
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(round2(f), 'f', -1, 64)
+}
+
+func formatDelta(f float64) string {
+	if f > 0 {
+		return "+" + formatFloat(f)
+	}
+
+	return formatFloat(f)
+}
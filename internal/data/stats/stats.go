@@ -0,0 +1,313 @@
+// Package datastats computes yearly reviews of the personal data domains:
+// items per month, score distributions, top authors and tags, reading time
+// and year-over-year comparisons.
+package datastats
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dataquery "github.com/xbpk3t/docs-alfred/internal/data/query"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+)
+
+// DefaultTop is the default length of the top authors and tags lists.
+const DefaultTop = 10
+
+// unscored is the score bucket of items without a score.
+const unscored = "-"
+
+// activityFields date an item, first present wins: when a book or movie was
+// finished, or the day of a diary entry.
+var activityFields = []string{"readAt", "date", "playAt"}
+
+// supported lists the domains with dated, scored items. Music has no
+// activity field (publishAt is the release date), so it is not reviewed.
+var supported = []data.DataDomain{data.DomainBooks, data.DomainMovie, data.DomainTV, data.DomainDiary, data.DomainNtl}
+
+var (
+	datePattern     = regexp.MustCompile(`^(\d{4})(?:-(\d{2}))?`)
+	readTimePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(h|小时|m|min|分钟)?$`)
+)
+
+// Options controls Run.
+type Options struct {
+	// Year restricts the review to one year and compares it with the year
+	// before (0 = all items).
+	Year int
+	// Top caps the top authors and tags lists (default: DefaultTop).
+	Top int
+}
+
+// Summary holds the headline numbers of a set of items.
+type Summary struct {
+	Count     int     `json:"count"`
+	Scored    int     `json:"scored"`
+	AvgScore  float64 `json:"avgScore"`
+	ReadHours float64 `json:"readHours"`
+}
+
+// YearSummary is the Summary of one year.
+type YearSummary struct {
+	Summary
+	Year int `json:"year"`
+}
+
+// Bucket is a labelled count.
+type Bucket struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// Result is the outcome of Run.
+type Result struct {
+	// Previous is the year before Year, set with Year.
+	Previous *YearSummary `json:"previous,omitempty"`
+	Domain   string       `json:"domain"`
+	Summary  Summary      `json:"summary"`
+	// Months counts the items per month of Year, or per month across all
+	// years without Year.
+	Months     []Bucket      `json:"months"`
+	Scores     []Bucket      `json:"scores"`
+	TopAuthors []Bucket      `json:"topAuthors"`
+	TopTags    []Bucket      `json:"topTags"`
+	Years      []YearSummary `json:"years"`
+	Year       int           `json:"year,omitempty"`
+	// Undated counts items without an activity date; they are only part of
+	// the review without Year.
+	Undated int `json:"undated"`
+	// UnparsedReadTime counts readTime values that are not hours or minutes.
+	UnparsedReadTime int `json:"unparsedReadTime,omitempty"`
+}
+
+// Supported reports whether stats can be computed for domain.
+func Supported(domain data.DataDomain) bool {
+	return slices.Contains(supported, domain)
+}
+
+// Run loads the items of domain from dir and computes the review.
+func Run(dir string, domain data.DataDomain, opts Options) (*Result, error) {
+	if !Supported(domain) {
+		return nil, fmt.Errorf("data stats %s is not supported", domain)
+	}
+	records, err := dataquery.LoadDir(dir, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compute(records, string(domain), opts), nil
+}
+
+// item is a record reduced to the values the review needs.
+type item struct {
+	authors  []string
+	tags     []string
+	score    string
+	year     int
+	month    int
+	hours    float64
+	badHours bool
+}
+
+// Compute reviews records, which must all belong to domain.
+func Compute(records []dataquery.Record, domain string, opts Options) *Result {
+	if opts.Top <= 0 {
+		opts.Top = DefaultTop
+	}
+	items := make([]item, 0, len(records))
+	for i := range records {
+		items = append(items, toItem(&records[i]))
+	}
+
+	result := &Result{Domain: domain, Year: opts.Year}
+	result.Years = yearSummaries(items)
+
+	selected := items
+	if opts.Year != 0 {
+		selected = slices.DeleteFunc(slices.Clone(items), func(it item) bool { return it.year != opts.Year })
+		prev := YearSummary{Year: opts.Year - 1}
+		if i := slices.IndexFunc(result.Years, func(y YearSummary) bool { return y.Year == opts.Year-1 }); i >= 0 {
+			prev = result.Years[i]
+		}
+		result.Previous = &prev
+	}
+	for _, it := range items {
+		if it.year == 0 {
+			result.Undated++
+		}
+	}
+
+	result.Summary = summarize(selected)
+	result.Months = monthBuckets(selected)
+	result.Scores = scoreBuckets(selected)
+	result.TopAuthors = topBuckets(selected, opts.Top, func(it *item) []string { return it.authors })
+	result.TopTags = topBuckets(selected, opts.Top, func(it *item) []string { return it.tags })
+	for _, it := range selected {
+		if it.badHours {
+			result.UnparsedReadTime++
+		}
+	}
+
+	return result
+}
+
+func toItem(rec *dataquery.Record) item {
+	it := item{
+		authors: dataquery.ScalarStrings(rec.Fields["author"]),
+		tags:    append(dataquery.ScalarStrings(rec.Fields["tags"]), dataquery.ScalarStrings(rec.Fields["tag"])...),
+		score:   unscored,
+	}
+	for _, field := range activityFields {
+		values := dataquery.ScalarStrings(rec.Fields[field])
+		if len(values) == 0 {
+			continue
+		}
+		if m := datePattern.FindStringSubmatch(values[0]); m != nil {
+			it.year, _ = strconv.Atoi(m[1])
+			it.month, _ = strconv.Atoi(m[2])
+		}
+
+		break
+	}
+	if scores := dataquery.ScalarStrings(rec.Fields["score"]); len(scores) > 0 {
+		it.score = scores[0]
+	}
+	if values := dataquery.ScalarStrings(rec.Fields["readTime"]); len(values) > 0 {
+		hours, ok := parseReadTime(values[0])
+		it.hours, it.badHours = hours, !ok
+	}
+
+	return it
+}
+
+// parseReadTime reads hours from a bare number, a Go duration (1h30m) or a
+// number with an hour or minute unit (90min, 3小时).
+func parseReadTime(s string) (float64, bool) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return d.Hours(), true
+	}
+	m := readTimePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	switch m[2] {
+	case "m", "min", "分钟":
+		return n / 60, true
+	}
+
+	return n, true
+}
+
+func summarize(items []item) Summary {
+	s := Summary{Count: len(items)}
+	total := 0.0
+	for _, it := range items {
+		if score, err := strconv.ParseFloat(it.score, 64); err == nil {
+			s.Scored++
+			total += score
+		}
+		s.ReadHours += it.hours
+	}
+	if s.Scored > 0 {
+		s.AvgScore = round2(total / float64(s.Scored))
+	}
+	s.ReadHours = round2(s.ReadHours)
+
+	return s
+}
+
+func yearSummaries(items []item) []YearSummary {
+	byYear := map[int][]item{}
+	for _, it := range items {
+		if it.year != 0 {
+			byYear[it.year] = append(byYear[it.year], it)
+		}
+	}
+	years := make([]YearSummary, 0, len(byYear))
+	for year, yearItems := range byYear {
+		years = append(years, YearSummary{Year: year, Summary: summarize(yearItems)})
+	}
+	slices.SortFunc(years, func(a, b YearSummary) int { return cmp.Compare(a.Year, b.Year) })
+
+	return years
+}
+
+func monthBuckets(items []item) []Bucket {
+	counts := make([]int, 12)
+	for _, it := range items {
+		if it.month >= 1 && it.month <= 12 {
+			counts[it.month-1]++
+		}
+	}
+	buckets := make([]Bucket, 12)
+	for i, n := range counts {
+		buckets[i] = Bucket{Key: fmt.Sprintf("%02d", i+1), Count: n}
+	}
+
+	return buckets
+}
+
+// scoreBuckets counts every score from 5 down to 0, then other values and
+// unscored items.
+func scoreBuckets(items []item) []Bucket {
+	counts := map[string]int{}
+	for _, it := range items {
+		counts[it.score]++
+	}
+	var buckets []Bucket
+	for score := 5; score >= 0; score-- {
+		key := strconv.Itoa(score)
+		buckets = append(buckets, Bucket{Key: key, Count: counts[key]})
+		delete(counts, key)
+	}
+	unscoredCount := counts[unscored]
+	delete(counts, unscored)
+	for _, key := range slices.Sorted(maps.Keys(counts)) {
+		buckets = append(buckets, Bucket{Key: key, Count: counts[key]})
+	}
+
+	return append(buckets, Bucket{Key: unscored, Count: unscoredCount})
+}
+
+func topBuckets(items []item, top int, values func(*item) []string) []Bucket {
+	counts := map[string]int{}
+	for i := range items {
+		for _, v := range values(&items[i]) {
+			if v = strings.TrimSpace(v); v != "" {
+				counts[v]++
+			}
+		}
+	}
+	buckets := make([]Bucket, 0, len(counts))
+	for key, n := range counts {
+		buckets = append(buckets, Bucket{Key: key, Count: n})
+	}
+	slices.SortFunc(buckets, func(a, b Bucket) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+
+		return strings.Compare(a.Key, b.Key)
+	})
+	if len(buckets) > top {
+		buckets = buckets[:top]
+	}
+
+	return buckets
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,135 @@
+package datastats
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	data "github.com/xbpk3t/docs-alfred/internal/gh/domrules"
+)
+
+const booksYAML = `- name: 万历十五年
+  author: 黄仁宇
+  score: 5
+  readAt: "2021-03-04"
+  readTime: 6h
+  tags: [history, ming]
+- name: 中国大历史
+  author: 黄仁宇
+  score: 4
+  readAt: "2021-03-20"
+  readTime: 90min
+  tags: [history]
+- name: Sapiens
+  author: Yuval Noah Harari
+  score: 3
+  readAt: "2020-07-01"
+  readTime: "10"
+  tags: [history]
+- name: Unread
+  readTime: a weekend
+`
+
+func writeBooks(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "history.yml"), []byte(booksYAML), 0o644))
+	// movie.yml shares the books directory but belongs to the movie domain.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "movie.yml"), []byte("- name: 霸王别姬\n  score: 5\n  readAt: \"2021-01-01\"\n"), 0o644))
+
+	return dir
+}
+
+func bucketCount(buckets []Bucket, key string) int {
+	for _, b := range buckets {
+		if b.Key == key {
+			return b.Count
+		}
+	}
+
+	return -1
+}
+
+func TestRunAllYears(t *testing.T) {
+	result, err := Run(writeBooks(t), data.DomainBooks, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, Summary{Count: 4, Scored: 3, AvgScore: 4, ReadHours: 17.5}, result.Summary)
+	assert.Nil(t, result.Previous)
+	assert.Equal(t, 1, result.Undated)
+	assert.Equal(t, 1, result.UnparsedReadTime)
+	assert.Equal(t, 2, bucketCount(result.Months, "03"))
+	assert.Equal(t, 1, bucketCount(result.Months, "07"))
+	assert.Equal(t, 1, bucketCount(result.Scores, "5"))
+	assert.Equal(t, 1, bucketCount(result.Scores, unscored))
+	assert.Equal(t, []Bucket{{Key: "黄仁宇", Count: 2}, {Key: "Yuval Noah Harari", Count: 1}}, result.TopAuthors)
+	assert.Equal(t, Bucket{Key: "history", Count: 3}, result.TopTags[0])
+
+	require.Len(t, result.Years, 2)
+	assert.Equal(t, 2020, result.Years[0].Year)
+	assert.Equal(t, 2021, result.Years[1].Year)
+	assert.Equal(t, 2, result.Years[1].Count)
+	assert.InDelta(t, 7.5, result.Years[1].ReadHours, 0.001)
+}
+
+func TestRunYearComparesWithPreviousYear(t *testing.T) {
+	result, err := Run(writeBooks(t), data.DomainBooks, Options{Year: 2021, Top: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Summary.Count)
+	assert.InDelta(t, 4.5, result.Summary.AvgScore, 0.001)
+	require.NotNil(t, result.Previous)
+	assert.Equal(t, 2020, result.Previous.Year)
+	assert.Equal(t, 1, result.Previous.Count)
+	assert.Equal(t, []Bucket{{Key: "黄仁宇", Count: 2}}, result.TopAuthors)
+	assert.Zero(t, result.UnparsedReadTime)
+
+	out := Markdown(result)
+	assert.Contains(t, out, "books · 2021")
+	assert.Contains(t, out, "Items vs 2020")
+	assert.Contains(t, out, "+1")
+	assert.Contains(t, out, "Year over year")
+}
+
+func TestRunRejectsUnsupportedDomain(t *testing.T) {
+	_, err := Run(t.TempDir(), data.DomainGH, Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+}
+
+func TestRunRejectsMusicYearReview(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "music.yml"), []byte("- name: Kind of Blue\n  score: 5\n  publishAt: \"1959\"\n"), 0o644))
+
+	_, err := Run(dir, data.DomainMusic, Options{Year: 2021})
+	require.ErrorContains(t, err, "data stats music is not supported")
+}
+
+func TestParseReadTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"3", 3, true},
+		{"1.5h", 1.5, true},
+		{"1h30m", 1.5, true},
+		{"90min", 1.5, true},
+		{"3小时", 3, true},
+		{"30分钟", 0.5, true},
+		{"a weekend", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseReadTime(tt.in)
+		assert.Equal(t, tt.ok, ok, tt.in)
+		assert.InDelta(t, tt.want, got, 0.001, tt.in)
+	}
+}
+
+func TestMarkdownWithoutItems(t *testing.T) {
+	result, err := Run(t.TempDir(), data.DomainMovie, Options{Year: 2024})
+	require.NoError(t, err)
+	assert.Contains(t, Markdown(result), "No dated items.")
+}
@@ -205,6 +205,34 @@ func TestRunWikiCheckTopicMismatch(t *testing.T) {
 	assert.True(t, found, "expected topic mismatch issue for terminal-zzz, got: %+v", result.Issues)
 }
 
+func TestRunWikiCheckProposesTopicMoves(t *testing.T) {
+	ghRoot := t.TempDir()
+	wikiRoot := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(ghRoot, "desktop"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(ghRoot, "desktop", "GUI.yml"),
+		[]byte("- type: GUI\n  topics:\n    - topic: terminal\n      kind: type\n    - topic: editor\n      kind: type\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(ghRoot, "desktop", "CLI.yml"), []byte("- type: CLI\n"), 0o600))
+	// "editor" moved from CLI to GUI and already has a wiki dir there; "term" was renamed to "terminal".
+	for _, dir := range []string{"desktop/GUI/term", "desktop/GUI/editor", "desktop/CLI/editor"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(wikiRoot, filepath.FromSlash(dir)), 0o700))
+	}
+
+	result, err := RunWikiCheck(WikiCheckInput{GhRoot: ghRoot, WikiRoot: wikiRoot})
+	require.NoError(t, err)
+	assert.Equal(t, []TopicMove{
+		{From: "desktop/CLI/editor", To: "desktop/GUI/editor", Merge: true},
+		{From: "desktop/GUI/term", To: "desktop/GUI/terminal", Guess: true},
+	}, result.TopicMoves)
+	found := false
+	for _, issue := range result.Issues {
+		if strings.Contains(issue.Message, "possible rename, check before running: docs-cli wiki mv desktop/GUI/term desktop/GUI/terminal") {
+			found = true
+		}
+	}
+	assert.True(t, found, "expected proposed fix in issues, got: %+v", result.Issues)
+}
+
 func TestRunWikiCheckTopicMatch(t *testing.T) {
 	ghRoot := t.TempDir()
 	wikiRoot := t.TempDir()
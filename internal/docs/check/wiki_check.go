@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
 	"slices"
 	"strings"
@@ -26,6 +27,18 @@ type WikiCheckResult struct {
 	ActualWikiDirs   []string
 	MissingWikiDirs  []string
 	ExtraWikiDirs    []string
+	TopicMoves       []TopicMove
+}
+
+// TopicMove is a proposed fix for a wiki topic dir that is not in data/gh
+// topics: move it to To, merging into To when that dir already exists.
+// Guess marks a rename inferred without a name match; it is only reported,
+// never applied by wiki check --fix.
+type TopicMove struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Merge bool   `json:"merge,omitempty"`
+	Guess bool   `json:"guess,omitempty"`
 }
 
 // Summary returns count-oriented wiki check details for structured output.
@@ -35,6 +48,7 @@ func (r *WikiCheckResult) Summary() map[string]any {
 		"actualWikiDirs":   len(r.ActualWikiDirs),
 		"missingWikiDirs":  len(r.MissingWikiDirs),
 		"extraWikiDirs":    len(r.ExtraWikiDirs),
+		"topicMoves":       len(r.TopicMoves),
 	}
 }
 
@@ -63,7 +77,7 @@ func RunWikiCheck(input WikiCheckInput) (*WikiCheckResult, error) {
 	issues := buildWikiIssues(missing, extra)
 
 	// Topic-level validation: wiki depth-3 dirs must match YAML topics.
-	topicIssues, err := checkTopicDirs(input.GhRoot, input.WikiRoot)
+	topicIssues, moves, err := checkTopicDirs(input.GhRoot, input.WikiRoot)
 	if err != nil {
 		return nil, err
 	}
@@ -82,35 +96,106 @@ func RunWikiCheck(input WikiCheckInput) (*WikiCheckResult, error) {
 		ActualWikiDirs:   actualDirs,
 		MissingWikiDirs:  missing,
 		ExtraWikiDirs:    extra,
+		TopicMoves:       moves,
 	}, nil
 }
 
-// checkTopicDirs validates that wiki depth-3 dirs match topics defined in data/gh YAML.
-func checkTopicDirs(ghRoot, wikiRoot string) ([]checkutil.Issue, error) {
+// checkTopicDirs validates that wiki depth-3 dirs match topics defined in data/gh YAML
+// and proposes a move for each stray dir that most likely belongs to a
+// renamed or relocated topic.
+func checkTopicDirs(ghRoot, wikiRoot string) ([]checkutil.Issue, []TopicMove, error) {
 	// Load expected topic paths from data/gh YAML.
 	expectedTopics, err := collectExpectedTopics(ghRoot)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Collect actual wiki depth-3 dirs.
 	actualTopics, err := collectActualTopicDirs(wikiRoot)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var issues []checkutil.Issue
+	var strays []string
 	for _, topic := range actualTopics {
 		if !expectedTopics[topic] {
-			issues = append(issues, checkutil.Issue{
-				File:     topic,
-				Severity: checkutil.SeverityError,
-				Message:  "wiki topic dir not in data/gh topics: " + topic,
-			})
+			strays = append(strays, topic)
+		}
+	}
+	moves := proposeTopicMoves(strays, expectedTopics, toSet(actualTopics))
+
+	var issues []checkutil.Issue
+	for _, topic := range strays {
+		msg := "wiki topic dir not in data/gh topics: " + topic
+		if move, ok := moves[topic]; ok {
+			label := "proposed fix"
+			if move.Guess {
+				label = "possible rename, check before running"
+			}
+			msg += fmt.Sprintf(" (%s: docs-cli wiki %s %s %s)", label, moveCommand(move), move.From, move.To)
+		}
+		issues = append(issues, checkutil.Issue{
+			File:     topic,
+			Severity: checkutil.SeverityError,
+			Message:  msg,
+		})
+	}
+
+	var proposed []TopicMove
+	for _, topic := range strays {
+		if move, ok := moves[topic]; ok {
+			proposed = append(proposed, move)
+		}
+	}
+
+	return issues, proposed, nil
+}
+
+func moveCommand(move TopicMove) string {
+	if move.Merge {
+		return "merge"
+	}
+
+	return "mv"
+}
+
+// proposeTopicMoves maps stray wiki topic dirs onto data/gh topics. A stray
+// dir goes to the only topic with the same name (the topic moved to another
+// folder or type); otherwise, when it is the only stray dir of its type, it is
+// guessed to be the only topic of that type without a wiki dir (the topic was
+// renamed).
+func proposeTopicMoves(strays []string, expected, actual map[string]bool) map[string]TopicMove {
+	byName := map[string][]string{}
+	unwritten := map[string][]string{}
+	for topic := range expected {
+		byName[strings.ToLower(path.Base(topic))] = append(byName[strings.ToLower(path.Base(topic))], topic)
+		if !actual[topic] {
+			unwritten[path.Dir(topic)] = append(unwritten[path.Dir(topic)], topic)
+		}
+	}
+	straysByType := map[string]int{}
+	for _, topic := range strays {
+		straysByType[path.Dir(topic)]++
+	}
+
+	moves := map[string]TopicMove{}
+	for _, topic := range strays {
+		var (
+			target string
+			guess  bool
+		)
+		switch same := byName[strings.ToLower(path.Base(topic))]; {
+		case len(same) == 1:
+			target = same[0]
+		case len(same) == 0 && straysByType[path.Dir(topic)] == 1 && len(unwritten[path.Dir(topic)]) == 1:
+			target, guess = unwritten[path.Dir(topic)][0], true
+		default:
+			continue
 		}
+		moves[topic] = TopicMove{From: topic, To: target, Merge: actual[target], Guess: guess}
 	}
 
-	return issues, nil
+	return moves
 }
 
 // collectExpectedTopics loads topic paths from data/gh YAML files via ghindex.
@@ -27,6 +27,14 @@ func (serviceWriter) WriteManualReviewEntry(
 	return wikiwrite.WriteManualReviewEntry(item, opts)
 }
 
+func (serviceWriter) MergeSummary(
+	item *wikitypes.ClassifyItem,
+	existing wikiwrite.LedgerEntry,
+	opts *wikiwrite.WriteOptions,
+) (string, error) {
+	return wikiwrite.MergeSummary(item, existing, opts)
+}
+
 type serviceInboxStore struct{}
 
 func (serviceInboxStore) ParseInbox(filePath string) ([]wikiwrite.InboxEntry, error) {
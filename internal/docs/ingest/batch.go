@@ -0,0 +1,100 @@
+package wikiingest
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+// batchClassifier is implemented by classifiers that can share one AI
+// request between several short items.
+type batchClassifier interface {
+	ClassifyBatch(ctx context.Context, items []wikiclassify.BatchItem) []*wikitypes.ClassifyResult
+}
+
+// prepareInboxBatched fetches every entry concurrently, then classifies the
+// fetched ones in groups of inboxCfg.batchSize so short items share requests.
+func prepareInboxBatched(
+	ctx context.Context,
+	deps *dependencies,
+	bc batchClassifier,
+	entries []wikiwrite.InboxEntry,
+	inboxCfg inboxConfig,
+	policy ledgerPolicy,
+) []pendingURLWrite {
+	pending := make([]pendingURLWrite, len(entries))
+	fetched := make([]*wikitypes.ContentFetchResult, len(entries))
+	existing := make([]*wikiwrite.LedgerEntry, len(entries))
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(inboxCfg.concurrency)
+	for i, entry := range entries {
+		dup, ex, skip := checkLedger(deps, entry.URL, policy)
+		if skip {
+			pending[i] = dup
+
+			continue
+		}
+		existing[i] = ex
+		g.Go(func() error {
+			urlCtx, cancel := context.WithTimeout(groupCtx, inboxCfg.perURLTimeout)
+			defer cancel()
+
+			result, failed := fetchInboxEntry(urlCtx, deps, entry.URL)
+			if failed != nil {
+				pending[i] = *failed
+			} else {
+				fetched[i] = result
+			}
+
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	ready := lo.Filter(lo.Range(len(entries)), func(i, _ int) bool { return fetched[i] != nil })
+
+	g, groupCtx = errgroup.WithContext(ctx)
+	g.SetLimit(inboxCfg.concurrency)
+	for _, group := range lo.Chunk(ready, inboxCfg.batchSize) {
+		g.Go(func() error {
+			// Items the batch cannot answer are classified one by one, so
+			// the group gets a per-URL budget for each of its members.
+			batchCtx, cancel := context.WithTimeout(groupCtx, inboxCfg.perURLTimeout*time.Duration(len(group)))
+			defer cancel()
+
+			items := lo.Map(group, func(i, _ int) wikiclassify.BatchItem {
+				return wikiclassify.BatchItem{
+					URL:     entries[i].URL,
+					Title:   fetchedTitle(entries[i].URL, fetched[i]),
+					Content: fetched[i].Body,
+					Hint:    entries[i].Hint,
+				}
+			})
+			results := bc.ClassifyBatch(batchCtx, items)
+			for n, i := range group {
+				var classResult *wikitypes.ClassifyResult
+				if n < len(results) {
+					classResult = results[n]
+				}
+				prepared, err := pendingFromClassifyResult(items[n].URL, items[n].Title, items[n].Content, classResult)
+				if err != nil {
+					prepared = pendingFromClassifyError(items[n].URL, err)
+				}
+				prepared.Existing = existing[i]
+				pending[i] = prepared
+			}
+
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return pending
+}
@@ -0,0 +1,100 @@
+package wikiingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
+	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	"github.com/xbpk3t/docs-alfred/pkg/ai"
+)
+
+const batchAnswer = `{"items":[
+{"id":"1","topicPath":"kernel/tool/devops","wikiType":"research","contentType":"text","summary":{"overview":"alpha overview","keyPoints":["a"],"worthNoting":"n"},"confidence":0.9},
+{"id":"2","topicPath":"kernel/tool/devops","wikiType":"research","contentType":"text","summary":{"overview":"beta overview","keyPoints":["b"],"worthNoting":"n"},"confidence":0.9}
+]}`
+
+// offlineConfig lays out <base>/wiki with a sibling data/gh topic tree, an
+// inbox of two short URLs and their cached fetches.
+func offlineConfig(t *testing.T) *Config {
+	t.Helper()
+	base := t.TempDir()
+	wikiRoot := filepath.Join(base, "wiki")
+	ghDir := filepath.Join(base, "data", "gh", "kernel")
+	require.NoError(t, os.MkdirAll(wikiRoot, 0o750))
+	require.NoError(t, os.MkdirAll(ghDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(ghDir, "tool.yml"), []byte("- type: tool\n  topics:\n    - topic: devops\n      kind: type\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(wikiRoot, "inbox.md"), []byte("- https://example.com/a\n- https://example.com/b\n"), 0o600))
+
+	cfg := testConfig(t)
+	cfg.Wiki.WikiRoot = wikiRoot
+	cfg.Wiki.PerURLTimeout = 10
+	cfg.Wiki.Cache = wikiCacheConfig{Dir: filepath.Join(base, "cache"), TTLHours: 1, Enabled: true}
+	cfg.Wiki.Batch = wikiBatchConfig{Size: 2, MaxContent: 4000}
+	cfg.AI.Fixtures = filepath.Join(base, "fixtures")
+
+	cache := wikifetch.NewCache(cfg.Wiki.Cache.Dir, time.Hour)
+	require.NoError(t, cache.Put("https://example.com/a", wikitypes.ContentText, &wikitypes.ContentFetchResult{Title: "A", Body: "alpha body"}))
+	require.NoError(t, cache.Put("https://example.com/b", wikitypes.ContentText, &wikitypes.ContentFetchResult{Title: "B", Body: "beta body"}))
+
+	return cfg
+}
+
+func TestRunDigestRecordThenReplayOffline(t *testing.T) {
+	cfg := offlineConfig(t)
+
+	// Record: a fake model answers the single batch request.
+	var calls atomic.Int32
+	cfg.AI.Mode = wikiclassify.AIModeRecord
+	recorder := wikiclassify.NewClassifier(newAIConfig(cfg), cfg.Wiki.WikiRoot, "",
+		wikiclassify.WithChatFn(func(_ context.Context, _ *ai.ClientConfig, messages []ai.Message) (string, error) {
+			calls.Add(1)
+			require.True(t, strings.Contains(messages[0].Content, "### 条目 2"))
+
+			return batchAnswer, nil
+		}),
+		wikiclassify.WithBatchSize(cfg.Wiki.Batch.Size),
+		wikiclassify.WithAIMode(cfg.AI.Mode, cfg.AI.Fixtures),
+	)
+	recorded, err := RunDigest(context.Background(), DigestInput{Config: cfg, DryRun: true, deps: &dependencies{classifier: recorder}})
+	require.NoError(t, err)
+	require.True(t, recorded.OK())
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Replay: classifier and fetcher come from config; nothing touches the network.
+	cfg.AI.Mode = wikiclassify.AIModeReplay
+	replayed, err := RunDigest(context.Background(), DigestInput{Config: cfg})
+	require.NoError(t, err)
+	require.True(t, replayed.OK())
+	require.Len(t, replayed.URLResults, 2)
+	for _, r := range replayed.URLResults {
+		assert.Equal(t, StatusSummaryWritten, r.Status, r.Error)
+	}
+	summary, err := os.ReadFile(filepath.Join(cfg.Wiki.WikiRoot, "kernel", "tool", "devops", "summary.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(summary), "alpha overview")
+	assert.Contains(t, string(summary), "beta overview")
+	assert.Equal(t, 2, replayed.Flushed)
+}
+
+func TestRunDigestReplayMissingFixtureIsAIError(t *testing.T) {
+	cfg := offlineConfig(t)
+	cfg.AI.Mode = wikiclassify.AIModeReplay
+
+	result, err := RunDigest(context.Background(), DigestInput{Config: cfg, DryRun: true})
+
+	require.NoError(t, err)
+	require.Len(t, result.URLResults, 2)
+	for _, r := range result.URLResults {
+		assert.NotEqual(t, StatusSummaryWritten, r.Status)
+	}
+}
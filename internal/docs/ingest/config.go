@@ -21,6 +21,8 @@ const (
 	StatusUnhandledError = "unhandled_error"
 	StatusDryRunSummary  = "dry_run_summary"
 	StatusDryRunFailure  = "dry_run_failure"
+	// StatusSkippedDuplicate marks a URL already recorded in the URL ledger.
+	StatusSkippedDuplicate = "skipped_duplicate"
 )
 
 // Config holds wiki workflow configuration shared by wiki subcommands.
@@ -69,12 +71,27 @@ type CompactLinearConfig struct {
 
 // WikiConfig contains wiki-specific workflow settings.
 type WikiConfig struct {
-	WikiRoot       string          `default:"wiki"    validate:"required" yaml:"wikiRoot"`
-	Driver         string          `default:"opencli"                     yaml:"driver"`
-	Concurrency    int             `default:"3"       validate:"gte:1"    yaml:"concurrency"`
-	PerURLTimeout  int             `default:"600"     validate:"gte:1"    yaml:"perURLTimeout"`
-	MaxContentSize int             `default:"20000"                       yaml:"maxContentSize"`
-	Media          wikiMediaConfig `yaml:"media"`
+	WikiRoot       string            `default:"wiki"    validate:"required" yaml:"wikiRoot"`
+	Driver         string            `default:"opencli"                     yaml:"driver"`
+	Concurrency    int               `default:"3"       validate:"gte:1"    yaml:"concurrency"`
+	PerURLTimeout  int               `default:"600"     validate:"gte:1"    yaml:"perURLTimeout"`
+	MaxContentSize int               `default:"20000"                       yaml:"maxContentSize"`
+	MaxPDFSizeMB   int               `default:"50"      validate:"gte:1"    yaml:"maxPDFSizeMB"`
+	Media          wikiMediaConfig   `yaml:"media"`
+	Cache          wikiCacheConfig   `yaml:"cache"`
+	Batch          wikiBatchConfig   `yaml:"batch"`
+	Related        wikiRelatedConfig `yaml:"related"`
+	Retry          wikiRetryConfig   `yaml:"retry"`
+	// Routes send matching URLs to another driver than Driver; the first
+	// match wins. Omitted, PDF and arXiv links go to the pdf driver.
+	Routes []wikiDriverRoute `yaml:"routes"`
+}
+
+// wikiDriverRoute routes URLs matching the Match regexp to Driver
+// (opencli, http-readability or pdf).
+type wikiDriverRoute struct {
+	Match  string `validate:"required" yaml:"match"`
+	Driver string `validate:"required" yaml:"driver"`
 }
 
 // wikiMediaConfig controls media content extraction.
@@ -82,6 +99,36 @@ type wikiMediaConfig struct {
 	Enabled bool `default:"true" yaml:"enabled"`
 }
 
+// wikiCacheConfig controls the on-disk fetch cache replayed by wiki reclassify.
+// Dir defaults to the docs-cli wiki-fetch cache directory.
+type wikiCacheConfig struct {
+	Dir      string `yaml:"dir"`
+	TTLHours int    `default:"720"  validate:"gte:1" yaml:"ttlHours"`
+	Enabled  bool   `default:"true" yaml:"enabled"`
+}
+
+// wikiBatchConfig packs short inbox items into one classify request during
+// digest. Size 1 disables batching; items over MaxContent runes go alone.
+type wikiBatchConfig struct {
+	Size       int `default:"1"    validate:"gte:1" yaml:"size"`
+	MaxContent int `default:"4000" validate:"gte:1" yaml:"maxContent"`
+}
+
+// wikiRelatedConfig controls the "related" links added to new summary
+// entries. Limit 0 disables linking.
+type wikiRelatedConfig struct {
+	Limit    int     `default:"3"    validate:"gte:0" yaml:"limit"`
+	MinScore float64 `default:"0.15"                  yaml:"minScore"`
+}
+
+// wikiRetryConfig controls wiki retry: a URL that keeps failing waits
+// BackoffHours, then twice as long after every further attempt, and is
+// reported as a permanent failure after MaxAttempts retries.
+type wikiRetryConfig struct {
+	MaxAttempts  int `default:"5" validate:"gte:1" yaml:"maxAttempts"`
+	BackoffHours int `default:"6" validate:"gte:1" yaml:"backoffHours"`
+}
+
 // AIConfig contains AI model settings.
 // Streaming is owned by pkg/ai.DefaultConfig (true by default); not a YAML knob.
 type AIConfig struct {
@@ -89,6 +136,12 @@ type AIConfig struct {
 	Model       string  `default:"deepseek-v4-flash"       validate:"required"     yaml:"model"`
 	BaseURL     string  `default:"https://api.lucc.dev/v1" validate:"required|url" yaml:"baseUrl"`
 	Temperature float64 `default:"0.3"                     yaml:"temperature"`
+	// Mode selects the chat backend: live, record (save every response as a
+	// fixture) or replay (answer from fixtures and the fetch cache only, so
+	// the pipeline runs without network). Fixtures defaults to the docs-cli
+	// wiki-ai-fixtures cache directory.
+	Mode     string `default:"live" validate:"in:live,record,replay" yaml:"mode"`
+	Fixtures string `yaml:"fixtures"`
 }
 
 // LoadConfig loads wiki config from disk, preserving defaults for omitted fields.
@@ -143,12 +196,14 @@ func defaultConfig() Config {
 
 type inboxConfig struct {
 	concurrency   int
+	batchSize     int
 	perURLTimeout time.Duration
 }
 
 func resolveInboxConfig(cfg *Config) inboxConfig {
 	resolved := inboxConfig{
 		concurrency:   cfg.Wiki.Concurrency,
+		batchSize:     cfg.Wiki.Batch.Size,
 		perURLTimeout: time.Duration(cfg.Wiki.PerURLTimeout) * time.Second,
 	}
 	if resolved.concurrency <= 0 {
@@ -0,0 +1,129 @@
+package wikiingest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/bookmarks"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// importChunkSize is how many URLs are digested between progress saves.
+const importChunkSize = 20
+
+// importProgress lists the URLs of one export that were already handled.
+type importProgress struct {
+	Source string   `json:"source"`
+	Format string   `json:"format"`
+	Done   []string `json:"done"`
+}
+
+// RunImport digests the URLs of a bookmark or read-later export. The folder
+// and tags each URL was filed under are passed to the classifier as a hint.
+func RunImport(ctx context.Context, input ImportInput) (*Result, error) {
+	if input.Config == nil {
+		return nil, errors.New("wiki config is required")
+	}
+	wikiRoot := resolveWikiRoot(input.Config)
+	if err := requireDir(wikiRoot, "wiki root"); err != nil {
+		return nil, err
+	}
+	if err := requireFile(input.Path, "import file"); err != nil {
+		return nil, err
+	}
+
+	items, format, err := bookmarks.ParseFile(input.Path, input.Format)
+	if err != nil {
+		return nil, err
+	}
+	progressPath := input.ProgressPath
+	if progressPath == "" {
+		progressPath, err = defaultImportProgressPath(input.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	progress := loadImportProgress(progressPath, input.Restart)
+	progress.Source, progress.Format = input.Path, format
+
+	result := &Result{Name: "wiki import", WikiRoot: wikiRoot, DryRun: input.DryRun}
+	done := make(map[string]bool, len(progress.Done))
+	for _, u := range progress.Done {
+		done[u] = true
+	}
+	entries := make([]wikiwrite.InboxEntry, 0, len(items))
+	for i, item := range items {
+		if done[item.URL] {
+			result.Resumed++
+
+			continue
+		}
+		entries = append(entries, wikiwrite.InboxEntry{URL: item.URL, LineIndex: i, Hint: item.Hint()})
+	}
+	slog.Info("wiki import: processing entries",
+		"file", input.Path, "format", format, "count", len(entries), "resumed", result.Resumed)
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	deps := resolveDependencies(input.Config, input.deps)
+	inboxCfg := resolveInboxConfig(input.Config)
+	policy := ledgerPolicy{force: input.Force, merge: input.Merge}
+	for start := 0; start < len(entries); start += importChunkSize {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("wiki import interrupted: %w", err)
+		}
+		chunk := entries[start:min(start+importChunkSize, len(entries))]
+		results := runInboxEntries(ctx, deps, wikiRoot, chunk, inboxCfg, policy, input.DryRun)
+		result.URLResults = append(result.URLResults, results...)
+		saveLedger(deps, input.DryRun)
+		if input.DryRun {
+			continue
+		}
+		for i := range results {
+			if results[i].Handled {
+				progress.Done = append(progress.Done, results[i].URL)
+			}
+		}
+		if err := fileutil.AtomicWriteJSONFile(progressPath, progress, fileutil.FilePermPrivate); err != nil {
+			return result, fmt.Errorf("save import progress: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// defaultImportProgressPath keys the progress file by the export's absolute
+// path, so re-running the same file resumes and a different file starts over.
+func defaultImportProgressPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolve import path: %w", err)
+	}
+	sum := sha256.Sum256([]byte(abs))
+
+	return fileutil.CachePath(filepath.Join("docs-cli", "wiki-import", hex.EncodeToString(sum[:8])+".json")), nil
+}
+
+func loadImportProgress(path string, restart bool) *importProgress {
+	if restart {
+		return &importProgress{}
+	}
+	progress, err := fileutil.ReadJSONFile[importProgress](path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			slog.Warn("Ignoring unreadable wiki import progress", "path", path, "error", err)
+		}
+
+		return &importProgress{}
+	}
+
+	return &progress
+}
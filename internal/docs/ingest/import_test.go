@@ -0,0 +1,124 @@
+package wikiingest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+const testBookmarks = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+  <DT><H3>Bookmarks bar</H3>
+  <DL><p>
+    <DT><H3>Databases</H3>
+    <DL><p>
+      <DT><A HREF="https://example.com/a" TAGS="postgres">A</A>
+      <DT><A HREF="https://example.com/b">B</A>
+    </DL><p>
+  </DL><p>
+</DL><p>
+`
+
+// hintClassifier records the hint each URL was classified with.
+type hintClassifier struct {
+	fakeClassifier
+	mu    sync.Mutex
+	hints map[string]string
+}
+
+func (h *hintClassifier) ClassifyItem(ctx context.Context, item wikiclassify.BatchItem) *wikitypes.ClassifyResult {
+	h.mu.Lock()
+	h.hints[item.URL] = item.Hint
+	h.mu.Unlock()
+
+	return h.fakeClassifier.ClassifyItem(ctx, item)
+}
+
+// flakyWriter fails the summary write of one URL.
+type flakyWriter struct {
+	*fakeWriter
+	failURL string
+}
+
+func (w *flakyWriter) WriteSummary(item *wikitypes.ClassifyItem, opts *wikiwrite.WriteOptions) (string, error) {
+	if item.URL == w.failURL {
+		return "", errors.New("disk full")
+	}
+
+	return w.fakeWriter.WriteSummary(item, opts)
+}
+
+func newImportTest(t *testing.T) (*Config, *fakeDeps, *hintClassifier, string) {
+	t.Helper()
+	cfg := testConfig(t)
+	deps := newFakeDeps()
+	classifier := &hintClassifier{fakeClassifier: fakeClassifier{results: map[string]*wikitypes.ClassifyResult{}}, hints: map[string]string{}}
+	for _, u := range []string{"https://example.com/a", "https://example.com/b"} {
+		deps.fetcher.results[u] = &wikitypes.ContentFetchResult{Title: u, Body: "body"}
+		classifier.results[u] = &wikitypes.ClassifyResult{
+			TopicPath:   "db/postgres",
+			WikiType:    wikitypes.TypeDeepDive,
+			ContentType: wikitypes.ContentText,
+			Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+		}
+	}
+	path := filepath.Join(t.TempDir(), "bookmarks.html")
+	require.NoError(t, os.WriteFile(path, []byte(testBookmarks), 0o600))
+
+	return cfg, deps, classifier, path
+}
+
+func TestRunImportPassesFolderHintAndResumes(t *testing.T) {
+	cfg, fake, classifier, path := newImportTest(t)
+	deps := fake.dependencies()
+	deps.classifier = classifier
+	writer := &flakyWriter{fakeWriter: fake.writer, failURL: "https://example.com/b"}
+	deps.writer = writer
+	progressPath := filepath.Join(t.TempDir(), "progress.json")
+
+	result, err := RunImport(context.Background(), ImportInput{Config: cfg, deps: deps, Path: path, ProgressPath: progressPath})
+	require.NoError(t, err)
+	require.Len(t, result.URLResults, 2)
+	assert.Equal(t, StatusSummaryWritten, result.URLResults[0].Status)
+	assert.False(t, result.OK())
+	assert.Equal(t, "folder: Databases; tags: postgres", classifier.hints["https://example.com/a"])
+
+	writer.failURL = ""
+	result, err = RunImport(context.Background(), ImportInput{Config: cfg, deps: deps, Path: path, ProgressPath: progressPath})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Resumed)
+	require.Len(t, result.URLResults, 1)
+	assert.Equal(t, "https://example.com/b", result.URLResults[0].URL)
+	assert.Equal(t, "folder: Databases", classifier.hints["https://example.com/b"])
+	assert.Contains(t, result.Actions()[0], "skipped 1 URL(s)")
+
+	result, err = RunImport(context.Background(), ImportInput{Config: cfg, deps: deps, Path: path, ProgressPath: progressPath, Restart: true})
+	require.NoError(t, err)
+	assert.Zero(t, result.Resumed)
+	assert.Len(t, result.URLResults, 2)
+}
+
+func TestRunImportDryRunLeavesProgressUntouched(t *testing.T) {
+	cfg, fake, _, path := newImportTest(t)
+	progressPath := filepath.Join(t.TempDir(), "progress.json")
+
+	result, err := RunImport(context.Background(), ImportInput{
+		Config: cfg, deps: fake.dependencies(), Path: path, ProgressPath: progressPath, DryRun: true,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.URLResults, 2)
+	assert.NoFileExists(t, progressPath)
+
+	_, err = RunImport(context.Background(), ImportInput{Config: cfg, deps: fake.dependencies(), Path: path, Format: "opml"})
+	require.ErrorContains(t, err, "unsupported import format")
+}
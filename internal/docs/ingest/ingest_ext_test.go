@@ -770,7 +770,7 @@ func TestProcessAddURLFetchFailure(t *testing.T) {
 		FailureKind: wikitypes.FailureResolve,
 	}
 
-	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", false)
+	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", ledgerPolicy{}, false)
 	assert.Equal(t, StatusFailureWritten, result.Status)
 	assert.Equal(t, wikitypes.FailureResolve, result.FailureType)
 }
@@ -780,7 +780,7 @@ func TestProcessAddURLUnhandledError(t *testing.T) {
 	deps.fetcher.results["https://example.com/a"] = nil
 	deps.fetcher.returnNil = true
 
-	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", false)
+	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", ledgerPolicy{}, false)
 	// nil fetch result → fetchFailureError → writePendingURL → failure written
 	assert.Equal(t, StatusFailureWritten, result.Status)
 }
@@ -1034,7 +1034,7 @@ func TestProcessAddURLUnhandledClassifyRetryError(t *testing.T) {
 	}
 	// No classifier result → AI failure JSONL (handled), not unhandled
 
-	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", false)
+	result := processAddURL(context.Background(), deps.dependencies(), t.TempDir(), "https://example.com/a", ledgerPolicy{}, false)
 	assert.Equal(t, StatusFailureWritten, result.Status)
 	assert.True(t, result.Handled)
 	assert.Equal(t, wikitypes.FailureAI, result.FailureType)
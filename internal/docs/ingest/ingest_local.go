@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
 	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
 	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 )
@@ -64,6 +65,7 @@ func RunDigestLocal(ctx context.Context, input DigestLocalInput) (*Result, error
 		urlResult := processLocalDir(ctx, deps, wikiRoot, dirPath)
 		result.URLResults = append(result.URLResults, urlResult)
 	}
+	saveLedger(deps, false)
 
 	return result, nil
 }
@@ -140,7 +142,7 @@ func processLocalDir(ctx context.Context, deps *dependencies, wikiRoot, dirPath
 		return urlResult
 	}
 
-	classResult := deps.classifier.ClassifyURL(ctx, url, inputs.title, inputs.content)
+	classResult := deps.classifier.ClassifyItem(ctx, wikiclassify.BatchItem{URL: url, Title: inputs.title, Content: inputs.content})
 	if classResult == nil {
 		item := &wikitypes.ClassifyItem{
 			URL: url, Title: inputs.title,
@@ -0,0 +1,61 @@
+package wikiingest
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+// ledgerPolicy decides what happens to URLs already recorded in the URL ledger.
+type ledgerPolicy struct {
+	force bool // re-digest and append a new entry
+	merge bool // re-digest and replace the existing entry in place
+}
+
+// checkLedger looks urlStr up in the ledger. It returns a pendingDuplicate
+// write (skip=true) when the URL was digested before and neither --force nor
+// --merge is set; with --merge the existing entry is returned for the write.
+func checkLedger(deps *dependencies, urlStr string, policy ledgerPolicy) (pending pendingURLWrite, existing *wikiwrite.LedgerEntry, skip bool) {
+	if deps.ledger == nil || policy.force {
+		return pendingURLWrite{}, nil, false
+	}
+	entry, ok := deps.ledger.Lookup(urlStr)
+	if !ok {
+		return pendingURLWrite{}, nil, false
+	}
+	if policy.merge {
+		return pendingURLWrite{}, &entry, false
+	}
+	slog.Info("Skipping already digested wiki URL", "url", urlStr, "topic", entry.TopicPath, "date", entry.Date)
+
+	return pendingURLWrite{URL: urlStr, Kind: pendingDuplicate, Existing: &entry}, nil, true
+}
+
+// recordLedger indexes a successful topic summary write.
+func recordLedger(deps *dependencies, pending *pendingURLWrite, result *URLResult) {
+	if deps.ledger == nil || pending.Item == nil || result.Status != StatusSummaryWritten {
+		return
+	}
+	deps.ledger.RecordSummary(pending.Item, result.OutputPath, "")
+}
+
+// saveLedger persists the ledger after a non-dry run.
+func saveLedger(deps *dependencies, dryRun bool) {
+	if deps.ledger == nil || dryRun {
+		return
+	}
+	if err := deps.ledger.Save(); err != nil {
+		slog.Warn("Failed to save wiki URL ledger", "error", err)
+	}
+}
+
+func skippedDuplicateResult(wikiRoot string, pending *pendingURLWrite) URLResult {
+	return URLResult{
+		URL:        pending.URL,
+		Status:     StatusSkippedDuplicate,
+		Handled:    true,
+		OutputPath: filepath.Join(wikiRoot, filepath.FromSlash(pending.Existing.Path)),
+		TopicPath:  pending.Existing.TopicPath,
+	}
+}
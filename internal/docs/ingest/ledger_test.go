@@ -0,0 +1,133 @@
+package wikiingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+func seedDigestedURL(t *testing.T, cfg *Config, urlStr string) {
+	t.Helper()
+	dir := filepath.Join(cfg.Wiki.WikiRoot, "topic", "path")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	content := "## 2026-01-02\n\n### Seen\n\n```markdown\nURL: " + urlStr + "\nType: deep_dive\n```\n\nold summary\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "summary.md"), []byte(content), 0o600))
+}
+
+func TestRunAddURLsSkipsLedgerDuplicates(t *testing.T) {
+	cfg := testConfig(t)
+	seedDigestedURL(t, cfg, "https://example.com/a")
+	deps := newFakeDeps()
+
+	result, err := RunAddURLs(context.Background(), AddInput{
+		Config: cfg,
+		URLs:   []string{"https://Example.com/a/"},
+		deps:   deps.dependencies(),
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.OK())
+	require.Len(t, result.URLResults, 1)
+	got := result.URLResults[0]
+	assert.Equal(t, StatusSkippedDuplicate, got.Status)
+	assert.True(t, got.Handled)
+	assert.Equal(t, "topic/path", got.TopicPath)
+	assert.Equal(t, filepath.Join(cfg.Wiki.WikiRoot, "topic", "path", "summary.md"), got.OutputPath)
+	assert.Empty(t, deps.writer.summaries)
+	assert.Equal(t, 1, result.Summary()["skippedDuplicates"])
+}
+
+func TestRunAddURLsForceAndMerge(t *testing.T) {
+	classified := &wikitypes.ClassifyResult{
+		TopicPath:   "topic/path",
+		WikiType:    wikitypes.TypeDeepDive,
+		ContentType: wikitypes.ContentText,
+		Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+	}
+
+	t.Run("force appends", func(t *testing.T) {
+		cfg := testConfig(t)
+		seedDigestedURL(t, cfg, "https://example.com/a")
+		deps := newFakeDeps()
+		deps.classifier.results["https://example.com/a"] = classified
+
+		result, err := RunAddURLs(context.Background(), AddInput{
+			Config: cfg, URLs: []string{"https://example.com/a"}, Force: true, deps: deps.dependencies(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, StatusSummaryWritten, result.URLResults[0].Status)
+		assert.Len(t, deps.writer.summaries, 1)
+		assert.Empty(t, deps.writer.merges)
+	})
+
+	t.Run("merge updates", func(t *testing.T) {
+		cfg := testConfig(t)
+		seedDigestedURL(t, cfg, "https://example.com/a")
+		deps := newFakeDeps()
+		deps.classifier.results["https://example.com/a"] = classified
+
+		result, err := RunAddURLs(context.Background(), AddInput{
+			Config: cfg, URLs: []string{"https://example.com/a"}, Merge: true, deps: deps.dependencies(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, StatusSummaryWritten, result.URLResults[0].Status)
+		assert.Equal(t, "topic/path", result.URLResults[0].TopicPath)
+		assert.Empty(t, deps.writer.summaries)
+		assert.Len(t, deps.writer.merges, 1)
+	})
+}
+
+func TestRunAddURLsRecordsWritesInLedger(t *testing.T) {
+	cfg := testConfig(t)
+	deps := newFakeDeps()
+	d := deps.dependencies()
+	d.writer = serviceWriter{}
+	d.validTopicPaths = map[string]bool{"topic/path": true}
+	deps.classifier.results["https://example.com/new"] = &wikitypes.ClassifyResult{
+		TopicPath:   "topic/path",
+		WikiType:    wikitypes.TypeDeepDive,
+		ContentType: wikitypes.ContentText,
+		Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+	}
+
+	result, err := RunAddURLs(context.Background(), AddInput{
+		Config: cfg,
+		URLs:   []string{"https://example.com/new", "https://example.com/new/"},
+		deps:   d,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result.URLResults, 2)
+	assert.Equal(t, StatusSummaryWritten, result.URLResults[0].Status)
+	assert.Equal(t, StatusSkippedDuplicate, result.URLResults[1].Status)
+
+	ledger, err := wikiwrite.LoadURLLedger(cfg.Wiki.WikiRoot)
+	require.NoError(t, err)
+	entry, ok := ledger.Lookup("https://example.com/new")
+	require.True(t, ok)
+	assert.Equal(t, "topic/path", entry.TopicPath)
+	assert.FileExists(t, filepath.Join(cfg.Wiki.WikiRoot, wikiwrite.LedgerFilename))
+}
+
+func TestRunDigestFlushesLedgerDuplicates(t *testing.T) {
+	cfg := testConfig(t)
+	seedDigestedURL(t, cfg, "https://example.com/a")
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.Wiki.WikiRoot, "inbox.md"), []byte("- https://example.com/a\n"), 0o600))
+	deps := newFakeDeps()
+	deps.inbox.entries = []wikiwrite.InboxEntry{{URL: "https://example.com/a", LineIndex: 0}}
+
+	result, err := RunDigest(context.Background(), DigestInput{Config: cfg, DryRun: true, deps: deps.dependencies()})
+
+	require.NoError(t, err)
+	assert.Equal(t, StatusSkippedDuplicate, result.URLResults[0].Status)
+	assert.Equal(t, 1, result.WouldFlush)
+	assert.Empty(t, deps.writer.summaries)
+	assert.NoFileExists(t, filepath.Join(cfg.Wiki.WikiRoot, wikiwrite.LedgerFilename))
+}
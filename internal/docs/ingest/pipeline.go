@@ -9,12 +9,18 @@ import (
 
 	"golang.org/x/sync/errgroup"
 
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
 	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
 	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
 )
 
-func processAddURL(ctx context.Context, deps *dependencies, wikiRoot, urlStr string, dryRun bool) URLResult {
+func processAddURL(ctx context.Context, deps *dependencies, wikiRoot, urlStr string, policy ledgerPolicy, dryRun bool) URLResult {
+	dup, existing, skip := checkLedger(deps, urlStr, policy)
+	if skip {
+		return writePendingURL(deps, wikiRoot, &dup, dryRun)
+	}
+
 	pending, err := prepareURLAttempt(ctx, deps, urlStr)
 	if err != nil {
 		var fetchErr *fetchFailureError
@@ -26,6 +32,7 @@ func processAddURL(ctx context.Context, deps *dependencies, wikiRoot, urlStr str
 
 		return URLResult{URL: urlStr, Status: StatusUnhandledError, Error: err.Error()}
 	}
+	pending.Existing = existing
 
 	return writePendingURL(deps, wikiRoot, &pending, dryRun)
 }
@@ -36,8 +43,33 @@ func runInboxEntries(
 	wikiRoot string,
 	entries []wikiwrite.InboxEntry,
 	inboxCfg inboxConfig,
+	policy ledgerPolicy,
 	dryRun bool,
 ) []URLResult {
+	var pending []pendingURLWrite
+	if bc, ok := deps.classifier.(batchClassifier); ok && inboxCfg.batchSize > 1 {
+		pending = prepareInboxBatched(ctx, deps, bc, entries, inboxCfg, policy)
+	} else {
+		pending = prepareInboxEntries(ctx, deps, entries, inboxCfg, policy)
+	}
+
+	results := make([]URLResult, len(entries))
+	for i, prepared := range pending {
+		result := writePendingURL(deps, wikiRoot, &prepared, dryRun)
+		result.LineIndex = entries[i].LineIndex
+		results[i] = result
+	}
+
+	return results
+}
+
+func prepareInboxEntries(
+	ctx context.Context,
+	deps *dependencies,
+	entries []wikiwrite.InboxEntry,
+	inboxCfg inboxConfig,
+	policy ledgerPolicy,
+) []pendingURLWrite {
 	pending := make([]pendingURLWrite, len(entries))
 	var mu sync.Mutex
 
@@ -45,8 +77,15 @@ func runInboxEntries(
 	g.SetLimit(inboxCfg.concurrency)
 
 	for i, entry := range entries {
+		dup, existing, skip := checkLedger(deps, entry.URL, policy)
+		if skip {
+			pending[i] = dup
+
+			continue
+		}
 		g.Go(func() error {
 			prepared := prepareInboxEntry(groupCtx, deps, entry, inboxCfg)
+			prepared.Existing = existing
 
 			mu.Lock()
 			pending[i] = prepared
@@ -57,14 +96,7 @@ func runInboxEntries(
 	}
 	_ = g.Wait()
 
-	results := make([]URLResult, len(entries))
-	for i, prepared := range pending {
-		result := writePendingURL(deps, wikiRoot, &prepared, dryRun)
-		result.LineIndex = entries[i].LineIndex
-		results[i] = result
-	}
-
-	return results
+	return pending
 }
 
 func prepareInboxEntry(
@@ -76,51 +108,72 @@ func prepareInboxEntry(
 	urlCtx, cancel := context.WithTimeout(ctx, inboxCfg.perURLTimeout)
 	defer cancel()
 
-	// Fetch once — content doesn't change between retries, no point re-fetching.
-	slog.Info("Processing wiki URL", "url", entry.URL)
+	fetchResult, failed := fetchInboxEntry(urlCtx, deps, entry.URL)
+	if failed != nil {
+		return *failed
+	}
 
-	contentType := wikifetch.DetectContentType(entry.URL)
-	fetchResult := deps.fetcher.FetchContent(urlCtx, entry.URL, contentType)
+	// Classify using pre-fetched content. Outer retry removed (streaming
+	// bypasses CF 524 timeout, so transient errors are rare). Inner retries
+	// in classifyOnly handle AI call failures.
+	result, classifyErr := classifyURLOnly(urlCtx, deps, entry.URL, entry.Hint, fetchResult)
+	if classifyErr != nil {
+		return pendingFromClassifyError(entry.URL, classifyErr)
+	}
+
+	return result
+}
+
+// fetchInboxEntry fetches an inbox URL once — content doesn't change between
+// retries, no point re-fetching. A non-nil pending write reports why the URL
+// cannot be classified.
+func fetchInboxEntry(ctx context.Context, deps *dependencies, urlStr string) (*wikitypes.ContentFetchResult, *pendingURLWrite) {
+	slog.Info("Processing wiki URL", "url", urlStr)
+
+	contentType := wikifetch.DetectContentType(urlStr)
+	fetchResult := deps.fetcher.FetchContent(ctx, urlStr, contentType)
 	if fetchResult == nil {
-		return newPendingFetchFailure(entry.URL, wikitypes.FailureFetch, "fetch content: empty result")
+		failed := newPendingFetchFailure(urlStr, wikitypes.FailureFetch, "fetch content: empty result")
+
+		return nil, &failed
 	}
 	if fetchResult.Error != "" {
-		return newPendingFetchFailure(entry.URL, failureKindForFetchResult(fetchResult), "fetch content: "+fetchResult.Error)
+		failed := newPendingFetchFailure(urlStr, failureKindForFetchResult(fetchResult), "fetch content: "+fetchResult.Error)
+
+		return nil, &failed
 	}
 
 	// Pre-classification content quality: for video content, require enough
 	// content for meaningful classification (i.e., actually got a transcript).
 	if contentType == wikitypes.ContentVideo && len([]rune(fetchResult.Body)) < 600 {
-		item := &wikitypes.ClassifyItem{URL: entry.URL, Title: fetchResult.Title, ContentType: contentType, Summary: &wikitypes.StructuredSummary{Overview: fetchResult.Body}}
+		item := &wikitypes.ClassifyItem{URL: urlStr, Title: fetchResult.Title, ContentType: contentType, Summary: &wikitypes.StructuredSummary{Overview: fetchResult.Body}}
+		failed := pendingExtractFailureWrite(item, "video content too short (likely no transcript)")
 
-		return pendingExtractFailureWrite(item, "video content too short (likely no transcript)")
+		return nil, &failed
 	}
 
-	// Classify using pre-fetched content. Outer retry removed (streaming
-	// bypasses CF 524 timeout, so transient errors are rare). Inner retries
-	// in classifyOnly handle AI call failures.
-	result, classifyErr := classifyURLOnly(urlCtx, deps, entry.URL, fetchResult)
-	if classifyErr != nil {
-		var cerr *classifyRetryError
-		if errors.As(classifyErr, &cerr) {
-			// Content was fetched but AI classify failed. Log as AI error JSONL
-			// (inbox still flushes; human re-adds URL). Do not dump raw into uncat.
-			msg := "AI classify unavailable after retries"
-			if cerr.message != "" {
-				msg = msg + ": " + cerr.message
-			}
-
-			return pendingURLWrite{
-				URL:   entry.URL,
-				Kind:  pendingAIError,
-				Error: msg,
-			}
+	return fetchResult, nil
+}
+
+// pendingFromClassifyError maps a classifyURLOnly error to its pending write.
+func pendingFromClassifyError(urlStr string, classifyErr error) pendingURLWrite {
+	var cerr *classifyRetryError
+	if errors.As(classifyErr, &cerr) {
+		// Content was fetched but AI classify failed. Log as AI error JSONL
+		// (inbox still flushes; human re-adds URL). Do not dump raw into uncat.
+		msg := "AI classify unavailable after retries"
+		if cerr.message != "" {
+			msg = msg + ": " + cerr.message
 		}
 
-		return newPendingUnhandled(entry.URL, classifyErr.Error())
+		return pendingURLWrite{
+			URL:   urlStr,
+			Kind:  pendingAIError,
+			Error: msg,
+		}
 	}
 
-	return result
+	return newPendingUnhandled(urlStr, classifyErr.Error())
 }
 
 type pendingWriteKind string
@@ -132,12 +185,14 @@ const (
 	pendingFetchFailure    pendingWriteKind = "fetch_failure"
 	pendingAIError         pendingWriteKind = "ai_error"
 	pendingUnhandled       pendingWriteKind = "unhandled"
+	pendingDuplicate       pendingWriteKind = "duplicate"
 )
 
 type pendingURLWrite struct {
 	URL         string
 	Kind        pendingWriteKind
 	Item        *wikitypes.ClassifyItem
+	Existing    *wikiwrite.LedgerEntry // ledger entry to merge into, or the duplicate that was skipped
 	FailureType wikitypes.FailureKind
 	ExtraInfo   string
 	Error       string
@@ -173,7 +228,7 @@ func prepareURLAttempt(ctx context.Context, deps *dependencies, urlStr string) (
 		return pendingExtractFailureWrite(item, "video content too short (likely no transcript)"), nil
 	}
 
-	classResult := deps.classifier.ClassifyURL(ctx, urlStr, title, content)
+	classResult := deps.classifier.ClassifyItem(ctx, wikiclassify.BatchItem{URL: urlStr, Title: title, Content: content})
 	if classResult == nil {
 		// Distinguish: empty content is a permanent classify failure (content-side issue);
 		// non-empty content with nil classifier means AI call failed (transient).
@@ -201,16 +256,18 @@ func prepareURLAttempt(ctx context.Context, deps *dependencies, urlStr string) (
 }
 
 // classifyURLOnly runs only the AI classification step using pre-fetched content.
-// Used by prepareInboxEntry to avoid re-fetching on retry.
-func classifyURLOnly(ctx context.Context, deps *dependencies, urlStr string, fetchResult *wikitypes.ContentFetchResult) (pendingURLWrite, error) {
-	title := fetchResult.Title
-	if title == "" {
-		title = urlStr
-	}
+// Used by prepareInboxEntry to avoid re-fetching on retry; hint is the inbox
+// entry's classification hint, if any.
+func classifyURLOnly(ctx context.Context, deps *dependencies, urlStr, hint string, fetchResult *wikitypes.ContentFetchResult) (pendingURLWrite, error) {
+	title := fetchedTitle(urlStr, fetchResult)
+	classResult := deps.classifier.ClassifyItem(ctx, wikiclassify.BatchItem{URL: urlStr, Title: title, Content: fetchResult.Body, Hint: hint})
 
-	content := fetchResult.Body
+	return pendingFromClassifyResult(urlStr, title, fetchResult.Body, classResult)
+}
 
-	classResult := deps.classifier.ClassifyURL(ctx, urlStr, title, content)
+// pendingFromClassifyResult turns a classifier result into the pending write
+// for urlStr. A nil result on non-empty content is a classifyRetryError.
+func pendingFromClassifyResult(urlStr, title, content string, classResult *wikitypes.ClassifyResult) (pendingURLWrite, error) {
 	if classResult == nil {
 		item := &wikitypes.ClassifyItem{URL: urlStr, Title: title, ContentType: wikifetch.DetectContentType(urlStr), Summary: &wikitypes.StructuredSummary{Overview: content}}
 		if strings.TrimSpace(content) == "" {
@@ -231,6 +288,14 @@ func classifyURLOnly(ctx context.Context, deps *dependencies, urlStr string, fet
 	return pendingURLWrite{URL: urlStr, Kind: pendingSummary, Item: item}, nil
 }
 
+func fetchedTitle(urlStr string, fetchResult *wikitypes.ContentFetchResult) string {
+	if fetchResult.Title == "" {
+		return urlStr
+	}
+
+	return fetchResult.Title
+}
+
 func classifyItemFromResult(urlStr, title string, classResult *wikitypes.ClassifyResult) *wikitypes.ClassifyItem {
 	return &wikitypes.ClassifyItem{
 		URL:               urlStr,
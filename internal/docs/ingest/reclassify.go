@@ -0,0 +1,94 @@
+package wikiingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+)
+
+// RunReclassify replays classification and writing from the fetch cache,
+// without fetching again. URLs already in the URL ledger are merged into
+// their existing entry; others are written as new entries.
+func RunReclassify(ctx context.Context, input ReclassifyInput) (*Result, error) {
+	if input.Config == nil {
+		return nil, errors.New("wiki config is required")
+	}
+	if input.Since.IsZero() && input.Topic == "" {
+		return nil, errors.New("--since or --topic is required")
+	}
+	wikiRoot := resolveWikiRoot(input.Config)
+	if err := requireDir(wikiRoot, "wiki root"); err != nil {
+		return nil, err
+	}
+
+	deps := resolveDependencies(input.Config, input.deps)
+	if deps.cache == nil {
+		return nil, errors.New("fetch cache is disabled (wiki.cache.enabled)")
+	}
+	entries, err := deps.cache.List(input.Since)
+	if err != nil {
+		return nil, err
+	}
+	if input.Topic != "" {
+		entries, err = filterCacheByTopic(deps, entries, input.Topic)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{Name: "wiki reclassify", WikiRoot: wikiRoot, DryRun: input.DryRun}
+	slog.Info("wiki reclassify: replaying cached content", "count", len(entries))
+	inboxCfg := resolveInboxConfig(input.Config)
+	for i := range entries {
+		pending := reclassifyCached(ctx, deps, &entries[i], inboxCfg)
+		result.URLResults = append(result.URLResults, writePendingURL(deps, wikiRoot, &pending, input.DryRun))
+	}
+	saveLedger(deps, input.DryRun)
+
+	return result, nil
+}
+
+func reclassifyCached(ctx context.Context, deps *dependencies, entry *wikifetch.CacheEntry, inboxCfg inboxConfig) pendingURLWrite {
+	urlCtx, cancel := context.WithTimeout(ctx, inboxCfg.perURLTimeout)
+	defer cancel()
+
+	slog.Info("Reclassifying cached wiki URL", "url", entry.URL, "fetchedAt", entry.FetchedAt)
+	pending, err := classifyURLOnly(urlCtx, deps, entry.URL, "", &entry.Result)
+	if err != nil {
+		var cerr *classifyRetryError
+		if errors.As(err, &cerr) {
+			return newPendingAIError(entry.URL, "AI classify unavailable after retries: "+cerr.message)
+		}
+
+		return newPendingUnhandled(entry.URL, err.Error())
+	}
+	if deps.ledger != nil {
+		if existing, ok := deps.ledger.Lookup(entry.URL); ok {
+			pending.Existing = &existing
+		}
+	}
+
+	return pending
+}
+
+// filterCacheByTopic keeps cached URLs whose ledger entry lives under topic.
+func filterCacheByTopic(deps *dependencies, entries []wikifetch.CacheEntry, topic string) ([]wikifetch.CacheEntry, error) {
+	if deps.ledger == nil {
+		return nil, fmt.Errorf("--topic needs the URL ledger, which is unavailable")
+	}
+	topic = strings.Trim(topic, "/")
+
+	var kept []wikifetch.CacheEntry
+	for _, e := range entries {
+		le, ok := deps.ledger.Lookup(e.URL)
+		if ok && (le.TopicPath == topic || strings.HasPrefix(le.TopicPath, topic+"/")) {
+			kept = append(kept, e)
+		}
+	}
+
+	return kept, nil
+}
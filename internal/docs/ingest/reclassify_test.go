@@ -0,0 +1,76 @@
+package wikiingest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+func TestRunReclassifyReplaysCachedContent(t *testing.T) {
+	cfg := testConfig(t)
+	seedDigestedURL(t, cfg, "https://example.com/a")
+	cache := wikifetch.NewCache(t.TempDir(), time.Hour)
+	require.NoError(t, cache.Put("https://example.com/a", wikitypes.ContentText, &wikitypes.ContentFetchResult{Title: "A", Body: "cached a"}))
+	require.NoError(t, cache.Put("https://example.com/b", wikitypes.ContentText, &wikitypes.ContentFetchResult{Title: "B", Body: "cached b"}))
+
+	deps := newFakeDeps()
+	deps.fetcher.returnNil = true // any fetch would fail the test below
+	for _, u := range []string{"https://example.com/a", "https://example.com/b"} {
+		deps.classifier.results[u] = &wikitypes.ClassifyResult{
+			TopicPath:   "topic/path",
+			WikiType:    wikitypes.TypeDeepDive,
+			ContentType: wikitypes.ContentText,
+			Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+		}
+	}
+	d := deps.dependencies()
+	d.cache = cache
+
+	result, err := RunReclassify(context.Background(), ReclassifyInput{
+		Config: cfg,
+		Since:  time.Now().Add(-time.Minute),
+		deps:   d,
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.OK())
+	require.Len(t, result.URLResults, 2)
+	assert.Equal(t, StatusSummaryWritten, result.URLResults[0].Status)
+	assert.Equal(t, StatusSummaryWritten, result.URLResults[1].Status)
+	assert.Len(t, deps.writer.merges, 1, "ledger URL is merged in place")
+	assert.Len(t, deps.writer.summaries, 1, "new URL is appended")
+}
+
+func TestRunReclassifyTopicFilter(t *testing.T) {
+	cfg := testConfig(t)
+	seedDigestedURL(t, cfg, "https://example.com/a")
+	cache := wikifetch.NewCache(t.TempDir(), time.Hour)
+	require.NoError(t, cache.Put("https://example.com/a", wikitypes.ContentText, &wikitypes.ContentFetchResult{Body: "a"}))
+	require.NoError(t, cache.Put("https://example.com/b", wikitypes.ContentText, &wikitypes.ContentFetchResult{Body: "b"}))
+
+	deps := newFakeDeps()
+	d := deps.dependencies()
+	d.cache = cache
+
+	result, err := RunReclassify(context.Background(), ReclassifyInput{Config: cfg, Topic: "topic/", DryRun: true, deps: d})
+
+	require.NoError(t, err)
+	require.Len(t, result.URLResults, 1)
+	assert.Equal(t, "https://example.com/a", result.URLResults[0].URL)
+}
+
+func TestRunReclassifyValidation(t *testing.T) {
+	cfg := testConfig(t)
+
+	_, err := RunReclassify(context.Background(), ReclassifyInput{Config: cfg})
+	require.ErrorContains(t, err, "--since or --topic")
+
+	_, err = RunReclassify(context.Background(), ReclassifyInput{Config: cfg, Topic: "x", deps: newFakeDeps().dependencies()})
+	require.ErrorContains(t, err, "fetch cache is disabled")
+}
@@ -0,0 +1,37 @@
+package wikiingest
+
+import (
+	"path/filepath"
+
+	wikirelated "github.com/xbpk3t/docs-alfred/internal/docs/wiki/related"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+// linkRelated attaches the closest existing entries to item before it is written.
+func linkRelated(deps *dependencies, item *wikitypes.ClassifyItem) {
+	if deps.related == nil || deps.relatedLimit <= 0 || item == nil {
+		return
+	}
+	matches := deps.related.Query(wikirelated.ItemText(item), deps.relatedLimit, item.URL)
+	item.Related = wikirelated.ToRelatedEntries(matches)
+}
+
+// indexRelated adds a topic summary write to the index so later items of the
+// same run can link to it.
+func indexRelated(deps *dependencies, wikiRoot string, pending *pendingURLWrite, result *URLResult) {
+	if deps.related == nil || pending.Item == nil || !result.Handled || filepath.Base(result.OutputPath) != wikiwrite.SummaryFilename {
+		return
+	}
+	rel, err := filepath.Rel(wikiRoot, result.OutputPath)
+	if err != nil {
+		return
+	}
+	doc := wikirelated.Document{
+		URL:       pending.Item.URL,
+		Title:     pending.Item.Title,
+		TopicPath: result.TopicPath,
+		Path:      filepath.ToSlash(rel),
+	}
+	deps.related.Add(doc, wikirelated.ItemText(pending.Item))
+}
@@ -0,0 +1,50 @@
+package wikiingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+func TestRunAddURLsLinksRelatedEntries(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Wiki.Related = wikiRelatedConfig{Limit: 3, MinScore: 0.1}
+	deps := newFakeDeps()
+	results := map[string]string{
+		"https://example.com/raft":  "Raft consensus keeps replicated logs consistent across nodes",
+		"https://example.com/paxos": "Paxos consensus compared with Raft for replicated logs",
+	}
+	for u, overview := range results {
+		deps.fetcher.results[u] = &wikitypes.ContentFetchResult{Title: filepath.Base(u), Body: overview}
+		deps.classifier.results[u] = &wikitypes.ClassifyResult{
+			TopicPath:   "topic/path",
+			WikiType:    wikitypes.TypeDeepDive,
+			ContentType: wikitypes.ContentText,
+			Summary:     &wikitypes.StructuredSummary{Overview: overview, KeyPoints: []string{"consensus"}},
+		}
+	}
+	d := deps.dependencies()
+	d.writer = nil
+	d.validTopicPaths = map[string]bool{"topic/path": true}
+
+	result, err := RunAddURLs(context.Background(), AddInput{
+		Config: cfg,
+		URLs:   []string{"https://example.com/raft", "https://example.com/paxos"},
+		deps:   d,
+	})
+
+	require.NoError(t, err)
+	require.True(t, result.OK())
+	data, err := os.ReadFile(filepath.Join(cfg.Wiki.WikiRoot, "topic", "path", "summary.md"))
+	require.NoError(t, err)
+	paxos := string(data)[strings.Index(string(data), "### paxos"):]
+	assert.Contains(t, paxos, "#### related\n- [raft](https://example.com/raft) · topic/path")
+	assert.Equal(t, 1, strings.Count(string(data), "#### related"), "the first entry had nothing to link")
+}
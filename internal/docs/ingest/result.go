@@ -2,24 +2,62 @@ package wikiingest
 
 import (
 	"fmt"
+	"time"
 
 	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
 )
 
 // AddInput contains inputs for wiki URL ingestion.
+//
+// URLs already recorded in the URL ledger are skipped unless Force
+// (re-digest and append a new entry) or Merge (re-digest and replace the
+// existing entry in place) is set.
 type AddInput struct {
 	Config *Config
 	deps   *dependencies
 	URLs   []string
 	DryRun bool
+	Force  bool
+	Merge  bool
 }
 
 // DigestInput contains inputs for wiki digest processing.
+// Force and Merge behave as in AddInput.
 type DigestInput struct {
 	Config *Config
 	deps   *dependencies
 	DryRun bool
+	Force  bool
+	Merge  bool
+}
+
+// ImportInput reads a bookmark or read-later export (see package bookmarks)
+// and digests its URLs like inbox entries. Format is detected when empty.
+// Progress is recorded in ProgressPath (a per-file cache path by default) so
+// an interrupted import resumes where it stopped; Restart ignores it.
+// Force and Merge behave as in AddInput.
+type ImportInput struct {
+	Config       *Config
+	deps         *dependencies
+	Path         string
+	Format       string
+	ProgressPath string
+	DryRun       bool
+	Force        bool
+	Merge        bool
+	Restart      bool
+}
+
+// ReclassifyInput selects cached fetches to classify and write again.
+// At least one of Since (fetched at or after) and Topic (ledger topic path,
+// including sub-topics) is required.
+type ReclassifyInput struct {
+	Since  time.Time
+	Config *Config
+	deps   *dependencies
+	Topic  string
+	DryRun bool
 }
 
 // AuditInput contains inputs for read-only wiki auditing.
@@ -68,6 +106,7 @@ type Result struct {
 	URLResults []URLResult `json:"urls"`
 	Flushed    int         `json:"flushed"`
 	WouldFlush int         `json:"wouldFlush"`
+	Resumed    int         `json:"resumed,omitempty"` // URLs skipped as imported by an earlier run
 	DryRun     bool        `json:"dryRun"`
 }
 
@@ -87,7 +126,7 @@ type URLResult struct {
 
 // Summary returns count-oriented command details for structured output.
 func (r *Result) Summary() map[string]any {
-	var succeeded, handledFailures, unhandledFailures, written int
+	var succeeded, handledFailures, unhandledFailures, skipped, written int
 	for i := range r.URLResults {
 		item := &r.URLResults[i]
 		switch item.Status {
@@ -97,22 +136,30 @@ func (r *Result) Summary() map[string]any {
 			handledFailures++
 		case StatusUnhandledError:
 			unhandledFailures++
+		case StatusSkippedDuplicate:
+			skipped++
 		}
 		if item.OutputPath != "" && (item.Status == StatusSummaryWritten || item.Status == StatusFailureWritten) {
 			written++
 		}
 	}
 
-	return map[string]any{
+	summary := map[string]any{
 		"processed":         len(r.URLResults),
 		"succeeded":         succeeded,
 		"handledFailures":   handledFailures,
 		"unhandledFailures": unhandledFailures,
+		"skippedDuplicates": skipped,
 		"written":           written,
 		"flushed":           r.Flushed,
 		"wouldFlush":        r.WouldFlush,
 		"dryRun":            r.DryRun,
 	}
+	if r.Resumed > 0 {
+		summary["resumed"] = r.Resumed
+	}
+
+	return summary
 }
 
 // OK reports whether the workflow had no unhandled URL-level failures.
@@ -130,6 +177,9 @@ func (r *Result) OK() bool {
 // Actions returns command actions for human-readable output.
 func (r *Result) Actions() []string {
 	var actions []string
+	if r.Resumed > 0 {
+		actions = append(actions, fmt.Sprintf("resumed: skipped %d URL(s) imported by an earlier run", r.Resumed))
+	}
 	if r.DryRun {
 		actions = append(actions, "dry-run: skipped wiki writes")
 		if r.WouldFlush > 0 {
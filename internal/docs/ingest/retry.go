@@ -0,0 +1,343 @@
+package wikiingest
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// RetryStateFilename records retry attempts per URL under the wiki root.
+const RetryStateFilename = "digest-retry.json"
+
+// Retry kinds accepted by RetryInput.Kinds. Resolve failures retry as fetch.
+var RetryKinds = []string{
+	string(wikitypes.FailureFetch),
+	string(wikitypes.FailureExtract),
+	string(wikitypes.FailureClassify),
+	string(wikitypes.FailureAI),
+}
+
+// RetryInput selects failed URLs from the digest logs to digest again.
+//
+// Kinds limits the failure kinds (default: all RetryKinds); OlderThan skips
+// URLs whose latest failure is more recent. MaxAttempts overrides
+// wiki.retry.maxAttempts when positive.
+type RetryInput struct {
+	Config      *Config
+	deps        *dependencies
+	now         func() time.Time
+	Kinds       []string
+	OlderThan   time.Duration
+	MaxAttempts int
+	DryRun      bool
+}
+
+// RetryRecord is the retry history of one failed URL.
+type RetryRecord struct {
+	LastAttempt time.Time `json:"lastAttempt"`
+	NextAttempt time.Time `json:"nextAttempt"`
+	URL         string    `json:"url"`
+	FailureKind string    `json:"failureKind"`
+	Error       string    `json:"error,omitempty"`
+	Attempts    int       `json:"attempts"`
+	Permanent   bool      `json:"permanent,omitempty"`
+}
+
+type retryState struct {
+	Records map[string]*RetryRecord `json:"records"` // keyed by urlutil.Normalize
+}
+
+// RetryResult extends Result with the retry queue outcome.
+type RetryResult struct {
+	*Result
+	Resolved  []string      `json:"resolved"`  // URLs removed from the failure logs
+	Permanent []RetryRecord `json:"permanent"` // URLs given up on
+	Deferred  int           `json:"deferred"`  // failures too recent or still backing off
+}
+
+// Summary adds the retry queue counts to Result.Summary.
+func (r *RetryResult) Summary() map[string]any {
+	summary := r.Result.Summary()
+	summary["resolved"] = len(r.Resolved)
+	summary["permanent"] = len(r.Permanent)
+	summary["deferred"] = r.Deferred
+
+	return summary
+}
+
+// Actions adds the resolved and permanent failures to Result.Actions.
+func (r *RetryResult) Actions() []string {
+	actions := r.Result.Actions()
+	if len(r.Resolved) > 0 {
+		verb := "removed"
+		if r.DryRun {
+			verb = "dry-run: would remove"
+		}
+		actions = append(actions, fmt.Sprintf("%s %d resolved URL(s) from the failure logs", verb, len(r.Resolved)))
+	}
+	if r.Deferred > 0 {
+		actions = append(actions, fmt.Sprintf("deferred %d failure(s) still backing off", r.Deferred))
+	}
+	for _, rec := range r.Permanent {
+		actions = append(actions, fmt.Sprintf("permanent failure after %d attempt(s): %s (%s) %s",
+			rec.Attempts, rec.URL, rec.FailureKind, rec.Error))
+	}
+
+	return actions
+}
+
+// failedURL is the latest failure logged for a URL.
+type failedURL struct {
+	at    time.Time
+	entry wikitypes.DigestEntry
+}
+
+// RunRetry digests failed URLs again. A URL that succeeds, or was digested
+// since it failed, is removed from the failure logs. A URL that fails again
+// backs off exponentially and is reported as permanent after MaxAttempts.
+// Retried URLs are fetched again rather than served from the fetch cache.
+func RunRetry(ctx context.Context, input RetryInput) (*RetryResult, error) {
+	if input.Config == nil {
+		return nil, errors.New("wiki config is required")
+	}
+	wikiRoot := resolveWikiRoot(input.Config)
+	if err := requireDir(wikiRoot, "wiki root"); err != nil {
+		return nil, err
+	}
+	kinds, err := retryKindSet(input.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now
+	if input.now != nil {
+		now = input.now
+	}
+	maxAttempts := cmp.Or(input.MaxAttempts, input.Config.Wiki.Retry.MaxAttempts, 5)
+	backoff := time.Duration(cmp.Or(input.Config.Wiki.Retry.BackoffHours, 6)) * time.Hour
+
+	logged, err := wikiwrite.ReadDigestEntries(wikiRoot)
+	if err != nil {
+		return nil, err
+	}
+	failed, succeeded := latestDigestOutcomes(logged)
+	statePath := filepath.Join(wikiRoot, RetryStateFilename)
+	state, err := loadRetryState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := resolveDependencies(input.Config, input.deps)
+	result := &RetryResult{Result: &Result{Name: "wiki retry", WikiRoot: wikiRoot, DryRun: input.DryRun}}
+	resolved := map[string]bool{}
+	var entries []wikiwrite.InboxEntry
+	for _, key := range sortedFailureKeys(failed) {
+		f := failed[key]
+		if at, ok := succeeded[key]; ok && !at.Before(f.at) || ledgerHas(deps, f.entry.URL) {
+			resolved[key] = true
+
+			continue
+		}
+		if !kinds[retryKind(f.entry.FailureKind)] {
+			continue
+		}
+		rec := state.Records[key]
+		switch {
+		case rec != nil && rec.Permanent:
+			result.Permanent = append(result.Permanent, *rec)
+		case input.OlderThan > 0 && now().Sub(f.at) < input.OlderThan,
+			rec != nil && now().Before(rec.NextAttempt):
+			result.Deferred++
+		default:
+			entries = append(entries, wikiwrite.InboxEntry{URL: f.entry.URL, LineIndex: len(entries)})
+		}
+	}
+
+	if !input.DryRun {
+		evictRetriedFetches(input.Config, deps, entries)
+	}
+
+	slog.Info("wiki retry: processing failed URLs", "count", len(entries), "resolved", len(resolved), "deferred", result.Deferred)
+	if len(entries) > 0 {
+		inboxCfg := resolveInboxConfig(input.Config)
+		result.URLResults = runInboxEntries(ctx, deps, wikiRoot, entries, inboxCfg, ledgerPolicy{}, input.DryRun)
+		saveLedger(deps, input.DryRun)
+	}
+
+	for i := range result.URLResults {
+		item := &result.URLResults[i]
+		key := urlutil.Normalize(item.URL)
+		switch item.Status {
+		case StatusSummaryWritten, StatusSkippedDuplicate, StatusDryRunSummary:
+			resolved[key] = true
+		default:
+			rec := recordRetryFailure(state, key, item, failed[key], now(), backoff, maxAttempts)
+			if rec.Permanent {
+				result.Permanent = append(result.Permanent, *rec)
+			}
+		}
+	}
+	for key := range resolved {
+		result.Resolved = append(result.Resolved, resolvedURL(key, failed))
+	}
+	slices.Sort(result.Resolved)
+	if input.DryRun {
+		return result, nil
+	}
+
+	if len(resolved) > 0 {
+		if _, err := wikiwrite.RemoveDigestFailures(wikiRoot, resolved); err != nil {
+			return result, fmt.Errorf("remove resolved failures: %w", err)
+		}
+		for key := range resolved {
+			delete(state.Records, key)
+		}
+	}
+	if err := fileutil.AtomicWriteJSONFile(statePath, state, fileutil.FilePermPrivate); err != nil {
+		return result, fmt.Errorf("save retry state: %w", err)
+	}
+
+	return result, nil
+}
+
+// evictRetriedFetches drops the cached fetch of every retried URL. A fetch
+// can succeed, be cached and still fail extraction (empty or short video
+// content); without eviction each retry would replay that same body. Replay
+// mode keeps the cache, which is all it has.
+func evictRetriedFetches(cfg *Config, deps *dependencies, entries []wikiwrite.InboxEntry) {
+	if deps.cache == nil || cfg.AI.Mode == wikiclassify.AIModeReplay {
+		return
+	}
+	for _, entry := range entries {
+		if err := deps.cache.Delete(entry.URL); err != nil {
+			slog.Warn("wiki retry: cached fetch kept", "url", entry.URL, "error", err)
+		}
+	}
+}
+
+func retryKindSet(kinds []string) (map[string]bool, error) {
+	if len(kinds) == 0 {
+		kinds = RetryKinds
+	}
+	set := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		if !slices.Contains(RetryKinds, kind) {
+			return nil, fmt.Errorf("unknown failure kind %q (want one of %v)", kind, RetryKinds)
+		}
+		set[kind] = true
+	}
+
+	return set, nil
+}
+
+func retryKind(failureKind string) string {
+	if failureKind == string(wikitypes.FailureResolve) {
+		return string(wikitypes.FailureFetch)
+	}
+
+	return failureKind
+}
+
+// latestDigestOutcomes returns the latest failure and latest success time
+// per normalized URL.
+func latestDigestOutcomes(entries []wikitypes.DigestEntry) (map[string]failedURL, map[string]time.Time) {
+	failed := map[string]failedURL{}
+	succeeded := map[string]time.Time{}
+	for _, entry := range entries {
+		at, _ := time.Parse(time.RFC3339, entry.Timestamp)
+		key := urlutil.Normalize(entry.URL)
+		if entry.Status == wikitypes.DigestSuccess {
+			if at.After(succeeded[key]) {
+				succeeded[key] = at
+			}
+
+			continue
+		}
+		if prev, ok := failed[key]; !ok || !at.Before(prev.at) {
+			failed[key] = failedURL{at: at, entry: entry}
+		}
+	}
+
+	return failed, succeeded
+}
+
+func sortedFailureKeys(failed map[string]failedURL) []string {
+	keys := make([]string, 0, len(failed))
+	for key := range failed {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(failed[a].at.Compare(failed[b].at), cmp.Compare(a, b))
+	})
+
+	return keys
+}
+
+func ledgerHas(deps *dependencies, urlStr string) bool {
+	if deps.ledger == nil {
+		return false
+	}
+	_, ok := deps.ledger.Lookup(urlStr)
+
+	return ok
+}
+
+func resolvedURL(key string, failed map[string]failedURL) string {
+	if f, ok := failed[key]; ok {
+		return f.entry.URL
+	}
+
+	return key
+}
+
+// recordRetryFailure counts a failed attempt and schedules the next one:
+// backoff, then doubling after every further attempt.
+func recordRetryFailure(
+	state *retryState,
+	key string,
+	item *URLResult,
+	prev failedURL,
+	now time.Time,
+	backoff time.Duration,
+	maxAttempts int,
+) *RetryRecord {
+	rec := state.Records[key]
+	if rec == nil {
+		rec = &RetryRecord{URL: item.URL}
+		state.Records[key] = rec
+	}
+	rec.Attempts++
+	rec.LastAttempt = now
+	rec.NextAttempt = now.Add(backoff << min(rec.Attempts-1, 16))
+	rec.FailureKind = cmp.Or(string(item.FailureType), prev.entry.FailureKind)
+	rec.Error = cmp.Or(item.Error, prev.entry.Error)
+	rec.Permanent = rec.Attempts >= maxAttempts
+
+	return rec
+}
+
+func loadRetryState(path string) (*retryState, error) {
+	state, err := fileutil.ReadJSONFile[retryState](path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("load retry state: %w", err)
+		}
+		state = retryState{}
+	}
+	if state.Records == nil {
+		state.Records = map[string]*RetryRecord{}
+	}
+
+	return &state, nil
+}
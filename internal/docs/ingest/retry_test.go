@@ -0,0 +1,185 @@
+package wikiingest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+func writeDigestLog(t *testing.T, wikiRoot, name string, entries ...wikitypes.DigestEntry) {
+	t.Helper()
+	var b strings.Builder
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		require.NoError(t, err)
+		b.Write(line)
+		b.WriteString("\n")
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(wikiRoot, name), []byte(b.String()), 0o600))
+}
+
+func newRetryTest(t *testing.T, now time.Time) (*Config, *fakeDeps) {
+	t.Helper()
+	cfg := testConfig(t)
+	root := cfg.Wiki.WikiRoot
+	failedAt := now.Add(-48 * time.Hour).Format(time.RFC3339)
+	failure := func(url string, kind wikitypes.FailureKind) wikitypes.DigestEntry {
+		return wikitypes.DigestEntry{
+			Timestamp: failedAt, URL: url, Status: wikitypes.DigestFailure, FailureKind: string(kind), Error: "boom",
+		}
+	}
+	writeDigestLog(t, root, "digest-fetch-error.jsonl",
+		failure("https://example.com/a", wikitypes.FailureFetch),
+		failure("https://example.com/c", wikitypes.FailureResolve))
+	writeDigestLog(t, root, "digest-ai-error.jsonl", failure("https://example.com/b", wikitypes.FailureAI))
+	writeDigestLog(t, root, "digest-success.jsonl", wikitypes.DigestEntry{
+		Timestamp: now.Add(-time.Hour).Format(time.RFC3339), URL: "https://example.com/c", Status: wikitypes.DigestSuccess,
+	})
+
+	fake := newFakeDeps()
+	fake.classifier.results["https://example.com/a"] = &wikitypes.ClassifyResult{
+		TopicPath:   "db/postgres",
+		WikiType:    wikitypes.TypeDeepDive,
+		ContentType: wikitypes.ContentText,
+		Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+	}
+
+	return cfg, fake
+}
+
+func TestRunRetryResolvesAndBacksOff(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cfg, fake := newRetryTest(t, now)
+	root := cfg.Wiki.WikiRoot
+	input := RetryInput{Config: cfg, deps: fake.dependencies(), now: func() time.Time { return now }, MaxAttempts: 2}
+
+	result, err := RunRetry(context.Background(), input)
+	require.NoError(t, err)
+	assert.Len(t, result.URLResults, 2)
+	assert.Equal(t, []string{"https://example.com/a", "https://example.com/c"}, result.Resolved)
+	assert.Empty(t, result.Permanent)
+
+	fetchLog, err := os.ReadFile(filepath.Join(root, "digest-fetch-error.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, string(fetchLog))
+	assert.FileExists(t, filepath.Join(root, "digest-ai-error.jsonl"))
+
+	state, err := loadRetryState(filepath.Join(root, RetryStateFilename))
+	require.NoError(t, err)
+	require.Contains(t, state.Records, "https://example.com/b")
+	rec := state.Records["https://example.com/b"]
+	assert.Equal(t, 1, rec.Attempts)
+	assert.Equal(t, now.Add(6*time.Hour), rec.NextAttempt)
+
+	// Still backing off.
+	result, err = RunRetry(context.Background(), input)
+	require.NoError(t, err)
+	assert.Empty(t, result.URLResults)
+	assert.Equal(t, 1, result.Deferred)
+
+	// Second failure reaches MaxAttempts.
+	later := now.Add(7 * time.Hour)
+	input.now = func() time.Time { return later }
+	result, err = RunRetry(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, result.Permanent, 1)
+	assert.Equal(t, 2, result.Permanent[0].Attempts)
+	assert.Contains(t, strings.Join(result.Actions(), "\n"), "permanent failure after 2 attempt(s): https://example.com/b")
+
+	result, err = RunRetry(context.Background(), input)
+	require.NoError(t, err)
+	assert.Empty(t, result.URLResults)
+	assert.Len(t, result.Permanent, 1)
+}
+
+func TestRunRetryFiltersAndDryRun(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cfg, fake := newRetryTest(t, now)
+	root := cfg.Wiki.WikiRoot
+	clock := func() time.Time { return now }
+
+	result, err := RunRetry(context.Background(), RetryInput{
+		Config: cfg, deps: fake.dependencies(), now: clock, Kinds: []string{"fetch"}, DryRun: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.URLResults, 1)
+	assert.Equal(t, StatusDryRunSummary, result.URLResults[0].Status)
+	assert.Contains(t, result.Actions(), "dry-run: would remove 2 resolved URL(s) from the failure logs")
+	fetchLog, err := os.ReadFile(filepath.Join(root, "digest-fetch-error.jsonl"))
+	require.NoError(t, err)
+	assert.Contains(t, string(fetchLog), "https://example.com/a")
+	assert.NoFileExists(t, filepath.Join(root, RetryStateFilename))
+
+	result, err = RunRetry(context.Background(), RetryInput{
+		Config: cfg, deps: fake.dependencies(), now: clock, Kinds: []string{"extract"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.URLResults)
+
+	result, err = RunRetry(context.Background(), RetryInput{
+		Config: cfg, deps: fake.dependencies(), now: clock, OlderThan: 72 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.URLResults)
+	assert.Equal(t, 2, result.Deferred)
+
+	_, err = RunRetry(context.Background(), RetryInput{Config: cfg, deps: fake.dependencies(), Kinds: []string{"nope"}})
+	require.ErrorContains(t, err, "unknown failure kind")
+}
+
+// countingDriver returns body for every URL and counts its fetches.
+type countingDriver struct {
+	body  string
+	calls int
+}
+
+func (d *countingDriver) Name() string { return "counting" }
+
+func (d *countingDriver) FetchContent(_ context.Context, urlStr, _ string) *wikitypes.ContentFetchResult {
+	d.calls++
+
+	return &wikitypes.ContentFetchResult{Title: urlStr, Body: d.body}
+}
+
+func TestRunRetryRefetchesCachedExtractFailure(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	cfg := testConfig(t)
+	root := cfg.Wiki.WikiRoot
+	const url = "https://example.com/empty"
+	writeDigestLog(t, root, "digest-extract-error.jsonl", wikitypes.DigestEntry{
+		Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339), URL: url,
+		Status: wikitypes.DigestFailure, FailureKind: string(wikitypes.FailureExtract), Error: "extraction failed: empty content",
+	})
+
+	cache := wikifetch.NewCache(t.TempDir(), 0)
+	require.NoError(t, cache.Put(url, wikitypes.ContentText, &wikitypes.ContentFetchResult{Title: url}))
+	driver := &countingDriver{body: "the page body"}
+	fake := newFakeDeps()
+	fake.classifier.results[url] = &wikitypes.ClassifyResult{
+		TopicPath:   "db/postgres",
+		WikiType:    wikitypes.TypeDeepDive,
+		ContentType: wikitypes.ContentText,
+		Summary:     &wikitypes.StructuredSummary{Overview: "summary"},
+	}
+	deps := fake.dependencies()
+	deps.cache = cache
+	deps.fetcher = wikifetch.NewFetcher(wikifetch.WithDriver(driver), wikifetch.WithCache(cache))
+
+	result, err := RunRetry(context.Background(), RetryInput{Config: cfg, deps: deps, now: func() time.Time { return now }})
+	require.NoError(t, err)
+	assert.Equal(t, 1, driver.calls)
+	assert.Equal(t, []string{url}, result.Resolved)
+	entry, err := cache.Load(url)
+	require.NoError(t, err)
+	assert.Equal(t, "the page body", entry.Result.Body)
+}
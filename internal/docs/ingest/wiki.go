@@ -7,10 +7,12 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"time"
 
 	wikiaudit "github.com/xbpk3t/docs-alfred/internal/docs/wiki/audit"
 	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
 	wikifetch "github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+	wikirelated "github.com/xbpk3t/docs-alfred/internal/docs/wiki/related"
 	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
 	"github.com/xbpk3t/docs-alfred/pkg/ai"
@@ -26,7 +28,11 @@ type dependencies struct {
 	classifier      classifier
 	writer          writer
 	inbox           inboxStore
-	validTopicPaths map[string]bool // loaded from ghindex for write-layer validation
+	ledger          *wikiwrite.URLLedger // URLs already digested into topic summaries
+	cache           *wikifetch.Cache     // fetched content, replayed by RunReclassify
+	related         *wikirelated.Index   // similarity index linking new entries to earlier ones
+	relatedLimit    int                  // related links per entry; 0 disables linking
+	validTopicPaths map[string]bool      // loaded from ghindex for write-layer validation
 }
 
 type fetcher interface {
@@ -34,7 +40,7 @@ type fetcher interface {
 }
 
 type classifier interface {
-	ClassifyURL(ctx context.Context, urlStr, title, content string) *wikitypes.ClassifyResult
+	ClassifyItem(ctx context.Context, item wikiclassify.BatchItem) *wikitypes.ClassifyResult
 }
 
 type writer interface {
@@ -49,6 +55,11 @@ type writer interface {
 		item *wikitypes.ClassifyItem,
 		opts *wikiwrite.WriteOptions,
 	) (string, error)
+	MergeSummary(
+		item *wikitypes.ClassifyItem,
+		existing wikiwrite.LedgerEntry,
+		opts *wikiwrite.WriteOptions,
+	) (string, error)
 }
 
 type inboxStore interface {
@@ -71,11 +82,13 @@ func RunAddURLs(ctx context.Context, input AddInput) (*Result, error) {
 
 	deps := resolveDependencies(input.Config, input.deps)
 	result := &Result{Name: "wiki add", WikiRoot: wikiRoot, DryRun: input.DryRun}
+	policy := ledgerPolicy{force: input.Force, merge: input.Merge}
 
 	for _, urlStr := range input.URLs {
-		itemResult := processAddURL(ctx, deps, wikiRoot, urlStr, input.DryRun)
+		itemResult := processAddURL(ctx, deps, wikiRoot, urlStr, policy, input.DryRun)
 		result.URLResults = append(result.URLResults, itemResult)
 	}
+	saveLedger(deps, input.DryRun)
 
 	return result, nil
 }
@@ -111,7 +124,9 @@ func RunDigest(ctx context.Context, input DigestInput) (*Result, error) {
 
 	slog.Info("wiki digest: processing entries", "count", len(entries))
 	inboxCfg := resolveInboxConfig(input.Config)
-	result.URLResults = runInboxEntries(ctx, deps, wikiRoot, entries, inboxCfg, input.DryRun)
+	policy := ledgerPolicy{force: input.Force, merge: input.Merge}
+	result.URLResults = runInboxEntries(ctx, deps, wikiRoot, entries, inboxCfg, policy, input.DryRun)
+	saveLedger(deps, input.DryRun)
 
 	processed := handledURLsByLine(result.URLResults)
 	if len(processed) == 0 {
@@ -222,30 +237,56 @@ func requireFile(path, label string) error {
 	return nil
 }
 
+// newContentDriver builds the configured driver with its URL routes, falling
+// back to a plain opencli driver when the driver or a route is invalid.
+func newContentDriver(cfg *Config) wikifetch.ContentDriver {
+	driverName := cfg.Wiki.Driver
+	if driverName == "" {
+		driverName = wikifetch.DriverOpenCLI
+	}
+	routes := wikifetch.DefaultRoutes()
+	if cfg.Wiki.Routes != nil {
+		routes = make([]wikifetch.DriverRoute, 0, len(cfg.Wiki.Routes))
+		for _, r := range cfg.Wiki.Routes {
+			routes = append(routes, wikifetch.DriverRoute{Match: r.Match, Driver: r.Driver})
+		}
+	}
+	opts := wikifetch.DriverOptions{
+		MaxBodySize:  cfg.Wiki.MaxContentSize,
+		MediaEnabled: cfg.Wiki.Media.Enabled,
+		MaxPDFSize:   cfg.Wiki.MaxPDFSizeMB << 20,
+	}
+
+	driver, err := wikifetch.NewRoutedDriver(driverName, routes, opts)
+	if err != nil {
+		slog.Warn("Invalid driver config, falling back to opencli", "driver", driverName, "error", err)
+		driver, _ = wikifetch.NewDriver(wikifetch.DriverOpenCLI, opts)
+	}
+
+	return driver
+}
+
 func resolveDependencies(cfg *Config, deps *dependencies) *dependencies {
 	if deps == nil {
 		deps = &dependencies{}
 	}
+	replay := cfg.AI.Mode == wikiclassify.AIModeReplay
+	if deps.cache == nil && (cfg.Wiki.Cache.Enabled || replay) {
+		deps.cache = wikifetch.NewCache(cfg.Wiki.Cache.Dir, time.Duration(cfg.Wiki.Cache.TTLHours)*time.Hour)
+	}
 	if deps.fetcher == nil {
-		driverName := cfg.Wiki.Driver
-		if driverName == "" {
-			driverName = "opencli"
-		}
-		driver, err := wikifetch.NewDriver(driverName, wikifetch.DriverOptions{
-			MaxBodySize:  cfg.Wiki.MaxContentSize,
-			MediaEnabled: cfg.Wiki.Media.Enabled,
-		})
-		if err != nil {
-			slog.Warn("Unknown driver, falling back to opencli", "driver", driverName, "error", err)
-			driver, _ = wikifetch.NewDriver("opencli", wikifetch.DriverOptions{
-				MaxBodySize:  cfg.Wiki.MaxContentSize,
-				MediaEnabled: cfg.Wiki.Media.Enabled,
-			})
-		}
-		deps.fetcher = wikifetch.NewFetcher(
+		driver := newContentDriver(cfg)
+		opts := []wikifetch.FetcherOption{
 			wikifetch.WithDriver(driver),
 			wikifetch.WithMediaEnabled(cfg.Wiki.Media.Enabled),
-		)
+		}
+		if deps.cache != nil {
+			opts = append(opts, wikifetch.WithCache(deps.cache))
+		}
+		if replay {
+			opts = append(opts, wikifetch.WithCacheOnly())
+		}
+		deps.fetcher = wikifetch.NewFetcher(opts...)
 	}
 	if deps.classifier == nil {
 		deps.classifier = wikiclassify.NewClassifier(
@@ -253,6 +294,9 @@ func resolveDependencies(cfg *Config, deps *dependencies) *dependencies {
 			resolveWikiRoot(cfg),
 			"",
 			wikiclassify.WithMaxContentSize(cfg.Wiki.MaxContentSize),
+			wikiclassify.WithBatchSize(cfg.Wiki.Batch.Size),
+			wikiclassify.WithBatchMaxContent(cfg.Wiki.Batch.MaxContent),
+			wikiclassify.WithAIMode(cfg.AI.Mode, cfg.AI.Fixtures),
 		)
 	}
 	if deps.writer == nil {
@@ -261,6 +305,26 @@ func resolveDependencies(cfg *Config, deps *dependencies) *dependencies {
 	if deps.inbox == nil {
 		deps.inbox = serviceInboxStore{}
 	}
+	if deps.ledger == nil {
+		ledger, err := wikiwrite.LoadURLLedger(resolveWikiRoot(cfg))
+		if err != nil {
+			slog.Warn("Wiki URL ledger unavailable, duplicate URLs will not be detected", "error", err)
+		} else {
+			deps.ledger = ledger
+		}
+	}
+	if deps.relatedLimit == 0 {
+		deps.relatedLimit = cfg.Wiki.Related.Limit
+	}
+	if deps.related == nil && deps.relatedLimit > 0 {
+		index, err := wikirelated.Build(resolveWikiRoot(cfg))
+		if err != nil {
+			slog.Warn("Wiki related index unavailable, entries will not be linked", "error", err)
+		} else {
+			index.MinScore = cfg.Wiki.Related.MinScore
+			deps.related = index
+		}
+	}
 	if deps.validTopicPaths == nil {
 		deps.validTopicPaths = wikiwrite.LoadValidTopicPaths(resolveWikiRoot(cfg))
 	}
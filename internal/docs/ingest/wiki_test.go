@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
 	wikitypes "github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
 	"github.com/xbpk3t/docs-alfred/pkg/cmdutil"
@@ -519,8 +520,8 @@ type fakeClassifier struct {
 	results map[string]*wikitypes.ClassifyResult
 }
 
-func (f *fakeClassifier) ClassifyURL(_ context.Context, urlStr, _, _ string) *wikitypes.ClassifyResult {
-	return f.results[urlStr]
+func (f *fakeClassifier) ClassifyItem(_ context.Context, item wikiclassify.BatchItem) *wikitypes.ClassifyResult {
+	return f.results[item.URL]
 }
 
 type fakeWriter struct {
@@ -528,6 +529,7 @@ type fakeWriter struct {
 	failureErr error
 	summaries  []writeCall
 	failures   []failureCall
+	merges     []writeCall
 }
 
 type writeCall struct {
@@ -573,6 +575,19 @@ func (f *fakeWriter) WriteManualReviewEntry(item *wikitypes.ClassifyItem, opts *
 	return filepath.Join(opts.WikiRoot, "uncat.md"), nil
 }
 
+func (f *fakeWriter) MergeSummary(
+	item *wikitypes.ClassifyItem,
+	existing wikiwrite.LedgerEntry,
+	opts *wikiwrite.WriteOptions,
+) (string, error) {
+	if f.summaryErr != nil {
+		return "", f.summaryErr
+	}
+	f.merges = append(f.merges, writeCall{url: item.URL, dryRun: opts.DryRun})
+
+	return filepath.Join(opts.WikiRoot, existing.Path), nil
+}
+
 type fakeInbox struct {
 	parseErr error
 	flushErr error
@@ -15,7 +15,19 @@ func writePendingURL(deps *dependencies, wikiRoot string, pending *pendingURLWri
 	}
 	switch pending.Kind {
 	case pendingSummary:
-		return writeSummary(deps, wikiRoot, pending.Item, dryRun)
+		linkRelated(deps, pending.Item)
+		var result URLResult
+		if pending.Existing != nil {
+			result = mergeSummary(deps, wikiRoot, pending.Item, *pending.Existing, dryRun)
+		} else {
+			result = writeSummary(deps, wikiRoot, pending.Item, dryRun)
+		}
+		recordLedger(deps, pending, &result)
+		indexRelated(deps, wikiRoot, pending, &result)
+
+		return result
+	case pendingDuplicate:
+		return skippedDuplicateResult(wikiRoot, pending)
 	case pendingClassifyFailure:
 		return writeClassifyFailure(deps, wikiRoot, pending.Item, pending.ExtraInfo, dryRun)
 	case pendingExtractFailure:
@@ -107,6 +119,30 @@ func writeSummary(deps *dependencies, wikiRoot string, item *wikitypes.ClassifyI
 	}
 }
 
+// mergeSummary replaces the ledger's existing entry for item instead of
+// appending a second one.
+func mergeSummary(deps *dependencies, wikiRoot string, item *wikitypes.ClassifyItem, existing wikiwrite.LedgerEntry, dryRun bool) URLResult {
+	path, err := deps.writer.MergeSummary(item, existing, newWriteOpts(deps, wikiRoot, dryRun))
+	if err != nil {
+		return URLResult{URL: item.URL, Status: StatusUnhandledError, Error: fmt.Sprintf("merge summary: %v", err)}
+	}
+
+	status := StatusSummaryWritten
+	if dryRun {
+		status = StatusDryRunSummary
+	}
+
+	return URLResult{
+		URL:         item.URL,
+		Status:      status,
+		Handled:     true,
+		OutputPath:  path,
+		TopicPath:   existing.TopicPath,
+		WikiType:    string(item.Type),
+		ContentType: item.ContentType,
+	}
+}
+
 // canWriteTopicDespiteNMR is a write-layer safety net: if item still has NMR but
 // carries a ValidTopicPaths path + overview, promote to topic write.
 // Also performs fuzzy resolve for the item in-place.
@@ -0,0 +1,338 @@
+// Package bookmarks reads browser bookmark and read-later exports into URL
+// items with the folder and tags they were filed under.
+package bookmarks
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/net/html"
+
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// Supported export formats. Parse detects the format when none is given.
+const (
+	FormatNetscape   = "netscape"   // browser bookmark HTML (Chrome, Firefox, Safari, Pinboard)
+	FormatPocket     = "pocket"     // Pocket CSV: title,url,time_added,tags,status
+	FormatInstapaper = "instapaper" // Instapaper CSV: URL,Title,Selection,Folder,Timestamp
+	FormatReadwise   = "readwise"   // Readwise Reader CSV: Title,URL,...,Document tags,...
+	FormatCSV        = "csv"        // any CSV with a url column
+	FormatURLs       = "urls"       // plain text, one or more URLs per line, "# folder" headings
+)
+
+// utf8BOM prefixes CSV files saved by spreadsheet tools.
+const utf8BOM = "\uFEFF"
+
+// Item is one imported URL.
+type Item struct {
+	URL    string   `json:"url"`
+	Title  string   `json:"title,omitempty"`
+	Folder string   `json:"folder,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// genericFolders are container folders that say nothing about the topic.
+var genericFolders = map[string]bool{
+	"bookmarks":         true,
+	"bookmarks bar":     true,
+	"bookmarks toolbar": true,
+	"bookmarks menu":    true,
+	"other bookmarks":   true,
+	"mobile bookmarks":  true,
+	"favorites":         true,
+	"favorites bar":     true,
+	"书签栏":               true,
+	"其他书签":              true,
+	"unread":            true,
+	"archive":           true,
+	"starred":           true,
+}
+
+// Hint describes where the user filed the item, for the classifier. It is
+// empty when the item has neither a meaningful folder nor tags.
+func (it Item) Hint() string {
+	var parts []string
+	if it.Folder != "" {
+		parts = append(parts, "folder: "+it.Folder)
+	}
+	if len(it.Tags) > 0 {
+		parts = append(parts, "tags: "+strings.Join(it.Tags, ", "))
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// ParseFile reads path with Parse.
+func ParseFile(path, format string) ([]Item, string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	return Parse(data, format)
+}
+
+// Parse reads an export in format, detecting it when format is empty, and
+// returns its http(s) items deduplicated by URL together with the format used.
+func Parse(data []byte, format string) ([]Item, string, error) {
+	if format == "" {
+		format = Detect(data)
+	}
+
+	var (
+		items []Item
+		err   error
+	)
+	switch format {
+	case FormatNetscape:
+		items, err = parseNetscape(data)
+	case FormatPocket, FormatInstapaper, FormatReadwise, FormatCSV:
+		items, err = parseCSV(data)
+	case FormatURLs:
+		items = parseURLList(data)
+	default:
+		return nil, "", fmt.Errorf("unsupported import format %q", format)
+	}
+	if err != nil {
+		return nil, format, fmt.Errorf("parse %s export: %w", format, err)
+	}
+
+	return dedupe(items), format, nil
+}
+
+// Detect guesses the export format from the file content.
+func Detect(data []byte) string {
+	head := strings.ToLower(string(data[:min(len(data), 2048)]))
+	if strings.Contains(head, "netscape-bookmark-file") || strings.Contains(head, "<dl") {
+		return FormatNetscape
+	}
+	firstLine, _, _ := strings.Cut(strings.TrimPrefix(head, utf8BOM), "\n")
+	header := csvHeader(firstLine)
+	switch {
+	case header["time_added"] && header["url"]:
+		return FormatPocket
+	case header["selection"] && header["url"]:
+		return FormatInstapaper
+	case header["document tags"] && header["url"]:
+		return FormatReadwise
+	case header["url"]:
+		return FormatCSV
+	default:
+		return FormatURLs
+	}
+}
+
+func csvHeader(line string) map[string]bool {
+	fields, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil || len(fields) < 2 {
+		return nil
+	}
+	header := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		header[strings.TrimSpace(strings.ToLower(f))] = true
+	}
+
+	return header
+}
+
+// parseNetscape walks the <DL> nesting of a bookmark export; each <H3>
+// names the <DL> that follows it.
+func parseNetscape(data []byte) ([]Item, error) {
+	z := html.NewTokenizer(bytes.NewReader(data))
+	var (
+		items    []Item
+		folders  []string
+		pending  string
+		cur      *Item
+		inFolder bool
+	)
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if errors.Is(z.Err(), io.EOF) {
+				return items, nil
+			}
+
+			return nil, z.Err()
+		case html.StartTagToken:
+			tok := z.Token()
+			switch tok.Data {
+			case "h3":
+				inFolder, pending = true, ""
+			case "dl":
+				folders = append(folders, pending)
+				pending = ""
+			case "a":
+				cur = &Item{URL: attr(tok, "href"), Folder: folderPath(folders), Tags: splitList(attr(tok, "tags"), ",")}
+			}
+		case html.TextToken:
+			text := string(z.Text())
+			switch {
+			case inFolder:
+				pending += text
+			case cur != nil:
+				cur.Title += text
+			}
+		case html.EndTagToken:
+			switch z.Token().Data {
+			case "h3":
+				inFolder, pending = false, strings.TrimSpace(pending)
+			case "dl":
+				if len(folders) > 0 {
+					folders = folders[:len(folders)-1]
+				}
+			case "a":
+				if cur != nil {
+					cur.Title = strings.TrimSpace(cur.Title)
+					items = append(items, *cur)
+					cur = nil
+				}
+			}
+		}
+	}
+}
+
+func attr(tok html.Token, name string) string {
+	for _, a := range tok.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+
+	return ""
+}
+
+// folderPath joins folder names, dropping generic containers.
+func folderPath(folders []string) string {
+	var parts []string
+	for _, f := range folders {
+		if f = strings.TrimSpace(f); f != "" && !genericFolders[strings.ToLower(f)] {
+			parts = append(parts, f)
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// parseCSV reads any CSV export by header name: url, title, folder and
+// tags (Pocket separates tags with "|", Readwise writes "['a', 'b']").
+func parseCSV(data []byte) ([]Item, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte(utf8BOM))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	col := map[string]int{}
+	for i, name := range records[0] {
+		col[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+	urlCol, ok := col["url"]
+	if !ok {
+		return nil, errors.New("no url column")
+	}
+	field := func(rec []string, names ...string) string {
+		for _, name := range names {
+			if i, ok := col[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+		}
+
+		return ""
+	}
+
+	var items []Item
+	for _, rec := range records[1:] {
+		if urlCol >= len(rec) {
+			continue
+		}
+		items = append(items, Item{
+			URL:    strings.TrimSpace(rec[urlCol]),
+			Title:  field(rec, "title"),
+			Folder: folderPath([]string{field(rec, "folder")}),
+			Tags:   parseTags(field(rec, "tags", "document tags")),
+		})
+	}
+
+	return items, nil
+}
+
+func parseTags(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "[") {
+		raw = strings.NewReplacer("[", "", "]", "", "'", "", `"`, "").Replace(raw)
+
+		return splitList(raw, ",")
+	}
+	if strings.Contains(raw, "|") {
+		return splitList(raw, "|")
+	}
+
+	return splitList(raw, ",")
+}
+
+func splitList(raw, sep string) []string {
+	var out []string
+	for _, s := range strings.Split(raw, sep) {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// parseURLList reads every URL of a plain text list; a "# heading" line
+// names the folder of the URLs below it.
+func parseURLList(data []byte) []Item {
+	var (
+		items  []Item
+		folder string
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if heading, ok := strings.CutPrefix(trimmed, "#"); ok && !strings.Contains(trimmed, "://") {
+			folder = folderPath([]string{strings.TrimLeft(heading, "# ")})
+
+			continue
+		}
+		refs := urlutil.ExtractURLRefs(line, urlutil.ExtractOptions{BareURLs: true, HTTPOnly: true, Normalize: true})
+		for _, ref := range refs {
+			items = append(items, Item{URL: ref.URL, Folder: folder})
+		}
+	}
+
+	return items
+}
+
+// dedupe cleans URLs, drops non-http ones and keeps the first of duplicates.
+func dedupe(items []Item) []Item {
+	seen := make(map[string]bool, len(items))
+	out := make([]Item, 0, len(items))
+	for _, it := range items {
+		it.URL = urlutil.CleanHTTPURL(it.URL)
+		if it.URL == "" {
+			continue
+		}
+		key := urlutil.Normalize(it.URL)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, it)
+	}
+
+	return out
+}
@@ -0,0 +1,101 @@
+package bookmarks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetscapeKeepsFolderPathAndTags(t *testing.T) {
+	data := []byte(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<DL><p>
+  <DT><H3>Bookmarks bar</H3>
+  <DL><p>
+    <DT><H3>Dev</H3>
+    <DL><p>
+      <DT><H3>Go</H3>
+      <DL><p>
+        <DT><A HREF="https://go.dev/blog/" TAGS="go,blog">Go Blog</A>
+      </DL><p>
+      <DT><A HREF="https://example.com/dev">Dev &amp; Ops</A>
+    </DL><p>
+    <DT><A HREF="javascript:void(0)">bookmarklet</A>
+    <DT><A HREF="https://example.com/top">Top</A>
+    <DT><A HREF="https://go.dev/blog">Go Blog again</A>
+  </DL><p>
+</DL><p>
+`)
+
+	items, format, err := Parse(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, FormatNetscape, format)
+	assert.Equal(t, []Item{
+		{URL: "https://go.dev/blog/", Title: "Go Blog", Folder: "Dev/Go", Tags: []string{"go", "blog"}},
+		{URL: "https://example.com/dev", Title: "Dev & Ops", Folder: "Dev"},
+		{URL: "https://example.com/top", Title: "Top"},
+	}, items)
+	assert.Equal(t, "folder: Dev/Go; tags: go, blog", items[0].Hint())
+	assert.Empty(t, items[2].Hint())
+}
+
+func TestParseCSVExports(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		format string
+		want   Item
+	}{
+		{
+			name:   "pocket",
+			data:   "title,url,time_added,tags,status\nRAG,https://example.com/rag,1700000000,ai|rag,unread\n",
+			format: FormatPocket,
+			want:   Item{URL: "https://example.com/rag", Title: "RAG", Tags: []string{"ai", "rag"}},
+		},
+		{
+			name:   "instapaper",
+			data:   utf8BOM + "URL,Title,Selection,Folder,Timestamp\nhttps://example.com/k8s,K8s,,Kubernetes,1700000000\n",
+			format: FormatInstapaper,
+			want:   Item{URL: "https://example.com/k8s", Title: "K8s", Folder: "Kubernetes"},
+		},
+		{
+			name:   "readwise",
+			data:   "Title,URL,ID,Document tags,Saved date\nDB,https://example.com/db,1,\"['postgres', 'sql']\",2026-01-01\n",
+			format: FormatReadwise,
+			want:   Item{URL: "https://example.com/db", Title: "DB", Tags: []string{"postgres", "sql"}},
+		},
+		{
+			name:   "generic",
+			data:   "url,folder\nhttps://example.com/x,Archive\n",
+			format: FormatCSV,
+			want:   Item{URL: "https://example.com/x"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			items, format, err := Parse([]byte(tc.data), "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, format)
+			assert.Equal(t, []Item{tc.want}, items)
+		})
+	}
+
+	_, _, err := Parse([]byte("title,link\na,b\n"), FormatCSV)
+	require.ErrorContains(t, err, "no url column")
+}
+
+func TestParseURLListUsesHeadingsAsFolders(t *testing.T) {
+	data := []byte("https://example.com/a\n\n# Networking\n- see https://example.com/b and https://example.com/c\n")
+
+	items, format, err := Parse(data, "")
+	require.NoError(t, err)
+	assert.Equal(t, FormatURLs, format)
+	assert.Equal(t, []Item{
+		{URL: "https://example.com/a"},
+		{URL: "https://example.com/b", Folder: "Networking"},
+		{URL: "https://example.com/c", Folder: "Networking"},
+	}, items)
+
+	_, _, err = Parse(data, "opml")
+	require.ErrorContains(t, err, `unsupported import format "opml"`)
+}
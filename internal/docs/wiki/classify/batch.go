@@ -0,0 +1,219 @@
+package classify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/samber/lo"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/fetch"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/prompt"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
+	"github.com/xbpk3t/docs-alfred/pkg/ai"
+)
+
+// defaultBatchMaxContent is the largest item (in runes) sent in a batch.
+const defaultBatchMaxContent = 4000
+
+// BatchItem is one fetched URL handed to ClassifyBatch.
+type BatchItem struct {
+	URL     string
+	Title   string
+	Content string
+	// Hint is a user clue for the classification, such as the bookmark
+	// folder and tags the URL was imported from. The topic must still come
+	// from the candidates.
+	Hint string
+}
+
+type batchPromptItem struct {
+	ID          string
+	URL         string
+	Title       string
+	ContentType string
+	Content     string
+	Hint        string
+}
+
+type batchPromptData struct {
+	CandidateTree string
+	Items         []batchPromptItem
+}
+
+type batchClassifyResponse struct {
+	Items []batchClassifyItem `json:"items"`
+}
+
+type batchClassifyItem struct {
+	ID string `json:"id"`
+	classifyOnlyResult
+}
+
+// WithBatchSize sets how many short items ClassifyBatch sends per AI request.
+func WithBatchSize(n int) ClassifierOption {
+	return func(c *Classifier) { c.BatchSize = n }
+}
+
+// WithBatchMaxContent sets the content size (runes) up to which items are batched.
+func WithBatchMaxContent(n int) ClassifierOption {
+	return func(c *Classifier) { c.BatchMaxContent = n }
+}
+
+// ClassifyBatch classifies items, packing short text items BatchSize at a
+// time into a single classify-batch request. Long, video or empty items, and
+// any item the batch response misses or gets wrong, go through ClassifyItem.
+// Batched items skip the verify pass — that second call is what batching
+// saves. Results align with items; nil means classification was unavailable.
+func (c *Classifier) ClassifyBatch(ctx context.Context, items []BatchItem) []*types.ClassifyResult {
+	results := make([]*types.ClassifyResult, len(items))
+
+	var batched []int
+	for i, item := range items {
+		if c.batchable(item) {
+			batched = append(batched, i)
+			continue
+		}
+		results[i] = c.ClassifyItem(ctx, item)
+	}
+	if len(batched) == 0 {
+		return results
+	}
+
+	candidates, err := c.classificationCandidates(ctx, "", "", "")
+	if err != nil || len(candidates) == 0 {
+		slog.Warn("Batch classification skipped with no topic candidates", "error", err)
+		for _, i := range batched {
+			results[i] = c.ClassifyItem(ctx, items[i])
+		}
+
+		return results
+	}
+
+	for _, chunk := range lo.Chunk(batched, c.BatchSize) {
+		c.classifyChunk(ctx, items, chunk, candidates, results)
+	}
+
+	return results
+}
+
+func (c *Classifier) batchable(item BatchItem) bool {
+	if c.BatchSize <= 1 || strings.TrimSpace(item.Content) == "" {
+		return false
+	}
+	if fetch.DetectContentType(strings.ToLower(item.URL)) == types.ContentVideo {
+		return false
+	}
+	maxLen := c.BatchMaxContent
+	if maxLen <= 0 {
+		maxLen = defaultBatchMaxContent
+	}
+
+	return len([]rune(item.Content)) <= maxLen
+}
+
+// classifyChunk classifies items[chunk...] with one request and writes each
+// result into results, falling back to ClassifyItem per item.
+func (c *Classifier) classifyChunk(
+	ctx context.Context,
+	items []BatchItem,
+	chunk []int,
+	candidates []ghindex.TopicCandidate,
+	results []*types.ClassifyResult,
+) {
+	parsed, err := c.classifyBatchOnly(ctx, items, chunk, candidates)
+	if err != nil {
+		slog.Warn("Batch classification failed, classifying items one by one", "items", len(chunk), "error", err)
+	}
+
+	for n, i := range chunk {
+		item := items[i]
+		got, ok := parsed[strconv.Itoa(n+1)]
+		if !ok {
+			results[i] = c.ClassifyItem(ctx, item)
+			continue
+		}
+		if err := validateClassifyResult(got); err != nil {
+			slog.Warn("Batch item invalid, classifying alone", "url", item.URL, "error", err)
+			results[i] = c.ClassifyItem(ctx, item)
+
+			continue
+		}
+		contentType := fetch.DetectContentType(strings.ToLower(item.URL))
+		results[i] = c.buildClassifyResult(got.toAIClassification(), contentType, candidates, item.URL)
+	}
+}
+
+// classifyBatchOnly runs the batch request and returns the parsed items by id.
+func (c *Classifier) classifyBatchOnly(
+	ctx context.Context,
+	items []BatchItem,
+	chunk []int,
+	candidates []ghindex.TopicCandidate,
+) (map[string]*classifyOnlyResult, error) {
+	data := &batchPromptData{CandidateTree: FormatTopicCandidatesGrouped(candidates)}
+	for n, i := range chunk {
+		data.Items = append(data.Items, batchPromptItem{
+			ID:          strconv.Itoa(n + 1),
+			URL:         items[i].URL,
+			Title:       truncate(items[i].Title, 200),
+			ContentType: fetch.DetectContentType(strings.ToLower(items[i].URL)),
+			Content:     items[i].Content,
+			Hint:        items[i].Hint,
+		})
+	}
+	promptText, err := prompt.Render("classify-batch.txt", data)
+	if err != nil {
+		return nil, fmt.Errorf("render batch prompt: %w", err)
+	}
+
+	var out map[string]*classifyOnlyResult
+	err = retry.Do(
+		func() error {
+			r, e := c.chat(ctx, c.AIConfig, []ai.Message{{Role: "user", Content: promptText}})
+			if e != nil {
+				return fmt.Errorf("AI batch classify call: %w", e)
+			}
+			parsed, e := parseBatchClassifyResult(r)
+			if e != nil {
+				return fmt.Errorf("parse batch classify JSON: %w", e)
+			}
+			out = parsed
+			return nil
+		},
+		retry.Attempts(2),
+		retry.Delay(1*time.Second),
+		retry.DelayType(retry.BackOffDelay),
+		retry.Context(ctx),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func parseBatchClassifyResult(raw string) (map[string]*classifyOnlyResult, error) {
+	var resp batchClassifyResponse
+	if err := json.Unmarshal([]byte(stripMarkdownFences(strings.TrimSpace(raw))), &resp); err != nil {
+		return nil, err
+	}
+	out := make(map[string]*classifyOnlyResult, len(resp.Items))
+	for _, item := range resp.Items {
+		id := strings.TrimSpace(item.ID)
+		if id == "" {
+			continue
+		}
+		if _, dup := out[id]; dup {
+			continue
+		}
+		result := item.classifyOnlyResult
+		out[id] = &result
+	}
+
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package classify
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
+	"github.com/xbpk3t/docs-alfred/pkg/ai"
+)
+
+const testClassifyJSON = `{"topicPath":"ai/tool/demo","wikiType":"research","contentType":"text","summary":{"overview":"ok","keyPoints":["p1"],"worthNoting":"n"},"confidence":0.9}`
+
+func newBatchTestClassifier(t *testing.T, chat chatFn, opts ...ClassifierOption) *Classifier {
+	t.Helper()
+	opts = append([]ClassifierOption{WithChatFn(chat)}, opts...)
+	c := NewClassifier(nil, t.TempDir(), "", opts...)
+	c.loadGHTopics = func() ([]ghindex.TopicCandidate, error) {
+		return []ghindex.TopicCandidate{{Path: "ai/tool/demo"}}, nil
+	}
+
+	return c
+}
+
+func TestClassifyBatchSendsShortItemsTogether(t *testing.T) {
+	var calls atomic.Int32
+	c := newBatchTestClassifier(t, func(_ context.Context, _ *ai.ClientConfig, messages []ai.Message) (string, error) {
+		calls.Add(1)
+		require.Contains(t, messages[0].Content, "### 条目 2")
+
+		return `{"items":[{"id":"1",` + testClassifyJSON[1:] + `,{"id":"2",` + testClassifyJSON[1:] + `]}`, nil
+	}, WithBatchSize(5))
+
+	results := c.ClassifyBatch(context.Background(), []BatchItem{
+		{URL: "https://example.com/a", Title: "A", Content: "alpha"},
+		{URL: "https://example.com/b", Title: "B", Content: "beta"},
+	})
+
+	require.Len(t, results, 2)
+	for _, r := range results {
+		require.NotNil(t, r)
+		assert.Equal(t, "ai/tool/demo", r.TopicPath)
+		assert.Empty(t, r.Summary.Verify)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClassifyBatchFallsBackForMissingAndLongItems(t *testing.T) {
+	var batchCalls, singleCalls atomic.Int32
+	c := newBatchTestClassifier(t, func(_ context.Context, _ *ai.ClientConfig, messages []ai.Message) (string, error) {
+		content := messages[0].Content
+		switch {
+		case strings.Contains(content, "### 条目"):
+			batchCalls.Add(1)
+			return `{"items":[{"id":"1",` + testClassifyJSON[1:] + `]}`, nil
+		case strings.Contains(content, "Output Schema"):
+			singleCalls.Add(1)
+			return testClassifyJSON, nil
+		default:
+			return "", errors.New("no verify in test")
+		}
+	}, WithBatchSize(5), WithBatchMaxContent(10))
+
+	results := c.ClassifyBatch(context.Background(), []BatchItem{
+		{URL: "https://example.com/a", Title: "A", Content: "short"},
+		{URL: "https://example.com/b", Title: "B", Content: "tiny"},
+		{URL: "https://example.com/c", Title: "C", Content: strings.Repeat("long ", 10)},
+	})
+
+	for _, r := range results {
+		require.NotNil(t, r)
+	}
+	assert.Equal(t, int32(1), batchCalls.Load())
+	assert.Equal(t, int32(2), singleCalls.Load(), "missing id 2 and the long item are classified alone")
+}
+
+func TestParseBatchClassifyResultFenced(t *testing.T) {
+	got, err := parseBatchClassifyResult("```json\n{\"items\":[{\"id\":\"1\",\"topicPath\":\"x/y/z\"},{\"id\":\"1\",\"topicPath\":\"dup\"}]}\n```")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "x/y/z", got["1"].TopicPath)
+}
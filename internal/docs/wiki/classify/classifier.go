@@ -51,6 +51,8 @@ type Classifier struct {
 	CandidateLimit    int
 	MinConfidence     float64
 	MaxContentSize    int // max chars sent to AI; 0 defaults to 20000
+	BatchSize         int // items per ClassifyBatch request; <= 1 disables batching
+	BatchMaxContent   int // items longer than this (runes) are classified alone
 	catalogMu         sync.Mutex
 	catalogLoaded     bool
 }
@@ -86,14 +88,16 @@ func WithChatFn(fn chatFn) ClassifierOption {
 // NewClassifier creates a new Classifier.
 func NewClassifier(aiCfg *ai.ClientConfig, wikiRoot, ghTopicsURL string, opts ...ClassifierOption) *Classifier {
 	c := &Classifier{
-		AIConfig:       aiCfg,
-		chat:           ai.ChatContext,
-		WikiRoot:       wikiRoot,
-		GhTopicsURL:    ghTopicsURL,
-		GhTopicsMaxAge: ghindex.DefaultMaxAge,
-		CandidateLimit: 120,
-		MinConfidence:  0.30,
-		MaxContentSize: 20000,
+		AIConfig:        aiCfg,
+		chat:            ai.ChatContext,
+		WikiRoot:        wikiRoot,
+		GhTopicsURL:     ghTopicsURL,
+		GhTopicsMaxAge:  ghindex.DefaultMaxAge,
+		CandidateLimit:  120,
+		MinConfidence:   0.30,
+		MaxContentSize:  20000,
+		BatchSize:       1,
+		BatchMaxContent: defaultBatchMaxContent,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -117,6 +121,12 @@ func NewClassifier(aiCfg *ai.ClientConfig, wikiRoot, ghTopicsURL string, opts ..
 // (text/media only; repo skips — reserved for vs pathway).
 // Returns nil if classification is unavailable (graceful degradation).
 func (c *Classifier) ClassifyURL(ctx context.Context, urlStr, title, content string) *types.ClassifyResult {
+	return c.ClassifyItem(ctx, BatchItem{URL: urlStr, Title: title, Content: content})
+}
+
+// ClassifyItem is ClassifyURL for a fetched item, showing its Hint to the model.
+func (c *Classifier) ClassifyItem(ctx context.Context, item BatchItem) *types.ClassifyResult {
+	urlStr, title, content := item.URL, item.Title, item.Content
 	contentType := fetch.DetectContentType(strings.ToLower(urlStr))
 	if strings.TrimSpace(content) == "" {
 		slog.Warn("Classification skipped for empty content", "url", urlStr)
@@ -151,7 +161,7 @@ func (c *Classifier) ClassifyURL(ctx context.Context, urlStr, title, content str
 
 	// Single-step classification using classify-json.txt which returns both
 	// classification (topic, type, metadata) and structured summary.
-	classified, err := c.classifyOnly(ctx, urlStr, title, contentType, content, candidates, maxLen)
+	classified, err := c.classifyOnly(ctx, urlStr, title, contentType, content, item.Hint, candidates, maxLen)
 	if err != nil {
 		slog.Warn("AI classification failed", "url", urlStr, "error", err)
 
@@ -529,11 +539,24 @@ type classifyOnlyResult struct {
 	NeedsManualReview bool                     `json:"needsManualReview"`
 }
 
+func (r *classifyOnlyResult) toAIClassification() *aiClassification {
+	return &aiClassification{
+		TopicPath:         r.TopicPath,
+		WikiType:          r.WikiType,
+		ContentType:       r.ContentType,
+		Summary:           r.Summary,
+		Metadata:          r.Metadata,
+		Confidence:        r.Confidence,
+		NeedsManualReview: r.NeedsManualReview,
+		RejectReason:      r.RejectReason,
+	}
+}
+
 // classifyOnly runs the AI classification call with retry.
 // Retries on AI call failure, JSON parse failure, or validation failure.
 func (c *Classifier) classifyOnly(
 	ctx context.Context,
-	urlStr, title, contentType, content string,
+	urlStr, title, contentType, content, hint string,
 	candidates []ghindex.TopicCandidate,
 	maxLen int,
 ) (*aiClassification, error) {
@@ -543,6 +566,7 @@ func (c *Classifier) classifyOnly(
 		URL:           urlStr,
 		ContentType:   contentType,
 		Content:       truncate(content, maxLen),
+		Hint:          hint,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("render classify prompt: %w", err)
@@ -565,16 +589,7 @@ func (c *Classifier) classifyOnly(
 				return fmt.Errorf("validate classify result: %w", e)
 			}
 
-			result = &aiClassification{
-				TopicPath:         parsed.TopicPath,
-				WikiType:          parsed.WikiType,
-				ContentType:       parsed.ContentType,
-				Summary:           parsed.Summary,
-				Metadata:          parsed.Metadata,
-				Confidence:        parsed.Confidence,
-				NeedsManualReview: parsed.NeedsManualReview,
-				RejectReason:      parsed.RejectReason,
-			}
+			result = parsed.toAIClassification()
 			return nil
 		},
 		retry.Attempts(3),
@@ -775,6 +790,7 @@ type promptData struct {
 	Content       string
 	TopicPath     string
 	WikiType      string
+	Hint          string
 }
 
 func (c *Classifier) classificationCandidates(
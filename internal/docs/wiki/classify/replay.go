@@ -0,0 +1,112 @@
+package classify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/xbpk3t/docs-alfred/pkg/ai"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// AI backend modes selectable with WithAIMode.
+const (
+	AIModeLive   = "live"   // call the model
+	AIModeRecord = "record" // call the model and save every response as a fixture
+	AIModeReplay = "replay" // answer from fixtures only, never touching the network
+)
+
+// ErrFixtureNotFound is returned in replay mode for a prompt with no recorded response.
+var ErrFixtureNotFound = errors.New("ai fixture not found")
+
+// Fixture is one recorded chat exchange.
+type Fixture struct {
+	Model    string       `json:"model,omitempty"`
+	Response string       `json:"response"`
+	Messages []ai.Message `json:"messages"`
+}
+
+// DefaultFixturesDir is where recorded chat fixtures live unless overridden.
+func DefaultFixturesDir() string {
+	return fileutil.CachePath("docs-cli/wiki-ai-fixtures")
+}
+
+// FixtureKey returns the content address of a prompt. The model is not part
+// of the key so fixtures survive model switches.
+func FixtureKey(messages []ai.Message) string {
+	data, _ := json.Marshal(messages)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func fixturePath(dir string, messages []ai.Message) string {
+	return filepath.Join(dir, FixtureKey(messages)+".json")
+}
+
+// WithAIMode selects the chat backend: live (default), record or replay.
+// Fixtures are read from and written to dir (default DefaultFixturesDir).
+// It wraps whatever chat is configured when the classifier is built, so it
+// must come after WithChatFn.
+func WithAIMode(mode, dir string) ClassifierOption {
+	return func(c *Classifier) {
+		if dir == "" {
+			dir = DefaultFixturesDir()
+		}
+		next := c.chat
+		if next == nil {
+			next = ai.ChatContext
+		}
+		switch mode {
+		case AIModeRecord:
+			c.chat = recordingChat(dir, next)
+		case AIModeReplay:
+			c.chat = replayChat(dir)
+		}
+	}
+}
+
+// recordingChat forwards to next and stores each successful response.
+func recordingChat(dir string, next chatFn) chatFn {
+	return func(ctx context.Context, cfg *ai.ClientConfig, messages []ai.Message) (string, error) {
+		resp, err := next(ctx, cfg, messages)
+		if err != nil {
+			return "", err
+		}
+		if err := fileutil.EnsureDir(dir); err != nil {
+			return "", fmt.Errorf("create fixtures dir: %w", err)
+		}
+		fixture := Fixture{Messages: messages, Response: resp}
+		if cfg != nil {
+			fixture.Model = cfg.Model
+		}
+		if err := fileutil.AtomicWriteJSONFile(fixturePath(dir, messages), fixture, fileutil.FilePermPrivate); err != nil {
+			return "", fmt.Errorf("record ai fixture: %w", err)
+		}
+
+		return resp, nil
+	}
+}
+
+// replayChat answers from fixtures recorded by recordingChat.
+func replayChat(dir string) chatFn {
+	return func(_ context.Context, _ *ai.ClientConfig, messages []ai.Message) (string, error) {
+		fixture, err := fileutil.ReadJSONFile[Fixture](fixturePath(dir, messages))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// Replaying again cannot produce the fixture; stop retries.
+				return "", retry.Unrecoverable(fmt.Errorf("%w: %s", ErrFixtureNotFound, FixtureKey(messages)))
+			}
+
+			return "", fmt.Errorf("read ai fixture: %w", err)
+		}
+
+		return fixture.Response, nil
+	}
+}
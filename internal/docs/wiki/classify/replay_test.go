@@ -0,0 +1,45 @@
+package classify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/pkg/ai"
+)
+
+func TestAIModeRecordThenReplay(t *testing.T) {
+	dir := t.TempDir()
+	messages := []ai.Message{{Role: "user", Content: "classify me"}}
+	live := 0
+	recorder := NewClassifier(&ai.ClientConfig{Model: "m"}, t.TempDir(), "",
+		WithChatFn(func(context.Context, *ai.ClientConfig, []ai.Message) (string, error) {
+			live++
+			return "recorded answer", nil
+		}),
+		WithAIMode(AIModeRecord, dir),
+	)
+	got, err := recorder.chat(context.Background(), recorder.AIConfig, messages)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded answer", got)
+	assert.Equal(t, 1, live)
+
+	replayer := NewClassifier(nil, t.TempDir(), "", WithAIMode(AIModeReplay, dir))
+	got, err = replayer.chat(context.Background(), nil, messages)
+	require.NoError(t, err)
+	assert.Equal(t, "recorded answer", got)
+
+	_, err = replayer.chat(context.Background(), nil, []ai.Message{{Role: "user", Content: "unseen"}})
+	require.ErrorIs(t, err, ErrFixtureNotFound)
+}
+
+func TestAIModeLiveKeepsChat(t *testing.T) {
+	c := NewClassifier(nil, t.TempDir(), "",
+		WithChatFn(func(context.Context, *ai.ClientConfig, []ai.Message) (string, error) { return "live", nil }),
+		WithAIMode(AIModeLive, t.TempDir()),
+	)
+	got, err := c.chat(context.Background(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "live", got)
+}
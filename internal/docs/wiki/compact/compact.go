@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/blog"
+	wikiexport "github.com/xbpk3t/docs-alfred/internal/docs/wiki/export"
 
 	carbon "github.com/dromara/carbon/v2"
 	"github.com/xbpk3t/docs-alfred/pkg/ai"
@@ -40,6 +41,7 @@ type CompactOptions struct {
 	BulkLogThreshold int
 	TopNotice        int
 	TopHot           int
+	TopHighlight     int // top picks of RunReaderDigest
 	SendMail         bool
 	CreateIssue      bool
 	DryRun           bool
@@ -61,6 +63,8 @@ type CompactResult struct {
 	HotTopics       []HotTopic
 	Judged          []CompactRecommend
 	Notices         []CompactRecommend
+	NewTopics       []ReaderTopic      // RunReaderDigest only
+	Highlights      []wikiexport.Entry // RunReaderDigest only
 	AIFailures      int
 	Skipped         bool
 	AISkipped       bool
@@ -396,6 +400,9 @@ func normalizeCompactOpts(opts *CompactOptions) {
 	if opts.TopNotice <= 0 {
 		opts.TopNotice = 5
 	}
+	if opts.TopHighlight <= 0 {
+		opts.TopHighlight = 5
+	}
 	if opts.BulkLogThreshold <= 0 {
 		opts.BulkLogThreshold = 10
 	}
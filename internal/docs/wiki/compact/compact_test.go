@@ -304,6 +304,7 @@ func TestNormalizeCompactOptsDefaults(t *testing.T) {
 	normalizeCompactOpts(&opts)
 	require.Equal(t, 10, opts.TopHot)
 	require.Equal(t, 5, opts.TopNotice)
+	require.Equal(t, 5, opts.TopHighlight)
 	require.Equal(t, 10, opts.BulkLogThreshold)
 	require.Equal(t, 40, opts.MinDeltaChars)
 	require.Equal(t, 2, opts.MinDeltaLines)
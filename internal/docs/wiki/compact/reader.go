@@ -0,0 +1,264 @@
+package compact
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	carbon "github.com/dromara/carbon/v2"
+	wikiexport "github.com/xbpk3t/docs-alfred/internal/docs/wiki/export"
+	"github.com/xbpk3t/docs-alfred/pkg/md"
+)
+
+// highlightMinQuality is the lowest "X/5" quality listed under top picks.
+const highlightMinQuality = 4
+
+// overviewMaxRunes caps each entry overview in the reader digest.
+const overviewMaxRunes = 280
+
+// ReaderTopic is one topic of the reader digest with its new entries,
+// newest first.
+type ReaderTopic struct {
+	TopicPath string
+	Entries   []wikiexport.Entry
+}
+
+// RunReaderDigest collects the wiki entries added in the schedule window,
+// grouped by topic with the best-rated ones highlighted, and delivers them
+// through the same mail/Linear paths as RunCompact. No git history or AI is
+// involved: entries are read from summary.md date sections. A relative
+// WikiRoot is resolved against RepoRoot, as in RunCompact.
+func RunReaderDigest(ctx context.Context, opts *CompactOptions) (*CompactResult, error) {
+	if opts == nil {
+		opts = &CompactOptions{}
+	}
+	normalizeCompactOpts(opts)
+	now := carbon.Now().StdTime()
+	if opts.Now != nil {
+		now = opts.Now()
+	}
+
+	win, inWindow, skipReason := opts.WindowFn(now)
+	if !inWindow {
+		return &CompactResult{
+			Since:      win.Start,
+			Until:      win.End,
+			Skipped:    true,
+			SkipReason: skipReason,
+		}, nil
+	}
+
+	repoRoot, wikiRel, err := resolveRepoAndWiki(opts)
+	if err != nil {
+		return nil, err
+	}
+	wikiRoot := filepath.FromSlash(wikiRel)
+	if !filepath.IsAbs(wikiRoot) {
+		wikiRoot = filepath.Join(repoRoot, wikiRoot)
+	}
+	site, err := wikiexport.Load(wikiRoot, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load wiki entries: %w", err)
+	}
+
+	topics := NewEntriesInWindow(site, win)
+	result := &CompactResult{
+		Since:      win.Start,
+		Until:      win.End,
+		NewTopics:  topics,
+		Highlights: SelectHighlights(topics, opts.TopHighlight),
+	}
+
+	in := ReaderMailInput{
+		Date:       now,
+		Since:      win.Start,
+		Until:      win.End,
+		Label:      win.Label,
+		Title:      opts.Title,
+		Topics:     topics,
+		Highlights: result.Highlights,
+	}
+	result.Subject = RenderReaderSubject(&in)
+	if result.HTMLBody, err = RenderReaderHTML(&in); err != nil {
+		return result, fmt.Errorf("render reader digest HTML: %w", err)
+	}
+	result.TextBody = RenderReaderText(&in)
+
+	if err := deliverCompact(ctx, opts, result, now); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// NewEntriesInWindow returns the entries whose date section falls inside
+// win, grouped by topic in topic order. Dates are compared as calendar days
+// of win.Start's location.
+func NewEntriesInWindow(site *wikiexport.Site, win Window) []ReaderTopic {
+	startDay := win.Start.Format(time.DateOnly)
+	endDay := ""
+	if !win.End.IsZero() {
+		endDay = win.End.In(win.Start.Location()).Format(time.DateOnly)
+	}
+
+	var topics []ReaderTopic
+	for _, t := range site.Topics {
+		var entries []wikiexport.Entry
+		for _, e := range t.Entries {
+			if e.Date < startDay || (endDay != "" && e.Date >= endDay) {
+				continue
+			}
+			entries = append(entries, e)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].Date > entries[j].Date })
+		topics = append(topics, ReaderTopic{TopicPath: t.Path, Entries: entries})
+	}
+
+	return topics
+}
+
+// SelectHighlights returns up to n entries rated at least
+// highlightMinQuality, best quality first; "try" verdicts win ties, then the
+// newest entry.
+func SelectHighlights(topics []ReaderTopic, n int) []wikiexport.Entry {
+	var picks []wikiexport.Entry
+	for _, t := range topics {
+		for _, e := range t.Entries {
+			if qualityScore(e.Quality) >= highlightMinQuality {
+				picks = append(picks, e)
+			}
+		}
+	}
+	sort.SliceStable(picks, func(i, j int) bool {
+		qi, qj := qualityScore(picks[i].Quality), qualityScore(picks[j].Quality)
+		if qi != qj {
+			return qi > qj
+		}
+		if ti, tj := picks[i].Verdict == "try", picks[j].Verdict == "try"; ti != tj {
+			return ti
+		}
+		return picks[i].Date > picks[j].Date
+	})
+	if n > 0 && len(picks) > n {
+		picks = picks[:n]
+	}
+
+	return picks
+}
+
+// qualityScore parses an "X/5" quality; anything else scores 0.
+func qualityScore(q string) int {
+	score, _, ok := strings.Cut(q, "/")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(score))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// ReaderMailInput is data for reader digest subject/body rendering.
+type ReaderMailInput struct {
+	Date       time.Time
+	Since      time.Time
+	Until      time.Time
+	Label      string
+	Title      string
+	Topics     []ReaderTopic
+	Highlights []wikiexport.Entry
+}
+
+func (in *ReaderMailInput) entryCount() int {
+	n := 0
+	for _, t := range in.Topics {
+		n += len(t.Entries)
+	}
+	return n
+}
+
+// RenderReaderSubject builds the reader digest mail subject.
+func RenderReaderSubject(in *ReaderMailInput) string {
+	if in == nil {
+		in = &ReaderMailInput{}
+	}
+	brand := CompactBrand(in.Title)
+	day := carbon.CreateFromStdTime(in.Date).ToDateString()
+	n := in.entryCount()
+	if n == 0 {
+		return fmt.Sprintf("[%s] %s — reader digest: no new entries", brand, day)
+	}
+	return fmt.Sprintf("[%s] %s — reader digest: %d new entries in %d topics", brand, day, n, len(in.Topics))
+}
+
+// RenderReaderHTML builds the reader digest email body via pkg/md.
+func RenderReaderHTML(in *ReaderMailInput) (string, error) {
+	return buildReaderDocument(in).ToHTML()
+}
+
+// RenderReaderText is Markdown from the same document (dry-run stdout and
+// Linear description).
+func RenderReaderText(in *ReaderMailInput) string {
+	return buildReaderDocument(in).Markdown()
+}
+
+func buildReaderDocument(in *ReaderMailInput) *md.Document {
+	doc := md.NewDocument()
+	doc.Add(md.Paragraph(formatWindowLine(&CompactMailInput{
+		Since:  in.Since,
+		Until:  in.Until,
+		Params: CompactParams{SinceDuration: in.Label},
+	})))
+
+	if in.entryCount() == 0 {
+		doc.Add(md.Paragraph("0 new wiki entries in this window."))
+		return doc
+	}
+
+	if len(in.Highlights) > 0 {
+		items := make([]string, 0, len(in.Highlights))
+		for i := range in.Highlights {
+			e := &in.Highlights[i]
+			items = append(items, fmt.Sprintf("%s · %s%s", md.Link(e.Title, e.URL), e.TopicPath, entryBadges(e)))
+		}
+		doc.Add(md.NamedSection(fmt.Sprintf("Top picks · %d", len(items)), md.BulletList(items, false)))
+	}
+
+	for _, t := range in.Topics {
+		items := make([]string, 0, len(t.Entries))
+		for i := range t.Entries {
+			e := &t.Entries[i]
+			item := md.Link(e.Title, e.URL) + entryBadges(e)
+			if e.Overview != "" {
+				item += " — " + truncateRunes(e.Overview, overviewMaxRunes, "…")
+			}
+			items = append(items, item)
+		}
+		doc.Add(md.NamedSection(fmt.Sprintf("%s · %d", t.TopicPath, len(items)), md.BulletList(items, false)))
+	}
+
+	return doc
+}
+
+// entryBadges renders the quality and verdict of an entry, when known.
+func entryBadges(e *wikiexport.Entry) string {
+	var badges []string
+	if e.Quality != "" {
+		badges = append(badges, "quality "+e.Quality)
+	}
+	if e.Verdict != "" {
+		badges = append(badges, "verdict "+e.Verdict)
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(badges, ", ") + ")"
+}
@@ -0,0 +1,103 @@
+package compact
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readerEntry(title, url, meta, overview string) string {
+	return "### " + title + "\n\n```markdown\nURL: " + url + "\nType: text\n" + meta + "\n```\n\n#### overview\n" + overview + "\n\n"
+}
+
+func writeReaderSummary(t *testing.T, root, topic, body string) {
+	t.Helper()
+	dir := filepath.Join(root, filepath.FromSlash(topic))
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "summary.md"), []byte("---\ntitle: x\n---\n\n"+body), 0o600))
+}
+
+func TestRunReaderDigestGroupsNewEntriesAndHighlights(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, loc) // Saturday; window [10-10, 10-17)
+
+	repo := t.TempDir()
+	root := filepath.Join(repo, "wiki")
+	writeReaderSummary(t, root, "db/sql/pg",
+		"## 2026-10-16\n\n"+
+			readerEntry("Vacuum", "https://example.com/vacuum", "quality: 5/5\nverdict: try", "How vacuum works.")+
+			"## 2026-10-12\n\n"+
+			readerEntry("Index tuning", "https://example.com/index", "quality: 3/5", "B-tree vs GIN.")+
+			"## 2026-10-01\n\n"+
+			readerEntry("Old", "https://example.com/old", "quality: 5/5", "Outside the window."))
+	writeReaderSummary(t, root, "ai/llm/rag",
+		"## 2026-10-17\n\n"+
+			readerEntry("Today", "https://example.com/today", "quality: 5/5", "Belongs to the next window.")+
+			"## 2026-10-10\n\n"+
+			readerEntry("RAG", "https://example.com/rag", "quality: 4/5\nverdict: watch", "Retrieval basics."))
+
+	res, err := RunReaderDigest(context.Background(), &CompactOptions{
+		Now:         func() time.Time { return now },
+		WindowFn:    func(n time.Time) (Window, bool, string) { return ScheduleWindow(1, n) },
+		RepoRoot:    repo,
+		WikiRoot:    "wiki",
+		Title:       "wiki weekly",
+		CreateIssue: true,
+		DryRun:      true,
+	})
+	require.NoError(t, err)
+	require.False(t, res.Skipped)
+
+	require.Len(t, res.NewTopics, 2)
+	require.Equal(t, "ai/llm/rag", res.NewTopics[0].TopicPath)
+	require.Len(t, res.NewTopics[0].Entries, 1)
+	require.Equal(t, "db/sql/pg", res.NewTopics[1].TopicPath)
+	require.Equal(t, "Vacuum", res.NewTopics[1].Entries[0].Title)
+	require.Len(t, res.NewTopics[1].Entries, 2)
+
+	require.Len(t, res.Highlights, 2)
+	require.Equal(t, "Vacuum", res.Highlights[0].Title)
+	require.Equal(t, "RAG", res.Highlights[1].Title)
+
+	require.Equal(t, "[wiki weekly] 2026-10-17 — reader digest: 3 new entries in 2 topics", res.Subject)
+	require.Contains(t, res.TextBody, "## Top picks · 2")
+	require.Contains(t, res.TextBody, "[Vacuum](https://example.com/vacuum) (quality 5/5, verdict try) — How vacuum works.")
+	require.NotContains(t, res.TextBody, "https://example.com/old")
+	require.NotContains(t, res.TextBody, "https://example.com/today")
+	require.Contains(t, res.HTMLBody, "Top picks")
+	require.Equal(t, "wiki weekly [2026-10-17]", res.IssueTitle)
+	require.False(t, res.IssueCreated)
+}
+
+func TestRunReaderDigestEmptyAndSkipped(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	saturday := time.Date(2026, 10, 17, 9, 0, 0, 0, loc)
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repo, "wiki"), 0o750))
+	opts := func(now time.Time) *CompactOptions {
+		return &CompactOptions{
+			Now:      func() time.Time { return now },
+			WindowFn: func(n time.Time) (Window, bool, string) { return ScheduleWindow(1, n) },
+			RepoRoot: repo,
+			WikiRoot: "wiki",
+		}
+	}
+
+	res, err := RunReaderDigest(context.Background(), opts(saturday))
+	require.NoError(t, err)
+	require.Empty(t, res.NewTopics)
+	require.True(t, strings.HasSuffix(res.Subject, "reader digest: no new entries"))
+	require.Contains(t, res.TextBody, "0 new wiki entries in this window.")
+
+	res, err = RunReaderDigest(context.Background(), opts(saturday.AddDate(0, 0, 1)))
+	require.NoError(t, err)
+	require.True(t, res.Skipped)
+	require.Contains(t, res.SkipReason, "not schedule day")
+}
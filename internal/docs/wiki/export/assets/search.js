@@ -0,0 +1,42 @@
+// Client-side search over window.wikiSearchIndex (see search-index.js).
+(function () {
+  var docs = (window.wikiSearchIndex || []).map(function (d) {
+    d.haystack = [d.title, d.topic, (d.tags || []).join(" "), d.text].join("\n").toLowerCase();
+    return d;
+  });
+  var input = document.getElementById("q");
+  var list = document.getElementById("results");
+
+  function render(query) {
+    var terms = query.toLowerCase().split(/\s+/).filter(Boolean);
+    list.textContent = "";
+    if (terms.length === 0) {
+      return;
+    }
+    docs.filter(function (d) {
+      return terms.every(function (t) { return d.haystack.indexOf(t) >= 0; });
+    }).slice(0, 100).forEach(function (d) {
+      var li = document.createElement("li");
+      var title = document.createElement("a");
+      title.href = d.url;
+      title.textContent = d.title;
+      var topic = document.createElement("a");
+      topic.href = d.page;
+      topic.textContent = d.topic;
+      li.appendChild(title);
+      li.appendChild(document.createTextNode(" · "));
+      li.appendChild(topic);
+      if (d.text) {
+        var p = document.createElement("div");
+        p.className = "meta";
+        p.textContent = d.text;
+        li.appendChild(p);
+      }
+      list.appendChild(li);
+    });
+  }
+
+  input.addEventListener("input", function () { render(input.value); });
+  input.value = new URLSearchParams(location.search).get("q") || "";
+  render(input.value);
+})();
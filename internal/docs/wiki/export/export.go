@@ -0,0 +1,155 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// Export formats.
+const (
+	FormatHTML = "html"
+	FormatJSON = "json"
+)
+
+// searchIndexFile is the client-side search index written by every format.
+const searchIndexFile = "search-index.json"
+
+// Options configures Export.
+type Options struct {
+	WikiRoot string
+	OutDir   string
+	Format   string
+	// Catalog is the gh topic catalog used for topic index pages; entries
+	// outside it are still exported.
+	Catalog []ghindex.TopicCandidate
+}
+
+// Result reports what Export wrote.
+type Result struct {
+	OutDir  string   `json:"outDir"`
+	Format  string   `json:"format"`
+	Topics  int      `json:"topics"`
+	Entries int      `json:"entries"`
+	Tags    int      `json:"tags"`
+	Files   []string `json:"files"`
+}
+
+// SearchDoc is one record of the client-side search index.
+type SearchDoc struct {
+	Title string   `json:"title"`
+	URL   string   `json:"url"`
+	Topic string   `json:"topic"`
+	Page  string   `json:"page"`
+	Date  string   `json:"date,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+	Text  string   `json:"text,omitempty"`
+}
+
+// Export renders the wiki under opts.WikiRoot into opts.OutDir.
+func Export(opts Options) (*Result, error) {
+	if opts.Format != FormatHTML && opts.Format != FormatJSON {
+		return nil, fmt.Errorf("unsupported export format %q (want html or json)", opts.Format)
+	}
+	if strings.TrimSpace(opts.OutDir) == "" {
+		return nil, errors.New("export output directory is required")
+	}
+	if err := checkOutDir(opts.WikiRoot, opts.OutDir); err != nil {
+		return nil, err
+	}
+
+	site, err := Load(opts.WikiRoot, opts.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	if err := fileutil.EnsureDir(opts.OutDir); err != nil {
+		return nil, fmt.Errorf("create export dir: %w", err)
+	}
+
+	w := &siteWriter{outDir: opts.OutDir}
+	if err := w.writeJSON(searchIndexFile, SearchIndex(site)); err != nil {
+		return nil, err
+	}
+	if opts.Format == FormatJSON {
+		err = w.writeJSON("wiki.json", site)
+	} else {
+		err = w.writeHTML(site)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		OutDir:  opts.OutDir,
+		Format:  opts.Format,
+		Topics:  len(site.Topics),
+		Entries: len(site.Entries()),
+		Tags:    len(site.Tags),
+		Files:   w.files,
+	}, nil
+}
+
+// checkOutDir refuses to export into the wiki tree itself.
+func checkOutDir(wikiRoot, outDir string) error {
+	root, err := filepath.Abs(wikiRoot)
+	if err != nil {
+		return fmt.Errorf("resolve wiki root: %w", err)
+	}
+	out, err := filepath.Abs(outDir)
+	if err != nil {
+		return fmt.Errorf("resolve export dir: %w", err)
+	}
+	rel, err := filepath.Rel(root, out)
+	if err == nil && (rel == "." || !strings.HasPrefix(rel, "..")) {
+		return fmt.Errorf("export dir %s is inside wiki root %s", outDir, wikiRoot)
+	}
+
+	return nil
+}
+
+// SearchIndex flattens site entries into search records whose Page links to
+// the topic page relative to the export root.
+func SearchIndex(site *Site) []SearchDoc {
+	entries := site.Entries()
+	docs := make([]SearchDoc, 0, len(entries))
+	for _, e := range entries {
+		docs = append(docs, SearchDoc{
+			Title: e.Title,
+			URL:   e.URL,
+			Topic: e.TopicPath,
+			Page:  pageFile(e.TopicPath),
+			Date:  e.Date,
+			Tags:  e.Tags,
+			Text:  e.Overview,
+		})
+	}
+
+	return docs
+}
+
+type siteWriter struct {
+	outDir string
+	files  []string
+}
+
+func (w *siteWriter) writeFile(rel string, data []byte) error {
+	if err := fileutil.AtomicWriteFile(filepath.Join(w.outDir, filepath.FromSlash(rel)), data, fileutil.FilePerm); err != nil {
+		return fmt.Errorf("write %s: %w", rel, err)
+	}
+	w.files = append(w.files, rel)
+
+	return nil
+}
+
+func (w *siteWriter) writeJSON(rel string, value any) error {
+	data, err := fileutil.MarshalJSON(value)
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", rel, err)
+	}
+
+	return w.writeFile(rel, data)
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
+)
+
+const testSummary = `---
+title: rag
+date: "2026-10-02"
+source: rss2nl-wiki
+type: text
+---
+
+## 2026-10-02
+
+### RAG pipelines
+
+` + "```markdown\nURL: https://example.com/rag\nType: text\nquality: 4/5\ntags: RAG, embedding\nverdict: try\n```" + `
+
+#### overview
+Retrieval augmented generation with vector search.
+
+#### keyPoints
+- chunking strategy matters
+
+## 2026-10-01
+
+### Vector databases
+
+` + "```markdown\nURL: https://example.com/vdb\ntags: rag, database\n```" + `
+
+#### overview
+Choosing a vector database.
+`
+
+func writeSummary(t *testing.T, root, topic, content string) {
+	t.Helper()
+	dir := filepath.Join(root, filepath.FromSlash(topic))
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, write.SummaryFilename), []byte(content), 0o600))
+}
+
+func TestLoadParsesEntriesAndTagCloud(t *testing.T) {
+	root := t.TempDir()
+	writeSummary(t, root, "ai/llm/rag", testSummary)
+
+	site, err := Load(root, []ghindex.TopicCandidate{{Path: "ai/llm/rag", Display: "RAG"}})
+	require.NoError(t, err)
+
+	require.Len(t, site.Topics, 1)
+	topic := site.Topics[0]
+	assert.Equal(t, "ai/llm/rag", topic.Path)
+	assert.Equal(t, "RAG", topic.Display)
+	assert.Equal(t, "2026-10-02", topic.Updated)
+	assert.NotContains(t, topic.Markdown, "source: rss2nl-wiki")
+
+	require.Len(t, topic.Entries, 2)
+	assert.Equal(t, Entry{
+		Title:     "RAG pipelines",
+		URL:       "https://example.com/rag",
+		TopicPath: "ai/llm/rag",
+		Date:      "2026-10-02",
+		Overview:  "Retrieval augmented generation with vector search.",
+		Quality:   "4/5",
+		Verdict:   "try",
+		Tags:      []string{"RAG", "embedding"},
+	}, topic.Entries[0])
+	assert.Equal(t, "2026-10-01", topic.Entries[1].Date)
+
+	assert.Equal(t, []Tag{{Name: "RAG", Count: 2}, {Name: "database", Count: 1}, {Name: "embedding", Count: 1}}, site.Tags)
+}
+
+func TestExportHTMLWritesTopicIndexTagsAndSearch(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "wiki")
+	out := filepath.Join(t.TempDir(), "site")
+	writeSummary(t, root, "ai/llm/rag", testSummary)
+
+	result, err := Export(Options{
+		WikiRoot: root,
+		OutDir:   out,
+		Format:   FormatHTML,
+		Catalog:  []ghindex.TopicCandidate{{Path: "ai/llm/rag"}, {Path: "kernel/tool/devops", Display: "DevOps"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Topics)
+	assert.Equal(t, 2, result.Entries)
+	assert.Equal(t, 3, result.Tags)
+
+	for _, rel := range []string{
+		"index.html", "ai/index.html", "ai/llm/index.html", "ai/llm/rag/index.html",
+		"kernel/tool/devops/index.html", "tags/index.html", "search.html",
+		"search-index.json", "search-index.js", "search.js", "style.css",
+	} {
+		assert.FileExists(t, filepath.Join(out, filepath.FromSlash(rel)))
+	}
+
+	rag, err := os.ReadFile(filepath.Join(out, "ai/llm/rag/index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(rag), `<h3 id="rag-pipelines">RAG pipelines</h3>`)
+	assert.Contains(t, string(rag), `href="../../../style.css"`)
+
+	home, err := os.ReadFile(filepath.Join(out, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(home), `<a href="ai/index.html">ai</a>`)
+	assert.Contains(t, string(home), `<a href="kernel/index.html">kernel</a> <span class="count">0</span>`)
+
+	devops, err := os.ReadFile(filepath.Join(out, "kernel/tool/devops/index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(devops), "<h1>DevOps</h1>")
+	assert.Contains(t, string(devops), "No entries yet.")
+
+	tags, err := os.ReadFile(filepath.Join(out, "tags/index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(tags), `<a class="w5" href="#tag-rag">RAG</a>`)
+	assert.Contains(t, string(tags), `<a href="../ai/llm/rag/index.html">ai/llm/rag</a>`)
+
+	docs, err := os.ReadFile(filepath.Join(out, searchIndexFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(docs), `"page": "ai/llm/rag/index.html"`)
+}
+
+func TestExportJSONWritesSiteDump(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "wiki")
+	out := filepath.Join(t.TempDir(), "site")
+	writeSummary(t, root, "ai/llm/rag", testSummary)
+
+	result, err := Export(Options{WikiRoot: root, OutDir: out, Format: FormatJSON})
+	require.NoError(t, err)
+	assert.Equal(t, []string{searchIndexFile, "wiki.json"}, result.Files)
+
+	data, err := os.ReadFile(filepath.Join(out, "wiki.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"topicPath": "ai/llm/rag"`)
+	assert.NoFileExists(t, filepath.Join(out, "index.html"))
+}
+
+func TestExportRejectsBadOptions(t *testing.T) {
+	root := t.TempDir()
+
+	_, err := Export(Options{WikiRoot: root, OutDir: filepath.Join(t.TempDir(), "site"), Format: "pdf"})
+	require.ErrorContains(t, err, "unsupported export format")
+
+	_, err = Export(Options{WikiRoot: root, OutDir: filepath.Join(root, "site"), Format: FormatHTML})
+	require.ErrorContains(t, err, "inside wiki root")
+}
@@ -0,0 +1,276 @@
+package export
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/md"
+)
+
+//go:embed templates/*.html assets/*
+var siteFS embed.FS
+
+var pageTmpl = template.Must(template.ParseFS(siteFS, "templates/*.html"))
+
+// tagWeights is the number of font-size steps in the tag cloud.
+const tagWeights = 5
+
+type crumb struct {
+	Name string
+	Href string
+}
+
+type childLink struct {
+	Name    string
+	Display string
+	Href    string
+	Entries int
+}
+
+type tagLink struct {
+	Tag
+	Slug    string
+	Weight  int
+	Entries []entryLink
+}
+
+type entryLink struct {
+	Entry
+	Page string
+}
+
+type pageData struct {
+	Root     string
+	Title    string
+	Updated  string
+	Crumbs   []crumb
+	Children []childLink
+	Content  template.HTML
+	Tags     []tagLink
+}
+
+// node is one directory of the exported topic tree.
+type node struct {
+	path     string
+	topic    *Topic
+	display  string
+	children map[string]bool
+	entries  int
+}
+
+// pageFile is the page of a topic path, relative to the export root.
+func pageFile(topicPath string) string {
+	if topicPath == "" || topicPath == "." {
+		return "index.html"
+	}
+
+	return topicPath + "/index.html"
+}
+
+// rootPrefix walks from the page of topicPath back to the export root.
+func rootPrefix(topicPath string) string {
+	if topicPath == "" || topicPath == "." {
+		return ""
+	}
+
+	return strings.Repeat("../", strings.Count(topicPath, "/")+1)
+}
+
+func (w *siteWriter) writeHTML(site *Site) error {
+	nodes := buildTree(site)
+	paths := make([]string, 0, len(nodes))
+	for p := range nodes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		data, err := topicPage(nodes, nodes[p])
+		if err != nil {
+			return err
+		}
+		if err := w.renderPage(pageFile(p), "topic.html", data); err != nil {
+			return err
+		}
+	}
+	if err := w.renderPage("tags/index.html", "tags.html", tagsPage(site)); err != nil {
+		return err
+	}
+	if err := w.renderPage("search.html", "search.html", &pageData{Title: "Search"}); err != nil {
+		return err
+	}
+
+	return w.writeAssets(site)
+}
+
+func (w *siteWriter) renderPage(rel, name string, data *pageData) error {
+	var buf bytes.Buffer
+	if err := pageTmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return fmt.Errorf("render %s: %w", rel, err)
+	}
+
+	return w.writeFile(rel, buf.Bytes())
+}
+
+func (w *siteWriter) writeAssets(site *Site) error {
+	for _, name := range []string{"style.css", "search.js"} {
+		data, err := siteFS.ReadFile("assets/" + name)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+		if err := w.writeFile(name, data); err != nil {
+			return err
+		}
+	}
+	// search.html loads the index as a script so it also works from file://.
+	data, err := fileutil.MarshalJSON(SearchIndex(site))
+	if err != nil {
+		return fmt.Errorf("encode search index: %w", err)
+	}
+
+	return w.writeFile("search-index.js", append(append([]byte("window.wikiSearchIndex = "), data...), ";\n"...))
+}
+
+// buildTree joins wiki topics and catalog paths into one directory tree
+// rooted at "".
+func buildTree(site *Site) map[string]*node {
+	nodes := map[string]*node{"": {children: map[string]bool{}}}
+	ensure := func(p string) *node {
+		if n, ok := nodes[p]; ok {
+			return n
+		}
+		n := &node{path: p, children: map[string]bool{}}
+		nodes[p] = n
+		for child, parent := p, parentPath(p); ; child, parent = parent, parentPath(parent) {
+			pn, ok := nodes[parent]
+			if !ok {
+				pn = &node{path: parent, children: map[string]bool{}}
+				nodes[parent] = pn
+			}
+			pn.children[child] = true
+			if ok {
+				break
+			}
+		}
+
+		return n
+	}
+
+	for _, c := range site.Catalog {
+		if c.Path != "" {
+			ensure(c.Path).display = c.Display
+		}
+	}
+	for i := range site.Topics {
+		t := &site.Topics[i]
+		if t.Path == "." {
+			continue
+		}
+		ensure(t.Path).topic = t
+		for p := t.Path; p != ""; p = parentPath(p) {
+			nodes[p].entries += len(t.Entries)
+		}
+		nodes[""].entries += len(t.Entries)
+	}
+
+	return nodes
+}
+
+func parentPath(p string) string {
+	if parent := path.Dir(p); parent != "." {
+		return parent
+	}
+
+	return ""
+}
+
+func topicPage(nodes map[string]*node, n *node) (*pageData, error) {
+	data := &pageData{Root: rootPrefix(n.path), Title: "wiki"}
+	if n.path != "" {
+		data.Title = n.path
+		if n.display != "" {
+			data.Title = n.display
+		}
+	}
+
+	if n.path != "" {
+		parts := strings.Split(n.path, "/")
+		for i := range parts {
+			p := strings.Join(parts[:i+1], "/")
+			data.Crumbs = append(data.Crumbs, crumb{Name: parts[i], Href: data.Root + pageFile(p)})
+		}
+	}
+
+	children := make([]string, 0, len(n.children))
+	for c := range n.children {
+		children = append(children, c)
+	}
+	sort.Strings(children)
+	for _, c := range children {
+		child := nodes[c]
+		data.Children = append(data.Children, childLink{
+			Name:    path.Base(c),
+			Display: child.display,
+			Href:    data.Root + pageFile(c),
+			Entries: child.entries,
+		})
+	}
+
+	if n.topic != nil {
+		data.Updated = n.topic.Updated
+		body, err := md.ToHTML(n.topic.Markdown)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", n.path, err)
+		}
+		data.Content = template.HTML(body) //nolint:gosec // goldmark output of our own wiki markdown
+	}
+
+	return data, nil
+}
+
+func tagsPage(site *Site) *pageData {
+	data := &pageData{Root: "../", Title: "Tags"}
+	maxCount := 1
+	for _, t := range site.Tags {
+		maxCount = max(maxCount, t.Count)
+	}
+	byTag := map[string][]entryLink{}
+	for _, e := range site.Entries() {
+		for _, name := range e.Tags {
+			key := strings.ToLower(name)
+			byTag[key] = append(byTag[key], entryLink{Entry: e, Page: data.Root + pageFile(e.TopicPath)})
+		}
+	}
+	for _, t := range site.Tags {
+		data.Tags = append(data.Tags, tagLink{
+			Tag:     t,
+			Slug:    tagSlug(t.Name),
+			Weight:  1 + (t.Count-1)*(tagWeights-1)/max(maxCount-1, 1),
+			Entries: byTag[strings.ToLower(t.Name)],
+		})
+	}
+
+	return data
+}
+
+// tagSlug makes a tag usable as an element id.
+func tagSlug(name string) string {
+	var b strings.Builder
+	b.WriteString("tag-")
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('-')
+		}
+	}
+
+	return b.String()
+}
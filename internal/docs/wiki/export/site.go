@@ -0,0 +1,170 @@
+// Package export renders the wiki tree into a self-contained static site or
+// a JSON dump, with topic index pages, a tag cloud and a search index.
+package export
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/internal/gh/index"
+)
+
+// Entry is one "### title" entry of a topic summary.
+type Entry struct {
+	Title     string   `json:"title"`
+	URL       string   `json:"url"`
+	TopicPath string   `json:"topicPath"`
+	Date      string   `json:"date,omitempty"`
+	Overview  string   `json:"overview,omitempty"`
+	Quality   string   `json:"quality,omitempty"` // "X/5"
+	Verdict   string   `json:"verdict,omitempty"` // watch, skip or try
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// Topic is one summary.md of the wiki tree.
+type Topic struct {
+	Path     string  `json:"path"`
+	Title    string  `json:"title"`
+	Display  string  `json:"display,omitempty"`
+	Updated  string  `json:"updated,omitempty"`
+	Markdown string  `json:"markdown"`
+	Entries  []Entry `json:"entries"`
+}
+
+// Tag is a tag-cloud bucket.
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Site is the whole wiki as exported.
+type Site struct {
+	Topics  []Topic                  `json:"topics"`
+	Tags    []Tag                    `json:"tags"`
+	Catalog []ghindex.TopicCandidate `json:"catalog,omitempty"`
+}
+
+// Entries returns every entry of every topic, in topic order.
+func (s *Site) Entries() []Entry {
+	var out []Entry
+	for _, t := range s.Topics {
+		out = append(out, t.Entries...)
+	}
+
+	return out
+}
+
+// Load reads every summary.md under wikiRoot. catalog names the formal gh
+// topics and may be empty.
+func Load(wikiRoot string, catalog []ghindex.TopicCandidate) (*Site, error) {
+	display := make(map[string]string, len(catalog))
+	for _, c := range catalog {
+		display[c.Path] = c.Display
+	}
+
+	site := &Site{Catalog: catalog}
+	err := filepath.WalkDir(wikiRoot, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			if p != wikiRoot && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if d.Name() != write.SummaryFilename {
+			return nil
+		}
+		rel, err := filepath.Rel(wikiRoot, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		topic := parseTopic(filepath.ToSlash(rel), string(data))
+		topic.Display = display[topic.Path]
+		site.Topics = append(site.Topics, topic)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan wiki summaries: %w", err)
+	}
+
+	sort.Slice(site.Topics, func(i, j int) bool { return site.Topics[i].Path < site.Topics[j].Path })
+	site.Tags = tagCloud(site.Entries())
+
+	return site, nil
+}
+
+func parseTopic(topicPath, raw string) Topic {
+	fm, body := write.SplitSummary(raw)
+	topic := Topic{Path: topicPath, Title: path.Base(topicPath), Markdown: strings.TrimSpace(body)}
+	if fm != nil {
+		if fm.Title != "" {
+			topic.Title = fm.Title
+		}
+		topic.Updated = fm.Date
+	}
+	topic.Entries = parseEntries(topicPath, body)
+
+	return topic
+}
+
+// parseEntries reads the entries written by write.WriteSummary.
+func parseEntries(topicPath, body string) []Entry {
+	parsed := write.ParseSummaryEntries(body)
+	entries := make([]Entry, 0, len(parsed))
+	for _, e := range parsed {
+		entries = append(entries, Entry{
+			Title:     e.Title,
+			URL:       e.URL,
+			TopicPath: topicPath,
+			Date:      e.Date,
+			Overview:  strings.Join(e.Sections["overview"], " "),
+			Quality:   e.Meta["quality"],
+			Verdict:   e.Meta["verdict"],
+			Tags:      e.Tags(),
+		})
+	}
+
+	return entries
+}
+
+// tagCloud counts tags case-insensitively, keeping the first spelling seen,
+// most used first.
+func tagCloud(entries []Entry) []Tag {
+	index := map[string]int{}
+	var tags []Tag
+	for _, e := range entries {
+		for _, name := range e.Tags {
+			key := strings.ToLower(name)
+			if i, ok := index[key]; ok {
+				tags[i].Count++
+
+				continue
+			}
+			index[key] = len(tags)
+			tags = append(tags, Tag{Name: name, Count: 1})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+
+		return strings.ToLower(tags[i].Name) < strings.ToLower(tags[j].Name)
+	})
+
+	return tags
+}
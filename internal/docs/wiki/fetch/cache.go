@@ -0,0 +1,132 @@
+package fetch
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// DefaultCacheTTL is how long a cached fetch is served to FetchContent.
+const DefaultCacheTTL = 30 * 24 * time.Hour
+
+// CacheEntry is one cached fetch result.
+type CacheEntry struct {
+	FetchedAt   time.Time                `json:"fetchedAt"`
+	URL         string                   `json:"url"`
+	ContentType string                   `json:"contentType"`
+	Result      types.ContentFetchResult `json:"result"`
+}
+
+// Cache stores successful fetch results on disk, one JSON file per
+// normalized URL (named by its sha256), so classification can be replayed
+// without fetching again.
+type Cache struct {
+	now func() time.Time
+	Dir string
+	TTL time.Duration
+}
+
+// NewCache returns a cache rooted at dir (default: the docs-cli wiki-fetch
+// cache directory). A non-positive ttl uses DefaultCacheTTL.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	if dir == "" {
+		dir = fileutil.CachePath("docs-cli/wiki-fetch")
+	}
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+
+	return &Cache{Dir: dir, TTL: ttl, now: time.Now}
+}
+
+// CacheKey returns the content address of urlStr.
+func CacheKey(urlStr string) string {
+	sum := sha256.Sum256([]byte(urlutil.Normalize(urlStr)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) path(urlStr string) string {
+	return filepath.Join(c.Dir, CacheKey(urlStr)+".json")
+}
+
+// Load returns the cached entry for urlStr regardless of age.
+func (c *Cache) Load(urlStr string) (*CacheEntry, error) {
+	entry, err := fileutil.ReadJSONFile[CacheEntry](c.path(urlStr))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// Get returns the cached entry for urlStr when it is younger than TTL.
+func (c *Cache) Get(urlStr string) (*CacheEntry, bool) {
+	entry, err := c.Load(urlStr)
+	if err != nil || c.now().Sub(entry.FetchedAt) > c.TTL {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// Put stores a successful fetch result. Failed results are not cached.
+func (c *Cache) Put(urlStr, contentType string, result *types.ContentFetchResult) error {
+	if result == nil || result.Error != "" {
+		return nil
+	}
+	if err := fileutil.EnsureDir(c.Dir); err != nil {
+		return fmt.Errorf("create fetch cache dir: %w", err)
+	}
+	entry := CacheEntry{FetchedAt: c.now(), URL: urlStr, ContentType: contentType, Result: *result}
+
+	return fileutil.AtomicWriteJSONFile(c.path(urlStr), entry, fileutil.FilePermPrivate)
+}
+
+// Delete removes the cached entry for urlStr, if any, so the next
+// FetchContent fetches it again.
+func (c *Cache) Delete(urlStr string) error {
+	if err := os.Remove(c.path(urlStr)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete fetch cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// List returns every cached entry fetched at or after since (zero = all),
+// oldest first. Unreadable files are skipped.
+func (c *Cache) List(since time.Time) ([]CacheEntry, error) {
+	files, err := os.ReadDir(c.Dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("read fetch cache: %w", err)
+	}
+
+	var entries []CacheEntry
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		entry, err := fileutil.ReadJSONFile[CacheEntry](filepath.Join(c.Dir, f.Name()))
+		if err != nil || entry.FetchedAt.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b CacheEntry) int { return a.FetchedAt.Compare(b.FetchedAt) })
+
+	return entries, nil
+}
@@ -0,0 +1,110 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+type countingDriver struct {
+	result *types.ContentFetchResult
+	calls  int
+}
+
+func (d *countingDriver) Name() string { return "counting" }
+
+func (d *countingDriver) FetchContent(context.Context, string, string) *types.ContentFetchResult {
+	d.calls++
+	r := *d.result
+
+	return &r
+}
+
+func newTestCache(t *testing.T, now time.Time) *Cache {
+	t.Helper()
+	c := NewCache(t.TempDir(), time.Hour)
+	c.now = func() time.Time { return now }
+
+	return c
+}
+
+func TestCachePutGetTTL(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCache(t, now)
+
+	require.NoError(t, c.Put("https://Example.com/a/", types.ContentText, &types.ContentFetchResult{Title: "A", Body: "body"}))
+	require.NoError(t, c.Put("https://example.com/bad", types.ContentText, &types.ContentFetchResult{Error: "boom"}))
+
+	entry, ok := c.Get("https://example.com/a")
+	require.True(t, ok)
+	assert.Equal(t, "A", entry.Result.Title)
+	assert.Equal(t, "https://Example.com/a/", entry.URL)
+
+	_, ok = c.Get("https://example.com/bad")
+	assert.False(t, ok, "failed fetches are not cached")
+
+	c.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, ok = c.Get("https://example.com/a")
+	assert.False(t, ok, "expired entry is not served")
+	loaded, err := c.Load("https://example.com/a")
+	require.NoError(t, err)
+	assert.Equal(t, "body", loaded.Result.Body)
+
+	require.NoError(t, c.Delete("https://example.com/a"))
+	_, err = c.Load("https://example.com/a")
+	require.Error(t, err)
+	require.NoError(t, c.Delete("https://example.com/a"), "deleting a missing entry is not an error")
+}
+
+func TestCacheList(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newTestCache(t, now)
+	require.NoError(t, c.Put("https://example.com/old", types.ContentText, &types.ContentFetchResult{Body: "old"}))
+	c.now = func() time.Time { return now.Add(48 * time.Hour) }
+	require.NoError(t, c.Put("https://example.com/new", types.ContentText, &types.ContentFetchResult{Body: "new"}))
+
+	all, err := c.List(time.Time{})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "https://example.com/old", all[0].URL)
+
+	recent, err := c.List(now.Add(24 * time.Hour))
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "https://example.com/new", recent[0].URL)
+
+	empty, err := NewCache(t.TempDir()+"/missing", 0).List(time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestFetcherWithCacheSkipsDriverOnHit(t *testing.T) {
+	driver := &countingDriver{result: &types.ContentFetchResult{Title: "T", Body: "body"}}
+	c := newTestCache(t, time.Now())
+	f := NewFetcher(WithDriver(driver), WithCache(c))
+
+	first := f.FetchContent(context.Background(), "https://example.com/post", types.ContentText)
+	second := f.FetchContent(context.Background(), "https://example.com/post#top", types.ContentText)
+
+	assert.Equal(t, 1, driver.calls)
+	assert.Equal(t, first, second)
+}
+
+func TestFetcherCacheOnlyNeverFetches(t *testing.T) {
+	driver := &countingDriver{result: &types.ContentFetchResult{Title: "T", Body: "fresh"}}
+	c := newTestCache(t, time.Now())
+	require.NoError(t, c.Put("https://example.com/old", types.ContentText, &types.ContentFetchResult{Body: "cached"}))
+	c.now = func() time.Time { return time.Now().Add(48 * time.Hour) }
+	f := NewFetcher(WithDriver(driver), WithCache(c), WithCacheOnly())
+
+	hit := f.FetchContent(context.Background(), "https://example.com/old", types.ContentText)
+	miss := f.FetchContent(context.Background(), "https://example.com/new", types.ContentText)
+
+	assert.Equal(t, "cached", hit.Body)
+	assert.NotEmpty(t, miss.Error)
+	assert.Zero(t, driver.calls)
+}
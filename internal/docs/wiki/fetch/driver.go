@@ -3,9 +3,19 @@ package fetch
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 )
 
+// Built-in driver names.
+const (
+	DriverOpenCLI         = "opencli"
+	DriverHTTPReadability = "http-readability"
+	DriverPDF             = "pdf"
+)
+
 // ContentDriver abstracts content fetching for different environments.
 // Each driver encapsulates its own URL routing and extraction logic.
 type ContentDriver interface {
@@ -19,22 +29,64 @@ type ContentDriver interface {
 var (
 	_ ContentDriver = (*openCLIDriver)(nil)
 	_ ContentDriver = (*httpDriver)(nil)
+	_ ContentDriver = (*pdfDriver)(nil)
+	_ ContentDriver = (*routedDriver)(nil)
 )
 
+// DriverFactory builds a ContentDriver from the shared driver options.
+type DriverFactory func(opts DriverOptions) ContentDriver
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DriverFactory{
+		DriverOpenCLI:         func(opts DriverOptions) ContentDriver { return newOpenCLIDriver(opts) },
+		DriverHTTPReadability: func(opts DriverOptions) ContentDriver { return newHTTPDriver(opts) },
+		DriverPDF:             func(opts DriverOptions) ContentDriver { return newPDFDriver(opts) },
+	}
+)
+
+// RegisterDriver makes a driver available to NewDriver and to routes under
+// name, replacing any driver already registered with that name.
+func RegisterDriver(name string, factory DriverFactory) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = factory
+}
+
+// DriverNames lists the registered driver names, sorted.
+func DriverNames() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // NewDriver creates a ContentDriver by name.
 func NewDriver(name string, opts DriverOptions) (ContentDriver, error) {
-	switch name {
-	case "opencli":
-		return newOpenCLIDriver(opts), nil
-	case "http-readability":
-		return newHTTPDriver(opts), nil
-	default:
+	driversMu.RLock()
+	factory, ok := drivers[name]
+	driversMu.RUnlock()
+	if !ok {
 		return nil, fmt.Errorf("unknown driver: %s", name)
 	}
+
+	return factory(opts), nil
 }
 
+// DefaultMaxPDFSize is the largest PDF download, in bytes, when
+// DriverOptions.MaxPDFSize is unset.
+const DefaultMaxPDFSize = 50 << 20
+
 // DriverOptions holds shared configuration for drivers.
 type DriverOptions struct {
 	MaxBodySize  int
 	MediaEnabled bool
+	// MaxPDFSize caps PDF downloads in bytes; 0 uses DefaultMaxPDFSize.
+	MaxPDFSize int
 }
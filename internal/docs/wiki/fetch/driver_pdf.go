@@ -0,0 +1,209 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/pdf"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	"github.com/xbpk3t/docs-alfred/pkg/httputil"
+	"github.com/xbpk3t/docs-alfred/pkg/textutil"
+)
+
+// pdfDriver downloads PDF documents and extracts their text in-process, so
+// papers work without a browser. arXiv abstract pages resolve to their PDF.
+type pdfDriver struct {
+	getBytes    func(ctx context.Context, urlStr string) ([]byte, error)
+	maxBodySize int
+	maxPDFSize  int
+}
+
+func newPDFDriver(opts DriverOptions) *pdfDriver {
+	maxBody := opts.MaxBodySize
+	if maxBody <= 0 {
+		maxBody = 5000
+	}
+	maxPDF := opts.MaxPDFSize
+	if maxPDF <= 0 {
+		maxPDF = DefaultMaxPDFSize
+	}
+
+	return &pdfDriver{
+		maxBodySize: maxBody,
+		maxPDFSize:  maxPDF,
+		getBytes: func(ctx context.Context, urlStr string) ([]byte, error) {
+			return httputil.GetBytes(ctx, urlStr, httputil.RequestOptions{MaxBodySize: maxPDF})
+		},
+	}
+}
+
+func (d *pdfDriver) Name() string { return DriverPDF }
+
+func (d *pdfDriver) FetchContent(ctx context.Context, urlStr, _ string) *types.ContentFetchResult {
+	pdfURL := ResolvePDFURL(urlStr)
+	data, err := d.getBytes(ctx, pdfURL)
+	if errors.Is(err, httputil.ErrBodyTooLarge) {
+		return &types.ContentFetchResult{
+			SourceURL:   urlStr,
+			Error:       fmt.Sprintf("pdf larger than the %d byte limit: %s", d.maxPDFSize, pdfURL),
+			FailureKind: types.FailureFetch,
+		}
+	}
+	if err != nil {
+		failureKind := types.FailureFetch
+		errorStr := err.Error()
+		if isHTTPBlockError(err) {
+			failureKind = types.FailureResolve
+			errorStr = "resolve: " + errorStr
+		}
+
+		return &types.ContentFetchResult{SourceURL: urlStr, Error: errorStr, FailureKind: failureKind}
+	}
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return extractFailure(urlStr, "not a PDF document: "+pdfURL)
+	}
+
+	doc, err := extractPDF(data)
+	if err != nil {
+		return extractFailure(urlStr, "pdf: "+err.Error())
+	}
+	body, kept := joinPDFPages(doc.pages, d.maxBodySize)
+	if strings.TrimSpace(body) == "" {
+		return extractFailure(urlStr, "pdf has no extractable text (scanned or image-only)")
+	}
+	slog.Info("PDF extraction succeeded", "url", pdfURL, "pages", len(doc.pages), "keptPages", kept, "bodyLen", len(body))
+
+	title := doc.title
+	if title == "" {
+		title = firstLine(body)
+	}
+	if title == "" {
+		title = urlStr
+	}
+	header := fmt.Sprintf("Title: %s\nURL: %s\nPDF: %s\nPages: %d\n\n", title, urlStr, pdfURL, len(doc.pages))
+
+	return &types.ContentFetchResult{Title: title, Body: header + body, SourceURL: urlStr}
+}
+
+var arxivPathRe = regexp.MustCompile(`^/(?:abs|pdf|html)/(.+?)(?:\.pdf)?/?$`)
+
+// ResolvePDFURL maps arXiv abstract, HTML and PDF links to the canonical
+// https://arxiv.org/pdf/<id> download; other URLs are returned unchanged.
+func ResolvePDFURL(rawURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return rawURL
+	}
+	switch strings.ToLower(parsed.Hostname()) {
+	case "arxiv.org", "www.arxiv.org", "export.arxiv.org":
+	default:
+		return rawURL
+	}
+	m := arxivPathRe.FindStringSubmatch(parsed.Path)
+	if m == nil {
+		return rawURL
+	}
+
+	return "https://arxiv.org/pdf/" + m[1]
+}
+
+type pdfDocument struct {
+	title string
+	pages []string
+}
+
+// extractPDF reads the document title and the plain text of every page.
+//
+//nolint:nonamedreturns // recover must be able to set the error
+func extractPDF(data []byte) (doc *pdfDocument, err error) {
+	// The PDF reader panics on some malformed objects.
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = nil, fmt.Errorf("malformed document: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	doc = &pdfDocument{title: strings.TrimSpace(reader.Trailer().Key("Info").Key("Title").Text())}
+	fonts := map[string]*pdf.Font{}
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		for _, name := range page.Fonts() {
+			if _, ok := fonts[name]; !ok {
+				font := page.Font(name)
+				fonts[name] = &font
+			}
+		}
+		text, err := page.GetPlainText(fonts)
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i, err)
+		}
+		doc.pages = append(doc.pages, normalizePDFText(text))
+	}
+
+	return doc, nil
+}
+
+// joinPDFPages concatenates whole pages until maxSize bytes are used, so the
+// body never ends mid-page unless the first page alone is too long. It
+// returns the body and the number of pages kept.
+func joinPDFPages(pages []string, maxSize int) (string, int) {
+	var b strings.Builder
+	kept := 0
+	for i, text := range pages {
+		if strings.TrimSpace(text) == "" {
+			kept++
+
+			continue
+		}
+		section := fmt.Sprintf("[page %d]\n%s\n\n", i+1, text)
+		if b.Len()+len(section) > maxSize {
+			if b.Len() == 0 {
+				b.WriteString(textutil.TruncateUTF8(section, maxSize))
+				kept++
+			}
+
+			break
+		}
+		b.WriteString(section)
+		kept++
+	}
+	if kept < len(pages) {
+		fmt.Fprintf(&b, "[truncated: pages %d-%d of %d omitted]\n", kept+1, len(pages), len(pages))
+	}
+
+	return strings.TrimSpace(b.String()), kept
+}
+
+// normalizePDFText drops blank lines and trailing spaces left by text objects.
+func normalizePDFText(text string) string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func firstLine(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "[page ") {
+			return textutil.TruncateUTF8(line, 200)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,184 @@
+package fetch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+// buildTestPDF writes a minimal uncompressed PDF with one Helvetica text
+// line per page and an Info title.
+func buildTestPDF(title string, pages []string) []byte {
+	n := len(pages)
+	fontID := 3 + 2*n
+	infoID := fontID + 1
+	objects := make([]string, infoID+1)
+	objects[1] = "<< /Type /Catalog /Pages 2 0 R >>"
+	kids := make([]string, n)
+	for i, text := range pages {
+		pageID, contentID := 3+2*i, 4+2*i
+		kids[i] = fmt.Sprintf("%d 0 R", pageID)
+		objects[pageID] = fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] "+
+			"/Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>", fontID, contentID)
+		stream := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+		objects[contentID] = fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(stream), stream)
+	}
+	objects[2] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), n)
+	objects[fontID] = "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"
+	objects[infoID] = fmt.Sprintf("<< /Title (%s) >>", title)
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for id := 1; id < len(objects); id++ {
+		offsets[id] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", id, objects[id])
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects))
+	for id := 1; id < len(objects); id++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[id])
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects), infoID, xref)
+
+	return buf.Bytes()
+}
+
+func newTestPDFDriver(maxBody int, data []byte, gotURL *string) *pdfDriver {
+	d := newPDFDriver(DriverOptions{MaxBodySize: maxBody})
+	d.getBytes = func(_ context.Context, urlStr string) ([]byte, error) {
+		*gotURL = urlStr
+
+		return data, nil
+	}
+
+	return d
+}
+
+func TestPDFDriverExtractsPagesFromArxivAbstract(t *testing.T) {
+	data := buildTestPDF("Attention Is All You Need", []string{"Abstract transformer", "Results on WMT"})
+	var got string
+	d := newTestPDFDriver(5000, data, &got)
+
+	result := d.FetchContent(context.Background(), "https://arxiv.org/abs/1706.03762v7", types.ContentText)
+
+	require.Empty(t, result.Error)
+	assert.Equal(t, "https://arxiv.org/pdf/1706.03762v7", got)
+	assert.Equal(t, "Attention Is All You Need", result.Title)
+	assert.Equal(t, "https://arxiv.org/abs/1706.03762v7", result.SourceURL)
+	assert.Contains(t, result.Body, "Pages: 2")
+	assert.Contains(t, result.Body, "[page 1]\nAbstract transformer")
+	assert.Contains(t, result.Body, "[page 2]\nResults on WMT")
+	assert.NotContains(t, result.Body, "truncated")
+}
+
+func TestPDFDriverRejectsNonPDFAndFetchErrors(t *testing.T) {
+	var got string
+	d := newTestPDFDriver(5000, []byte("<html>abstract page</html>"), &got)
+	result := d.FetchContent(context.Background(), "https://example.com/paper.pdf", types.ContentText)
+	assert.Equal(t, types.FailureExtract, result.FailureKind)
+	assert.Contains(t, result.Error, "not a PDF document")
+
+	d.getBytes = func(context.Context, string) ([]byte, error) {
+		return nil, errors.New("GET https://example.com/paper.pdf: HTTP 403: denied")
+	}
+	result = d.FetchContent(context.Background(), "https://example.com/paper.pdf", types.ContentText)
+	assert.Equal(t, types.FailureResolve, result.FailureKind)
+}
+
+func TestPDFDriverRejectsPDFOverSizeLimit(t *testing.T) {
+	data := buildTestPDF("Big", []string{strings.Repeat("x", 2048)})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	d := newPDFDriver(DriverOptions{MaxPDFSize: 1024})
+	result := d.FetchContent(context.Background(), server.URL+"/big.pdf", types.ContentText)
+	assert.Equal(t, types.FailureFetch, result.FailureKind)
+	assert.Contains(t, result.Error, "pdf larger than the 1024 byte limit")
+	assert.Empty(t, result.Body)
+
+	d = newPDFDriver(DriverOptions{MaxPDFSize: len(data)})
+	result = d.FetchContent(context.Background(), server.URL+"/big.pdf", types.ContentText)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, "Big", result.Title)
+}
+
+func TestJoinPDFPagesKeepsWholePages(t *testing.T) {
+	pages := []string{strings.Repeat("a", 40), strings.Repeat("b", 40), strings.Repeat("c", 40)}
+
+	body, kept := joinPDFPages(pages, 110)
+	assert.Equal(t, 2, kept)
+	assert.Contains(t, body, "[page 2]\n"+strings.Repeat("b", 40))
+	assert.NotContains(t, body, "ccc")
+	assert.True(t, strings.HasSuffix(body, "[truncated: pages 3-3 of 3 omitted]"))
+
+	body, kept = joinPDFPages(pages, 20)
+	assert.Equal(t, 1, kept)
+	assert.True(t, strings.HasPrefix(body, "[page 1]\naaa"))
+}
+
+func TestResolvePDFURL(t *testing.T) {
+	cases := map[string]string{
+		"https://arxiv.org/abs/2401.00001":           "https://arxiv.org/pdf/2401.00001",
+		"https://www.arxiv.org/pdf/2401.00001v2.pdf": "https://arxiv.org/pdf/2401.00001v2",
+		"http://export.arxiv.org/abs/cs/0112017":     "https://arxiv.org/pdf/cs/0112017",
+		"https://arxiv.org/html/2401.00001v1":        "https://arxiv.org/pdf/2401.00001v1",
+		"https://arxiv.org/list/cs.AI/recent":        "https://arxiv.org/list/cs.AI/recent",
+		"https://example.com/paper.pdf":              "https://example.com/paper.pdf",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, ResolvePDFURL(in), in)
+	}
+}
+
+type stubDriver struct{ name string }
+
+func (s stubDriver) Name() string { return s.name }
+
+func (s stubDriver) FetchContent(_ context.Context, urlStr, _ string) *types.ContentFetchResult {
+	return &types.ContentFetchResult{Title: s.name, SourceURL: urlStr}
+}
+
+func TestRoutedDriverSendsMatchingURLsToRouteDriver(t *testing.T) {
+	RegisterDriver("stub-a", func(DriverOptions) ContentDriver { return stubDriver{name: "stub-a"} })
+	RegisterDriver("stub-b", func(DriverOptions) ContentDriver { return stubDriver{name: "stub-b"} })
+	assert.Contains(t, DriverNames(), DriverPDF)
+
+	d, err := NewRoutedDriver("stub-a", []DriverRoute{{Match: `(?i)\.pdf$`, Driver: "stub-b"}}, DriverOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "stub-a", d.Name())
+	assert.Equal(t, "stub-b", d.FetchContent(context.Background(), "https://x.com/A.PDF", "").Title)
+	assert.Equal(t, "stub-a", d.FetchContent(context.Background(), "https://x.com/a.html", "").Title)
+
+	plain, err := NewRoutedDriver("stub-a", nil, DriverOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, stubDriver{name: "stub-a"}, plain)
+
+	_, err = NewRoutedDriver("stub-a", []DriverRoute{{Match: "(", Driver: "stub-b"}}, DriverOptions{})
+	require.ErrorContains(t, err, "driver route")
+	_, err = NewRoutedDriver("stub-a", []DriverRoute{{Match: "x", Driver: "missing"}}, DriverOptions{})
+	require.ErrorContains(t, err, "unknown driver: missing")
+}
+
+func TestDefaultRoutesMatchPDFAndArxiv(t *testing.T) {
+	d, err := NewRoutedDriver(DriverHTTPReadability, DefaultRoutes(), DriverOptions{})
+	require.NoError(t, err)
+	routed, ok := d.(*routedDriver)
+	require.True(t, ok)
+
+	assert.Equal(t, DriverPDF, routed.route("https://example.com/a/paper.PDF?dl=1").Name())
+	assert.Equal(t, DriverPDF, routed.route("https://arxiv.org/abs/2401.00001").Name())
+	assert.Equal(t, DriverHTTPReadability, routed.route("https://example.com/pdf-tips").Name())
+}
@@ -0,0 +1,86 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+// DriverRoute sends URLs matching the Match regexp to the named Driver.
+type DriverRoute struct {
+	Match  string
+	Driver string
+}
+
+// DefaultRoutes send PDF documents and arXiv papers to the pdf driver.
+func DefaultRoutes() []DriverRoute {
+	return []DriverRoute{
+		{Match: `(?i)\.pdf([?#]|$)`, Driver: DriverPDF},
+		{Match: `(?i)^https?://(www\.|export\.)?arxiv\.org/(abs|pdf)/`, Driver: DriverPDF},
+	}
+}
+
+type compiledRoute struct {
+	re     *regexp.Regexp
+	driver ContentDriver
+}
+
+// routedDriver dispatches each URL to the first route whose pattern matches
+// and to the fallback driver otherwise.
+type routedDriver struct {
+	fallback ContentDriver
+	routes   []compiledRoute
+}
+
+// NewRoutedDriver creates the fallback driver and one driver per route. With
+// no routes it returns the fallback driver itself.
+func NewRoutedDriver(fallback string, routes []DriverRoute, opts DriverOptions) (ContentDriver, error) {
+	base, err := NewDriver(fallback, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return base, nil
+	}
+
+	built := map[string]ContentDriver{fallback: base}
+	d := &routedDriver{fallback: base}
+	for _, route := range routes {
+		re, err := regexp.Compile(route.Match)
+		if err != nil {
+			return nil, fmt.Errorf("driver route %q: %w", route.Match, err)
+		}
+		driver, ok := built[route.Driver]
+		if !ok {
+			if driver, err = NewDriver(route.Driver, opts); err != nil {
+				return nil, fmt.Errorf("driver route %q: %w", route.Match, err)
+			}
+			built[route.Driver] = driver
+		}
+		d.routes = append(d.routes, compiledRoute{re: re, driver: driver})
+	}
+
+	return d, nil
+}
+
+// Name reports the fallback driver; routed URLs name their driver in logs.
+func (d *routedDriver) Name() string { return d.fallback.Name() }
+
+func (d *routedDriver) FetchContent(ctx context.Context, urlStr, contentType string) *types.ContentFetchResult {
+	return d.route(urlStr).FetchContent(ctx, urlStr, contentType)
+}
+
+func (d *routedDriver) route(urlStr string) ContentDriver {
+	for _, r := range d.routes {
+		if r.re.MatchString(urlStr) {
+			slog.Info("FetchContent routed", "url", urlStr, "driver", r.driver.Name())
+
+			return r.driver
+		}
+	}
+
+	return d.fallback
+}
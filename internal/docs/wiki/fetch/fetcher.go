@@ -26,6 +26,8 @@ const noneVal = "none"
 // Fetcher handles fetching content from various sources.
 type Fetcher struct {
 	driver       ContentDriver
+	cache        *Cache
+	cacheOnly    bool
 	GHClient     *resty.Client
 	GHBaseURL    string
 	MaxBodySize  int
@@ -45,6 +47,17 @@ func WithMediaEnabled(enabled bool) FetcherOption {
 	return func(f *Fetcher) { f.MediaEnabled = enabled }
 }
 
+// WithCache serves fresh cached results and stores successful fetches in c.
+func WithCache(c *Cache) FetcherOption {
+	return func(f *Fetcher) { f.cache = c }
+}
+
+// WithCacheOnly serves cached results regardless of age and never fetches;
+// a cache miss is reported as a fetch error. Used to replay digests offline.
+func WithCacheOnly() FetcherOption {
+	return func(f *Fetcher) { f.cacheOnly = true }
+}
+
 // NewFetcher creates a new Fetcher with default settings.
 func NewFetcher(opts ...FetcherOption) *Fetcher {
 	f := &Fetcher{
@@ -71,8 +84,37 @@ func NewFetcher(opts ...FetcherOption) *Fetcher {
 
 // FetchContent fetches content based on the URL pattern.
 // GitHub repos and podcasts are handled directly; all other URLs
-// are delegated to the configured ContentDriver.
+// are delegated to the configured ContentDriver. With a cache, fresh
+// cached results are returned without fetching.
 func (f *Fetcher) FetchContent(ctx context.Context, urlStr, contentType string) *types.ContentFetchResult {
+	if f.cache == nil {
+		return f.fetchContent(ctx, urlStr, contentType)
+	}
+	if f.cacheOnly {
+		entry, err := f.cache.Load(urlStr)
+		if err != nil {
+			return &types.ContentFetchResult{Error: "not in fetch cache (offline replay)"}
+		}
+		result := entry.Result
+
+		return &result
+	}
+	if entry, ok := f.cache.Get(urlStr); ok {
+		slog.Info("FetchContent cache hit", "url", urlStr, "fetchedAt", entry.FetchedAt)
+		result := entry.Result
+
+		return &result
+	}
+
+	result := f.fetchContent(ctx, urlStr, contentType)
+	if err := f.cache.Put(urlStr, contentType, result); err != nil {
+		slog.Warn("Failed to cache fetch result", "url", urlStr, "error", err)
+	}
+
+	return result
+}
+
+func (f *Fetcher) fetchContent(ctx context.Context, urlStr, contentType string) *types.ContentFetchResult {
 	slog.Info("FetchContent", "url", urlStr, "type", contentType)
 
 	if err := urlutil.ValidateURL(urlStr); err != nil {
@@ -0,0 +1,38 @@
+package related
+
+import (
+	"path"
+	"strings"
+
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+// Entry is a Document parsed from a summary.md together with its indexed text.
+type Entry struct {
+	Document
+	Text string
+}
+
+// ParseSummary extracts the "### title" entries of a summary.md. Only the
+// overview and keyPoints sections and the tags metadata line are kept as
+// text, so an entry's own related section never feeds back into the index.
+func ParseSummary(rel, raw string) []Entry {
+	_, body := wikiwrite.SplitSummary(raw)
+	topic := path.Dir(rel)
+
+	var entries []Entry
+	for _, e := range wikiwrite.ParseSummaryEntries(body) {
+		text := e.Tags()
+		for _, section := range []string{"overview", "keyPoints"} {
+			for _, line := range e.Sections[section] {
+				text = append(text, strings.TrimPrefix(line, "- "))
+			}
+		}
+		entries = append(entries, Entry{
+			Document: Document{URL: e.URL, Title: e.Title, TopicPath: topic, Path: rel},
+			Text:     strings.Join(text, "\n"),
+		})
+	}
+
+	return entries
+}
@@ -0,0 +1,244 @@
+// Package related finds wiki entries that overlap with each other using a
+// local TF-IDF index over summary overviews, key points and tags.
+package related
+
+import (
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// DefaultMinScore is the cosine similarity below which matches are dropped.
+const DefaultMinScore = 0.15
+
+// Document is one indexed wiki entry.
+type Document struct {
+	URL       string `json:"url"`
+	Title     string `json:"title"`
+	TopicPath string `json:"topicPath"`
+	Path      string `json:"path"` // summary.md, relative to the wiki root
+	terms     map[string]int
+}
+
+// Match is a Document scored against a query.
+type Match struct {
+	Document
+	Score float64 `json:"score"`
+}
+
+// Index is an in-memory TF-IDF index of wiki entries keyed by normalized URL.
+type Index struct {
+	docs     map[string]*Document
+	df       map[string]int
+	MinScore float64
+	mu       sync.RWMutex
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{docs: map[string]*Document{}, df: map[string]int{}, MinScore: DefaultMinScore}
+}
+
+// Build indexes every entry of every summary.md under wikiRoot.
+func Build(wikiRoot string) (*Index, error) {
+	ix := NewIndex()
+	err := filepath.WalkDir(wikiRoot, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || d.Name() != wikiwrite.SummaryFilename {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(wikiRoot, path)
+		if err != nil {
+			return err
+		}
+		for _, entry := range ParseSummary(filepath.ToSlash(rel), string(data)) {
+			ix.Add(entry.Document, entry.Text)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan wiki summaries: %w", err)
+	}
+
+	return ix, nil
+}
+
+// Len returns the number of indexed entries.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return len(ix.docs)
+}
+
+// Add indexes text under doc.URL, replacing any earlier version of the entry.
+func (ix *Index) Add(doc Document, text string) {
+	terms := termFreq(Tokenize(text))
+	if doc.URL == "" || len(terms) == 0 {
+		return
+	}
+	key := urlutil.Normalize(doc.URL)
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if old, ok := ix.docs[key]; ok {
+		for t := range old.terms {
+			ix.df[t]--
+		}
+	}
+	doc.terms = terms
+	ix.docs[key] = &doc
+	for t := range terms {
+		ix.df[t]++
+	}
+}
+
+// Query returns up to limit entries most similar to text, best first,
+// skipping excludeURL (usually the entry being written).
+func (ix *Index) Query(text string, limit int, excludeURL string) []Match {
+	query := termFreq(Tokenize(text))
+
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	return ix.query(query, limit, excludeURL)
+}
+
+// Similar returns up to limit entries most similar to the indexed entry for
+// urlStr; ok is false when urlStr is not indexed.
+func (ix *Index) Similar(urlStr string, limit int) ([]Match, bool) {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+
+	doc, ok := ix.docs[urlutil.Normalize(urlStr)]
+	if !ok {
+		return nil, false
+	}
+
+	return ix.query(doc.terms, limit, urlStr), true
+}
+
+// query scores every entry against terms. Callers hold mu.
+func (ix *Index) query(query map[string]int, limit int, excludeURL string) []Match {
+	if limit <= 0 || len(query) == 0 || len(ix.docs) == 0 {
+		return nil
+	}
+	exclude := ""
+	if excludeURL != "" {
+		exclude = urlutil.Normalize(excludeURL)
+	}
+
+	qvec := ix.weigh(query)
+	qnorm := norm(qvec)
+	var matches []Match
+	for key, doc := range ix.docs {
+		if key == exclude {
+			continue
+		}
+		dvec := ix.weigh(doc.terms)
+		var dot float64
+		for t, w := range qvec {
+			dot += w * dvec[t]
+		}
+		if dot == 0 {
+			continue
+		}
+		score := dot / (qnorm * norm(dvec))
+		if score < ix.MinScore {
+			continue
+		}
+		matches = append(matches, Match{Document: *doc, Score: math.Round(score*1000) / 1000})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+
+		return matches[i].URL < matches[j].URL
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	return matches
+}
+
+// weigh turns term frequencies into tf-idf weights. Callers hold mu.
+func (ix *Index) weigh(terms map[string]int) map[string]float64 {
+	n := float64(len(ix.docs))
+	vec := make(map[string]float64, len(terms))
+	for t, tf := range terms {
+		idf := math.Log((n+1)/float64(ix.df[t]+1)) + 1
+		vec[t] = (1 + math.Log(float64(tf))) * idf
+	}
+
+	return vec
+}
+
+func norm(vec map[string]float64) float64 {
+	var sum float64
+	for _, w := range vec {
+		sum += w * w
+	}
+
+	return math.Sqrt(sum)
+}
+
+func termFreq(tokens []string) map[string]int {
+	tf := make(map[string]int, len(tokens))
+	for _, t := range tokens {
+		tf[t]++
+	}
+
+	return tf
+}
+
+// SummaryText is the text indexed for an entry: overview, key points and tags.
+func SummaryText(summary *types.StructuredSummary, tags []string) string {
+	var parts []string
+	if summary != nil {
+		parts = append(parts, summary.Overview)
+		parts = append(parts, summary.KeyPoints...)
+	}
+	parts = append(parts, tags...)
+
+	return strings.Join(parts, "\n")
+}
+
+// ItemText is SummaryText for a classified item, reading tags from its
+// metadata block.
+func ItemText(item *types.ClassifyItem) string {
+	if item == nil {
+		return ""
+	}
+	tags := wikiwrite.SplitTags(wikiwrite.ParseSummaryMeta(item.MetadataBlock)["tags"])
+
+	return SummaryText(item.Summary, tags)
+}
+
+// ToRelatedEntries converts matches to the entries rendered under a new
+// wiki entry.
+func ToRelatedEntries(matches []Match) []types.RelatedEntry {
+	out := make([]types.RelatedEntry, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, types.RelatedEntry{Title: m.Title, URL: m.URL, TopicPath: m.TopicPath, Score: m.Score})
+	}
+
+	return out
+}
@@ -0,0 +1,120 @@
+package related
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+const testSummary = `---
+title: rag
+---
+
+## 2026-10-01
+
+### RAG pipelines
+
+` + "```markdown\nURL: https://example.com/rag\nType: text\ntags: rag, embedding, 向量检索\n```" + `
+
+#### overview
+Retrieval augmented generation with vector search and embedding models.
+
+#### keyPoints
+- chunking strategy matters for recall
+- 向量数据库 的选型
+
+#### related
+- [Kubernetes](https://example.com/k8s) · devops/k8s/ops
+
+### Kubernetes operators
+
+` + "```markdown\nURL: https://example.com/k8s\ntags: kubernetes, operator\n```" + `
+
+#### overview
+Writing Kubernetes operators with controller-runtime reconcile loops.
+
+#### keyPoints
+- reconcile loops should be idempotent
+`
+
+func writeSummary(t *testing.T, root, topic, content string) {
+	t.Helper()
+	dir := filepath.Join(root, filepath.FromSlash(topic))
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, wikiwrite.SummaryFilename), []byte(content), 0o600))
+}
+
+func TestTokenizeCJKBigramsAndLatinWords(t *testing.T) {
+	assert.Equal(t, []string{"分布", "布式", "raft", "共识"}, Tokenize("分布式 The Raft 共识"))
+	assert.Equal(t, []string{"库"}, Tokenize("a 库"))
+}
+
+func TestParseSummaryKeepsOverviewKeyPointsAndTags(t *testing.T) {
+	entries := ParseSummary("ai/llm/rag/summary.md", testSummary)
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://example.com/rag", entries[0].URL)
+	assert.Equal(t, "RAG pipelines", entries[0].Title)
+	assert.Equal(t, "ai/llm/rag", entries[0].TopicPath)
+	assert.Contains(t, entries[0].Text, "向量检索")
+	assert.Contains(t, entries[0].Text, "chunking strategy")
+	assert.NotContains(t, entries[0].Text, "Kubernetes", "related section is not indexed")
+}
+
+func TestBuildAndQuery(t *testing.T) {
+	root := t.TempDir()
+	writeSummary(t, root, "ai/llm/rag", testSummary)
+
+	ix, err := Build(root)
+	require.NoError(t, err)
+	require.Equal(t, 2, ix.Len())
+
+	matches := ix.Query("embedding models for vector search and 向量数据库", 3, "")
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "https://example.com/rag", matches[0].URL)
+	assert.Equal(t, "ai/llm/rag", matches[0].TopicPath)
+
+	assert.Empty(t, ix.Query("embedding vector search", 3, "https://example.com/rag/"), "excluded URL is normalized")
+	assert.Empty(t, ix.Query("cooking recipes", 3, ""))
+}
+
+func TestAddReplacesEntry(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(Document{URL: "https://example.com/a", Title: "A"}, "golang generics")
+	ix.Add(Document{URL: "https://example.com/a", Title: "A2"}, "rust borrow checker")
+
+	require.Equal(t, 1, ix.Len())
+	assert.Empty(t, ix.Query("golang generics", 3, ""))
+	matches := ix.Query("rust borrow checker", 3, "")
+	require.Len(t, matches, 1)
+	assert.Equal(t, "A2", matches[0].Title)
+}
+
+func TestItemTextReadsTagsFromMetadata(t *testing.T) {
+	text := ItemText(&types.ClassifyItem{
+		MetadataBlock: "Type: text\ntags: raft, consensus",
+		Summary:       &types.StructuredSummary{Overview: "overview", KeyPoints: []string{"point"}},
+	})
+	assert.Equal(t, "overview\npoint\nraft\nconsensus", text)
+}
+
+func TestSimilarUsesIndexedEntry(t *testing.T) {
+	ix := NewIndex()
+	ix.Add(Document{URL: "https://example.com/a", Title: "A"}, "raft consensus replicated log")
+	ix.Add(Document{URL: "https://example.com/b", Title: "B"}, "raft consensus leader election")
+	ix.Add(Document{URL: "https://example.com/c", Title: "C"}, "css grid layout")
+
+	matches, ok := ix.Similar("https://example.com/a", 5)
+	require.True(t, ok)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "B", matches[0].Title)
+
+	_, ok = ix.Similar("https://example.com/missing", 5)
+	assert.False(t, ok)
+}
@@ -0,0 +1,64 @@
+package related
+
+import "unicode"
+
+// stopwords are common English words that carry no topical signal.
+var stopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "that": true, "this": true,
+	"are": true, "was": true, "from": true, "into": true, "its": true, "can": true,
+	"you": true, "not": true, "but": true, "has": true, "have": true, "how": true,
+	"what": true, "when": true, "which": true, "will": true, "more": true, "than": true,
+}
+
+// Tokenize splits text into index terms. Latin and digit runs become
+// lowercased words (two or more characters, minus stopwords); CJK runs,
+// which have no spaces, become overlapping character bigrams so 分布式 and
+// 分布式系统 still share terms.
+func Tokenize(text string) []string {
+	var (
+		tokens []string
+		word   []rune
+		cjk    []rune
+	)
+	flushWord := func() {
+		if len(word) >= 2 {
+			if w := string(word); !stopwords[w] {
+				tokens = append(tokens, w)
+			}
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			tokens = append(tokens, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+1 < len(cjk); i++ {
+				tokens = append(tokens, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, unicode.ToLower(r))
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
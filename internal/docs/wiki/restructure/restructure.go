@@ -0,0 +1,350 @@
+// Package restructure moves and merges wiki topic directories and rewrites
+// what refers to them: the topic title in frontmatter, related-entry lines
+// and relative links in every wiki page, the URL ledger and the digest logs.
+package restructure
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	wikiclassify "github.com/xbpk3t/docs-alfred/internal/docs/wiki/classify"
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// Restructuring modes.
+const (
+	ModeMove  = "move"
+	ModeMerge = "merge"
+)
+
+// Options selects the topic to move or merge. From and To are topic paths
+// (folder/type/topic) relative to WikiRoot. When ValidTopicPaths is set, To
+// must be one of them.
+type Options struct {
+	ValidTopicPaths map[string]bool
+	WikiRoot        string
+	From            string
+	To              string
+	DryRun          bool
+}
+
+// Result records what a move or merge changed. File paths are relative to
+// the wiki root.
+type Result struct {
+	Mode           string   `json:"mode"`
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	MovedFiles     []string `json:"movedFiles"`
+	MergedFiles    []string `json:"mergedFiles,omitempty"`
+	RewrittenPages []string `json:"rewrittenPages,omitempty"`
+	LedgerEntries  int      `json:"ledgerEntries"`
+	DigestEntries  int      `json:"digestEntries"`
+	DryRun         bool     `json:"dryRun"`
+}
+
+// fileOp moves one file of the source topic into the target topic.
+type fileOp struct {
+	src   string // relative to the wiki root
+	dst   string
+	merge bool // dst exists and src is appended to it
+}
+
+// Move renames topic From to To. To must not exist yet.
+func Move(opts Options) (*Result, error) {
+	return run(opts, ModeMove)
+}
+
+// Merge moves every file of topic From into topic To and removes From.
+// summary.md date sections are combined; other markdown pages that exist in
+// both topics get the From body appended. To is created when missing.
+func Merge(opts Options) (*Result, error) {
+	return run(opts, ModeMerge)
+}
+
+func run(opts Options, mode string) (*Result, error) {
+	if err := validate(opts, mode); err != nil {
+		return nil, err
+	}
+	ops, err := plan(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Mode: mode, From: opts.From, To: opts.To, DryRun: opts.DryRun}
+	for _, op := range ops {
+		if op.merge {
+			result.MergedFiles = append(result.MergedFiles, op.dst)
+		} else {
+			result.MovedFiles = append(result.MovedFiles, op.dst)
+		}
+	}
+
+	// Page rewrites are computed from the old and new location of each page,
+	// so relative links resolve the same way after the move, but only written
+	// once every file has moved: a failed move leaves the links untouched.
+	staged, changed, err := rewritePages(opts)
+	if err != nil {
+		return nil, err
+	}
+	result.RewrittenPages = changed
+	if !opts.DryRun {
+		if err := apply(opts, ops, staged); err != nil {
+			return result, err
+		}
+		if err := writePages(opts, staged); err != nil {
+			return result, err
+		}
+	}
+	if result.LedgerEntries, err = retargetLedger(opts); err != nil {
+		return result, err
+	}
+	if result.DigestEntries, err = wikiwrite.RetargetDigestLogs(opts.WikiRoot, opts.From, opts.To, opts.DryRun); err != nil {
+		return result, fmt.Errorf("update digest logs: %w", err)
+	}
+	slog.Info("Wiki topic restructured", "mode", mode, "from", opts.From, "to", opts.To,
+		"files", len(ops), "pages", len(result.RewrittenPages), "dryRun", opts.DryRun)
+
+	return result, nil
+}
+
+func validate(opts Options, mode string) error {
+	if opts.WikiRoot == "" {
+		return errors.New("wiki root is required")
+	}
+	for _, p := range []string{opts.From, opts.To} {
+		if !wikiclassify.ValidateTopicPathDepth(p) || strings.Contains(p, "..") || strings.HasPrefix(p, "/") {
+			return fmt.Errorf("invalid topic path %q: want folder/type/topic", p)
+		}
+	}
+	if opts.From == opts.To {
+		return fmt.Errorf("source and target are the same topic: %s", opts.From)
+	}
+	if opts.ValidTopicPaths != nil && !opts.ValidTopicPaths[opts.To] {
+		return fmt.Errorf("target topic not in data/gh topics: %s (add it to data/gh first)", opts.To)
+	}
+
+	info, err := os.Stat(topicDir(opts.WikiRoot, opts.From))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("wiki topic dir not found: %s", opts.From)
+	}
+	if _, err := os.Stat(topicDir(opts.WikiRoot, opts.To)); err == nil && mode == ModeMove {
+		return fmt.Errorf("wiki topic dir already exists: %s (use merge)", opts.To)
+	}
+
+	return nil
+}
+
+// plan lists the file operations and rejects conflicts before anything is
+// changed: a non-markdown file present in both topics cannot be merged.
+func plan(opts Options) ([]fileOp, error) {
+	fromDir := topicDir(opts.WikiRoot, opts.From)
+	var ops []fileOp
+	err := filepath.WalkDir(fromDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(fromDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		op := fileOp{src: opts.From + "/" + rel, dst: opts.To + "/" + rel}
+		if _, err := os.Stat(filepath.Join(opts.WikiRoot, filepath.FromSlash(op.dst))); err == nil {
+			if filepath.Ext(rel) != ".md" {
+				return fmt.Errorf("cannot merge %s: %s already exists", op.src, op.dst)
+			}
+			op.merge = true
+		}
+		ops = append(ops, op)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan topic %s: %w", opts.From, err)
+	}
+
+	return ops, nil
+}
+
+// apply moves and merges the files of ops. Merged pages are combined from
+// their staged rewrites, which are consumed; the remaining rewrites are left
+// for writePages.
+func apply(opts Options, ops []fileOp, staged map[string]string) error {
+	for _, op := range ops {
+		src := filepath.Join(opts.WikiRoot, filepath.FromSlash(op.src))
+		dst := filepath.Join(opts.WikiRoot, filepath.FromSlash(op.dst))
+		if !op.merge {
+			if err := fileutil.EnsureDir(filepath.Dir(dst)); err != nil {
+				return fmt.Errorf("create %s: %w", filepath.Dir(op.dst), err)
+			}
+			if err := os.Rename(src, dst); err != nil {
+				return fmt.Errorf("move %s: %w", op.src, err)
+			}
+
+			continue
+		}
+
+		if err := mergeFile(src, dst, op, staged); err != nil {
+			return fmt.Errorf("merge %s into %s: %w", op.src, op.dst, err)
+		}
+		delete(staged, op.src)
+		delete(staged, op.dst)
+	}
+	if err := os.RemoveAll(topicDir(opts.WikiRoot, opts.From)); err != nil {
+		return fmt.Errorf("remove topic dir %s: %w", opts.From, err)
+	}
+
+	return nil
+}
+
+// mergeFile merges src into dst, taking either page from its staged rewrite
+// when it has one.
+func mergeFile(src, dst string, op fileOp, staged map[string]string) error {
+	srcData, err := readUnlessStaged(src, staged, op.src)
+	if err != nil {
+		return err
+	}
+	dstData, err := readUnlessStaged(dst, staged, op.dst)
+	if err != nil {
+		return err
+	}
+
+	var merged string
+	if filepath.Base(dst) == wikiwrite.SummaryFilename {
+		merged = mergeSummaries(dstData, srcData)
+	} else {
+		_, srcBody := wikiwrite.SplitSummary(srcData)
+		merged = strings.TrimRight(dstData, "\n") + "\n\n" + strings.TrimLeft(srcBody, "\n")
+	}
+
+	return fileutil.AtomicWriteFile(dst, []byte(merged), fileutil.FilePermPrivate)
+}
+
+func readUnlessStaged(path string, staged map[string]string, rel string) (string, error) {
+	if content, ok := staged[rel]; ok {
+		return content, nil
+	}
+	data, err := os.ReadFile(path)
+
+	return string(data), err
+}
+
+// retargetLedger updates the URL ledger when one has been written.
+func retargetLedger(opts Options) (int, error) {
+	// Without a ledger file there is nothing to update: it is built from the
+	// summaries on first use.
+	if _, err := os.Stat(filepath.Join(opts.WikiRoot, wikiwrite.LedgerFilename)); errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	ledger, err := wikiwrite.LoadURLLedger(opts.WikiRoot)
+	if err != nil {
+		return 0, fmt.Errorf("load url ledger: %w", err)
+	}
+	n := ledger.RetargetTopic(opts.From, opts.To)
+	if n == 0 || opts.DryRun {
+		return n, nil
+	}
+	if err := ledger.Save(); err != nil {
+		return n, fmt.Errorf("save url ledger: %w", err)
+	}
+
+	return n, nil
+}
+
+func topicDir(wikiRoot, topic string) string {
+	return filepath.Join(wikiRoot, filepath.FromSlash(topic))
+}
+
+// movedPath maps a wiki-relative slash path inside topic from to topic to.
+func movedPath(rel, from, to string) string {
+	if rel == from {
+		return to
+	}
+	if rest, ok := strings.CutPrefix(rel, from+"/"); ok {
+		return path.Join(to, rest)
+	}
+
+	return rel
+}
+
+// listPages returns every markdown page under wikiRoot, skipping dot dirs.
+func listPages(wikiRoot string) ([]string, error) {
+	var pages []string
+	err := filepath.WalkDir(wikiRoot, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			if p != wikiRoot && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		if filepath.Ext(p) != ".md" {
+			return nil
+		}
+		rel, err := filepath.Rel(wikiRoot, p)
+		if err != nil {
+			return err
+		}
+		pages = append(pages, filepath.ToSlash(rel))
+
+		return nil
+	})
+	slices.Sort(pages)
+
+	return pages, err
+}
+
+// Summary returns count-oriented details for structured output.
+func (r *Result) Summary() map[string]any {
+	return map[string]any{
+		"mode":           r.Mode,
+		"movedFiles":     len(r.MovedFiles),
+		"mergedFiles":    len(r.MergedFiles),
+		"rewrittenPages": len(r.RewrittenPages),
+		"ledgerEntries":  r.LedgerEntries,
+		"digestEntries":  r.DigestEntries,
+		"dryRun":         r.DryRun,
+	}
+}
+
+// Actions returns one line per change for human-readable output.
+func (r *Result) Actions() []string {
+	verb := func(planned, done string) string {
+		if r.DryRun {
+			return "dry-run: would " + planned
+		}
+
+		return done
+	}
+	moved := verb("move", "moved")
+	if r.Mode == ModeMerge {
+		moved = verb("merge", "merged")
+	}
+	actions := []string{fmt.Sprintf("%s %s → %s (%d file(s) moved, %d merged)",
+		moved, r.From, r.To, len(r.MovedFiles), len(r.MergedFiles))}
+	for _, page := range r.RewrittenPages {
+		actions = append(actions, verb("rewrite", "rewrote")+" "+page)
+	}
+	if r.LedgerEntries > 0 {
+		actions = append(actions, fmt.Sprintf("%s %d URL ledger entry(ies)", verb("retarget", "retargeted"), r.LedgerEntries))
+	}
+	if r.DigestEntries > 0 {
+		actions = append(actions, fmt.Sprintf("%s %d digest log entry(ies)", verb("retarget", "retargeted"), r.DigestEntries))
+	}
+
+	return actions
+}
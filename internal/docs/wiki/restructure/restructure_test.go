@@ -0,0 +1,184 @@
+package restructure
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+)
+
+const oldSummary = `---
+title: pg
+date: "2026-10-02"
+source: rss2nl-wiki
+type: digest
+batch_id: wiki-2026-10-02
+total_urls: 2
+succeeded: 2
+failed: 0
+---
+
+## 2026-10-02
+
+### Postgres vacuum
+
+` + "```markdown\nURL: https://example.com/vacuum\n```" + `
+
+#### related
+- [Index tuning](https://example.com/index) · db/sql/pg
+
+## 2026-09-30
+
+### Index tuning
+
+` + "```markdown\nURL: https://example.com/index\n```" + `
+
+See [log](log.md) and [mysql](../mysql/summary.md#2026-09-01).
+`
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o750))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+}
+
+func readFile(t *testing.T, root, rel string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func newTestWiki(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFile(t, root, "db/sql/pg/summary.md", oldSummary)
+	writeFile(t, root, "db/sql/pg/log.md", "---\ntitle: pg\ndate: 2026-10-01\nsource: manual\ntype: log\n---\n\nnotes\n")
+	writeFile(t, root, "db/sql/mysql/summary.md", "---\ntitle: mysql\n---\n\n#### related\n- [Vacuum](https://example.com/vacuum) · db/sql/pg\n\nSee [pg](../pg/summary.md).\n")
+	writeFile(t, root, "digest-success.jsonl",
+		`{"timestamp":"t","url":"https://example.com/vacuum","stage":"write","status":"success","topicPath":"db/sql/pg","outputPath":"`+
+			filepath.ToSlash(filepath.Join(root, "db/sql/pg/summary.md"))+`"}`+"\n"+
+			`{"timestamp":"t","url":"https://example.com/x","stage":"write","status":"success","topicPath":"db/sql/mysql"}`+"\n")
+	ledger, err := wikiwrite.BuildURLLedger(root)
+	require.NoError(t, err)
+	require.NoError(t, ledger.Save())
+
+	return root
+}
+
+func TestMoveRewritesPagesLedgerAndDigestLog(t *testing.T) {
+	root := newTestWiki(t)
+
+	result, err := Move(Options{
+		WikiRoot:        root,
+		From:            "db/sql/pg",
+		To:              "db/rdbms/postgres",
+		ValidTopicPaths: map[string]bool{"db/rdbms/postgres": true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db/rdbms/postgres/log.md", "db/rdbms/postgres/summary.md"}, result.MovedFiles)
+	assert.ElementsMatch(t, []string{"db/rdbms/postgres/log.md", "db/rdbms/postgres/summary.md", "db/sql/mysql/summary.md"}, result.RewrittenPages)
+	assert.Equal(t, 2, result.LedgerEntries)
+	assert.Equal(t, 1, result.DigestEntries)
+	assert.NoDirExists(t, filepath.Join(root, "db/sql/pg"))
+
+	summary := readFile(t, root, "db/rdbms/postgres/summary.md")
+	assert.Contains(t, summary, "\ntitle: postgres\n")
+	assert.Contains(t, summary, "- [Index tuning](https://example.com/index) · db/rdbms/postgres")
+	assert.Contains(t, summary, "See [log](log.md) and [mysql](../../sql/mysql/summary.md#2026-09-01).")
+	assert.Contains(t, readFile(t, root, "db/rdbms/postgres/log.md"), "\ntitle: postgres\n")
+
+	mysql := readFile(t, root, "db/sql/mysql/summary.md")
+	assert.Contains(t, mysql, "· db/rdbms/postgres")
+	assert.Contains(t, mysql, "[pg](../../rdbms/postgres/summary.md)")
+	assert.Contains(t, mysql, "\ntitle: mysql\n")
+
+	ledger, err := wikiwrite.LoadURLLedger(root)
+	require.NoError(t, err)
+	entry, ok := ledger.Lookup("https://example.com/vacuum")
+	require.True(t, ok)
+	assert.Equal(t, "db/rdbms/postgres", entry.TopicPath)
+	assert.Equal(t, "db/rdbms/postgres/summary.md", entry.Path)
+
+	log := readFile(t, root, "digest-success.jsonl")
+	assert.Contains(t, log, `"topicPath":"db/rdbms/postgres"`)
+	assert.Contains(t, log, filepath.ToSlash(filepath.Join(root, "db/rdbms/postgres/summary.md")))
+	assert.Contains(t, log, `"topicPath":"db/sql/mysql"`)
+}
+
+func TestMergeCombinesSummarySections(t *testing.T) {
+	root := newTestWiki(t)
+	writeFile(t, root, "db/rdbms/postgres/summary.md", `---
+title: postgres
+date: "2026-09-30"
+source: rss2nl-wiki
+type: digest
+total_urls: 1
+succeeded: 1
+---
+
+## 2026-09-30
+
+### WAL internals
+
+`+"```markdown\nURL: https://example.com/wal\n```\n")
+
+	_, err := Move(Options{WikiRoot: root, From: "db/sql/pg", To: "db/rdbms/postgres"})
+	require.ErrorContains(t, err, "use merge")
+
+	result, err := Merge(Options{WikiRoot: root, From: "db/sql/pg", To: "db/rdbms/postgres"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db/rdbms/postgres/summary.md"}, result.MergedFiles)
+	assert.Equal(t, []string{"db/rdbms/postgres/log.md"}, result.MovedFiles)
+
+	merged := readFile(t, root, "db/rdbms/postgres/summary.md")
+	fm, body := wikiwrite.SplitSummary(merged)
+	require.NotNil(t, fm)
+	assert.Equal(t, "postgres", fm.Title)
+	assert.Equal(t, "2026-10-02", fm.Date)
+	assert.Equal(t, 3, fm.TotalURLs)
+	assert.Equal(t, 1, strings.Count(body, "## 2026-09-30"))
+	assert.Less(t, strings.Index(body, "## 2026-10-02"), strings.Index(body, "## 2026-09-30"))
+	assert.Less(t, strings.Index(body, "### WAL internals"), strings.Index(body, "### Index tuning"))
+	assert.Contains(t, body, "- [Index tuning](https://example.com/index) · db/rdbms/postgres")
+	assert.Contains(t, body, "[mysql](../../sql/mysql/summary.md#2026-09-01)")
+	assert.NoDirExists(t, filepath.Join(root, "db/sql/pg"))
+}
+
+func TestFailedMoveLeavesPagesUntouched(t *testing.T) {
+	root := newTestWiki(t)
+	mysql := readFile(t, root, "db/sql/mysql/summary.md")
+	// A file where the target's parent directory should be makes the move fail.
+	writeFile(t, root, "db/rdbms", "")
+
+	_, err := Move(Options{WikiRoot: root, From: "db/sql/pg", To: "db/rdbms/postgres"})
+	require.Error(t, err)
+	assert.Equal(t, mysql, readFile(t, root, "db/sql/mysql/summary.md"))
+	assert.Equal(t, oldSummary, readFile(t, root, "db/sql/pg/summary.md"))
+}
+
+func TestDryRunAndValidation(t *testing.T) {
+	root := newTestWiki(t)
+
+	result, err := Move(Options{WikiRoot: root, From: "db/sql/pg", To: "db/rdbms/postgres", DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, result.RewrittenPages, 3)
+	assert.Equal(t, 2, result.LedgerEntries)
+	assert.DirExists(t, filepath.Join(root, "db/sql/pg"))
+	assert.Equal(t, oldSummary, readFile(t, root, "db/sql/pg/summary.md"))
+	assert.Contains(t, result.Actions()[0], "dry-run: would move db/sql/pg → db/rdbms/postgres")
+
+	_, err = Move(Options{WikiRoot: root, From: "db/sql/pg", To: "db/rdbms/postgres", ValidTopicPaths: map[string]bool{}})
+	require.ErrorContains(t, err, "not in data/gh topics")
+	_, err = Move(Options{WikiRoot: root, From: "db/sql/missing", To: "db/rdbms/postgres"})
+	require.ErrorContains(t, err, "not found")
+	_, err = Move(Options{WikiRoot: root, From: "db/sql/pg", To: "db/postgres"})
+	require.ErrorContains(t, err, "invalid topic path")
+}
@@ -0,0 +1,242 @@
+package restructure
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	wikiwrite "github.com/xbpk3t/docs-alfred/internal/docs/wiki/write"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// markdownLinkRe captures the target of an inline markdown link.
+var markdownLinkRe = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+
+// rewritePages computes the new content of every page that mentions the
+// moved topic, keyed by the page's path before the move, and returns it
+// with the pages changed, by their path after the move. Nothing is written:
+// writePages does that once the files are in place.
+func rewritePages(opts Options) (map[string]string, []string, error) {
+	pages, err := listPages(opts.WikiRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list wiki pages: %w", err)
+	}
+
+	staged := map[string]string{}
+	var changed []string
+	for _, rel := range pages {
+		data, err := os.ReadFile(filepath.Join(opts.WikiRoot, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("read %s: %w", rel, err)
+		}
+		content, ok := rewritePage(string(data), rel, opts.From, opts.To)
+		if !ok {
+			continue
+		}
+		staged[rel] = content
+		changed = append(changed, movedPath(rel, opts.From, opts.To))
+	}
+
+	return staged, changed, nil
+}
+
+// writePages writes the staged page rewrites to where the pages live after
+// the move.
+func writePages(opts Options, staged map[string]string) error {
+	for _, rel := range slices.Sorted(maps.Keys(staged)) {
+		dst := movedPath(rel, opts.From, opts.To)
+		if err := fileutil.AtomicWriteFile(filepath.Join(opts.WikiRoot, filepath.FromSlash(dst)), []byte(staged[rel]), fileutil.FilePermPrivate); err != nil {
+			return fmt.Errorf("write %s: %w", dst, err)
+		}
+	}
+
+	return nil
+}
+
+// rewritePage rewrites one page located at rel (before the move). Pages of
+// the moved topic get the new topic title; every page gets its related-entry
+// lines and relative links pointed at the new location.
+func rewritePage(content, rel, from, to string) (string, bool) {
+	out := content
+	if movedPath(rel, from, to) != rel {
+		out = retitle(out, path.Base(from), path.Base(to))
+	}
+	out = rewriteRelatedLines(out, from, to)
+	out = rewriteLinks(out, rel, from, to)
+
+	return out, out != content
+}
+
+// retitle replaces a frontmatter title equal to the old topic name.
+func retitle(content, oldTitle, newTitle string) string {
+	if !strings.HasPrefix(content, "---\n") {
+		return content
+	}
+	end := strings.Index(content[4:], "\n---")
+	if end < 0 {
+		return content
+	}
+	head := content[:4+end]
+	for _, quoted := range []string{oldTitle, `"` + oldTitle + `"`, `'` + oldTitle + `'`} {
+		line := "\ntitle: " + quoted + "\n"
+		if strings.Contains(head+"\n", line) {
+			head = strings.Replace(head+"\n", line, "\ntitle: "+newTitle+"\n", 1)
+			head = strings.TrimSuffix(head, "\n")
+
+			break
+		}
+	}
+
+	return head + content[4+end:]
+}
+
+// rewriteRelatedLines updates the topic suffix of "#### related" lines
+// ("- [title](url) · topic").
+func rewriteRelatedLines(content, from, to string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "- [") {
+			continue
+		}
+		if head, ok := strings.CutSuffix(line, " · "+from); ok {
+			lines[i] = head + " · " + to
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// rewriteLinks re-resolves the relative links of a page: targets inside the
+// moved topic follow it, and a page that moves keeps its links to other
+// pages working from its new directory.
+func rewriteLinks(content, rel, from, to string) string {
+	oldDir := path.Dir(rel)
+	newDir := path.Dir(movedPath(rel, from, to))
+
+	return markdownLinkRe.ReplaceAllStringFunc(content, func(m string) string {
+		target := m[2 : len(m)-1]
+		if strings.Contains(target, "://") || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "mailto:") {
+			return m
+		}
+		link, fragment, _ := strings.Cut(target, "#")
+		if link == "" {
+			return m
+		}
+
+		var resolved string
+		rooted := strings.HasPrefix(link, "/")
+		if rooted {
+			resolved = path.Clean(strings.TrimPrefix(link, "/"))
+		} else {
+			resolved = path.Join(oldDir, link)
+		}
+		if strings.HasPrefix(resolved, "../") {
+			return m // outside the wiki
+		}
+		moved := movedPath(resolved, from, to)
+		if moved == resolved && oldDir == newDir {
+			return m
+		}
+
+		var newLink string
+		if rooted {
+			newLink = "/" + moved
+		} else {
+			r, err := filepath.Rel(filepath.FromSlash(newDir), filepath.FromSlash(moved))
+			if err != nil {
+				return m
+			}
+			newLink = filepath.ToSlash(r)
+		}
+		if fragment != "" {
+			newLink += "#" + fragment
+		}
+
+		return "](" + newLink + ")"
+	})
+}
+
+// mergeSummaries combines the date sections of two summary.md files, newest
+// first. Entries of a date present in both follow the target's entries. The
+// target frontmatter is kept with the source counters added.
+func mergeSummaries(dst, src string) string {
+	dstFM, dstBody := wikiwrite.SplitSummary(dst)
+	srcFM, srcBody := wikiwrite.SplitSummary(src)
+
+	preamble, sections := splitDateSections(dstBody)
+	srcPreamble, srcSections := splitDateSections(srcBody)
+	preamble += srcPreamble
+	for date, entries := range srcSections {
+		if existing, ok := sections[date]; ok {
+			sections[date] = existing + "\n\n" + entries
+		} else {
+			sections[date] = entries
+		}
+	}
+
+	dates := make([]string, 0, len(sections))
+	for date := range sections {
+		dates = append(dates, date)
+	}
+	slices.Sort(dates)
+	slices.Reverse(dates)
+
+	var b strings.Builder
+	b.WriteString(preamble)
+	for _, date := range dates {
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", date, sections[date])
+	}
+	body := strings.TrimRight(b.String(), "\n") + "\n"
+
+	if dstFM == nil {
+		return body
+	}
+	if srcFM != nil {
+		dstFM.TotalURLs += srcFM.TotalURLs
+		dstFM.Succeeded += srcFM.Succeeded
+		dstFM.Failed += srcFM.Failed
+		dstFM.Date = max(dstFM.Date, srcFM.Date)
+	}
+
+	return wikiwrite.RenderSummary(dstFM, body)
+}
+
+// splitDateSections splits a summary body at its "## YYYY-MM-DD" headings.
+// The text before the first heading is returned as the preamble.
+func splitDateSections(body string) (string, map[string]string) {
+	var (
+		preamble strings.Builder
+		current  string
+		lines    = map[string][]string{}
+	)
+	for _, line := range strings.Split(body, "\n") {
+		if date, ok := wikiwrite.SummaryDateHeading(line); ok {
+			current = date
+			lines[current] = append(lines[current], "")
+
+			continue
+		}
+		if current == "" {
+			preamble.WriteString(line + "\n")
+
+			continue
+		}
+		lines[current] = append(lines[current], line)
+	}
+
+	sections := make(map[string]string, len(lines))
+	for date, ls := range lines {
+		sections[date] = strings.TrimSpace(strings.Join(ls, "\n"))
+	}
+
+	if strings.TrimSpace(preamble.String()) == "" {
+		return "", sections
+	}
+
+	return strings.TrimSpace(preamble.String()) + "\n\n", sections
+}
@@ -30,6 +30,7 @@ type ClassifyItem struct {
 	RouteReason       string             `json:"routeReason,omitempty"`
 	Confidence        float64            `json:"confidence,omitempty"`
 	NeedsManualReview bool               `json:"needsManualReview,omitempty"`
+	Related           []RelatedEntry     `json:"related,omitempty"`
 }
 
 // ClassifyResult is the structured output from classifyItem.
@@ -74,3 +75,11 @@ type EntryMetadata struct {
 	Tags  []string `json:"tags,omitempty"              validate:"required|min_len:3"`
 	Stars int      `json:"stars,omitempty"`
 }
+
+// RelatedEntry links a wiki entry to an earlier entry with overlapping content.
+type RelatedEntry struct {
+	Title     string  `json:"title"`
+	URL       string  `json:"url"`
+	TopicPath string  `json:"topicPath"`
+	Score     float64 `json:"score"`
+}
@@ -1,16 +1,20 @@
 package write
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	carbon "github.com/dromara/carbon/v2"
 	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
 )
 
 // digestFilenames maps outcomes to log files.
@@ -92,3 +96,210 @@ func LogDigestEntry(entry *types.DigestEntry, opts *WriteOptions) (string, error
 
 	return logPath, nil
 }
+
+// digestFilenames lists every digest log file.
+var digestFilenames = []string{
+	digestFilenameSuccess,
+	digestFilenameFetchError,
+	digestFilenameExtractError,
+	digestFilenameAIError,
+	digestFilenameClassifyReject,
+}
+
+// RetargetDigestLogs rewrites the topic and output paths of digest log
+// entries that point at topic from so they point at topic to. Only those
+// fields are patched; other lines, and lines that do not parse, are kept
+// byte for byte. It returns the number of entries changed.
+func RetargetDigestLogs(wikiRoot, from, to string, dryRun bool) (int, error) {
+	total := 0
+	for _, name := range digestFilenames {
+		n, err := retargetDigestLog(filepath.Join(wikiRoot, name), from, to, dryRun)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+// retargetDigestLog holds the log lock from read to write so concurrent
+// LogDigestEntry appends are not lost.
+func retargetDigestLog(logPath, from, to string, dryRun bool) (int, error) {
+	unlock := lockPath(logPath)
+	defer unlock()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("read digest log %s: %w", logPath, err)
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	changed := 0
+	for i, line := range lines {
+		patched, ok, err := retargetDigestLine(line, from, to)
+		if err != nil {
+			return 0, fmt.Errorf("patch digest entry in %s: %w", logPath, err)
+		}
+		if ok {
+			lines[i] = patched
+			changed++
+		}
+	}
+	if changed == 0 || dryRun {
+		return changed, nil
+	}
+	if err := fileutil.AtomicWriteFile(logPath, bytes.Join(lines, []byte("\n")), fileutil.FilePermPrivate); err != nil {
+		return 0, fmt.Errorf("write digest log %s: %w", logPath, err)
+	}
+
+	return changed, nil
+}
+
+// retargetDigestLine patches the topic fields of one log line, keeping every
+// other field of the entry. It reports false when the line is unchanged.
+func retargetDigestLine(line []byte, from, to string) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &fields) != nil {
+		return line, false, nil
+	}
+
+	changed := false
+	for key, retarget := range map[string]func(string) string{
+		"topicPath":      func(v string) string { return retargetTopic(v, from, to) },
+		"suggestedTopic": func(v string) string { return retargetTopic(v, from, to) },
+		"outputPath":     func(v string) string { return retargetOutputPath(v, from, to) },
+	} {
+		var value string
+		if raw, ok := fields[key]; !ok || json.Unmarshal(raw, &value) != nil {
+			continue
+		}
+		next := retarget(value)
+		if next == value {
+			continue
+		}
+		raw, err := json.Marshal(next)
+		if err != nil {
+			return nil, false, err
+		}
+		fields[key], changed = raw, true
+	}
+	if !changed {
+		return line, false, nil
+	}
+	out, err := json.Marshal(fields)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out, true, nil
+}
+
+func retargetTopic(topic, from, to string) string {
+	if topic == from {
+		return to
+	}
+
+	return topic
+}
+
+func retargetOutputPath(outputPath, from, to string) string {
+	sep := string(filepath.Separator)
+	oldDir, newDir := filepath.FromSlash(from)+sep, filepath.FromSlash(to)+sep
+	switch {
+	case strings.HasPrefix(outputPath, oldDir):
+		return newDir + strings.TrimPrefix(outputPath, oldDir)
+	case strings.Contains(outputPath, sep+oldDir):
+		return strings.Replace(outputPath, sep+oldDir, sep+newDir, 1)
+	default:
+		return outputPath
+	}
+}
+
+// ReadDigestEntries returns the entries of every digest log, file by file in
+// append order. Lines that do not parse are skipped.
+func ReadDigestEntries(wikiRoot string) ([]types.DigestEntry, error) {
+	var entries []types.DigestEntry
+	for _, name := range digestFilenames {
+		logPath := filepath.Join(wikiRoot, name)
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, fmt.Errorf("read digest log %s: %w", logPath, err)
+		}
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			var entry types.DigestEntry
+			if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &entry) != nil {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// RemoveDigestFailures drops the failure log lines of the given URLs, keyed
+// by urlutil.Normalize. It returns the number of lines removed.
+func RemoveDigestFailures(wikiRoot string, urls map[string]bool) (int, error) {
+	removed := 0
+	for _, name := range digestFilenames {
+		if name == digestFilenameSuccess {
+			continue
+		}
+		logPath := filepath.Join(wikiRoot, name)
+		n, err := removeDigestLines(logPath, urls)
+		if err != nil {
+			return removed, err
+		}
+		removed += n
+	}
+
+	return removed, nil
+}
+
+func removeDigestLines(logPath string, urls map[string]bool) (int, error) {
+	unlock := lockPath(logPath)
+	defer unlock()
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("read digest log %s: %w", logPath, err)
+	}
+
+	var kept [][]byte
+	removed := 0
+	for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		var entry types.DigestEntry
+		if json.Unmarshal(line, &entry) == nil && urls[urlutil.Normalize(entry.URL)] {
+			removed++
+
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if removed == 0 {
+		return 0, nil
+	}
+
+	out := bytes.Join(kept, []byte("\n"))
+	if len(out) > 0 {
+		out = append(out, '\n')
+	}
+	if err := fileutil.AtomicWriteFile(logPath, out, fileutil.FilePermPrivate); err != nil {
+		return 0, fmt.Errorf("write digest log %s: %w", logPath, err)
+	}
+
+	return removed, nil
+}
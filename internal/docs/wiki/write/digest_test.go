@@ -2,6 +2,9 @@ package write
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -142,3 +145,51 @@ func TestLogDigestEntryUsesOptsBatchIDWhenEmpty(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, string(data), "opts-batch")
 }
+
+func TestRetargetDigestLogsPatchesOnlyTopicFields(t *testing.T) {
+	root := t.TempDir()
+	logPath := filepath.Join(root, digestFilenameSuccess)
+	untouched := `{"url":"https://example.com/b",  "topicPath":"db/sql/mysql"}`
+	require.NoError(t, os.WriteFile(logPath, []byte(
+		`{"url":"https://example.com/a","topicPath":"db/sql/pg","outputPath":"db/sql/pg/summary.md","extra":{"k":1}}`+"\n"+
+			untouched+"\n"+
+			"not json\n"), 0o600))
+
+	n, err := RetargetDigestLogs(root, "db/sql/pg", "db/rdbms/postgres", false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	lines := strings.Split(string(data), "\n")
+	require.Len(t, lines, 4)
+	assert.JSONEq(t, `{"url":"https://example.com/a","topicPath":"db/rdbms/postgres","outputPath":"db/rdbms/postgres/summary.md","extra":{"k":1}}`, lines[0])
+	assert.Equal(t, untouched, lines[1])
+	assert.Equal(t, "not json", lines[2])
+}
+
+func TestRetargetDigestLogsKeepsConcurrentAppends(t *testing.T) {
+	root := t.TempDir()
+	opts := &WriteOptions{WikiRoot: root}
+	for range 50 {
+		_, err := LogDigestEntry(&types.DigestEntry{URL: "https://example.com/a", Status: types.DigestSuccess, TopicPath: "db/sql/pg"}, opts)
+		require.NoError(t, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range 50 {
+			_, err := LogDigestEntry(&types.DigestEntry{URL: "https://example.com/b", Status: types.DigestSuccess, TopicPath: "db/sql/pg"}, opts)
+			assert.NoError(t, err)
+		}
+	})
+	for range 20 {
+		_, err := RetargetDigestLogs(root, "db/sql/pg", "db/rdbms/postgres", false)
+		require.NoError(t, err)
+	}
+	wg.Wait()
+
+	entries, err := ReadDigestEntries(root)
+	require.NoError(t, err)
+	assert.Len(t, entries, 100)
+}
@@ -0,0 +1,274 @@
+package write
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	carbon "github.com/dromara/carbon/v2"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// LedgerFilename is the URL ledger file under the wiki root.
+const LedgerFilename = "url-ledger.json"
+
+// LedgerEntry records where an already-digested URL lives in the wiki.
+type LedgerEntry struct {
+	URL       string `json:"url"`
+	TopicPath string `json:"topicPath"`
+	Path      string `json:"path"` // summary.md, relative to the wiki root
+	Title     string `json:"title,omitempty"`
+	Date      string `json:"date,omitempty"`
+	BatchID   string `json:"batchId,omitempty"`
+}
+
+// URLLedger indexes every URL written to a topic summary.md, keyed by
+// urlutil.Normalize, so digests can skip or merge links seen before.
+type URLLedger struct {
+	Entries  map[string]LedgerEntry `json:"entries"`
+	wikiRoot string
+	mu       sync.Mutex
+}
+
+// LoadURLLedger reads <wikiRoot>/url-ledger.json, building it from the
+// existing summaries when the file does not exist yet.
+func LoadURLLedger(wikiRoot string) (*URLLedger, error) {
+	path := filepath.Join(wikiRoot, LedgerFilename)
+	stored, err := fileutil.ReadJSONFile[URLLedger](path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return BuildURLLedger(wikiRoot)
+		}
+
+		return nil, fmt.Errorf("read url ledger: %w", err)
+	}
+
+	l := &URLLedger{Entries: stored.Entries, wikiRoot: wikiRoot}
+	if l.Entries == nil {
+		l.Entries = map[string]LedgerEntry{}
+	}
+
+	return l, nil
+}
+
+// BuildURLLedger scans every summary.md under wikiRoot and indexes the URL
+// of each entry with its topic, date section and the file's batch id.
+func BuildURLLedger(wikiRoot string) (*URLLedger, error) {
+	l := &URLLedger{Entries: map[string]LedgerEntry{}, wikiRoot: wikiRoot}
+	err := filepath.WalkDir(wikiRoot, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() || d.Name() != SummaryFilename {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(wikiRoot, path)
+		if err != nil {
+			return err
+		}
+		l.indexSummary(filepath.ToSlash(rel), string(data))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan wiki summaries: %w", err)
+	}
+
+	return l, nil
+}
+
+func (l *URLLedger) indexSummary(rel, raw string) {
+	body := raw
+	batchID := ""
+	if parsed := parseSummaryFrontmatter(raw); parsed != nil {
+		body = parsed.body
+		batchID = parsed.fm.BatchID
+	}
+	topic := filepath.ToSlash(filepath.Dir(rel))
+
+	for _, e := range ParseSummaryEntries(body) {
+		key := urlutil.Normalize(e.URL)
+		if _, seen := l.Entries[key]; seen {
+			continue
+		}
+		l.Entries[key] = LedgerEntry{URL: e.URL, TopicPath: topic, Path: rel, Title: e.Title, Date: e.Date, BatchID: batchID}
+	}
+}
+
+// Lookup returns the ledger entry for urlStr, if it was digested before.
+func (l *URLLedger) Lookup(urlStr string) (LedgerEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.Entries[urlutil.Normalize(urlStr)]
+
+	return e, ok
+}
+
+// Len returns the number of indexed URLs.
+func (l *URLLedger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return len(l.Entries)
+}
+
+// RecordSummary indexes an item just written to outputPath. Writes that did
+// not land in a topic summary.md (uncat.md, failure logs) are ignored.
+func (l *URLLedger) RecordSummary(item *types.ClassifyItem, outputPath, batchID string) {
+	if item == nil || filepath.Base(outputPath) != SummaryFilename {
+		return
+	}
+	rel, err := filepath.Rel(l.wikiRoot, outputPath)
+	if err != nil {
+		return
+	}
+	rel = filepath.ToSlash(rel)
+	today := carbon.Now().ToDateString()
+	if batchID == "" {
+		batchID = "wiki-" + today
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.Entries[urlutil.Normalize(item.URL)] = LedgerEntry{
+		URL:       item.URL,
+		TopicPath: filepath.ToSlash(filepath.Dir(rel)),
+		Path:      rel,
+		Title:     item.Title,
+		Date:      today,
+		BatchID:   batchID,
+	}
+}
+
+// RetargetTopic points every entry of topic from at topic to, for a topic
+// directory that was moved or merged. It returns the number of entries changed.
+func (l *URLLedger) RetargetTopic(from, to string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	n := 0
+	for key, e := range l.Entries {
+		if e.TopicPath != from {
+			continue
+		}
+		e.TopicPath = to
+		e.Path = to + strings.TrimPrefix(e.Path, from)
+		l.Entries[key] = e
+		n++
+	}
+
+	return n
+}
+
+// Save writes the ledger to <wikiRoot>/url-ledger.json.
+func (l *URLLedger) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return fileutil.AtomicWriteJSONFile(filepath.Join(l.wikiRoot, LedgerFilename), l, fileutil.FilePermPrivate)
+}
+
+// MergeSummary replaces the entry for item.URL inside the summary.md recorded
+// in existing instead of appending a duplicate. The entry keeps its original
+// date section. When the entry can no longer be found it falls back to
+// WriteSummary.
+func MergeSummary(item *types.ClassifyItem, existing LedgerEntry, opts *WriteOptions) (string, error) {
+	summaryPath := filepath.Join(opts.WikiRoot, filepath.FromSlash(existing.Path))
+	if opts.DryRun {
+		slog.Info("[DRY RUN] Would merge summary entry", "path", summaryPath, "url", item.URL)
+
+		return summaryPath, nil
+	}
+
+	unlock := lockPath(summaryPath)
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		unlock()
+		slog.Warn("Ledger summary unreadable, appending instead", "path", summaryPath, "error", err)
+
+		return WriteSummary(item, opts)
+	}
+
+	if item.Title == "" {
+		item.Title = existing.Title
+	}
+	merged, ok := replaceEntryBlock(string(data), item.URL, buildEntry(item))
+	if !ok {
+		unlock()
+		slog.Warn("Ledger entry not found in summary, appending instead", "path", summaryPath, "url", item.URL)
+
+		return WriteSummary(item, opts)
+	}
+	defer unlock()
+
+	if err := fileutil.AtomicWriteFile(summaryPath, []byte(merged), fileutil.FilePermPrivate); err != nil {
+		return "", fmt.Errorf("merge summary: %w", err)
+	}
+	slog.Info("Summary entry merged", "path", summaryPath, "url", item.URL)
+
+	if _, err := LogSuccessEntry(item, summaryPath, opts); err != nil {
+		slog.Warn("Failed to log success entry", "url", item.URL, "error", err)
+	}
+
+	return summaryPath, nil
+}
+
+// replaceEntryBlock swaps the "### …" block whose metadata carries urlStr for
+// entry. A block ends at the next "### " or "## " heading.
+func replaceEntryBlock(content, urlStr, entry string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	key := urlutil.Normalize(urlStr)
+
+	urlLine := -1
+	for i, line := range lines {
+		if u, ok := entryURL(line); ok && urlutil.Normalize(u) == key {
+			urlLine = i
+			break
+		}
+	}
+	if urlLine < 0 {
+		return content, false
+	}
+
+	start := -1
+	for i := urlLine; i >= 0; i-- {
+		if strings.HasPrefix(lines[i], "### ") {
+			start = i
+			break
+		}
+		if strings.HasPrefix(lines[i], "## ") {
+			break
+		}
+	}
+	if start < 0 {
+		return content, false
+	}
+
+	end := len(lines)
+	for i := urlLine + 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "### ") || strings.HasPrefix(lines[i], "## ") {
+			end = i
+			break
+		}
+	}
+
+	replacement := append(strings.Split(strings.TrimRight(entry, "\n"), "\n"), "")
+	out := make([]string, 0, len(lines)-(end-start)+len(replacement))
+	out = append(out, lines[:start]...)
+	out = append(out, replacement...)
+	out = append(out, lines[end:]...)
+
+	return strings.Join(out, "\n"), true
+}
@@ -0,0 +1,148 @@
+package write
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/internal/docs/wiki/types"
+)
+
+const ledgerSummary = `---
+title: path
+date: 2026-06-06
+source: rss2nl-wiki
+type: deep_dive
+batch_id: wiki-2026-06-06
+total_urls: 2
+succeeded: 2
+failed: 0
+---
+
+## 2026-06-06
+
+### Newer
+
+` + "```markdown\nURL: https://example.com/b\nType: deep_dive\n```" + `
+
+old b summary
+
+## 2026-05-01
+
+### Older
+
+- URL: https://Example.com/a/
+- Type: deep_dive
+
+old a summary
+`
+
+func writeLedgerFixture(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	dir := filepath.Join(root, "topic", "path")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "summary.md"), []byte(ledgerSummary), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "uncat.md"), []byte("### X\n\nURL: https://example.com/uncat\n"), 0o600))
+
+	return root
+}
+
+func TestBuildURLLedgerIndexesSummaries(t *testing.T) {
+	root := writeLedgerFixture(t)
+
+	l, err := BuildURLLedger(root)
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.Len())
+
+	a, ok := l.Lookup("https://example.com/a")
+	require.True(t, ok)
+	assert.Equal(t, LedgerEntry{
+		URL: "https://Example.com/a/", TopicPath: "topic/path", Path: "topic/path/summary.md",
+		Title: "Older", Date: "2026-05-01", BatchID: "wiki-2026-06-06",
+	}, a)
+
+	b, ok := l.Lookup("https://example.com/b#frag")
+	require.True(t, ok)
+	assert.Equal(t, "2026-06-06", b.Date)
+
+	_, ok = l.Lookup("https://example.com/uncat")
+	assert.False(t, ok)
+}
+
+func TestLoadURLLedgerBuildsThenReadsSavedFile(t *testing.T) {
+	root := writeLedgerFixture(t)
+
+	l, err := LoadURLLedger(root)
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.Len())
+
+	item := &types.ClassifyItem{URL: "https://example.com/c", Title: "C"}
+	l.RecordSummary(item, filepath.Join(root, "other", "topic", "summary.md"), "batch-1")
+	l.RecordSummary(item, filepath.Join(root, "uncat.md"), "")
+	require.NoError(t, l.Save())
+
+	reloaded, err := LoadURLLedger(root)
+	require.NoError(t, err)
+	assert.Equal(t, 3, reloaded.Len())
+	c, ok := reloaded.Lookup("https://example.com/c")
+	require.True(t, ok)
+	assert.Equal(t, "other/topic", c.TopicPath)
+	assert.Equal(t, "batch-1", c.BatchID)
+}
+
+func TestMergeSummaryReplacesExistingEntry(t *testing.T) {
+	root := writeLedgerFixture(t)
+	l, err := BuildURLLedger(root)
+	require.NoError(t, err)
+	existing, ok := l.Lookup("https://example.com/a")
+	require.True(t, ok)
+
+	item := &types.ClassifyItem{
+		URL:     "https://example.com/a",
+		Title:   "Older, revisited",
+		Type:    types.TypeDeepDive,
+		Summary: &types.StructuredSummary{Overview: "fresh a summary"},
+	}
+	path, err := MergeSummary(item, existing, &WriteOptions{WikiRoot: root})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "topic", "path", "summary.md"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+	assert.NotContains(t, content, "old a summary")
+	assert.Contains(t, content, "fresh a summary")
+	assert.Contains(t, content, "old b summary")
+	assert.Equal(t, 1, strings.Count(content, "example.com/a"))
+	assert.Less(t, strings.Index(content, "## 2026-05-01"), strings.Index(content, "### Older, revisited"))
+	assert.True(t, strings.HasSuffix(content, "\n"))
+}
+
+func TestMergeSummaryFallsBackToAppend(t *testing.T) {
+	root := writeLedgerFixture(t)
+	item := &types.ClassifyItem{
+		URL:       "https://example.com/gone",
+		Title:     "Gone",
+		TopicPath: "topic/path",
+		Type:      types.TypeDeepDive,
+		Summary:   &types.StructuredSummary{Overview: "appended"},
+	}
+
+	_, err := MergeSummary(item, LedgerEntry{Path: "topic/path/summary.md"}, &WriteOptions{WikiRoot: root})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(root, "topic", "path", "summary.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "URL: https://example.com/gone")
+	assert.Contains(t, string(data), "old a summary")
+}
+
+func TestReplaceEntryBlockMissingURL(t *testing.T) {
+	out, ok := replaceEntryBlock("## 2026-01-01\n\n### A\n\nURL: https://a\n", "https://b", "x")
+	assert.False(t, ok)
+	assert.Equal(t, "## 2026-01-01\n\n### A\n\nURL: https://a\n", out)
+}
@@ -0,0 +1,153 @@
+package write
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SummaryFilename is the digest file of every wiki topic directory.
+const SummaryFilename = "summary.md"
+
+var dateHeadingRe = regexp.MustCompile(`^##\s+(\d{4}-\d{2}-\d{2})\s*$`)
+
+// SummaryEntry is one "### title" entry of a summary.md body, as written by
+// buildEntry: a fenced metadata block followed by "#### section" headings.
+type SummaryEntry struct {
+	// Meta holds the "key: value" lines of the metadata block by key as
+	// written, e.g. "tags", "quality", "Type". The URL line is kept in URL.
+	Meta map[string]string
+	// Sections holds the non-empty lines of each "#### name" section,
+	// trimmed, e.g. Sections["overview"].
+	Sections map[string][]string
+	Title    string
+	URL      string
+	// Date is the "## YYYY-MM-DD" section the entry is listed under.
+	Date string
+}
+
+// Tags splits the comma-separated tags metadata line.
+func (e *SummaryEntry) Tags() []string {
+	return SplitTags(e.Meta["tags"])
+}
+
+// SplitTags splits a comma-separated tags value, dropping empty tags.
+func SplitTags(v string) []string {
+	var tags []string
+	for _, t := range strings.Split(v, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+
+	return tags
+}
+
+// ParseSummaryMeta reads the "key: value" lines of an entry metadata block,
+// such as ClassifyItem.MetadataBlock, the way ParseSummaryEntries fills Meta.
+func ParseSummaryMeta(block string) map[string]string {
+	meta := map[string]string{}
+	for _, line := range strings.Split(block, "\n") {
+		if key, value, ok := metaLine(strings.TrimSpace(line)); ok {
+			meta[key] = value
+		}
+	}
+
+	return meta
+}
+
+// SummaryDateHeading returns the date of a "## YYYY-MM-DD" heading line.
+func SummaryDateHeading(line string) (string, bool) {
+	m := dateHeadingRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+
+	return m[1], true
+}
+
+// ParseSummaryEntries reads the entries of a summary.md body (see
+// SplitSummary). Metadata is every line of an entry before its first
+// "####" heading, fenced or not; entries without a URL are skipped.
+func ParseSummaryEntries(body string) []SummaryEntry {
+	var (
+		entries []SummaryEntry
+		cur     *SummaryEntry
+		date    string
+		section string
+	)
+	flush := func() {
+		if cur != nil && cur.URL != "" {
+			entries = append(entries, *cur)
+		}
+		cur, section = nil, ""
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "## "):
+			flush()
+			date, _ = SummaryDateHeading(line)
+
+			continue
+		case strings.HasPrefix(line, "### "):
+			flush()
+			cur = &SummaryEntry{Title: strings.TrimSpace(line[4:]), Date: date, Meta: map[string]string{}, Sections: map[string][]string{}}
+
+			continue
+		case cur == nil, trimmed == "", strings.HasPrefix(trimmed, "```"):
+			continue
+		case strings.HasPrefix(line, "#### "):
+			section = strings.TrimSpace(line[5:])
+
+			continue
+		}
+
+		if section != "" {
+			cur.Sections[section] = append(cur.Sections[section], trimmed)
+
+			continue
+		}
+		if u, ok := entryURL(trimmed); ok {
+			if cur.URL == "" {
+				cur.URL = u
+			}
+
+			continue
+		}
+		if key, value, ok := metaLine(trimmed); ok {
+			cur.Meta[key] = value
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// metaLine reads a "key: value" metadata line, including the legacy
+// "- key: value" form. URL lines are left to entryURL.
+func metaLine(line string) (string, string, bool) {
+	if _, ok := entryURL(line); ok {
+		return "", "", false
+	}
+	key, value, ok := strings.Cut(strings.TrimPrefix(line, "- "), ":")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" || strings.Contains(key, " ") {
+		return "", "", false
+	}
+
+	return key, strings.TrimSpace(value), true
+}
+
+// entryURL extracts the URL from an entry metadata line ("URL: …" or the
+// legacy "- URL: …").
+func entryURL(line string) (string, bool) {
+	line = strings.TrimPrefix(strings.TrimSpace(line), "- ")
+	u, ok := strings.CutPrefix(line, "URL: ")
+	if !ok {
+		return "", false
+	}
+	u = strings.TrimSpace(u)
+
+	return u, u != ""
+}
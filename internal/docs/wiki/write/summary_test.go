@@ -0,0 +1,47 @@
+package write
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSummaryEntries(t *testing.T) {
+	body := "## 2026-10-02\n\n" +
+		"### RAG pipelines\n\n" +
+		"```markdown\nURL: https://example.com/rag\nType: text\nquality: 4/5\ntags: rag, 向量检索,\n```\n\n" +
+		"#### overview\nRetrieval augmented generation.\n\nWith embeddings.\n\n" +
+		"#### related\n- [K8s](https://example.com/k8s) · devops/k8s/ops\n\n" +
+		"### No URL\n\n#### overview\nskipped\n\n" +
+		"## Notes\n\n" +
+		"### Legacy entry\n\n- URL: https://example.com/old\n- verdict: skip\n"
+
+	entries := ParseSummaryEntries(body)
+	require.Len(t, entries, 2)
+
+	rag := entries[0]
+	assert.Equal(t, "RAG pipelines", rag.Title)
+	assert.Equal(t, "https://example.com/rag", rag.URL)
+	assert.Equal(t, "2026-10-02", rag.Date)
+	assert.Equal(t, map[string]string{"Type": "text", "quality": "4/5", "tags": "rag, 向量检索,"}, rag.Meta)
+	assert.Equal(t, []string{"rag", "向量检索"}, rag.Tags())
+	assert.Equal(t, []string{"Retrieval augmented generation.", "With embeddings."}, rag.Sections["overview"])
+	assert.Equal(t, []string{"- [K8s](https://example.com/k8s) · devops/k8s/ops"}, rag.Sections["related"])
+
+	legacy := entries[1]
+	assert.Equal(t, "https://example.com/old", legacy.URL)
+	assert.Empty(t, legacy.Date, "a non-date heading ends the date section")
+	assert.Equal(t, "skip", legacy.Meta["verdict"])
+}
+
+func TestParseSummaryMeta(t *testing.T) {
+	meta := ParseSummaryMeta("Type: text\ntags: a, b\nnot a key line\n")
+	assert.Equal(t, map[string]string{"Type": "text", "tags": "a, b"}, meta)
+
+	date, ok := SummaryDateHeading("## 2026-10-02 ")
+	assert.True(t, ok)
+	assert.Equal(t, "2026-10-02", date)
+	_, ok = SummaryDateHeading("## Notes")
+	assert.False(t, ok)
+}
@@ -209,7 +209,30 @@ func buildEntry(item *types.ClassifyItem) string {
 		metaBlock += fmt.Sprintf("Type: %s", item.Type)
 	}
 
-	return fmt.Sprintf("### %s\n\n```markdown\n%s\n```\n\n%s\n", title, metaBlock, classify.RenderStructuredSummary(item.Summary))
+	body := classify.RenderStructuredSummary(item.Summary)
+	if related := renderRelated(item.Related); related != "" {
+		body += "\n\n" + related
+	}
+
+	return fmt.Sprintf("### %s\n\n```markdown\n%s\n```\n\n%s\n", title, metaBlock, body)
+}
+
+// renderRelated formats links to overlapping entries as a "#### related" section.
+func renderRelated(related []types.RelatedEntry) string {
+	if len(related) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("#### related\n")
+	for _, r := range related {
+		title := r.Title
+		if title == "" {
+			title = r.URL
+		}
+		fmt.Fprintf(&b, "- [%s](%s) · %s\n", title, r.URL, r.TopicPath)
+	}
+
+	return strings.TrimSpace(b.String())
 }
 
 //nolint:nonamedreturns
@@ -511,6 +534,21 @@ func parseSummaryFrontmatter(raw string) *parseResult {
 	return &parseResult{fm: &fm, body: string(body)}
 }
 
+// SplitSummary separates a summary.md into its frontmatter and markdown body.
+// A file without frontmatter yields a nil frontmatter and the raw content.
+func SplitSummary(raw string) (*SummaryFrontmatter, string) {
+	if parsed := parseSummaryFrontmatter(raw); parsed != nil {
+		return parsed.fm, parsed.body
+	}
+
+	return nil, raw
+}
+
+// RenderSummary is the inverse of SplitSummary.
+func RenderSummary(fm *SummaryFrontmatter, body string) string {
+	return renderContent(fm, body)
+}
+
 // ParseInbox parses inbox.md and returns a list of URL entries.
 func ParseInbox(filePath string) ([]InboxEntry, error) {
 	data, err := os.ReadFile(filePath)
@@ -572,6 +610,9 @@ func cleanInboxURL(raw string) string {
 type InboxEntry struct {
 	URL       string `json:"url"`
 	LineIndex int    `json:"lineIndex"`
+	// Hint is a classification clue carried by imported entries (bookmark
+	// folder, tags); inbox.md lines have none.
+	Hint string `json:"hint,omitempty"`
 }
 
 // FlushInbox removes handled URLs from inbox.md without dropping unhandled URLs
@@ -406,6 +406,24 @@ func TestBuildFailureEntryNoSummary(t *testing.T) {
 	assert.Contains(t, entry, "(无内容)")
 }
 
+// --- buildEntry related ---
+
+func TestBuildEntryRendersRelated(t *testing.T) {
+	entry := buildEntry(&types.ClassifyItem{
+		URL:     "https://example.com/new",
+		Title:   "New",
+		Summary: &types.StructuredSummary{Overview: "overview", KeyPoints: []string{"point"}},
+		Related: []types.RelatedEntry{{Title: "Old", URL: "https://example.com/old", TopicPath: "ai/llm/rag"}},
+	})
+	assert.Contains(t, entry, "#### related\n- [Old](https://example.com/old) · ai/llm/rag")
+	assert.Less(t, strings.Index(entry, "#### keyPoints"), strings.Index(entry, "#### related"))
+}
+
+func TestBuildEntryWithoutRelated(t *testing.T) {
+	entry := buildEntry(&types.ClassifyItem{URL: "https://example.com", Summary: &types.StructuredSummary{Overview: "overview"}})
+	assert.NotContains(t, entry, "#### related")
+}
+
 // --- cleanFlushedInboxLine ---
 
 func TestCleanFlushedInboxLinePunctuationArtifacts(t *testing.T) {
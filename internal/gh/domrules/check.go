@@ -140,7 +140,7 @@ func checkMappingAST(file string, mapping *ast.MappingNode, allowedFields map[st
 	}
 
 	// Check required fields
-	if scope != ScopeDiary && scope != ScopeJav {
+	if requiresName(scope) {
 		if !hasName {
 			issues = append(issues, checkutil.Issue{
 				File: file, Line: yamlutil.NodeLine(mapping),
@@ -281,14 +281,28 @@ func checkDateFieldIntValueAST(file string, val *ast.IntegerNode, field string,
 }
 
 func checkPublishAtAST(file string, val ast.Node, scope RuleScope) []checkutil.Issue {
-	switch scope {
-	case ScopeBooks, ScopeMovie, ScopeJav, ScopeVG:
+	if checksPublishAt(scope) {
 		return checkDateFieldValueAST(file, val, "publishAt", DateYear, kindYear)
 	}
 
 	return nil
 }
 
+// requiresName reports whether items of scope must have a name.
+func requiresName(scope RuleScope) bool {
+	return scope != ScopeDiary && scope != ScopeJav
+}
+
+// checksPublishAt reports whether publishAt of scope must be a year.
+func checksPublishAt(scope RuleScope) bool {
+	switch scope {
+	case ScopeBooks, ScopeMovie, ScopeJav, ScopeVG:
+		return true
+	}
+
+	return false
+}
+
 func checkIsSequenceAST(file string, val ast.Node, field string) []checkutil.Issue {
 	if _, ok := val.(*ast.SequenceNode); !ok {
 		return []checkutil.Issue{errIssue(file, val, field+" 必须是数组")}
@@ -2,6 +2,7 @@ package domrules
 
 import (
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -29,6 +30,11 @@ var domainSpecs = []DomainSpec{
 	{Domain: DomainNtl, DefaultPath: "data/.archive/ntl", RuleScope: RuleScope(DomainNtl), StructuredCheck: true},
 }
 
+// DomainSpecs returns the behavior of every data domain, in declaration order.
+func DomainSpecs() []DomainSpec {
+	return slices.Clone(domainSpecs)
+}
+
 // SpecForDomain returns the configured behavior for a data domain.
 func SpecForDomain(domain DataDomain) (DomainSpec, bool) {
 	for _, spec := range domainSpecs {
@@ -0,0 +1,226 @@
+package domrules
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	"github.com/goccy/go-yaml/token"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+const (
+	minScore = 0
+	maxScore = 5
+)
+
+// DefaultFieldAliases maps legacy or misspelt field names to the rule field
+// check --fix renames them to.
+var DefaultFieldAliases = map[string]string{
+	"rating":      fieldScore,
+	"rate":        fieldScore,
+	"desc":        fieldDes,
+	"description": fieldDes,
+	"link":        fieldURL,
+	"readDate":    fieldReadAt,
+	"publishedAt": fieldPublishAt,
+}
+
+// looseDate matches year-month-day dates with other separators or unpadded
+// parts: 2021/3/4, 2021.03.04, 2021年3月4日.
+var looseDate = regexp.MustCompile(`^(\d{4})\s*[-/.年]\s*(\d{1,2})\s*[-/.月]\s*(\d{1,2})\s*日?$`)
+
+// FixOptions controls RunStructuredDataFix.
+type FixOptions struct {
+	// Aliases extends DefaultFieldAliases; an empty target drops a default.
+	Aliases map[string]string
+	DryRun  bool
+}
+
+// RunStructuredDataFix applies safe mechanical fixes to the YAML files in a
+// directory: quoted values are trimmed, readAt and date are normalised to
+// YYYY-MM-DD, scores are clamped to 0-5 and alias fields are renamed.
+// Edits are made at the parsed token positions, so comments, ordering and
+// layout are kept. Files that fail to parse are left to the check.
+func RunStructuredDataFix(targetDir, scope string, opts FixOptions) (*checkutil.FixResult, error) {
+	files, err := listYAMLFiles(targetDir)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := maps.Clone(DefaultFieldAliases)
+	for from, to := range opts.Aliases {
+		if to == "" {
+			delete(aliases, from)
+
+			continue
+		}
+		aliases[from] = to
+	}
+
+	result := &checkutil.FixResult{DryRun: opts.DryRun}
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		parsed, err := yamlparser.ParseBytes(src, yamlparser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		ruleScope := ResolveScope(file, scope)
+		f := &fixer{file: file, aliases: aliases, allowed: AllowedFieldsForScope(ruleScope)}
+		for _, doc := range parsed.Docs {
+			if doc == nil || doc.Body == nil {
+				continue
+			}
+			if seq, ok := yamlutil.Sequence(doc.Body); ok {
+				f.fixItems(seq)
+			}
+		}
+
+		diff, err := checkutil.ApplyFileEdits(file, src, f.edits, opts.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Diff += diff
+		result.Fixes = append(result.Fixes, f.fixes...)
+	}
+
+	return result, nil
+}
+
+type fixer struct {
+	aliases map[string]string
+	allowed map[string]bool
+	file    string
+	edits   []yamlutil.Edit
+	fixes   []checkutil.Fix
+}
+
+func (f *fixer) fixItems(seq *ast.SequenceNode) {
+	for _, item := range seq.Values {
+		if mapping, ok := yamlutil.Mapping(item); ok {
+			f.fixMapping(mapping)
+		}
+	}
+}
+
+func (f *fixer) fixMapping(mapping *ast.MappingNode) {
+	for _, kv := range mapping.Values {
+		if kv == nil {
+			continue
+		}
+		key := f.renameKey(mapping, kv)
+		f.fixValue(key, kv.Value)
+		if seq, ok := yamlutil.Sequence(kv.Value); ok && key == fieldSub {
+			f.fixItems(seq)
+		}
+	}
+}
+
+// renameKey renames an alias field unless its target is already set or not
+// allowed in the scope, and returns the resulting key.
+func (f *fixer) renameKey(mapping *ast.MappingNode, kv *ast.MappingValueNode) string {
+	key := yamlutil.KeyString(kv.Key)
+	target, ok := f.aliases[key]
+	if !ok || f.allowed[key] || !f.allowed[target] || yamlutil.MappingValue(mapping, target) != nil {
+		return key
+	}
+	if s, ok := kv.Key.(*ast.StringNode); !ok || s.GetToken().Type != token.StringType {
+		return key
+	}
+	f.add(key, kv.Key, target)
+
+	return target
+}
+
+func (f *fixer) fixValue(key string, val ast.Node) {
+	if key == fieldScore {
+		if score, ok := clampScore(val); ok {
+			f.add(key, val, score)
+		}
+
+		return
+	}
+
+	s, ok := val.(*ast.StringNode)
+	if !ok {
+		return
+	}
+	value := s.Value
+	if yamlutil.IsQuoted(s) {
+		value = strings.TrimSpace(value)
+	}
+	if key == fieldReadAt || key == fieldDate {
+		if date, ok := normalizeDate(value); ok {
+			value = date
+		}
+	}
+	if value != s.Value && value != "" {
+		f.add(key, val, yamlutil.QuoteLike(s, value))
+	}
+}
+
+func (f *fixer) add(field string, n ast.Node, replacement string) {
+	edit, ok := yamlutil.ReplaceEdit(n, replacement)
+	if !ok || edit.Old == edit.New {
+		return
+	}
+	f.edits = append(f.edits, edit)
+	f.fixes = append(f.fixes, checkutil.Fix{File: f.file, Line: edit.Line, Field: field, From: edit.Old, To: edit.New})
+}
+
+// clampScore returns the 0-5 integer for an out-of-range, integral float or
+// quoted integer score. Fractional scores are left to the check.
+func clampScore(val ast.Node) (string, bool) {
+	var score int64
+	switch v := val.(type) {
+	case *ast.IntegerNode:
+		switch n := v.Value.(type) {
+		case int64:
+			score = n
+		case uint64:
+			score = int64(min(n, maxScore+1))
+		default:
+			return "", false
+		}
+	case *ast.FloatNode:
+		if v.Value != math.Trunc(v.Value) {
+			return "", false
+		}
+		score = int64(max(min(v.Value, maxScore+1), minScore-1))
+	case *ast.StringNode:
+		n, err := strconv.ParseInt(strings.TrimSpace(v.Value), 10, 64)
+		if err != nil {
+			return "", false
+		}
+		score = n
+	default:
+		return "", false
+	}
+
+	return strconv.FormatInt(min(max(score, minScore), maxScore), 10), true
+}
+
+func normalizeDate(value string) (string, bool) {
+	m := looseDate.FindStringSubmatch(value)
+	if m == nil {
+		return "", false
+	}
+	parsed, err := time.Parse("2006-1-2", m[1]+"-"+m[2]+"-"+m[3])
+	if err != nil {
+		return "", false
+	}
+
+	return parsed.Format(time.DateOnly), true
+}
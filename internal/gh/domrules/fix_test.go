@@ -0,0 +1,132 @@
+package domrules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+)
+
+const fixBooksYAML = `# 历史
+- name: 万历十五年 # 黄仁宇
+  author: "黄仁宇 "
+  rating: 5
+  readAt: "2021/3/4"
+  tags: [history, ming]
+- name: Sapiens
+  score: 7
+  readAt: 2019年7月1日
+  desc: a brief history
+  des: kept
+  sub:
+    - name: Part one
+      score: "4"
+- name: Dune
+  score: 4.5
+  readAt: "2021/2/30"
+`
+
+const fixedBooksYAML = `# 历史
+- name: 万历十五年 # 黄仁宇
+  author: "黄仁宇"
+  score: 5
+  readAt: "2021-03-04"
+  tags: [history, ming]
+- name: Sapiens
+  score: 5
+  readAt: 2019-07-01
+  desc: a brief history
+  des: kept
+  sub:
+    - name: Part one
+      score: 4
+- name: Dune
+  score: 4.5
+  readAt: "2021/2/30"
+`
+
+func writeFixFile(t *testing.T, content string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "history.yml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	return dir, file
+}
+
+func TestRunStructuredDataFix(t *testing.T) {
+	dir, file := writeFixFile(t, fixBooksYAML)
+
+	result, err := RunStructuredDataFix(dir, "books", FixOptions{})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, fixedBooksYAML, string(got))
+	assert.Contains(t, result.Fixes, checkutil.Fix{File: file, Line: 4, Field: "rating", From: "rating", To: "score"})
+	assert.Contains(t, result.Fixes, checkutil.Fix{File: file, Line: 5, Field: "readAt", From: `"2021/3/4"`, To: `"2021-03-04"`})
+	assert.Len(t, result.Fixes, 6)
+	assert.Contains(t, result.Diff, "-  rating: 5\n-  readAt: \"2021/3/4\"\n+  author: \"黄仁宇\"\n")
+
+	again, err := RunStructuredDataFix(dir, "books", FixOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, again.Fixes, "fixes are idempotent")
+}
+
+func TestRunStructuredDataFixDryRun(t *testing.T) {
+	dir, file := writeFixFile(t, fixBooksYAML)
+
+	result, err := RunStructuredDataFix(dir, "books", FixOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Contains(t, result.Diff, "--- "+file)
+	assert.Contains(t, result.Diff, `-  readAt: "2021/3/4"`)
+	assert.Contains(t, result.Diff, `+  readAt: "2021-03-04"`)
+
+	got, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, fixBooksYAML, string(got), "dry run must not write")
+}
+
+func TestRunStructuredDataFixAliases(t *testing.T) {
+	dir, file := writeFixFile(t, "- name: Dune\n  rating: 4\n  link: https://example.com\n")
+
+	_, err := RunStructuredDataFix(dir, "books", FixOptions{Aliases: map[string]string{"rating": "", "link": "source"}})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "- name: Dune\n  rating: 4\n  source: https://example.com\n", string(got))
+}
+
+func TestClampScore(t *testing.T) {
+	tests := map[string]struct {
+		want string
+		ok   bool
+	}{
+		"score: -1":  {"0", true},
+		"score: 9":   {"5", true},
+		"score: 3.0": {"3", true},
+		"score: 3.5": {"", false},
+		`score: "2"`: {"2", true},
+		"score: abc": {"", false},
+	}
+	for src, tt := range tests {
+		dir, _ := writeFixFile(t, "- name: x\n  "+src+"\n")
+		result, err := RunStructuredDataFix(dir, "books", FixOptions{DryRun: true})
+		require.NoError(t, err)
+		if !tt.ok {
+			assert.Empty(t, result.Fixes, src)
+
+			continue
+		}
+		if src == "score: "+tt.want {
+			continue
+		}
+		require.Len(t, result.Fixes, 1, src)
+		assert.Equal(t, tt.want, result.Fixes[0].To, src)
+	}
+}
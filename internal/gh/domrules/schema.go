@@ -0,0 +1,66 @@
+package domrules
+
+import (
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
+)
+
+// maxYear bounds integer publishAt values, which must match DateYear.
+const maxYear = 9999
+
+const schemaItem = "item"
+
+// JSONSchema returns the JSON Schema of a structured data file in scope,
+// derived from the tables the check uses: the allowed fields of the scope,
+// ForbiddenFields, the date patterns and the score range. Unknown fields,
+// which the check warns about, are rejected so editors flag them inline.
+func JSONSchema(scope RuleScope) *jsonschema.Schema {
+	allowed := AllowedFieldsForScope(scope)
+	properties := make(map[string]*jsonschema.Schema, len(allowed)+len(ForbiddenFields))
+	for field := range allowed {
+		properties[field] = fieldSchema(field, scope)
+	}
+	for field := range ForbiddenFields {
+		properties[field] = jsonschema.Never().Describe("forbidden field")
+	}
+
+	item := jsonschema.Object(properties)
+	item.AdditionalProperties = false
+	if requiresName(scope) {
+		item.Required = []string{fieldName}
+	}
+
+	return &jsonschema.Schema{
+		Schema:      jsonschema.Draft07,
+		Title:       "docs-alfred " + string(scope) + " data",
+		Type:        "array",
+		Items:       jsonschema.Ref(schemaItem),
+		Definitions: map[string]*jsonschema.Schema{schemaItem: item},
+	}
+}
+
+func fieldSchema(field string, scope RuleScope) *jsonschema.Schema {
+	switch field {
+	case fieldScore:
+		return jsonschema.IntegerRange(minScore, maxScore)
+	case fieldReadAt:
+		return jsonschema.Pattern(DateFull.String()).Describe("YYYY-MM-DD")
+	case fieldPublishAt:
+		if checksPublishAt(scope) {
+			return &jsonschema.Schema{
+				Description: "year",
+				AnyOf: []*jsonschema.Schema{
+					jsonschema.Pattern(DateYear.String()),
+					jsonschema.IntegerRange(-maxYear, maxYear),
+				},
+			}
+		}
+	case fieldRecord, fieldItem, fieldTable, fieldRecite:
+		return jsonschema.Array(nil)
+	case fieldSub:
+		return jsonschema.Array(jsonschema.Ref(schemaItem))
+	case fieldTags:
+		return &jsonschema.Schema{Type: []string{"array", "string"}, Description: "array preferred"}
+	}
+
+	return jsonschema.Any()
+}
@@ -0,0 +1,121 @@
+package domrules
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
+)
+
+// schemaScopeFiles maps each rule scope to a file name and check scope that
+// resolve to it.
+var schemaScopeFiles = map[RuleScope][2]string{
+	ScopeBooks: {"history.yml", "books"},
+	ScopeMovie: {"movie.yml", "movie"},
+	ScopeMusic: {"music-jazz.yml", "music"},
+	ScopeDiary: {"2024.yml", "diary"},
+	ScopeJav:   {"jav.yml", "ntl"},
+	ScopeVG:    {"vg.yml", "ntl"},
+}
+
+func checkScopeYAML(t *testing.T, scope RuleScope, content string) []checkutil.Issue {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), schemaScopeFiles[scope][0])
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	return checkFile(file, schemaScopeFiles[scope][1])
+}
+
+func hasError(issues []checkutil.Issue, substr string) bool {
+	return slices.ContainsFunc(issues, func(issue checkutil.Issue) bool {
+		return issue.Severity == checkutil.SeverityError && strings.Contains(issue.Message, substr)
+	})
+}
+
+// accepts evaluates the keywords fieldSchema uses.
+func accepts(s *jsonschema.Schema, v any) bool {
+	if len(s.AnyOf) > 0 {
+		return slices.ContainsFunc(s.AnyOf, func(alt *jsonschema.Schema) bool { return accepts(alt, v) })
+	}
+	switch s.Type {
+	case "string":
+		str, ok := v.(string)
+
+		return ok && (s.Pattern == "" || regexp.MustCompile(s.Pattern).MatchString(str))
+	case "integer":
+		var f float64
+		switch n := v.(type) {
+		case uint64:
+			f = float64(n)
+		case int64:
+			f = float64(n)
+		case float64:
+			f = n
+		default:
+			return false
+		}
+
+		return f == math.Trunc(f) && (s.Minimum == nil || f >= *s.Minimum) && (s.Maximum == nil || f <= *s.Maximum)
+	}
+
+	return true
+}
+
+func TestJSONSchemaMatchesFieldTables(t *testing.T) {
+	for scope := range schemaScopeFiles {
+		item := JSONSchema(scope).Definitions[schemaItem]
+		require.NotNil(t, item, scope)
+
+		var want []string
+		for field := range AllowedFieldsForScope(scope) {
+			want = append(want, field)
+		}
+		for field := range ForbiddenFields {
+			want = append(want, field)
+			assert.NotNil(t, item.Properties[field].Not, "%s: %s is forbidden", scope, field)
+		}
+		var got []string
+		for field := range item.Properties {
+			got = append(got, field)
+		}
+		assert.ElementsMatch(t, want, got, scope)
+		assert.Equal(t, false, item.AdditionalProperties, scope)
+
+		missingName := hasError(checkScopeYAML(t, scope, "- des: x\n"), "缺少必填字段 name")
+		assert.Equal(t, missingName, slices.Contains(item.Required, fieldName), scope)
+	}
+}
+
+func TestJSONSchemaMatchesValueChecks(t *testing.T) {
+	samples := map[string][]string{
+		fieldScore:     {"0", "5", "6", "-1", "3.0", "3.5", `"4"`, "abc"},
+		fieldReadAt:    {`"2021-03-04"`, `"2021/3/4"`, `"2021-03"`, "20210304"},
+		fieldPublishAt: {"1981", `"1981"`, `"-300"`, "-300", `"1981-01"`, "19810"},
+	}
+	for scope := range schemaScopeFiles {
+		item := JSONSchema(scope).Definitions[schemaItem]
+		for field, values := range samples {
+			if !AllowedFieldsForScope(scope)[field] {
+				continue
+			}
+			for _, value := range values {
+				var v any
+				require.NoError(t, yaml.Unmarshal([]byte(value), &v))
+				issues := checkScopeYAML(t, scope, "- name: x\n  "+field+": "+value+"\n")
+				rejected := slices.ContainsFunc(issues, func(issue checkutil.Issue) bool {
+					return issue.Severity == checkutil.SeverityError && strings.Contains(issue.Message, field)
+				})
+				assert.Equal(t, !rejected, accepts(item.Properties[field], v), "%s %s: %s", scope, field, value)
+			}
+		}
+	}
+}
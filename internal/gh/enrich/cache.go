@@ -0,0 +1,47 @@
+package enrich
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+)
+
+// Cache stores repo statuses keyed by lowercase owner/name.
+type Cache struct {
+	Repos map[string]*RepoStatus `json:"repos"`
+	path  string
+	// StaleAfter is the threshold of the run that saved the cache, so
+	// CachedIssues reports the same stale repos.
+	StaleAfter time.Duration `json:"staleAfter,omitempty"`
+}
+
+// DefaultCachePath returns the status cache under the docs-alfred cache dir.
+func DefaultCachePath() string {
+	return fileutil.CachePath(DefaultCacheFile)
+}
+
+// LoadCache reads the cache at path. A missing file is an empty cache.
+func LoadCache(path string) (*Cache, error) {
+	cache, err := fileutil.ReadJSONFile[Cache](path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load gh enrich cache: %w", err)
+	}
+	if cache.Repos == nil {
+		cache.Repos = map[string]*RepoStatus{}
+	}
+	cache.path = path
+
+	return &cache, nil
+}
+
+// Save writes the cache back to the path it was loaded from.
+func (c *Cache) Save() error {
+	if err := fileutil.AtomicWriteJSONFile(c.path, c, fileutil.FilePermPrivate); err != nil {
+		return fmt.Errorf("save gh enrich cache: %w", err)
+	}
+
+	return nil
+}
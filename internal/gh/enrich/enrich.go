@@ -0,0 +1,298 @@
+// Package enrich checks the GitHub repos of data/gh for liveness: stars, last
+// push, archived state and renames, cached on disk so data-cli check gh can
+// report dead, renamed and stale repos without calling the API.
+package enrich
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+)
+
+// Defaults for Options.
+const (
+	DefaultCacheFile   = "gh-enrich.json"
+	DefaultTTL         = 7 * 24 * time.Hour
+	DefaultStaleAfter  = 2 * 365 * 24 * time.Hour
+	defaultConcurrency = 4
+)
+
+// RepoStatus is the liveness of one GitHub repo as last seen by the API.
+type RepoStatus struct {
+	CheckedAt   time.Time `json:"checkedAt"`
+	PushedAt    time.Time `json:"pushedAt,omitzero"`
+	FullName    string    `json:"fullName"`              // owner/name as listed in data/gh
+	RedirectURL string    `json:"redirectURL,omitempty"` // set when the repo was renamed or transferred
+	Error       string    `json:"error,omitempty"`       // API error other than not found
+	Stars       int       `json:"stars"`
+	Archived    bool      `json:"archived,omitempty"`
+	Missing     bool      `json:"missing,omitempty"` // deleted or made private
+}
+
+// Fetcher looks up one repo. A repo that does not exist is returned with
+// Missing set, not as an error.
+type Fetcher interface {
+	FetchRepo(ctx context.Context, owner, name string) (*RepoStatus, error)
+}
+
+// Options controls Run.
+type Options struct {
+	Fetcher Fetcher
+	Now     func() time.Time
+	// Root is the data/gh directory.
+	Root string
+	// CachePath is the status cache file (default: DefaultCacheFile under the
+	// docs-alfred cache dir).
+	CachePath string
+	// TTL is how long a cached status is reused without an API call.
+	TTL time.Duration
+	// StaleAfter flags repos without a push for this long.
+	StaleAfter  time.Duration
+	Concurrency int
+	// Refresh ignores the TTL and queries every repo.
+	Refresh bool
+	// Fix rewrites renamed repo URLs in the YAML files.
+	Fix    bool
+	DryRun bool
+}
+
+// Result is the outcome of Run.
+type Result struct {
+	Issues    []checkutil.Issue `json:"issues"`
+	Rewrites  []Rewrite         `json:"rewrites,omitempty"`
+	Repos     int               `json:"repos"`
+	Fetched   int               `json:"fetched"`
+	Cached    int               `json:"cached"`
+	Failed    int               `json:"failed"`
+	Missing   int               `json:"missing"`
+	Renamed   int               `json:"renamed"`
+	Archived  int               `json:"archived"`
+	Stale     int               `json:"stale"`
+	DryRun    bool              `json:"dryRun"`
+	Rewritten bool              `json:"rewritten"`
+}
+
+// Run scans Root for GitHub repo URLs, refreshes their status through the
+// cache and reports problems as issues. With Fix, renamed URLs are rewritten
+// in place.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	normalizeOptions(&opts)
+	if opts.Fetcher == nil {
+		return nil, errors.New("github fetcher is required")
+	}
+	now := opts.Now()
+
+	refs, err := ScanRepoRefs(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+	cache, err := LoadCache(opts.CachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DryRun: opts.DryRun}
+	var todo []string
+	for _, key := range uniqueRepoKeys(refs) {
+		status, ok := cache.Repos[key]
+		if ok && status.Error == "" && !opts.Refresh && now.Sub(status.CheckedAt) < opts.TTL {
+			result.Cached++
+
+			continue
+		}
+		todo = append(todo, key)
+	}
+	result.Repos = result.Cached + len(todo)
+	slog.Info("Enriching gh repos", "repos", result.Repos, "cached", result.Cached, "fetch", len(todo))
+
+	fetched := fetchAll(ctx, opts, todo, now)
+	for key, status := range fetched {
+		result.Fetched++
+		// A failed lookup keeps the last good status; it is retried next run.
+		if prev, ok := cache.Repos[key]; ok && status.Error != "" && prev.Error == "" {
+			result.Failed++
+
+			continue
+		}
+		cache.Repos[key] = status
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !opts.DryRun {
+		cache.StaleAfter = opts.StaleAfter
+		if err := cache.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Issues = Issues(refs, cache, opts.StaleAfter, now)
+	countStatuses(result, refs, cache, opts.StaleAfter, now)
+
+	if opts.Fix {
+		result.Rewrites, err = RewriteRenamed(refs, cache, opts.DryRun)
+		if err != nil {
+			return result, err
+		}
+		result.Rewritten = len(result.Rewrites) > 0 && !opts.DryRun
+	}
+
+	return result, nil
+}
+
+func normalizeOptions(opts *Options) {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.CachePath == "" {
+		opts.CachePath = DefaultCachePath()
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultTTL
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = DefaultStaleAfter
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultConcurrency
+	}
+}
+
+func fetchAll(ctx context.Context, opts Options, keys []string, now time.Time) map[string]*RepoStatus {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out = make(map[string]*RepoStatus, len(keys))
+		sem = make(chan struct{}, opts.Concurrency)
+	)
+	for _, key := range keys {
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			owner, name, _ := strings.Cut(key, "/")
+			status, err := opts.Fetcher.FetchRepo(ctx, owner, name)
+			if err != nil {
+				slog.Warn("Fetch gh repo failed", "repo", key, "error", err)
+				status = &RepoStatus{Error: err.Error()}
+			}
+			status.FullName = key
+			status.CheckedAt = now
+			mu.Lock()
+			out[key] = status
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return out
+}
+
+// Issues reports the problems of every cached repo referenced by refs, one
+// issue per YAML line. Repos without a cached status are skipped.
+func Issues(refs []RepoRef, cache *Cache, staleAfter time.Duration, now time.Time) []checkutil.Issue {
+	var issues []checkutil.Issue
+	for _, ref := range refs {
+		status, ok := cache.Repos[ref.Key()]
+		if !ok {
+			continue
+		}
+		issue := checkutil.Issue{File: ref.File, Line: ref.Line}
+		switch {
+		case status.Missing:
+			issue.Severity = checkutil.SeverityError
+			issue.Message = fmt.Sprintf("repo not found (deleted or private): %s", ref.URL)
+		case status.RedirectURL != "":
+			issue.Severity = checkutil.SeverityWarn
+			issue.Message = fmt.Sprintf("repo renamed: %s -> %s (fix with data-cli enrich gh --fix)", ref.URL, status.RedirectURL)
+		case status.Archived:
+			issue.Severity = checkutil.SeverityWarn
+			issue.Message = fmt.Sprintf("repo archived: %s", ref.URL)
+		case isStale(status, staleAfter, now):
+			issue.Severity = checkutil.SeverityWarn
+			issue.Message = fmt.Sprintf("repo stale: %s (last push %s)", ref.URL, status.PushedAt.Format(time.DateOnly))
+		default:
+			continue
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// CachedIssues reports the problems recorded in the cache for the repos under
+// root without calling the API. A missing cache yields no issues. A
+// non-positive staleAfter uses the threshold saved with the cache by Run.
+func CachedIssues(root, cachePath string, staleAfter time.Duration, now time.Time) ([]checkutil.Issue, error) {
+	if cachePath == "" {
+		cachePath = DefaultCachePath()
+	}
+	cache, err := LoadCache(cachePath)
+	if err != nil {
+		return nil, err
+	}
+	if staleAfter <= 0 {
+		staleAfter = cmp.Or(cache.StaleAfter, DefaultStaleAfter)
+	}
+	if len(cache.Repos) == 0 {
+		return nil, nil
+	}
+	refs, err := ScanRepoRefs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return Issues(refs, cache, staleAfter, now), nil
+}
+
+func isStale(status *RepoStatus, staleAfter time.Duration, now time.Time) bool {
+	return !status.PushedAt.IsZero() && now.Sub(status.PushedAt) > staleAfter
+}
+
+func countStatuses(result *Result, refs []RepoRef, cache *Cache, staleAfter time.Duration, now time.Time) {
+	for _, key := range uniqueRepoKeys(refs) {
+		status, ok := cache.Repos[key]
+		if !ok {
+			continue
+		}
+		switch {
+		case status.Error != "":
+			result.Failed++
+		case status.Missing:
+			result.Missing++
+		case status.RedirectURL != "":
+			result.Renamed++
+		case status.Archived:
+			result.Archived++
+		case isStale(status, staleAfter, now):
+			result.Stale++
+		}
+	}
+}
+
+func uniqueRepoKeys(refs []RepoRef) []string {
+	seen := make(map[string]bool, len(refs))
+	var keys []string
+	for _, ref := range refs {
+		key := ref.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
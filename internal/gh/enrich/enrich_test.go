@@ -0,0 +1,257 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+)
+
+const testYAML = `# proxies
+- type: network
+  topics:
+    - topic: 内网穿透工具
+      kind: tools
+      repo:
+        - url: https://github.com/acme/frp # main tool
+          des: reverse proxy
+          rel:
+            - url: "https://github.com/acme/old-name"
+        - url: https://github.com/acme/gone
+        - url: https://github.com/acme/archived
+        - url: https://github.com/acme/stale
+        - url: https://gitlab.com/acme/elsewhere
+`
+
+type fakeFetcher struct {
+	mu       sync.Mutex
+	statuses map[string]*RepoStatus
+	calls    []string
+}
+
+func (f *fakeFetcher) FetchRepo(_ context.Context, owner, name string) (*RepoStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := owner + "/" + name
+	f.calls = append(f.calls, key)
+	status, ok := f.statuses[key]
+	if !ok {
+		return nil, errors.New("rate limited")
+	}
+	copied := *status
+
+	return &copied, nil
+}
+
+func newEnrichTest(t *testing.T, now time.Time) (Options, *fakeFetcher, string) {
+	t.Helper()
+	root := t.TempDir()
+	file := filepath.Join(root, "network.yml")
+	require.NoError(t, os.WriteFile(file, []byte(testYAML), 0o600))
+	fetcher := &fakeFetcher{statuses: map[string]*RepoStatus{
+		"acme/frp":      {Stars: 90000, PushedAt: now.AddDate(0, -1, 0)},
+		"acme/old-name": {Stars: 10, PushedAt: now, RedirectURL: "https://github.com/acme/new-name"},
+		"acme/gone":     {Missing: true},
+		"acme/archived": {Stars: 5, Archived: true, PushedAt: now.AddDate(-1, 0, 0)},
+		"acme/stale":    {Stars: 1, PushedAt: now.AddDate(-3, 0, 0)},
+	}}
+
+	return Options{
+		Root:      root,
+		CachePath: filepath.Join(t.TempDir(), "cache.json"),
+		Fetcher:   fetcher,
+		Now:       func() time.Time { return now },
+	}, fetcher, file
+}
+
+func TestRunReportsIssuesAndUsesCache(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	opts, fetcher, file := newEnrichTest(t, now)
+
+	result, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Repos)
+	assert.Equal(t, 5, result.Fetched)
+	assert.Equal(t, 1, result.Missing)
+	assert.Equal(t, 1, result.Renamed)
+	assert.Equal(t, 1, result.Archived)
+	assert.Equal(t, 1, result.Stale)
+
+	require.Len(t, result.Issues, 4)
+	assert.Equal(t, checkutil.Issue{
+		File:     file,
+		Line:     10,
+		Severity: checkutil.SeverityWarn,
+		Message:  "repo renamed: https://github.com/acme/old-name -> https://github.com/acme/new-name (fix with data-cli enrich gh --fix)",
+	}, result.Issues[0])
+	assert.Equal(t, checkutil.SeverityError, result.Issues[1].Severity)
+	assert.Contains(t, result.Issues[1].Message, "repo not found")
+	assert.Contains(t, result.Issues[2].Message, "repo archived")
+	assert.Contains(t, result.Issues[3].Message, "last push 2023-10-19")
+
+	cache, err := LoadCache(opts.CachePath)
+	require.NoError(t, err)
+	assert.Equal(t, 90000, cache.Repos["acme/frp"].Stars)
+
+	result, err = Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Cached)
+	assert.Zero(t, result.Fetched)
+	assert.Len(t, fetcher.calls, 5)
+
+	opts.Refresh = true
+	_, err = Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Len(t, fetcher.calls, 10)
+
+	issues, err := CachedIssues(opts.Root, opts.CachePath, 0, now)
+	require.NoError(t, err)
+	assert.Len(t, issues, 4)
+}
+
+func TestCachedIssuesUseSavedStaleAfter(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	opts, _, _ := newEnrichTest(t, now)
+	opts.StaleAfter = 20 * 24 * time.Hour
+
+	result, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Stale)
+
+	issues, err := CachedIssues(opts.Root, opts.CachePath, 0, now)
+	require.NoError(t, err)
+	assert.Len(t, issues, 5)
+	assert.Contains(t, issues[0].Message, "acme/frp")
+}
+
+func TestRunFixRewritesRenamedURLsInPlace(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	opts, fetcher, file := newEnrichTest(t, now)
+	delete(fetcher.statuses, "acme/stale")
+	opts.Fix = true
+	opts.DryRun = true
+
+	result, err := Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Failed)
+	require.Equal(t, []Rewrite{{File: file, Line: 10, From: "https://github.com/acme/old-name", To: "https://github.com/acme/new-name"}}, result.Rewrites)
+	assert.False(t, result.Rewritten)
+	assert.NoFileExists(t, opts.CachePath)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, testYAML, string(data))
+
+	opts.DryRun = false
+	result, err = Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.True(t, result.Rewritten)
+	data, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Replace(testYAML, "acme/old-name", "acme/new-name", 1), string(data))
+}
+
+func TestRewriteRenamedEditsURLNodesOnly(t *testing.T) {
+	const src = `- type: network
+  topics:
+    - topic: tools
+      des: "mirror of url: https://github.com/acme/old-name"
+      repo:
+        - {url: 'https://github.com/acme/old-name', des: flow}
+        - url: https://github.com/acme/old-name # block
+`
+	file := filepath.Join(t.TempDir(), "network.yml")
+	require.NoError(t, os.WriteFile(file, []byte(src), 0o600))
+
+	refs, err := ScanRepoRefs(filepath.Dir(file))
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	assert.Equal(t, []int{6, 7}, []int{refs[0].Line, refs[1].Line})
+
+	cache := &Cache{Repos: map[string]*RepoStatus{"acme/old-name": {RedirectURL: "https://github.com/acme/new-name"}}}
+	rewrites, err := RewriteRenamed(refs, cache, false)
+	require.NoError(t, err)
+	assert.Len(t, rewrites, 2)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	want := strings.Replace(src, "{url: 'https://github.com/acme/old-name'", "{url: 'https://github.com/acme/new-name'", 1)
+	want = strings.Replace(want, "url: https://github.com/acme/old-name # block", "url: https://github.com/acme/new-name # block", 1)
+	assert.Equal(t, want, string(data))
+
+	require.NoError(t, os.WriteFile(file, []byte(src), 0o600))
+	refs[1].URL = "https://github.com/acme/other"
+	_, err = RewriteRenamed(refs[1:], cache, false)
+	require.ErrorContains(t, err, "changed since scan")
+}
+
+func TestRewriteRenamedKeepsURLSubpath(t *testing.T) {
+	const src = `- url: https://github.com/acme/old-name/tree/main/cmd#usage
+- url: https://github.com/acme/old-name.git
+- url: https://github.com/acme/old-name?tab=readme
+`
+	file := filepath.Join(t.TempDir(), "network.yml")
+	require.NoError(t, os.WriteFile(file, []byte(src), 0o600))
+	refs, err := ScanRepoRefs(filepath.Dir(file))
+	require.NoError(t, err)
+	require.Len(t, refs, 3)
+
+	cache := &Cache{Repos: map[string]*RepoStatus{"acme/old-name": {RedirectURL: "https://github.com/acme/new-name"}}}
+	_, err = RewriteRenamed(refs, cache, false)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, `- url: https://github.com/acme/new-name/tree/main/cmd#usage
+- url: https://github.com/acme/new-name.git
+- url: https://github.com/acme/new-name?tab=readme
+`, string(data))
+}
+
+func TestGitHubFetcherDetectsRenameAndMissing(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/old-name", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/repositories/42", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/repositories/42", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"full_name":"acme/new-name","html_url":"https://github.com/acme/new-name",`+
+			`"stargazers_count":7,"archived":true,"pushed_at":"2026-01-02T03:04:05Z"}`)
+	})
+	mux.HandleFunc("/repos/acme/frp", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprint(w, `{"full_name":"ACME/frp","html_url":"https://github.com/ACME/frp","stargazers_count":3}`)
+	})
+	mux.HandleFunc("/repos/acme/gone", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	fetcher, err := NewGitHubFetcher("", server.URL)
+	require.NoError(t, err)
+
+	status, err := fetcher.FetchRepo(context.Background(), "acme", "old-name")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/new-name", status.RedirectURL)
+	assert.Equal(t, 7, status.Stars)
+	assert.True(t, status.Archived)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), status.PushedAt.UTC())
+
+	status, err = fetcher.FetchRepo(context.Background(), "acme", "frp")
+	require.NoError(t, err)
+	assert.Empty(t, status.RedirectURL)
+
+	status, err = fetcher.FetchRepo(context.Background(), "acme", "gone")
+	require.NoError(t, err)
+	assert.True(t, status.Missing)
+}
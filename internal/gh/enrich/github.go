@@ -0,0 +1,65 @@
+package enrich
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v70/github"
+)
+
+// GitHubFetcher looks repos up with the GitHub REST API.
+type GitHubFetcher struct {
+	client *github.Client
+}
+
+// NewGitHubFetcher returns a fetcher authenticated with token when set.
+// baseURL overrides the API endpoint (tests, GitHub Enterprise).
+func NewGitHubFetcher(token, baseURL string) (*GitHubFetcher, error) {
+	client := github.NewClient(nil)
+	client.UserAgent = "data-cli-enrich"
+	if token != "" {
+		client = client.WithAuthToken(token)
+	}
+	if baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		parsed, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse GitHub base URL: %w", err)
+		}
+		client.BaseURL = parsed
+	}
+
+	return &GitHubFetcher{client: client}, nil
+}
+
+// FetchRepo implements Fetcher. GitHub answers a renamed or transferred repo
+// with a redirect that the HTTP client follows, so a full name different
+// from owner/name marks a rename.
+func (f *GitHubFetcher) FetchRepo(ctx context.Context, owner, name string) (*RepoStatus, error) {
+	repo, _, err := f.client.Repositories.Get(ctx, owner, name)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound {
+			return &RepoStatus{Missing: true}, nil
+		}
+
+		return nil, fmt.Errorf("get repo %s/%s: %w", owner, name, err)
+	}
+
+	status := &RepoStatus{
+		Stars:    repo.GetStargazersCount(),
+		Archived: repo.GetArchived(),
+		PushedAt: repo.GetPushedAt().Time,
+	}
+	if !strings.EqualFold(repo.GetFullName(), owner+"/"+name) {
+		status.RedirectURL = repo.GetHTMLURL()
+	}
+
+	return status, nil
+}
@@ -0,0 +1,218 @@
+package enrich
+
+import (
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+// RepoRef is one GitHub repo URL of a data/gh YAML file.
+type RepoRef struct {
+	File  string `json:"file"`
+	URL   string `json:"url"`
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+	Line  int    `json:"line"`
+}
+
+// Key returns the cache key of the repo: lowercase owner/name.
+func (r *RepoRef) Key() string {
+	return strings.ToLower(r.Owner + "/" + r.Name)
+}
+
+// Rewrite is one renamed URL replaced in a YAML file.
+type Rewrite struct {
+	File string `json:"file"`
+	From string `json:"from"`
+	To   string `json:"to"`
+	Line int    `json:"line"`
+}
+
+// ScanRepoRefs lists the GitHub repo URLs of every YAML file under root,
+// including related repos: the value of every `url` key at any depth. Files
+// that do not parse are skipped.
+func ScanRepoRefs(root string) ([]RepoRef, error) {
+	files, err := fileutil.ListYAMLFilesRecursive(root)
+	if err != nil {
+		return nil, fmt.Errorf("list gh yaml under %s: %w", root, err)
+	}
+
+	var refs []RepoRef
+	for _, file := range files {
+		nodes, _, err := parseURLNodes(file)
+		if err != nil {
+			slog.Warn("skipping unparseable gh yaml", "file", file, "error", err)
+
+			continue
+		}
+		for _, n := range nodes {
+			repo, ok := urlutil.GitHubOwnerRepo(n.Value)
+			if !ok {
+				continue
+			}
+			refs = append(refs, RepoRef{File: file, Line: yamlutil.NodeLine(n), URL: n.Value, Owner: repo.Owner, Name: repo.Name})
+		}
+	}
+
+	return refs, nil
+}
+
+// RewriteRenamed points the URL of every renamed repo at its new owner/name
+// in place, keeping the rest of the file byte for byte. Unless dryRun, files are written
+// atomically.
+func RewriteRenamed(refs []RepoRef, cache *Cache, dryRun bool) ([]Rewrite, error) {
+	byFile := map[string][]Rewrite{}
+	var files []string
+	for _, ref := range refs {
+		status, ok := cache.Repos[ref.Key()]
+		if !ok || status.Missing || status.RedirectURL == "" {
+			continue
+		}
+		to, ok := renamedURL(ref.URL, status.RedirectURL)
+		if !ok || to == ref.URL {
+			continue
+		}
+		if _, seen := byFile[ref.File]; !seen {
+			files = append(files, ref.File)
+		}
+		byFile[ref.File] = append(byFile[ref.File], Rewrite{File: ref.File, Line: ref.Line, From: ref.URL, To: to})
+	}
+
+	var rewrites []Rewrite
+	for _, file := range files {
+		rewrites = append(rewrites, byFile[file]...)
+		if dryRun {
+			continue
+		}
+		if err := rewriteFile(file, byFile[file]); err != nil {
+			return rewrites, err
+		}
+	}
+
+	return rewrites, nil
+}
+
+// renamedURL swaps the owner/name path segments of rawURL for those of the
+// redirect target, keeping any subpath, ".git" suffix, query and fragment.
+func renamedURL(rawURL, redirectURL string) (string, bool) {
+	repo, ok := urlutil.GitHubOwnerRepo(redirectURL)
+	if !ok {
+		return "", false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	hostEnd := strings.Index(rawURL, u.Host)
+	if hostEnd < 0 {
+		return "", false
+	}
+	hostEnd += len(u.Host)
+	rest, ok := strings.CutPrefix(rawURL[hostEnd:], "/")
+	if !ok {
+		return "", false
+	}
+	owner, rest, ok := strings.Cut(rest, "/")
+	if !ok || owner == "" {
+		return "", false
+	}
+	nameEnd := strings.IndexAny(rest, "/?#")
+	if nameEnd < 0 {
+		nameEnd = len(rest)
+	}
+	name := repo.Name
+	if strings.HasSuffix(rest[:nameEnd], ".git") {
+		name += ".git"
+	}
+
+	return rawURL[:hostEnd] + "/" + repo.Owner + "/" + name + rest[nameEnd:], true
+}
+
+// rewriteFile re-parses file and edits the url scalars named by rewrites,
+// keeping their quoting style.
+func rewriteFile(file string, rewrites []Rewrite) error {
+	nodes, src, err := parseURLNodes(file)
+	if err != nil {
+		return err
+	}
+
+	edits := make([]yamlutil.Edit, 0, len(rewrites))
+	for _, rw := range rewrites {
+		edit, ok := rewriteEdit(nodes, rw)
+		if !ok {
+			return fmt.Errorf("%s:%d: %s changed since scan", file, rw.Line, rw.From)
+		}
+		edits = append(edits, edit)
+	}
+	_, err = checkutil.ApplyFileEdits(file, src, edits, false)
+
+	return err
+}
+
+func rewriteEdit(nodes []*ast.StringNode, rw Rewrite) (yamlutil.Edit, bool) {
+	for _, n := range nodes {
+		if yamlutil.NodeLine(n) == rw.Line && n.Value == rw.From {
+			return yamlutil.ReplaceEdit(n, yamlutil.QuoteLike(n, rw.To))
+		}
+	}
+
+	return yamlutil.Edit{}, false
+}
+
+// parseURLNodes returns the `url` scalars of file with its source.
+func parseURLNodes(file string) ([]*ast.StringNode, []byte, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", file, err)
+	}
+	parsed, err := yamlparser.ParseBytes(src, yamlparser.ParseComments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+
+	var nodes []*ast.StringNode
+	for _, doc := range parsed.Docs {
+		if doc != nil {
+			nodes = collectURLNodes(doc.Body, nodes)
+		}
+	}
+
+	return nodes, src, nil
+}
+
+// collectURLNodes appends the string values of `url` keys under n.
+func collectURLNodes(n ast.Node, nodes []*ast.StringNode) []*ast.StringNode {
+	if seq, ok := yamlutil.Sequence(n); ok {
+		for _, item := range seq.Values {
+			nodes = collectURLNodes(item, nodes)
+		}
+
+		return nodes
+	}
+	mapping, ok := yamlutil.Mapping(n)
+	if !ok {
+		return nodes
+	}
+	for _, kv := range mapping.Values {
+		if kv == nil {
+			continue
+		}
+		if s, ok := kv.Value.(*ast.StringNode); ok && yamlutil.KeyString(kv.Key) == "url" {
+			nodes = append(nodes, s)
+
+			continue
+		}
+		nodes = collectURLNodes(kv.Value, nodes)
+	}
+
+	return nodes
+}
@@ -0,0 +1,112 @@
+package ghcheck
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+// kindAliases maps long or plural spellings to the allowed topic.kind.
+var kindAliases = map[string]string{
+	"mechanism": KindMechanism,
+	"types":     KindType,
+	"repos":     KindRepo,
+	"tool":      KindTools,
+	"template":  KindTemp,
+}
+
+var allowedKinds = []string{KindMechanism, KindType, KindRepo, KindTools, KindTemp}
+
+// RunFix normalises topic.kind values (case, whitespace and kindAliases) in
+// all YAML files under ghRoot, editing the values in place. Unless dryRun,
+// changed files are rewritten.
+func RunFix(ghRoot string, dryRun bool) (*checkutil.FixResult, error) {
+	files, err := fileutil.ListYAMLFilesRecursive(ghRoot)
+	if err != nil {
+		return nil, fmt.Errorf("list gh yaml under %s: %w", ghRoot, err)
+	}
+
+	result := &checkutil.FixResult{DryRun: dryRun}
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+		parsed, err := yamlparser.ParseBytes(src, yamlparser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		var edits []yamlutil.Edit
+		for _, doc := range parsed.Docs {
+			if doc == nil || doc.Body == nil {
+				continue
+			}
+			for _, kind := range kindNodes(doc.Body) {
+				edit, ok := kindEdit(kind)
+				if !ok {
+					continue
+				}
+				edits = append(edits, edit)
+				result.Fixes = append(result.Fixes, checkutil.Fix{File: file, Line: edit.Line, Field: "kind", From: edit.Old, To: edit.New})
+			}
+		}
+
+		diff, err := checkutil.ApplyFileEdits(file, src, edits, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		result.Diff += diff
+	}
+
+	return result, nil
+}
+
+// kindNodes returns the topic.kind scalars of a data/gh document.
+func kindNodes(body ast.Node) []*ast.StringNode {
+	sections, ok := yamlutil.Sequence(body)
+	if !ok {
+		return nil
+	}
+	var kinds []*ast.StringNode
+	for _, rawSection := range sections.Values {
+		section, ok := yamlutil.Mapping(rawSection)
+		if !ok {
+			continue
+		}
+		topics, ok := yamlutil.Sequence(yamlutil.MappingValue(section, "topics"))
+		if !ok {
+			continue
+		}
+		for _, rawTopic := range topics.Values {
+			topic, ok := yamlutil.Mapping(rawTopic)
+			if !ok {
+				continue
+			}
+			if kind, ok := yamlutil.MappingValue(topic, "kind").(*ast.StringNode); ok {
+				kinds = append(kinds, kind)
+			}
+		}
+	}
+
+	return kinds
+}
+
+func kindEdit(kind *ast.StringNode) (yamlutil.Edit, bool) {
+	want := strings.ToLower(strings.TrimSpace(kind.Value))
+	if alias, ok := kindAliases[want]; ok {
+		want = alias
+	}
+	if want == kind.Value || !slices.Contains(allowedKinds, want) {
+		return yamlutil.Edit{}, false
+	}
+
+	return yamlutil.ReplaceEdit(kind, yamlutil.QuoteLike(kind, want))
+}
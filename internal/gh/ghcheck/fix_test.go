@@ -0,0 +1,54 @@
+package ghcheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunFixNormalisesKind(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "tool.yml")
+	content := `- type: tool
+  topics:
+    - topic: 内网穿透 # keep
+      kind: Tools
+    - topic: orm
+      kind: " mechanism "
+    - topic: misc
+      kind: unknown
+`
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	result, err := RunFix(dir, true)
+	require.NoError(t, err)
+	require.Len(t, result.Fixes, 2)
+	assert.Equal(t, "Tools", result.Fixes[0].From)
+	assert.Equal(t, "tools", result.Fixes[0].To)
+	assert.Equal(t, `"mech"`, result.Fixes[1].To)
+	assert.Contains(t, result.Diff, "+      kind: tools\n")
+
+	_, err = RunFix(dir, false)
+	require.NoError(t, err)
+	got, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "    - topic: 内网穿透 # keep\n      kind: tools\n")
+	assert.Contains(t, string(got), `kind: "mech"`)
+	assert.Contains(t, string(got), "kind: unknown")
+	assert.Empty(t, checkFileKinds(t, file), "fixed kinds pass the check")
+}
+
+func checkFileKinds(t *testing.T, file string) []string {
+	t.Helper()
+	var messages []string
+	for _, issue := range checkFile(file) {
+		if issue.Message != `topic "misc": invalid kind "unknown" (allowed: mech|type|repo|tools|temp)` {
+			messages = append(messages, issue.Message)
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,39 @@
+package ghcheck
+
+import (
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
+)
+
+// nonBlank matches the strings the trim filter of the validate rules keeps.
+const nonBlank = `\S`
+
+// JSONSchema returns the JSON Schema of a data/gh file, derived from the
+// section, topic and mdscc rules of the check. Fields the check does not
+// look at stay open.
+func JSONSchema() *jsonschema.Schema {
+	minTopics, maxTopics := 1, MaxTopicsPerSection
+	topics := jsonschema.Array(jsonschema.Ref("topic"))
+	topics.MinItems, topics.MaxItems = &minTopics, &maxTopics
+
+	mdscc := jsonschema.Object(map[string]*jsonschema.Schema{}, "meta", "derive", "sol", "cost", "case")
+	for _, field := range mdscc.Required {
+		mdscc.Properties[field] = jsonschema.Pattern(nonBlank)
+	}
+
+	return &jsonschema.Schema{
+		Schema: jsonschema.Draft07,
+		Title:  "docs-alfred gh data",
+		Type:   "array",
+		Items: jsonschema.Object(map[string]*jsonschema.Schema{
+			"type":   jsonschema.Pattern(nonBlank),
+			"topics": topics,
+		}, "type", "topics"),
+		Definitions: map[string]*jsonschema.Schema{
+			"topic": jsonschema.Object(map[string]*jsonschema.Schema{
+				"topic": jsonschema.Pattern(nonBlank),
+				"kind":  jsonschema.Enum(allowedKinds...),
+				"mdscc": mdscc.Describe("optional; when present every field is required"),
+			}, "topic", "kind"),
+		},
+	}
+}
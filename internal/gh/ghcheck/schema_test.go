@@ -0,0 +1,33 @@
+package ghcheck
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaMatchesRules(t *testing.T) {
+	s := JSONSchema()
+
+	topic := s.Definitions["topic"]
+	require.NotNil(t, topic)
+	assert.Equal(t, strings.Split(AllowedKindsCSV, "|"), topic.Properties["kind"].Enum)
+	assert.ElementsMatch(t, []string{"topic", "kind"}, topic.Required)
+	assert.ElementsMatch(t, []string{"meta", "derive", "sol", "cost", "case"}, topic.Properties["mdscc"].Required)
+
+	topics := s.Items.Properties["topics"]
+	require.NotNil(t, topics.MaxItems)
+	assert.Equal(t, MaxTopicsPerSection, *topics.MaxItems)
+	assert.ElementsMatch(t, []string{"type", "topics"}, s.Items.Required)
+
+	for _, kind := range []string{"mech", "type", "repo", "tools", "temp", "Tools", "tool", "unset"} {
+		file := filepath.Join(t.TempDir(), "tool.yml")
+		require.NoError(t, os.WriteFile(file, []byte("- type: tool\n  topics:\n    - topic: t\n      kind: "+kind+"\n"), 0644))
+		assert.Equal(t, len(checkFile(file)) == 0, slices.Contains(topic.Properties["kind"].Enum, kind), kind)
+	}
+}
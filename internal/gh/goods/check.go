@@ -20,7 +20,10 @@ const (
 	fieldEndDate  = "endDate"
 	fieldEndPrice = "endPrice"
 	fieldItem     = "item"
+	fieldName     = "name"
+	fieldPrice    = "price"
 	fieldTag      = "tag"
+	fieldType     = "type"
 	fieldUsing    = "using"
 )
 
@@ -0,0 +1,402 @@
+package goods
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml/ast"
+	yamlparser "github.com/goccy/go-yaml/parser"
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/md"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+// DefaultNearingRatio flags active items once they have been used for this
+// share of the typical lifespan of their type.
+const DefaultNearingRatio = 0.8
+
+const hoursInDay = 24
+
+// ReportOptions controls RunReport.
+type ReportOptions struct {
+	// Now ends the use period of active items (default: time.Now).
+	Now time.Time
+	// NearingRatio is the share of the typical lifespan after which an
+	// active item is flagged (default: DefaultNearingRatio).
+	NearingRatio float64
+}
+
+// ReportItem is the lifecycle cost of one owned item.
+type ReportItem struct {
+	Name    string `json:"name"`
+	Tag     string `json:"tag"`
+	Type    string `json:"type"`
+	File    string `json:"file"`
+	Date    string `json:"date"`
+	EndDate string `json:"endDate,omitempty"`
+	// ExpectedEnd is Date plus the typical lifespan of Type, for active items
+	// of a type with retired items.
+	ExpectedEnd string  `json:"expectedEnd,omitempty"`
+	Line        int     `json:"line"`
+	Days        int     `json:"days"`
+	Price       float64 `json:"price"`
+	EndPrice    float64 `json:"endPrice"`
+	// Cost is Price minus the resale EndPrice.
+	Cost       float64 `json:"cost"`
+	CostPerDay float64 `json:"costPerDay"`
+	// LifeUsed is Days divided by the typical lifespan of Type.
+	LifeUsed float64 `json:"lifeUsed,omitempty"`
+	Retired  bool    `json:"retired"`
+}
+
+// CategoryReport sums the items of one goods tag.
+type CategoryReport struct {
+	Tag          string  `json:"tag"`
+	Active       int     `json:"active"`
+	Retired      int     `json:"retired"`
+	ActiveValue  float64 `json:"activeValue"`
+	RetiredValue float64 `json:"retiredValue"`
+	Resale       float64 `json:"resale"`
+	Cost         float64 `json:"cost"`
+	// Recovery is Resale divided by RetiredValue.
+	Recovery float64 `json:"recovery"`
+}
+
+// TypeLifespan is the typical lifespan of a goods type: the median use
+// period of its retired items.
+type TypeLifespan struct {
+	Type       string `json:"type"`
+	Samples    int    `json:"samples"`
+	MedianDays int    `json:"medianDays"`
+}
+
+// Report is the outcome of RunReport.
+type Report struct {
+	Items      []ReportItem     `json:"items"`
+	Categories []CategoryReport `json:"categories"`
+	Lifespans  []TypeLifespan   `json:"lifespans"`
+	// Nearing lists active items past NearingRatio of their typical
+	// lifespan, most worn first.
+	Nearing []ReportItem   `json:"nearing"`
+	Total   CategoryReport `json:"total"`
+	// Unpriced counts owned items whose price is not a one-off CNY amount.
+	Unpriced int `json:"unpriced"`
+}
+
+// RunReport computes total cost of ownership, cost per day, resale recovery,
+// inventory value per tag and lifespan forecasts for the lifecycle goods
+// under path. Only items with a date are owned; those with an endDate are
+// retired.
+func RunReport(path string, opts ReportOptions) (*Report, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.NearingRatio <= 0 {
+		opts.NearingRatio = DefaultNearingRatio
+	}
+
+	files, err := fileutil.ListYAMLFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	for _, file := range files {
+		if err := report.addFile(file, opts.Now); err != nil {
+			return nil, err
+		}
+	}
+	report.summarize(opts.NearingRatio)
+
+	return report, nil
+}
+
+func (r *Report) addFile(file string, now time.Time) error {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", file, err)
+	}
+	if strings.TrimSpace(string(raw)) == "" {
+		return nil
+	}
+	parsed, err := yamlparser.ParseBytes(raw, yamlparser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("goods YAML parse %s: %w", file, err)
+	}
+
+	for _, doc := range parsed.Docs {
+		if doc == nil || doc.Body == nil {
+			continue
+		}
+		seq, ok := yamlutil.Sequence(doc.Body)
+		if !ok {
+			continue
+		}
+		for _, rawCategory := range seq.Values {
+			category, ok := yamlutil.Mapping(rawCategory)
+			if !ok {
+				continue
+			}
+			tag, _ := nodeString(yamlutil.MappingValue(category, fieldTag))
+			if !eligibleLifecycleTags[tag] {
+				continue
+			}
+			typ, _ := nodeString(yamlutil.MappingValue(category, fieldType))
+			r.addItem(file, tag, typ, yamlutil.MappingValue(category, fieldUsing), now)
+			if items, ok := yamlutil.Sequence(yamlutil.MappingValue(category, fieldItem)); ok {
+				for _, rawItem := range items.Values {
+					r.addItem(file, tag, typ, rawItem, now)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Report) addItem(file, tag, typ string, node ast.Node, now time.Time) {
+	item, ok := yamlutil.Mapping(node)
+	if !ok || item == nil {
+		return
+	}
+	date, ok := reportDate(yamlutil.MappingValue(item, fieldDate))
+	if !ok {
+		return
+	}
+	price, ok := reportPrice(yamlutil.MappingValue(item, fieldPrice))
+	if !ok {
+		r.Unpriced++
+
+		return
+	}
+
+	name, _ := nodeString(yamlutil.MappingValue(item, fieldName))
+	ri := ReportItem{
+		Name:  name,
+		Tag:   tag,
+		Type:  typ,
+		File:  file,
+		Line:  yamlutil.NodeLine(item),
+		Date:  date.Format(time.DateOnly),
+		Price: price,
+	}
+	end := now
+	if endDate, ok := reportDate(yamlutil.MappingValue(item, fieldEndDate)); ok {
+		end = endDate
+		ri.EndDate = endDate.Format(time.DateOnly)
+		ri.Retired = true
+		ri.EndPrice, _ = reportPrice(yamlutil.MappingValue(item, fieldEndPrice))
+	}
+	ri.Days = max(int(end.Sub(date).Hours()/hoursInDay), 1)
+	ri.Cost = round2(ri.Price - ri.EndPrice)
+	ri.CostPerDay = round2(ri.Cost / float64(ri.Days))
+	r.Items = append(r.Items, ri)
+}
+
+func (r *Report) summarize(nearingRatio float64) {
+	r.Lifespans = typeLifespans(r.Items)
+	lifespans := make(map[string]TypeLifespan, len(r.Lifespans))
+	for _, l := range r.Lifespans {
+		lifespans[l.Type] = l
+	}
+
+	categories := map[string]*CategoryReport{}
+	r.Total = CategoryReport{}
+	for i := range r.Items {
+		item := &r.Items[i]
+		category := categories[item.Tag]
+		if category == nil {
+			category = &CategoryReport{Tag: item.Tag}
+			categories[item.Tag] = category
+		}
+		category.add(item)
+		r.Total.add(item)
+
+		lifespan, ok := lifespans[item.Type]
+		if item.Retired || !ok {
+			continue
+		}
+		date, _ := time.Parse(time.DateOnly, item.Date)
+		item.ExpectedEnd = date.AddDate(0, 0, lifespan.MedianDays).Format(time.DateOnly)
+		item.LifeUsed = round2(float64(item.Days) / float64(lifespan.MedianDays))
+		if item.LifeUsed >= nearingRatio {
+			r.Nearing = append(r.Nearing, *item)
+		}
+	}
+	slices.SortFunc(r.Nearing, func(a, b ReportItem) int {
+		if a.LifeUsed != b.LifeUsed {
+			return cmp.Compare(b.LifeUsed, a.LifeUsed)
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, category := range categories {
+		category.finish()
+		r.Categories = append(r.Categories, *category)
+	}
+	slices.SortFunc(r.Categories, func(a, b CategoryReport) int { return strings.Compare(a.Tag, b.Tag) })
+	r.Total.finish()
+}
+
+func (c *CategoryReport) add(item *ReportItem) {
+	if item.Retired {
+		c.Retired++
+		c.RetiredValue += item.Price
+		c.Resale += item.EndPrice
+	} else {
+		c.Active++
+		c.ActiveValue += item.Price
+	}
+	c.Cost += item.Cost
+}
+
+func (c *CategoryReport) finish() {
+	c.ActiveValue = round2(c.ActiveValue)
+	c.RetiredValue = round2(c.RetiredValue)
+	c.Resale = round2(c.Resale)
+	c.Cost = round2(c.Cost)
+	if c.RetiredValue > 0 {
+		c.Recovery = round2(c.Resale / c.RetiredValue)
+	}
+}
+
+func typeLifespans(items []ReportItem) []TypeLifespan {
+	byType := map[string][]int{}
+	for _, item := range items {
+		if item.Retired && item.Type != "" {
+			byType[item.Type] = append(byType[item.Type], item.Days)
+		}
+	}
+
+	lifespans := make([]TypeLifespan, 0, len(byType))
+	for typ, days := range byType {
+		slices.Sort(days)
+		median := days[len(days)/2]
+		if len(days)%2 == 0 {
+			median = (days[len(days)/2-1] + median) / 2
+		}
+		lifespans = append(lifespans, TypeLifespan{Type: typ, Samples: len(days), MedianDays: median})
+	}
+	slices.SortFunc(lifespans, func(a, b TypeLifespan) int { return strings.Compare(a.Type, b.Type) })
+
+	return lifespans
+}
+
+func reportDate(node ast.Node) (time.Time, bool) {
+	value, ok := nodeString(node)
+	if !ok {
+		return time.Time{}, false
+	}
+	parsed, err := time.Parse(time.DateOnly, value)
+
+	return parsed, err == nil
+}
+
+// reportPrice reads a one-off CNY amount such as ¥149; ranges and
+// subscriptions are not priced.
+func reportPrice(node ast.Node) (float64, bool) {
+	value, ok := nodeString(node)
+	if !ok || !isStrictCNYPrice(value) {
+		return 0, false
+	}
+	value = strings.TrimSpace(strings.TrimLeft(value, "¥￥"))
+	price, err := strconv.ParseFloat(value, 64)
+
+	return price, err == nil
+}
+
+// ReportMarkdown renders the goods report as a markdown document.
+func ReportMarkdown(r *Report) string {
+	doc := md.NewDocument()
+	doc.Add(md.NamedSection("Goods lifecycle", md.StatsGrid([]md.StatItem{
+		{Label: "Active", Value: r.Total.Active},
+		{Label: "Retired", Value: r.Total.Retired},
+		{Label: "Active value", Value: formatAmount(r.Total.ActiveValue)},
+		{Label: "Total cost", Value: formatAmount(r.Total.Cost)},
+		{Label: "Resale recovery", Value: formatPercent(r.Total.Recovery)},
+		{Label: "Unpriced", Value: r.Unpriced},
+	})))
+	if len(r.Items) == 0 {
+		doc.AddEmpty("No owned lifecycle goods.")
+
+		return doc.Markdown()
+	}
+
+	categoryRows := make([][]string, 0, len(r.Categories))
+	for _, c := range r.Categories {
+		categoryRows = append(categoryRows, []string{
+			c.Tag,
+			strconv.Itoa(c.Active),
+			formatAmount(c.ActiveValue),
+			strconv.Itoa(c.Retired),
+			formatAmount(c.RetiredValue),
+			formatAmount(c.Resale),
+			formatPercent(c.Recovery),
+			formatAmount(c.Cost),
+		})
+	}
+	doc.Add(md.NamedSection("Categories", md.Table(
+		[]string{"Tag", "Active", "Active value", "Retired", "Retired value", "Resale", "Recovery", "Cost"},
+		categoryRows,
+	)))
+
+	items := slices.Clone(r.Items)
+	slices.SortStableFunc(items, func(a, b ReportItem) int { return cmp.Compare(b.CostPerDay, a.CostPerDay) })
+	itemRows := make([][]string, 0, len(items))
+	for _, item := range items {
+		state := "active"
+		if item.Retired {
+			state = "retired"
+		}
+		itemRows = append(itemRows, []string{
+			item.Name,
+			item.Type,
+			state,
+			strconv.Itoa(item.Days),
+			formatAmount(item.Cost),
+			formatAmount(item.CostPerDay),
+		})
+	}
+	doc.Add(md.NamedSection("Cost per day", md.Table(
+		[]string{"Item", "Type", "State", "Days", "Cost", "Cost/day"},
+		itemRows,
+	)))
+
+	if len(r.Nearing) > 0 {
+		nearingRows := make([][]string, 0, len(r.Nearing))
+		for _, item := range r.Nearing {
+			nearingRows = append(nearingRows, []string{
+				item.Name,
+				item.Type,
+				item.Date,
+				item.ExpectedEnd,
+				formatPercent(item.LifeUsed),
+			})
+		}
+		doc.Add(md.NamedSection("Nearing end of life", md.Table(
+			[]string{"Item", "Type", "Since", "Expected end", "Life used"},
+			nearingRows,
+		)))
+	}
+
+	return doc.Markdown()
+}
+
+func formatAmount(f float64) string {
+	return strconv.FormatFloat(round2(f), 'f', -1, 64)
+}
+
+func formatPercent(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100), 'f', -1, 64) + "%"
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
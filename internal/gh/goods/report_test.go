@@ -0,0 +1,131 @@
+package goods
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reportGoodsYAML = `---
+- type: 耳机
+  tag: EDC
+  score: 3
+  using:
+    name: C60
+    price: ¥300
+    date: 2024-01-01
+  item:
+    - name: C50
+      price: ¥179
+      date: 2023-01-01
+      endDate: 2024-01-01
+      endPrice: ¥50
+    - name: C40
+      price: ¥100
+      date: 2021-01-01
+      endDate: 2022-07-01
+    - name: C70
+      price: 200-300
+      date: 2024-06-01
+    - name: C80
+      price: ¥999
+- type: 跑步长裤
+  tag: clothes
+  score: 5
+  using:
+    name: 梭织透气 跑步长裤
+    price: ¥149
+    date: 2025-01-01
+- type: 饼干
+  tag: food
+  item:
+    - name: 酵母减盐苏打饼干
+      price: ¥22
+      date: 2025-01-01
+`
+
+func runGoodsReport(t *testing.T, content string, now time.Time) *Report {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "goods.yml"), []byte(content), 0644))
+
+	report, err := RunReport(dir, ReportOptions{Now: now})
+	require.NoError(t, err)
+
+	return report
+}
+
+func findReportItem(t *testing.T, items []ReportItem, name string) ReportItem {
+	t.Helper()
+	for _, item := range items {
+		if item.Name == name {
+			return item
+		}
+	}
+	require.Failf(t, "item not found", "%s", name)
+
+	return ReportItem{}
+}
+
+func TestRunReportComputesLifecycleCost(t *testing.T) {
+	report := runGoodsReport(t, reportGoodsYAML, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	require.Len(t, report.Items, 4, "food is not a lifecycle tag; C80 is not owned")
+	assert.Equal(t, 1, report.Unpriced)
+
+	c50 := findReportItem(t, report.Items, "C50")
+	assert.True(t, c50.Retired)
+	assert.Equal(t, 365, c50.Days)
+	assert.InDelta(t, 129, c50.Cost, 0.001)
+	assert.InDelta(t, 0.35, c50.CostPerDay, 0.001)
+
+	c60 := findReportItem(t, report.Items, "C60")
+	assert.False(t, c60.Retired)
+	assert.Equal(t, 366, c60.Days)
+	assert.InDelta(t, 300, c60.Cost, 0.001)
+
+	require.Len(t, report.Categories, 2)
+	edc := report.Categories[0]
+	assert.Equal(t, "EDC", edc.Tag)
+	assert.Equal(t, 1, edc.Active)
+	assert.Equal(t, 2, edc.Retired)
+	assert.InDelta(t, 300, edc.ActiveValue, 0.001)
+	assert.InDelta(t, 279, edc.RetiredValue, 0.001)
+	assert.InDelta(t, 50, edc.Resale, 0.001)
+	assert.InDelta(t, 0.18, edc.Recovery, 0.001)
+	assert.Equal(t, 2, report.Total.Active)
+}
+
+func TestRunReportForecastsLifespanByType(t *testing.T) {
+	report := runGoodsReport(t, reportGoodsYAML, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	require.Len(t, report.Lifespans, 1)
+	assert.Equal(t, TypeLifespan{Type: "耳机", Samples: 2, MedianDays: 455}, report.Lifespans[0])
+
+	c60 := findReportItem(t, report.Items, "C60")
+	assert.Equal(t, "2025-03-31", c60.ExpectedEnd)
+	assert.InDelta(t, 0.8, c60.LifeUsed, 0.001)
+	require.Len(t, report.Nearing, 1)
+	assert.Equal(t, "C60", report.Nearing[0].Name)
+
+	trousers := findReportItem(t, report.Items, "梭织透气 跑步长裤")
+	assert.Empty(t, trousers.ExpectedEnd, "no retired trousers to learn a lifespan from")
+}
+
+func TestReportMarkdown(t *testing.T) {
+	report := runGoodsReport(t, reportGoodsYAML, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	out := ReportMarkdown(report)
+	assert.Contains(t, out, "Goods lifecycle")
+	assert.Contains(t, out, "Cost per day")
+	assert.Contains(t, out, "Nearing end of life")
+	assert.Contains(t, out, "2025-03-31")
+	assert.Contains(t, out, "18%")
+
+	empty := runGoodsReport(t, "", time.Now())
+	assert.Contains(t, ReportMarkdown(empty), "No owned lifecycle goods.")
+}
@@ -0,0 +1,59 @@
+package goods
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+	"github.com/xbpk3t/docs-alfred/pkg/jsonschema"
+)
+
+// JSONSchema returns the JSON Schema of a data/goods file, derived from the
+// lifecycle rules of the check: endDate and endPrice only on using and
+// item[] of lifecycle tags, endDate with a date, endPrice with an endDate and
+// as a one-off CNY amount. endDate not before date is left to the check.
+func JSONSchema() *jsonschema.Schema {
+	date := jsonschema.Pattern(checkutil.DateFullPattern.String()).Describe("YYYY-MM-DD")
+	item := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			fieldEndDate:  date,
+			fieldEndPrice: jsonschema.Pattern(strictCNYPricePattern.String()).Describe("one-off CNY amount, e.g. ¥149"),
+		},
+		Dependencies: map[string][]string{
+			fieldEndDate:  {fieldDate},
+			fieldEndPrice: {fieldEndDate},
+		},
+		If:   &jsonschema.Schema{Required: []string{fieldEndDate}},
+		Then: &jsonschema.Schema{Properties: map[string]*jsonschema.Schema{fieldDate: date}},
+	}
+
+	noLifecycle := &jsonschema.Schema{Properties: map[string]*jsonschema.Schema{
+		fieldEndDate:  jsonschema.Never(),
+		fieldEndPrice: jsonschema.Never(),
+	}}
+	category := jsonschema.Object(map[string]*jsonschema.Schema{
+		fieldTag:      jsonschema.String(),
+		fieldType:     jsonschema.String(),
+		fieldUsing:    jsonschema.Ref(fieldItem),
+		fieldItem:     jsonschema.Array(jsonschema.Ref(fieldItem)),
+		fieldEndDate:  jsonschema.Never().Describe("only on using or item[]"),
+		fieldEndPrice: jsonschema.Never().Describe("only on using or item[]"),
+	})
+	category.If = &jsonschema.Schema{Not: &jsonschema.Schema{
+		Properties: map[string]*jsonschema.Schema{fieldTag: jsonschema.Enum(slices.Sorted(maps.Keys(eligibleLifecycleTags))...)},
+		Required:   []string{fieldTag},
+	}}
+	category.Then = &jsonschema.Schema{Properties: map[string]*jsonschema.Schema{
+		fieldUsing: noLifecycle,
+		fieldItem:  {Items: noLifecycle},
+	}}
+
+	return &jsonschema.Schema{
+		Schema:      jsonschema.Draft07,
+		Title:       "docs-alfred goods data",
+		Type:        "array",
+		Items:       category,
+		Definitions: map[string]*jsonschema.Schema{fieldItem: item},
+	}
+}
@@ -0,0 +1,39 @@
+package goods
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xbpk3t/docs-alfred/pkg/checkutil"
+)
+
+func TestJSONSchemaMatchesRules(t *testing.T) {
+	s := JSONSchema()
+
+	var tags []string
+	for tag := range eligibleLifecycleTags {
+		tags = append(tags, tag)
+	}
+	require.NotNil(t, s.Items.If)
+	assert.ElementsMatch(t, tags, s.Items.If.Not.Properties[fieldTag].Enum)
+	assert.NotNil(t, s.Items.Properties[fieldEndDate].Not, "endDate is forbidden on categories")
+
+	item := s.Definitions[fieldItem]
+	require.NotNil(t, item)
+	assert.Equal(t, []string{fieldDate}, item.Dependencies[fieldEndDate])
+	assert.Equal(t, []string{fieldEndDate}, item.Dependencies[fieldEndPrice])
+
+	endPrice := regexp.MustCompile(item.Properties[fieldEndPrice].Pattern)
+	for _, price := range []string{"¥20", "￥ 20.5", "20", "20元", "¥20-30", "免费"} {
+		result := checkGoodsYAML(t, "- type: 耳机\n  tag: EDC\n  item:\n    - name: C50\n      date: 2023-01-01\n      endDate: 2024-01-01\n      endPrice: "+price+"\n")
+		assert.Equal(t, !checkutil.HasErrors(result.Issues), endPrice.MatchString(price), price)
+	}
+
+	endDate := regexp.MustCompile(item.Properties[fieldEndDate].Pattern)
+	for _, date := range []string{"2024-01-01", "2024/1/1", "2024-1-1"} {
+		result := checkGoodsYAML(t, "- type: 耳机\n  tag: EDC\n  item:\n    - name: C50\n      date: 2023-01-01\n      endDate: "+date+"\n")
+		assert.Equal(t, !checkutil.HasErrors(result.Issues), endDate.MatchString(date), date)
+	}
+}
@@ -0,0 +1,187 @@
+package ghindex
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// Frecency tuning: each use counts frecencyPerUse and halves every
+// frecencyHalfLife, so a repo opened daily outranks one opened often months
+// ago. The store keeps at most maxHistoryEntries repos.
+const (
+	frecencyPerUse    = 5.0
+	frecencyHalfLife  = 14 * 24 * time.Hour
+	maxHistoryEntries = 500
+)
+
+// HistoryEntry is the usage record of one repo.
+type HistoryEntry struct {
+	LastUsed time.Time `json:"lastUsed"`
+	// Frecency is the decayed use score as of LastUsed.
+	Frecency float64 `json:"frecency"`
+	Count    int     `json:"count"`
+}
+
+// History is the local usage store of gh-alfred, keyed by lowercase
+// owner/name.
+type History struct {
+	Repos map[string]*HistoryEntry `json:"repos"`
+	path  string
+}
+
+// HistoryPathFor returns the history file kept alongside a gh.yml cache.
+func HistoryPathFor(configPath string) string {
+	if configPath == "" {
+		configPath = DefaultConfigPath
+	}
+
+	return strings.TrimSuffix(configPath, filepath.Ext(configPath)) + "-history.json"
+}
+
+// HistoryPath returns the history file of the manager's cache.
+func (m *Manager) HistoryPath() string {
+	return HistoryPathFor(m.configPath)
+}
+
+// LoadHistory reads the history at path. A missing file is an empty history.
+func LoadHistory(path string) (*History, error) {
+	history, err := fileutil.ReadJSONFile[History](path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load gh-alfred history: %w", err)
+	}
+	if history.Repos == nil {
+		history.Repos = map[string]*HistoryEntry{}
+	}
+	history.path = path
+
+	return &history, nil
+}
+
+// Save writes the history back to the path it was loaded from.
+func (h *History) Save() error {
+	if err := fileutil.AtomicWriteJSONFile(h.path, h, fileutil.FilePermPrivate); err != nil {
+		return fmt.Errorf("save gh-alfred history: %w", err)
+	}
+
+	return nil
+}
+
+// Record counts one use of the repo behind rawURL (a GitHub URL or
+// owner/name). It reports false for args that are not GitHub repos, such as
+// docs or nixpkgs links opened through a modifier.
+func (h *History) Record(rawURL string, now time.Time) (string, bool) {
+	key, ok := historyKey(rawURL)
+	if !ok {
+		return "", false
+	}
+	entry := h.Repos[key]
+	if entry == nil {
+		entry = &HistoryEntry{}
+		h.Repos[key] = entry
+	}
+	entry.Frecency = entry.frecencyAt(now) + frecencyPerUse
+	entry.Count++
+	entry.LastUsed = now
+	h.prune(now)
+
+	return key, true
+}
+
+// Scores returns the current frecency of every repo, for SearchOptions.Recent.
+func (h *History) Scores(now time.Time) map[string]float64 {
+	scores := make(map[string]float64, len(h.Repos))
+	for key, entry := range h.Repos {
+		scores[key] = entry.frecencyAt(now)
+	}
+
+	return scores
+}
+
+// Reset forgets all repos, or only the given ones (GitHub URLs or
+// owner/name), and returns how many were removed.
+func (h *History) Reset(repos ...string) int {
+	if len(repos) == 0 {
+		n := len(h.Repos)
+		h.Repos = map[string]*HistoryEntry{}
+
+		return n
+	}
+	removed := 0
+	for _, repo := range repos {
+		key, ok := historyKey(repo)
+		if _, found := h.Repos[key]; ok && found {
+			delete(h.Repos, key)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// HistoryItem is one repo of History.Ranked.
+type HistoryItem struct {
+	LastUsed time.Time `json:"lastUsed"`
+	Repo     string    `json:"repo"`
+	Frecency float64   `json:"frecency"`
+	Count    int       `json:"count"`
+}
+
+// Ranked lists the repos by current frecency, highest first.
+func (h *History) Ranked(now time.Time) []HistoryItem {
+	items := make([]HistoryItem, 0, len(h.Repos))
+	for key, entry := range h.Repos {
+		items = append(items, HistoryItem{Repo: key, Count: entry.Count, LastUsed: entry.LastUsed, Frecency: entry.frecencyAt(now)})
+	}
+	slices.SortFunc(items, func(a, b HistoryItem) int {
+		if a.Frecency != b.Frecency {
+			return cmp.Compare(b.Frecency, a.Frecency)
+		}
+
+		return strings.Compare(a.Repo, b.Repo)
+	})
+
+	return items
+}
+
+func (e *HistoryEntry) frecencyAt(now time.Time) float64 {
+	age := now.Sub(e.LastUsed)
+	if age <= 0 {
+		return e.Frecency
+	}
+
+	return e.Frecency * math.Exp2(-float64(age)/float64(frecencyHalfLife))
+}
+
+// prune drops the coldest repos once the store exceeds maxHistoryEntries.
+func (h *History) prune(now time.Time) {
+	if len(h.Repos) <= maxHistoryEntries {
+		return
+	}
+	ranked := h.Ranked(now)
+	for _, item := range ranked[maxHistoryEntries:] {
+		delete(h.Repos, item.Repo)
+	}
+}
+
+func historyKey(raw string) (string, bool) {
+	query := normalizeSearchQuery(raw)
+	owner, name, found := strings.Cut(query, "/")
+	if !found || owner == "" || name == "" || strings.Contains(name, "/") || strings.ContainsAny(owner, ":#") {
+		return "", false
+	}
+	if _, ok := urlutil.GitHubOwnerRepo("https://github.com/" + query); !ok {
+		return "", false
+	}
+
+	return query, true
+}
@@ -0,0 +1,66 @@
+package ghindex
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryPathFor(t *testing.T) {
+	assert.Equal(t, "/tmp/gh-alfred-gh-history.json", HistoryPathFor("/tmp/gh-alfred-gh.yml"))
+	assert.Equal(t, HistoryPathFor(DefaultConfigPath), HistoryPathFor(""))
+}
+
+func TestHistoryRecordDecayAndPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	history, err := LoadHistory(path)
+	require.NoError(t, err)
+	assert.Empty(t, history.Repos)
+
+	key, ok := history.Record("https://github.com/Go-Gorm/gorm.git", now.Add(-frecencyHalfLife))
+	require.True(t, ok)
+	assert.Equal(t, "go-gorm/gorm", key)
+	_, ok = history.Record("go-gorm/gorm", now)
+	require.True(t, ok)
+	_, ok = history.Record("ent/ent", now.Add(-2*frecencyHalfLife))
+	require.True(t, ok)
+
+	for _, arg := range []string{"https://docs.lucc.dev/#/data/gh", "nixpkgs#gorm", "github:nix/nixpkgs", ""} {
+		_, ok = history.Record(arg, now)
+		assert.False(t, ok, arg)
+	}
+	require.NoError(t, history.Save())
+
+	loaded, err := LoadHistory(path)
+	require.NoError(t, err)
+	scores := loaded.Scores(now)
+	assert.InDelta(t, 7.5, scores["go-gorm/gorm"], 1e-9)
+	assert.InDelta(t, 1.25, scores["ent/ent"], 1e-9)
+
+	ranked := loaded.Ranked(now)
+	require.Len(t, ranked, 2)
+	assert.Equal(t, "go-gorm/gorm", ranked[0].Repo)
+	assert.Equal(t, 2, ranked[0].Count)
+
+	assert.Equal(t, 1, loaded.Reset("https://github.com/ent/ent", "missing/repo"))
+	assert.Equal(t, 1, loaded.Reset())
+	assert.Empty(t, loaded.Repos)
+}
+
+func TestHistoryPrunesColdestRepos(t *testing.T) {
+	history, err := LoadHistory(filepath.Join(t.TempDir(), "history.json"))
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	history.Record("cold/repo", now.Add(-365*24*time.Hour))
+	for i := range maxHistoryEntries {
+		history.Record("hot/repo-"+string(rune('a'+i%26))+string(rune('a'+i/26)), now)
+	}
+
+	assert.Len(t, history.Repos, maxHistoryEntries)
+	assert.NotContains(t, history.Repos, "cold/repo")
+}
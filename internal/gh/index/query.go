@@ -1,6 +1,7 @@
 package ghindex
 
 import (
+	"cmp"
 	"slices"
 	"strings"
 
@@ -11,41 +12,41 @@ import (
 
 type repoMatch struct {
 	repo  *content.Repo
-	score int
+	score float64
 	index int
 }
 
-// FilterRepos filters repositories by query string.
+// FilterRepos filters repositories by query. See SearchRepos.
 func FilterRepos(r Repos, query string) Repos {
+	return SearchRepos(r, query, SearchOptions{})
+}
+
+// SearchRepos filters and ranks repositories by query. Every free-text term
+// must match the repo name (exactly, by prefix, substring, typo or
+// subsequence) or its tag, type, topic or description; field filters such as
+// tag:langs or nix:yes must all hold. Matches are ordered by relevance, then
+// boosted by Repo.Score and opts.Recent, then by their original position.
+func SearchRepos(r Repos, query string, opts SearchOptions) Repos {
 	if len(r) == 0 {
 		return nil
 	}
-	query = normalizeSearchQuery(query)
-	if query == "" {
+	parsed := ParseSearchQuery(query)
+	if parsed.IsEmpty() && len(opts.Recent) == 0 {
 		return r
 	}
 
 	matches := make([]repoMatch, 0, len(r))
 	for i, repo := range r {
-		if score, ok := matchRepo(repo, query); ok {
+		if score, ok := matchRepo(repo, &parsed, &opts); ok {
 			matches = append(matches, repoMatch{repo: repo, score: score, index: i})
 		}
 	}
 	slices.SortStableFunc(matches, func(a, b repoMatch) int {
-		if a.score < b.score {
-			return -1
-		}
-		if a.score > b.score {
-			return 1
-		}
-		if a.index < b.index {
-			return -1
-		}
-		if a.index > b.index {
-			return 1
+		if a.score != b.score {
+			return cmp.Compare(b.score, a.score)
 		}
 
-		return 0
+		return cmp.Compare(a.index, b.index)
 	})
 
 	return lo.Map(matches, func(match repoMatch, _ int) *content.Repo {
@@ -68,41 +69,24 @@ func normalizeSearchQuery(query string) string {
 	return strings.TrimSuffix(query, ".git")
 }
 
-func matchRepo(repo *content.Repo, query string) (int, bool) {
-	if repo == nil {
+// matchRepo returns the ranking score of repo for query; higher is better.
+// Without terms, only filters and boosts apply.
+func matchRepo(repo *content.Repo, query *SearchQuery, opts *SearchOptions) (float64, bool) {
+	if repo == nil || !query.matchFilters(repo) {
 		return 0, false
 	}
 	fullName := strings.ToLower(FullName(repo))
-	name := repoNameFromFullName(fullName)
-
-	switch {
-	case fullName == query:
-		return 0, true
-	case name == query:
-		return 1, true
-	case strings.HasSuffix(fullName, query):
-		return 2, true
-	case strings.Contains(fullName, query):
-		return 3, true
-	}
 
-	// Slash queries are path-oriented, matching old Alfred item-title filtering.
-	// Do not match metadata for queries like /git, otherwise github.com-style URL
-	// prefixes and prose descriptions drown out the intended repo-path matches.
-	if strings.Contains(query, "/") {
-		return 0, false
-	}
-
-	switch {
-	case strings.Contains(strings.ToLower(repo.Tag), query):
-		return 4, true
-	case strings.Contains(strings.ToLower(repo.Type), query):
-		return 4, true
-	case strings.Contains(strings.ToLower(repo.Des), query):
-		return 5, true
+	total := 0
+	for _, term := range query.Terms {
+		rel, ok := matchTerm(repo, fullName, term)
+		if !ok {
+			return 0, false
+		}
+		total += rel
 	}
 
-	return 0, false
+	return float64(total) + boost(repo, fullName, opts), true
 }
 
 func repoNameFromFullName(fullName string) string {
@@ -162,7 +162,7 @@ func TestQueryReposByTagAndType(t *testing.T) {
 }
 
 func TestMatchRepo_NilRepo(t *testing.T) {
-	_, ok := matchRepo(nil, "test")
+	_, ok := matchRepo(nil, &SearchQuery{Terms: []string{"test"}}, &SearchOptions{})
 	assert.False(t, ok)
 }
 
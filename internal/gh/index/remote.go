@@ -201,3 +201,8 @@ func (m *Manager) ConfigRepos() ConfigRepos {
 func (m *Manager) Filter(query string) Repos {
 	return FilterRepos(m.repos, query)
 }
+
+// Search filters and ranks repositories by query with opts. See SearchRepos.
+func (m *Manager) Search(query string, opts SearchOptions) Repos {
+	return SearchRepos(m.repos, query, opts)
+}
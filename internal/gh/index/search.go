@@ -0,0 +1,281 @@
+package ghindex
+
+import (
+	"strings"
+
+	"github.com/xbpk3t/docs-alfred/internal/gh/content"
+	"github.com/xbpk3t/docs-alfred/pkg/urlutil"
+)
+
+// Relevance of one query term against a repo; higher ranks first. Repo name
+// matches outrank metadata, so "gin" finds gin-gonic/gin before repos that
+// merely mention gin in their description.
+const (
+	relFullName   = 100
+	relName       = 90
+	relSuffix     = 80
+	relNamePrefix = 75
+	relContains   = 70
+	relTypo       = 55
+	relMeta       = 50
+	relFuzzyMax   = 40
+	relDes        = 35
+	relFuzzyMin   = 25
+)
+
+// Ranking boosts added on top of the term relevance.
+const (
+	scoreBoostPerPoint = 2
+	maxScoreBoost      = 10
+	maxRecentBoost     = 15
+)
+
+// SearchOptions tunes SearchRepos ranking.
+type SearchOptions struct {
+	// Recent boosts recently or frequently used repos, keyed by lowercase
+	// owner/name. Values are capped at maxRecentBoost.
+	Recent map[string]float64
+}
+
+// SearchQuery is a parsed search query: free-text terms plus field filters
+// such as tag:langs, type:golang, topic:orm or nix:yes.
+type SearchQuery struct {
+	Terms   []string
+	Filters []FieldFilter
+}
+
+// FieldFilter restricts results by one repo field.
+type FieldFilter struct {
+	Field  string
+	Value  string
+	Negate bool
+}
+
+// searchFields are the operators accepted by ParseSearchQuery.
+var searchFields = map[string]bool{
+	"tag":   true,
+	"type":  true,
+	"topic": true,
+	"nix":   true,
+	"doc":   true,
+}
+
+// ParseSearchQuery splits query into lowercase terms and field filters. A
+// GitHub URL is reduced to a single owner/name term; unknown field:value
+// tokens are kept as terms. A leading "-" negates a filter.
+func ParseSearchQuery(query string) SearchQuery {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return SearchQuery{}
+	}
+	if isGitHubURLQuery(query) {
+		return SearchQuery{Terms: []string{normalizeSearchQuery(query)}}
+	}
+
+	var parsed SearchQuery
+	for _, token := range strings.Fields(query) {
+		negate := strings.HasPrefix(token, "-")
+		field, value, found := strings.Cut(strings.TrimPrefix(token, "-"), ":")
+		if found && value != "" && searchFields[field] {
+			parsed.Filters = append(parsed.Filters, FieldFilter{Field: field, Value: value, Negate: negate})
+
+			continue
+		}
+		if term := strings.TrimSuffix(token, ".git"); term != "" {
+			parsed.Terms = append(parsed.Terms, term)
+		}
+	}
+
+	return parsed
+}
+
+func isGitHubURLQuery(query string) bool {
+	if strings.ContainsAny(query, " \t") {
+		return false
+	}
+	if _, ok := urlutil.GitHubOwnerRepo(query); ok {
+		return true
+	}
+	_, ok := urlutil.GitHubOwnerRepo("https://" + query)
+
+	return ok && strings.HasPrefix(query, "github.com/")
+}
+
+// IsEmpty reports whether the query has neither terms nor filters.
+func (q *SearchQuery) IsEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Filters) == 0
+}
+
+func (q *SearchQuery) matchFilters(repo *content.Repo) bool {
+	for _, f := range q.Filters {
+		if f.match(repo) == f.Negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *FieldFilter) match(repo *content.Repo) bool {
+	switch f.Field {
+	case "tag":
+		return strings.Contains(strings.ToLower(repo.Tag), f.Value)
+	case "type":
+		return strings.Contains(strings.ToLower(repo.Type), f.Value)
+	case "topic":
+		return strings.Contains(strings.ToLower(repo.TopicName), f.Value)
+	case "nix":
+		return HasNix(repo) == isYes(f.Value)
+	case "doc":
+		return (strings.TrimSpace(repo.Doc) != "") == isYes(f.Value)
+	}
+
+	return false
+}
+
+func isYes(value string) bool {
+	switch value {
+	case "yes", "y", "true", "1":
+		return true
+	}
+
+	return false
+}
+
+// matchTerm scores one term against repo, or returns false when no field
+// matches. Slash terms are path-oriented and only match owner/name.
+func matchTerm(repo *content.Repo, fullName, term string) (int, bool) {
+	name := repoNameFromFullName(fullName)
+
+	switch {
+	case fullName == term:
+		return relFullName, true
+	case name == term:
+		return relName, true
+	case strings.HasSuffix(fullName, term):
+		return relSuffix, true
+	case strings.HasPrefix(name, term):
+		return relNamePrefix, true
+	case strings.Contains(fullName, term):
+		return relContains, true
+	}
+
+	// Slash queries are path-oriented, matching old Alfred item-title filtering.
+	// Do not match metadata for queries like /git, otherwise github.com-style URL
+	// prefixes and prose descriptions drown out the intended repo-path matches.
+	if strings.Contains(term, "/") {
+		return 0, false
+	}
+
+	if withinTypoDistance(term, name) {
+		return relTypo, true
+	}
+	switch {
+	case strings.Contains(strings.ToLower(repo.Tag), term),
+		strings.Contains(strings.ToLower(repo.Type), term),
+		strings.Contains(strings.ToLower(repo.TopicName), term):
+		return relMeta, true
+	}
+	if rel, ok := subsequenceRelevance(term, name); ok && rel > relDes {
+		return rel, true
+	}
+	if strings.Contains(strings.ToLower(repo.Des), term) {
+		return relDes, true
+	}
+
+	return subsequenceRelevance(term, name)
+}
+
+// withinTypoDistance allows one edit for terms of four or more runes and two
+// for eight or more, so short terms do not match unrelated names.
+func withinTypoDistance(term, name string) bool {
+	n := len([]rune(term))
+	maxDist := 0
+	switch {
+	case n >= 8:
+		maxDist = 2
+	case n >= 4:
+		maxDist = 1
+	}
+	if maxDist == 0 || abs(n-len([]rune(name))) > maxDist {
+		return false
+	}
+
+	return editDistance(term, name) <= maxDist
+}
+
+// editDistance is the optimal string alignment distance: insertions,
+// deletions, substitutions and adjacent transpositions each cost one.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+// subsequenceRelevance matches term as an in-order subsequence of name
+// ("ghcli" in "github-cli"). Tighter matches score closer to relFuzzyMax;
+// matches spread over more than twice the term length are rejected.
+func subsequenceRelevance(term, name string) (int, bool) {
+	rt, rn := []rune(term), []rune(name)
+	if len(rt) < 3 {
+		return 0, false
+	}
+	start, pos := -1, 0
+	for i, r := range rn {
+		if r != rt[pos] {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		pos++
+		if pos == len(rt) {
+			span := i - start + 1
+			if span > 2*len(rt) {
+				return 0, false
+			}
+
+			return relFuzzyMin + (relFuzzyMax-relFuzzyMin)*len(rt)/span, true
+		}
+	}
+
+	return 0, false
+}
+
+// boost ranks well-rated and recently used repos higher among similar matches.
+func boost(repo *content.Repo, fullName string, opts *SearchOptions) float64 {
+	b := float64(min(max(repo.Score, 0)*scoreBoostPerPoint, maxScoreBoost))
+	if recent := opts.Recent[fullName]; recent > 0 {
+		b += min(recent, maxRecentBoost)
+	}
+
+	return b
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,112 @@
+package ghindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchFixture() Repos {
+	return Repos{
+		{URL: "https://github.com/go-gorm/gorm", Tag: "langs", Type: "golang", TopicName: "ORM", Score: 5, NixURL: "nixpkgs#gorm"},
+		{URL: "https://github.com/ent/ent", Tag: "langs", Type: "golang", TopicName: "ORM", Des: "entity framework"},
+		{URL: "https://github.com/kubernetes/kubernetes", Tag: "devops", Type: "k8s", Des: "container orchestration"},
+		{URL: "https://github.com/cli/cli", Tag: "devops", Type: "github", Des: "GitHub CLI"},
+		{URL: "https://github.com/sqlalchemy/sqlalchemy", Tag: "langs", Type: "python", TopicName: "ORM"},
+	}
+}
+
+func names(repos Repos) []string {
+	out := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		out = append(out, FullName(repo))
+	}
+
+	return out
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	got := ParseSearchQuery("  Tag:Langs gorm -nix:yes foo:bar topic: ")
+	assert.Equal(t, []string{"gorm", "foo:bar", "topic:"}, got.Terms)
+	assert.Equal(t, []FieldFilter{
+		{Field: "tag", Value: "langs"},
+		{Field: "nix", Value: "yes", Negate: true},
+	}, got.Filters)
+
+	got = ParseSearchQuery("https://github.com/Git/Git.git")
+	assert.Equal(t, []string{"git/git"}, got.Terms)
+	assert.Empty(t, got.Filters)
+	assert.True(t, (&SearchQuery{}).IsEmpty())
+}
+
+func TestSearchReposFieldOperators(t *testing.T) {
+	repos := searchFixture()
+
+	assert.Equal(t, []string{"go-gorm/gorm", "ent/ent"}, names(SearchRepos(repos, "tag:langs type:golang topic:orm", SearchOptions{})))
+	assert.Equal(t, []string{"go-gorm/gorm"}, names(SearchRepos(repos, "nix:yes", SearchOptions{})))
+	assert.Equal(t, []string{"ent/ent", "sqlalchemy/sqlalchemy"}, names(SearchRepos(repos, "topic:orm -nix:yes", SearchOptions{})))
+	assert.Equal(t, []string{"ent/ent"}, names(SearchRepos(repos, "type:golang entity", SearchOptions{})))
+	assert.Empty(t, SearchRepos(repos, "type:rust", SearchOptions{}))
+}
+
+func TestSearchReposToleratesTypos(t *testing.T) {
+	repos := searchFixture()
+
+	got := SearchRepos(repos, "kuberentes", SearchOptions{})
+	require.NotEmpty(t, got)
+	assert.Equal(t, "kubernetes/kubernetes", FullName(got[0]))
+
+	got = SearchRepos(repos, "sqlalchmy", SearchOptions{})
+	require.NotEmpty(t, got)
+	assert.Equal(t, "sqlalchemy/sqlalchemy", FullName(got[0]))
+
+	// Short terms need an exact or substring match.
+	assert.Empty(t, SearchRepos(repos, "clj", SearchOptions{}))
+}
+
+func TestSearchReposMatchesSubsequence(t *testing.T) {
+	repos := Repos{
+		{URL: "https://github.com/junegunn/fzf"},
+		{URL: "https://github.com/a/docker-compose"},
+	}
+
+	got := SearchRepos(repos, "dkrcmp", SearchOptions{})
+	require.Len(t, got, 1)
+	assert.Equal(t, "a/docker-compose", FullName(got[0]))
+}
+
+func TestSearchReposRequiresEveryTerm(t *testing.T) {
+	got := SearchRepos(searchFixture(), "github cli", SearchOptions{})
+	assert.Equal(t, []string{"cli/cli"}, names(got))
+}
+
+func TestSearchReposBoostsScoreAndRecentUse(t *testing.T) {
+	repos := Repos{
+		{URL: "https://github.com/a/orm-one", Tag: "orm"},
+		{URL: "https://github.com/b/orm-two", Tag: "orm"},
+		{URL: "https://github.com/c/orm-three", Tag: "orm", Score: 3},
+	}
+
+	assert.Equal(t, []string{"c/orm-three", "a/orm-one", "b/orm-two"}, names(SearchRepos(repos, "tag:orm", SearchOptions{})))
+
+	recent := SearchOptions{Recent: map[string]float64{"b/orm-two": 100}}
+	assert.Equal(t, []string{"b/orm-two", "c/orm-three", "a/orm-one"}, names(SearchRepos(repos, "tag:orm", recent)))
+	assert.Equal(t, []string{"b/orm-two", "c/orm-three", "a/orm-one"}, names(SearchRepos(repos, "", recent)))
+
+	// Boosts reorder close matches but do not lift a description hit over a
+	// repo name match.
+	repos = Repos{
+		{URL: "https://github.com/x/orm", Des: "gorm"},
+		{URL: "https://github.com/y/other", Des: "an orm", Score: 5},
+	}
+	got := SearchRepos(repos, "orm", SearchOptions{Recent: map[string]float64{"y/other": 100}})
+	assert.Equal(t, []string{"x/orm", "y/other"}, names(got))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("gorm", "gorm"))
+	assert.Equal(t, 1, editDistance("gorm", "grom"))
+	assert.Equal(t, 1, editDistance("gorm", "grm"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
@@ -0,0 +1,92 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetIssueActivity returns issues updated since the given time plus every
+// issue that is still open (unstarted/started), each with its complete state
+// history. Period reports derive throughput, cycle time and carry-over from it.
+func (c *Client) GetIssueActivity(ctx context.Context, since time.Time) ([]IssueActivity, error) {
+	filter := map[string]any{
+		"or": []map[string]any{
+			{"updatedAt": map[string]any{"gte": since.Format(time.RFC3339)}},
+			{"state": map[string]any{"type": map[string]any{keyIn: []string{stateStarted, stateUnstarted}}}},
+		},
+	}
+	c.applyTeamFilter(filter)
+
+	gql := c.graphQLClient()
+	nodes, err := collectPages(ctx, "issueActivity", "", c.limit(), func(ctx context.Context, after string) ([]PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue, pageInfo, error) {
+		resp, err := PeriodActivity(ctx, gql, filter, c.first(), after, c.first())
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Viewer.AssignedIssues
+
+		return conn.Nodes, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: conn.PageInfo.HasNextPage}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query issue activity: %w", err)
+	}
+
+	activity := make([]IssueActivity, 0, len(nodes))
+	for i := range nodes {
+		n := &nodes[i]
+		history := make([]historyEntry, 0, len(n.History.Nodes))
+		for _, h := range n.History.Nodes {
+			history = append(history, historyEntry{
+				fromState: h.FromState.Name,
+				fromType:  h.FromState.Type,
+				toState:   h.ToState.Name,
+				toType:    h.ToState.Type,
+				createdAt: h.CreatedAt,
+			})
+		}
+		if n.History.PageInfo.HasNextPage {
+			more, err := c.fetchRemainingHistory(ctx, n.Id, n.History.PageInfo.EndCursor, "", c.limit()-len(history))
+			if err != nil {
+				return nil, fmt.Errorf("query history for %s: %w", n.Identifier, err)
+			}
+			history = append(history, more...)
+		}
+
+		a := IssueActivity{
+			Identifier:  n.Identifier,
+			Title:       n.Title,
+			StateName:   n.State.Name,
+			StateType:   n.State.Type,
+			TeamName:    n.Team.Name,
+			TeamKey:     n.Team.Key,
+			URL:         n.Url,
+			CreatedAt:   n.CreatedAt,
+			UpdatedAt:   n.UpdatedAt,
+			CompletedAt: n.CompletedAt,
+			Priority:    n.Priority,
+			Estimate:    n.Estimate,
+			Labels:      make([]string, 0, len(n.Labels.Nodes)),
+			History:     make([]StateTransition, 0, len(history)),
+		}
+		for _, l := range n.Labels.Nodes {
+			a.Labels = append(a.Labels, l.Name)
+		}
+		for _, h := range history {
+			// Non-state history rows (assignee, label edits...) carry no states.
+			if h.fromState == "" && h.toState == "" {
+				continue
+			}
+			a.History = append(a.History, StateTransition{
+				FromState: h.fromState,
+				FromType:  h.fromType,
+				ToState:   h.toState,
+				ToType:    h.toType,
+				CreatedAt: h.createdAt,
+			})
+		}
+		activity = append(activity, a)
+	}
+
+	return activity, nil
+}
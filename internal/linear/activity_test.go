@@ -0,0 +1,71 @@
+package linear
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIssueActivity(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "PeriodActivity":
+			return map[string]any{"viewer": map[string]any{"assignedIssues": map[string]any{
+				"nodes": []any{map[string]any{
+					"id": "uuid-1", "identifier": "ENG-1", "title": "Task",
+					"createdAt": "2024-05-30T10:00:00Z", "completedAt": "2024-06-03T10:00:00Z",
+					"estimate": 3,
+					"state":    map[string]any{"name": "Done", "type": "completed"},
+					"team":     map[string]any{"name": "Eng", "key": "ENG"},
+					"labels":   map[string]any{"nodes": []any{map[string]any{"name": "bug"}}},
+					"history": map[string]any{
+						"nodes": []any{
+							map[string]any{
+								"fromState": map[string]any{"name": "In Progress", "type": "started"},
+								"toState":   map[string]any{"name": "Done", "type": "completed"},
+								"createdAt": "2024-06-03T10:00:00Z",
+							},
+							// Assignee change: no states, must be skipped.
+							map[string]any{"createdAt": "2024-06-02T10:00:00Z"},
+						},
+						"pageInfo": pageInfoNode{EndCursor: "h-1", HasNextPage: true},
+					},
+				}},
+			}}}
+		case "IssueHistory":
+			assert.Equal(t, "uuid-1", req.Variables["id"])
+			assert.Equal(t, "h-1", req.Variables["after"])
+			return map[string]any{"issue": map[string]any{"history": map[string]any{
+				"nodes": []any{map[string]any{
+					"fromState": map[string]any{"name": "Todo", "type": "unstarted"},
+					"toState":   map[string]any{"name": "In Progress", "type": "started"},
+					"createdAt": "2024-06-01T10:00:00Z",
+				}},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	since, _ := time.Parse(time.RFC3339, "2024-06-01T00:00:00Z")
+	activity, err := c.GetIssueActivity(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, activity, 1)
+
+	a := activity[0]
+	assert.Equal(t, "ENG-1", a.Identifier)
+	assert.Equal(t, "completed", a.StateType)
+	assert.Equal(t, "2024-05-30T10:00:00Z", a.CreatedAt)
+	assert.Equal(t, []string{"bug"}, a.Labels)
+	require.Len(t, a.History, 2)
+	assert.Equal(t, "completed", a.History[0].ToType)
+	assert.Equal(t, "started", a.History[1].ToType)
+	assert.Equal(t, "unstarted", a.History[1].FromType)
+}
@@ -26,36 +26,48 @@ type Client struct {
 	apiKey   string
 	apiURL   string
 	teamKeys []string
+	pageSize int
+	maxItems int
 }
 
 // NewClient creates a new Linear API client.
-func NewClient(apiKey string, teamKeys []string) *Client {
-	return &Client{
+func NewClient(apiKey string, teamKeys []string, opts ...ClientOption) *Client {
+	c := &Client{
 		apiKey:   apiKey,
 		teamKeys: teamKeys,
 		apiURL:   linearAPI,
 		http:     httputil.StdHTTPClient(30 * time.Second),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // NewClientWithHTTP creates a Linear API client with a custom HTTP client for testing.
-func NewClientWithHTTP(apiKey string, teamKeys []string, apiURL string, httpClient *http.Client) *Client {
-	return &Client{
+func NewClientWithHTTP(apiKey string, teamKeys []string, apiURL string, httpClient *http.Client, opts ...ClientOption) *Client {
+	c := &Client{
 		apiKey:   apiKey,
 		teamKeys: teamKeys,
 		apiURL:   apiURL,
 		http:     httpClient,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // GetActiveIssues returns non-completed issues assigned to the viewer.
 func (c *Client) GetActiveIssues(ctx context.Context) ([]Issue, error) {
-	resp, err := AssignedIssues(ctx, c.graphQLClient(), c.baseFilter(), 50)
+	nodes, err := c.assignedIssues(ctx, c.baseFilter())
 	if err != nil {
 		return nil, fmt.Errorf("query active issues: %w", err)
 	}
 
-	return mapAssignedIssues(resp.Viewer.AssignedIssues.Nodes), nil
+	return mapAssignedIssues(nodes), nil
 }
 
 // GetFocusedIssues returns issues due today with started/unstarted state.
@@ -66,12 +78,12 @@ func (c *Client) GetFocusedIssues(ctx context.Context, date string) ([]Issue, er
 	}
 	c.applyTeamFilter(filter)
 
-	resp, err := AssignedIssues(ctx, c.graphQLClient(), filter, 50)
+	nodes, err := c.assignedIssues(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("query focused issues: %w", err)
 	}
 
-	return mapAssignedIssues(resp.Viewer.AssignedIssues.Nodes), nil
+	return mapAssignedIssues(nodes), nil
 }
 
 // GetCompletedTodayIssues returns issues completed since the given time.
@@ -81,12 +93,12 @@ func (c *Client) GetCompletedTodayIssues(ctx context.Context, since time.Time) (
 	}
 	c.applyTeamFilter(filter)
 
-	resp, err := AssignedIssues(ctx, c.graphQLClient(), filter, 50)
+	nodes, err := c.assignedIssues(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("query completed today: %w", err)
 	}
 
-	return mapAssignedIssues(resp.Viewer.AssignedIssues.Nodes), nil
+	return mapAssignedIssues(nodes), nil
 }
 
 // GetInProgressIssues returns currently in-progress issues.
@@ -96,46 +108,83 @@ func (c *Client) GetInProgressIssues(ctx context.Context) ([]Issue, error) {
 	}
 	c.applyTeamFilter(filter)
 
-	resp, err := AssignedIssues(ctx, c.graphQLClient(), filter, 50)
+	nodes, err := c.assignedIssues(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("query in-progress issues: %w", err)
 	}
 
-	return mapAssignedIssues(resp.Viewer.AssignedIssues.Nodes), nil
+	return mapAssignedIssues(nodes), nil
+}
+
+// assignedIssues pages through the viewer's assigned issues matching filter.
+func (c *Client) assignedIssues(ctx context.Context, filter map[string]any) ([]AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue, error) {
+	gql := c.graphQLClient()
+
+	return collectPages(ctx, "assignedIssues", "", c.limit(), func(ctx context.Context, after string) ([]AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue, pageInfo, error) {
+		resp, err := AssignedIssues(ctx, gql, filter, c.first(), after)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Viewer.AssignedIssues
+
+		return conn.Nodes, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: conn.PageInfo.HasNextPage}, nil
+	})
 }
 
 // GetStateChanges returns state transitions since the given time.
+// Issues are fully paginated up to the client cap; each issue's history
+// starts with a small page and is only paged further while it stays newer
+// than since.
 func (c *Client) GetStateChanges(ctx context.Context, since time.Time) ([]StateChange, error) {
 	sinceStr := since.Format(time.RFC3339)
 	filter := c.baseFilter()
 	filter["updatedAt"] = map[string]any{"gte": sinceStr}
 
-	resp, err := StateChanges(ctx, c.graphQLClient(), filter, 20, 5)
+	gql := c.graphQLClient()
+	nodes, err := collectPages(ctx, "stateChanges", "", c.limit(), func(ctx context.Context, after string) ([]StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue, pageInfo, error) {
+		resp, err := StateChanges(ctx, gql, filter, c.first(), after, stateHistoryFirst)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Viewer.AssignedIssues
+
+		return conn.Nodes, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: conn.PageInfo.HasNextPage}, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("query state changes: %w", err)
 	}
 
-	changes := make([]StateChange, 0, len(resp.Viewer.AssignedIssues.Nodes))
-	for i := range resp.Viewer.AssignedIssues.Nodes {
-		n := &resp.Viewer.AssignedIssues.Nodes[i]
+	changes := make([]StateChange, 0, len(nodes))
+	for i := range nodes {
+		n := &nodes[i]
+		history := make([]historyEntry, 0, len(n.History.Nodes))
 		for _, h := range n.History.Nodes {
-			if h.CreatedAt < sinceStr {
+			history = append(history, historyEntry{fromState: h.FromState.Name, toState: h.ToState.Name, createdAt: h.CreatedAt})
+		}
+		if n.History.PageInfo.HasNextPage && !reachedSince(history, sinceStr) {
+			more, err := c.fetchRemainingHistory(ctx, n.Id, n.History.PageInfo.EndCursor, sinceStr, c.limit()-len(history))
+			if err != nil {
+				return nil, fmt.Errorf("query history for %s: %w", n.Identifier, err)
+			}
+			history = append(history, more...)
+		}
+
+		for _, h := range history {
+			if h.createdAt < sinceStr {
 				continue
 			}
-			fromName := h.FromState.Name
-			toName := h.ToState.Name
-			if fromName == "" && toName == "" {
+			if h.fromState == "" && h.toState == "" {
 				continue
 			}
-			if fromName == toName {
+			if h.fromState == h.toState {
 				continue
 			}
 			changes = append(changes, StateChange{
 				IssueIdentifier: n.Identifier,
 				IssueTitle:      n.Title,
-				FromState:       fromName,
-				ToState:         toName,
-				CreatedAt:       h.CreatedAt,
+				FromState:       h.fromState,
+				ToState:         h.toState,
+				CreatedAt:       h.createdAt,
 				TeamName:        n.Team.Name,
 				TeamKey:         n.Team.Key,
 				URL:             n.Url,
@@ -149,13 +198,11 @@ func (c *Client) GetStateChanges(ctx context.Context, since time.Time) ([]StateC
 // GetActiveIssuesWithDetails returns non-completed issues assigned to the viewer,
 // including full description and comments for AI review.
 func (c *Client) GetActiveIssuesWithDetails(ctx context.Context) ([]IssueDetail, error) {
-	resp, err := UpdatedIssuesWithDetails(ctx, c.graphQLClient(), c.baseFilter(), 50, 100)
+	details, err := c.issuesWithDetails(ctx, c.baseFilter())
 	if err != nil {
 		return nil, fmt.Errorf("query active issues with details: %w", err)
 	}
 
-	details := mapIssueDetails(resp.Viewer.AssignedIssues.Nodes)
-
 	return details, nil
 }
 
@@ -167,12 +214,43 @@ func (c *Client) GetUpdatedIssuesWithDetails(ctx context.Context, since time.Tim
 	}
 	c.applyTeamFilter(filter)
 
-	resp, err := UpdatedIssuesWithDetails(ctx, c.graphQLClient(), filter, 50, 100)
+	details, err := c.issuesWithDetails(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("query updated issues with details: %w", err)
 	}
 
-	details := mapIssueDetails(resp.Viewer.AssignedIssues.Nodes)
+	return details, nil
+}
+
+// issuesWithDetails pages through UpdatedIssuesWithDetails and then completes
+// the comment list of every issue whose first comment page was not the last.
+func (c *Client) issuesWithDetails(ctx context.Context, filter map[string]any) ([]IssueDetail, error) {
+	gql := c.graphQLClient()
+	nodes, err := collectPages(ctx, "issuesWithDetails", "", c.limit(), func(ctx context.Context, after string) ([]UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue, pageInfo, error) {
+		resp, err := UpdatedIssuesWithDetails(ctx, gql, filter, c.first(), after, c.first())
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Viewer.AssignedIssues
+
+		return conn.Nodes, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: conn.PageInfo.HasNextPage}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	details := mapIssueDetails(nodes)
+	for i := range nodes {
+		page := nodes[i].Comments.PageInfo
+		if !page.HasNextPage {
+			continue
+		}
+		more, err := c.fetchRemainingComments(ctx, nodes[i].Id, page.EndCursor, c.limit()-len(details[i].Comments))
+		if err != nil {
+			return nil, fmt.Errorf("query comments for %s: %w", nodes[i].Identifier, err)
+		}
+		details[i].Comments = append(details[i].Comments, more...)
+	}
 
 	return details, nil
 }
@@ -183,10 +261,12 @@ func mapIssueDetails(nodes []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIss
 	for i := range nodes {
 		n := &nodes[i]
 		d := IssueDetail{
+			ID:               n.Id,
 			Identifier:       n.Identifier,
 			Title:            n.Title,
 			Description:      n.Description,
 			Priority:         n.Priority,
+			Estimate:         n.Estimate,
 			StateName:        n.State.Name,
 			StateType:        n.State.Type,
 			TeamName:         n.Team.Name,
@@ -195,8 +275,12 @@ func mapIssueDetails(nodes []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIss
 			CompletedAt:      n.CompletedAt,
 			UpdatedAt:        n.UpdatedAt,
 			ParentIdentifier: n.Parent.Identifier,
+			Labels:           make([]string, 0, len(n.Labels.Nodes)),
 			Comments:         make([]Comment, 0, len(n.Comments.Nodes)),
 		}
+		for _, l := range n.Labels.Nodes {
+			d.Labels = append(d.Labels, l.Name)
+		}
 		for _, c := range n.Comments.Nodes {
 			d.Comments = append(d.Comments, Comment{
 				Body:      c.Body,
@@ -301,6 +385,8 @@ type CreateIssueInput struct {
 	StateID string
 	// AssigneeID assigns the issue. Empty leaves unassigned.
 	AssigneeID string
+	// ParentID makes the new issue a sub-issue. Empty creates a top-level issue.
+	ParentID string
 	// Priority is Linear priority: 0 none, 1 urgent, 2 high, 3 medium, 4 low.
 	// Negative means omit (API default).
 	Priority int
@@ -407,7 +493,7 @@ func (c *Client) ResolveTeamID(ctx context.Context, teamKey string) (string, err
 // including description and comments. Uses the issue(id:) query which accepts
 // both UUIDs and identifiers like "LUC-153".
 func (c *Client) GetIssueByIdentifier(ctx context.Context, identifier string) (*IssueDetail, error) {
-	resp, err := IssueByIDQuery(ctx, c.graphQLClient(), identifier, 100)
+	resp, err := IssueByID(ctx, c.graphQLClient(), identifier, c.first())
 	if err != nil {
 		return nil, fmt.Errorf("query issue %s: %w", identifier, err)
 	}
@@ -418,6 +504,7 @@ func (c *Client) GetIssueByIdentifier(ctx context.Context, identifier string) (*
 
 	n := resp.Issue
 	d := &IssueDetail{
+		ID:          n.Id,
 		Identifier:  n.Identifier,
 		Title:       n.Title,
 		Description: n.Description,
@@ -429,8 +516,12 @@ func (c *Client) GetIssueByIdentifier(ctx context.Context, identifier string) (*
 		URL:         n.Url,
 		CompletedAt: n.CompletedAt,
 		UpdatedAt:   n.UpdatedAt,
+		Labels:      make([]string, 0, len(n.Labels.Nodes)),
 		Comments:    make([]Comment, 0, len(n.Comments.Nodes)),
 	}
+	for _, l := range n.Labels.Nodes {
+		d.Labels = append(d.Labels, l.Name)
+	}
 
 	for _, cm := range n.Comments.Nodes {
 		d.Comments = append(d.Comments, Comment{
@@ -440,119 +531,18 @@ func (c *Client) GetIssueByIdentifier(ctx context.Context, identifier string) (*
 		})
 	}
 
-	return d, nil
-}
-
-// IssueByIDResponse is returned by IssueByIDQuery on success.
-type IssueByIDResponse struct {
-	Issue IssueByIDIssue `json:"issue"`
-}
-
-// IssueByIDIssue includes the requested fields of the GraphQL type Issue.
-type IssueByIDIssue struct {
-	State       IssueByIDIssueStateWorkflowState        `json:"state"`
-	Team        IssueByIDIssueTeam                      `json:"team"`
-	Id          string                                  `json:"id"`
-	Identifier  string                                  `json:"identifier"`
-	Title       string                                  `json:"title"`
-	Description string                                  `json:"description"`
-	Url         string                                  `json:"url"`
-	CompletedAt string                                  `json:"completedAt"`
-	UpdatedAt   string                                  `json:"updatedAt"`
-	Comments    IssueByIDIssueCommentsCommentConnection `json:"comments"`
-	Priority    float64                                 `json:"priority"`
-}
-
-// IssueByIDIssueStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
-type IssueByIDIssueStateWorkflowState struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
-}
-
-// IssueByIDIssueTeam includes the requested fields of the GraphQL type Team.
-type IssueByIDIssueTeam struct {
-	Name string `json:"name"`
-	Key  string `json:"key"`
-}
-
-// IssueByIDIssueCommentsCommentConnection includes the requested fields of the GraphQL type CommentConnection.
-type IssueByIDIssueCommentsCommentConnection struct {
-	Nodes []IssueByIDIssueCommentsCommentConnectionNodesComment `json:"nodes"`
-}
-
-// IssueByIDIssueCommentsCommentConnectionNodesComment includes the requested fields of the GraphQL type Comment.
-type IssueByIDIssueCommentsCommentConnectionNodesComment struct {
-	Body      string                                                  `json:"body"`
-	CreatedAt string                                                  `json:"createdAt"`
-	User      IssueByIDIssueCommentsCommentConnectionNodesCommentUser `json:"user"`
-}
-
-// IssueByIDIssueCommentsCommentConnectionNodesCommentUser includes the requested fields of the GraphQL type User.
-type IssueByIDIssueCommentsCommentConnectionNodesCommentUser struct {
-	Name string `json:"name"`
-}
-
-// IssueByIDQuery fetches a single issue by ID or identifier with comments.
-func IssueByIDQuery(
-	ctx context.Context,
-	c graphql.Client,
-	id string,
-	commentsFirst int,
-) (data *IssueByIDResponse, err error) {
-	req := &graphql.Request{
-		OpName: "IssueByID",
-		Query: `
-query IssueByID ($id: String!, $commentsFirst: Int) {
-	issue(id: $id) {
-		id
-		identifier
-		title
-		description
-		priority
-		url
-		completedAt
-		updatedAt
-		state {
-			name
-			type
-		}
-		team {
-			name
-			key
-		}
-		comments(first: $commentsFirst) {
-			nodes {
-				body
-				createdAt
-				user {
-					name
-				}
-			}
+	if n.Comments.PageInfo.HasNextPage {
+		more, err := c.fetchRemainingComments(ctx, n.Id, n.Comments.PageInfo.EndCursor, c.limit()-len(d.Comments))
+		if err != nil {
+			return nil, fmt.Errorf("query comments for %s: %w", identifier, err)
 		}
+		d.Comments = append(d.Comments, more...)
 	}
-}
-`,
-		Variables: &issueByIDInput{
-			ID:            id,
-			CommentsFirst: commentsFirst,
-		},
-	}
-
-	data = &IssueByIDResponse{}
-	resp := &graphql.Response{Data: data}
 
-	err = c.MakeRequest(ctx, req, resp)
-
-	return data, err
-}
-
-// issueByIDInput holds variables for the IssueByID query.
-type issueByIDInput struct {
-	ID            string `json:"id"`
-	CommentsFirst int    `json:"commentsFirst"`
+	return d, nil
 }
 
-// --- issueCreate (hand-written, same style as IssueByIDQuery) ---
+// --- issueCreate (hand-written, same style as the genqlient output) ---
 
 type issueCreateResponse struct {
 	IssueCreate issueCreatePayload `json:"issueCreate"`
@@ -605,6 +595,9 @@ func issueCreateMutation(
 	if strings.TrimSpace(in.AssigneeID) != "" {
 		input["assigneeId"] = in.AssigneeID
 	}
+	if strings.TrimSpace(in.ParentID) != "" {
+		input["parentId"] = in.ParentID
+	}
 	if in.Priority >= 0 {
 		input["priority"] = in.Priority
 	}
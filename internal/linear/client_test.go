@@ -666,7 +666,13 @@ type parentNode struct {
 }
 
 type commentConnection struct {
-	Nodes []commentNode `json:"nodes"`
+	PageInfo *pageInfoNode `json:"pageInfo,omitempty"`
+	Nodes    []commentNode `json:"nodes"`
+}
+
+type pageInfoNode struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
 }
 
 type commentNode struct {
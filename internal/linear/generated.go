@@ -28,7 +28,8 @@ func (v *AssignedIssuesViewerUser) GetAssignedIssues() AssignedIssuesViewerUserA
 
 // AssignedIssuesViewerUserAssignedIssuesIssueConnection includes the requested fields of the GraphQL type IssueConnection.
 type AssignedIssuesViewerUserAssignedIssuesIssueConnection struct {
-	Nodes []AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	Nodes    []AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	PageInfo AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo     `json:"pageInfo"`
 }
 
 // GetNodes returns AssignedIssuesViewerUserAssignedIssuesIssueConnection.Nodes, and is useful for accessing the field via an interface.
@@ -36,6 +37,11 @@ func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnection) GetNodes() []Ass
 	return v.Nodes
 }
 
+// GetPageInfo returns AssignedIssuesViewerUserAssignedIssuesIssueConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnection) GetPageInfo() AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo {
+	return v.PageInfo
+}
+
 // AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue includes the requested fields of the GraphQL type Issue.
 type AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssue struct {
 	Id          string                                                                            `json:"id"`
@@ -114,38 +120,662 @@ func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueParentIs
 	return v.Id
 }
 
-// AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
-type AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState struct {
+// AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetName() string {
+	return v.Name
+}
+
+// GetType returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetType() string {
+	return v.Type
+}
+
+// AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam includes the requested fields of the GraphQL type Team.
+type AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// GetName returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Name, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetName() string {
+	return v.Name
+}
+
+// GetKey returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Key, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetKey() string {
+	return v.Key
+}
+
+// AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// IssueByIDIssue includes the requested fields of the GraphQL type Issue.
+type IssueByIDIssue struct {
+	Id          string                                   `json:"id"`
+	Identifier  string                                   `json:"identifier"`
+	Title       string                                   `json:"title"`
+	Description string                                   `json:"description"`
+	Priority    float64                                  `json:"priority"`
+	Url         string                                   `json:"url"`
+	CompletedAt string                                   `json:"completedAt"`
+	UpdatedAt   string                                   `json:"updatedAt"`
+	State       IssueByIDIssueStateWorkflowState         `json:"state"`
+	Team        IssueByIDIssueTeam                       `json:"team"`
+	Labels      IssueByIDIssueLabelsIssueLabelConnection `json:"labels"`
+	Comments    IssueByIDIssueCommentsCommentConnection  `json:"comments"`
+}
+
+// GetId returns IssueByIDIssue.Id, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetId() string { return v.Id }
+
+// GetIdentifier returns IssueByIDIssue.Identifier, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetIdentifier() string { return v.Identifier }
+
+// GetTitle returns IssueByIDIssue.Title, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetTitle() string { return v.Title }
+
+// GetDescription returns IssueByIDIssue.Description, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetDescription() string { return v.Description }
+
+// GetPriority returns IssueByIDIssue.Priority, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetPriority() float64 { return v.Priority }
+
+// GetUrl returns IssueByIDIssue.Url, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetUrl() string { return v.Url }
+
+// GetCompletedAt returns IssueByIDIssue.CompletedAt, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetCompletedAt() string { return v.CompletedAt }
+
+// GetUpdatedAt returns IssueByIDIssue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetUpdatedAt() string { return v.UpdatedAt }
+
+// GetState returns IssueByIDIssue.State, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetState() IssueByIDIssueStateWorkflowState { return v.State }
+
+// GetTeam returns IssueByIDIssue.Team, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetTeam() IssueByIDIssueTeam { return v.Team }
+
+// GetLabels returns IssueByIDIssue.Labels, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetLabels() IssueByIDIssueLabelsIssueLabelConnection { return v.Labels }
+
+// GetComments returns IssueByIDIssue.Comments, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssue) GetComments() IssueByIDIssueCommentsCommentConnection { return v.Comments }
+
+// IssueByIDIssueCommentsCommentConnection includes the requested fields of the GraphQL type CommentConnection.
+type IssueByIDIssueCommentsCommentConnection struct {
+	Nodes    []IssueByIDIssueCommentsCommentConnectionNodesComment `json:"nodes"`
+	PageInfo IssueByIDIssueCommentsCommentConnectionPageInfo       `json:"pageInfo"`
+}
+
+// GetNodes returns IssueByIDIssueCommentsCommentConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnection) GetNodes() []IssueByIDIssueCommentsCommentConnectionNodesComment {
+	return v.Nodes
+}
+
+// GetPageInfo returns IssueByIDIssueCommentsCommentConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnection) GetPageInfo() IssueByIDIssueCommentsCommentConnectionPageInfo {
+	return v.PageInfo
+}
+
+// IssueByIDIssueCommentsCommentConnectionNodesComment includes the requested fields of the GraphQL type Comment.
+type IssueByIDIssueCommentsCommentConnectionNodesComment struct {
+	Body      string                                                  `json:"body"`
+	CreatedAt string                                                  `json:"createdAt"`
+	User      IssueByIDIssueCommentsCommentConnectionNodesCommentUser `json:"user"`
+}
+
+// GetBody returns IssueByIDIssueCommentsCommentConnectionNodesComment.Body, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionNodesComment) GetBody() string { return v.Body }
+
+// GetCreatedAt returns IssueByIDIssueCommentsCommentConnectionNodesComment.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionNodesComment) GetCreatedAt() string {
+	return v.CreatedAt
+}
+
+// GetUser returns IssueByIDIssueCommentsCommentConnectionNodesComment.User, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionNodesComment) GetUser() IssueByIDIssueCommentsCommentConnectionNodesCommentUser {
+	return v.User
+}
+
+// IssueByIDIssueCommentsCommentConnectionNodesCommentUser includes the requested fields of the GraphQL type User.
+type IssueByIDIssueCommentsCommentConnectionNodesCommentUser struct {
+	Name string `json:"name"`
+}
+
+// GetName returns IssueByIDIssueCommentsCommentConnectionNodesCommentUser.Name, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionNodesCommentUser) GetName() string { return v.Name }
+
+// IssueByIDIssueCommentsCommentConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type IssueByIDIssueCommentsCommentConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns IssueByIDIssueCommentsCommentConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
+// GetEndCursor returns IssueByIDIssueCommentsCommentConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueCommentsCommentConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// IssueByIDIssueLabelsIssueLabelConnection includes the requested fields of the GraphQL type IssueLabelConnection.
+type IssueByIDIssueLabelsIssueLabelConnection struct {
+	Nodes []IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel `json:"nodes"`
+}
+
+// GetNodes returns IssueByIDIssueLabelsIssueLabelConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueLabelsIssueLabelConnection) GetNodes() []IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel {
+	return v.Nodes
+}
+
+// IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel includes the requested fields of the GraphQL type IssueLabel.
+type IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel struct {
+	Name string `json:"name"`
+}
+
+// GetName returns IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel.Name, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueLabelsIssueLabelConnectionNodesIssueLabel) GetName() string { return v.Name }
+
+// IssueByIDIssueStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type IssueByIDIssueStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns IssueByIDIssueStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueStateWorkflowState) GetName() string { return v.Name }
+
+// GetType returns IssueByIDIssueStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueStateWorkflowState) GetType() string { return v.Type }
+
+// IssueByIDIssueTeam includes the requested fields of the GraphQL type Team.
+type IssueByIDIssueTeam struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// GetName returns IssueByIDIssueTeam.Name, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueTeam) GetName() string { return v.Name }
+
+// GetKey returns IssueByIDIssueTeam.Key, and is useful for accessing the field via an interface.
+func (v *IssueByIDIssueTeam) GetKey() string { return v.Key }
+
+// IssueByIDResponse is returned by IssueByID on success.
+type IssueByIDResponse struct {
+	Issue IssueByIDIssue `json:"issue"`
+}
+
+// GetIssue returns IssueByIDResponse.Issue, and is useful for accessing the field via an interface.
+func (v *IssueByIDResponse) GetIssue() IssueByIDIssue { return v.Issue }
+
+// IssueCommentsIssue includes the requested fields of the GraphQL type Issue.
+type IssueCommentsIssue struct {
+	Comments IssueCommentsIssueCommentsCommentConnection `json:"comments"`
+}
+
+// GetComments returns IssueCommentsIssue.Comments, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssue) GetComments() IssueCommentsIssueCommentsCommentConnection {
+	return v.Comments
+}
+
+// IssueCommentsIssueCommentsCommentConnection includes the requested fields of the GraphQL type CommentConnection.
+type IssueCommentsIssueCommentsCommentConnection struct {
+	Nodes    []IssueCommentsIssueCommentsCommentConnectionNodesComment `json:"nodes"`
+	PageInfo IssueCommentsIssueCommentsCommentConnectionPageInfo       `json:"pageInfo"`
+}
+
+// GetNodes returns IssueCommentsIssueCommentsCommentConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnection) GetNodes() []IssueCommentsIssueCommentsCommentConnectionNodesComment {
+	return v.Nodes
+}
+
+// GetPageInfo returns IssueCommentsIssueCommentsCommentConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnection) GetPageInfo() IssueCommentsIssueCommentsCommentConnectionPageInfo {
+	return v.PageInfo
+}
+
+// IssueCommentsIssueCommentsCommentConnectionNodesComment includes the requested fields of the GraphQL type Comment.
+type IssueCommentsIssueCommentsCommentConnectionNodesComment struct {
+	Body      string                                                      `json:"body"`
+	CreatedAt string                                                      `json:"createdAt"`
+	User      IssueCommentsIssueCommentsCommentConnectionNodesCommentUser `json:"user"`
+}
+
+// GetBody returns IssueCommentsIssueCommentsCommentConnectionNodesComment.Body, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionNodesComment) GetBody() string { return v.Body }
+
+// GetCreatedAt returns IssueCommentsIssueCommentsCommentConnectionNodesComment.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionNodesComment) GetCreatedAt() string {
+	return v.CreatedAt
+}
+
+// GetUser returns IssueCommentsIssueCommentsCommentConnectionNodesComment.User, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionNodesComment) GetUser() IssueCommentsIssueCommentsCommentConnectionNodesCommentUser {
+	return v.User
+}
+
+// IssueCommentsIssueCommentsCommentConnectionNodesCommentUser includes the requested fields of the GraphQL type User.
+type IssueCommentsIssueCommentsCommentConnectionNodesCommentUser struct {
+	Name string `json:"name"`
+}
+
+// GetName returns IssueCommentsIssueCommentsCommentConnectionNodesCommentUser.Name, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionNodesCommentUser) GetName() string { return v.Name }
+
+// IssueCommentsIssueCommentsCommentConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type IssueCommentsIssueCommentsCommentConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns IssueCommentsIssueCommentsCommentConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns IssueCommentsIssueCommentsCommentConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *IssueCommentsIssueCommentsCommentConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// IssueCommentsResponse is returned by IssueComments on success.
+type IssueCommentsResponse struct {
+	Issue IssueCommentsIssue `json:"issue"`
+}
+
+// GetIssue returns IssueCommentsResponse.Issue, and is useful for accessing the field via an interface.
+func (v *IssueCommentsResponse) GetIssue() IssueCommentsIssue { return v.Issue }
+
+// IssueHistoryIssue includes the requested fields of the GraphQL type Issue.
+type IssueHistoryIssue struct {
+	History IssueHistoryIssueHistoryIssueHistoryConnection `json:"history"`
+}
+
+// GetHistory returns IssueHistoryIssue.History, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssue) GetHistory() IssueHistoryIssueHistoryIssueHistoryConnection {
+	return v.History
+}
+
+// IssueHistoryIssueHistoryIssueHistoryConnection includes the requested fields of the GraphQL type IssueHistoryConnection.
+type IssueHistoryIssueHistoryIssueHistoryConnection struct {
+	Nodes    []IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory `json:"nodes"`
+	PageInfo IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo            `json:"pageInfo"`
+}
+
+// GetNodes returns IssueHistoryIssueHistoryIssueHistoryConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnection) GetNodes() []IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory {
+	return v.Nodes
+}
+
+// GetPageInfo returns IssueHistoryIssueHistoryIssueHistoryConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnection) GetPageInfo() IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo {
+	return v.PageInfo
+}
+
+// IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory includes the requested fields of the GraphQL type IssueHistory.
+type IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory struct {
+	FromState IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState `json:"fromState"`
+	ToState   IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState   `json:"toState"`
+	CreatedAt string                                                                                `json:"createdAt"`
+}
+
+// GetFromState returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory.FromState, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetFromState() IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState {
+	return v.FromState
+}
+
+// GetToState returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory.ToState, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetToState() IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState {
+	return v.ToState
+}
+
+// GetCreatedAt returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory.CreatedAt, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetCreatedAt() string {
+	return v.CreatedAt
+}
+
+// IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState) GetName() string {
+	return v.Name
+}
+
+// GetType returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState) GetType() string {
+	return v.Type
+}
+
+// IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState) GetName() string {
+	return v.Name
+}
+
+// GetType returns IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState) GetType() string {
+	return v.Type
+}
+
+// IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *IssueHistoryIssueHistoryIssueHistoryConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// IssueHistoryResponse is returned by IssueHistory on success.
+type IssueHistoryResponse struct {
+	Issue IssueHistoryIssue `json:"issue"`
+}
+
+// GetIssue returns IssueHistoryResponse.Issue, and is useful for accessing the field via an interface.
+func (v *IssueHistoryResponse) GetIssue() IssueHistoryIssue { return v.Issue }
+
+// PeriodActivityResponse is returned by PeriodActivity on success.
+type PeriodActivityResponse struct {
+	Viewer PeriodActivityViewerUser `json:"viewer"`
+}
+
+// GetViewer returns PeriodActivityResponse.Viewer, and is useful for accessing the field via an interface.
+func (v *PeriodActivityResponse) GetViewer() PeriodActivityViewerUser { return v.Viewer }
+
+// PeriodActivityViewerUser includes the requested fields of the GraphQL type User.
+type PeriodActivityViewerUser struct {
+	AssignedIssues PeriodActivityViewerUserAssignedIssuesIssueConnection `json:"assignedIssues"`
+}
+
+// GetAssignedIssues returns PeriodActivityViewerUser.AssignedIssues, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUser) GetAssignedIssues() PeriodActivityViewerUserAssignedIssuesIssueConnection {
+	return v.AssignedIssues
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnection includes the requested fields of the GraphQL type IssueConnection.
+type PeriodActivityViewerUserAssignedIssuesIssueConnection struct {
+	Nodes    []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	PageInfo PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo     `json:"pageInfo"`
+}
+
+// GetNodes returns PeriodActivityViewerUserAssignedIssuesIssueConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnection) GetNodes() []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue {
+	return v.Nodes
+}
+
+// GetPageInfo returns PeriodActivityViewerUserAssignedIssuesIssueConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnection) GetPageInfo() PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo {
+	return v.PageInfo
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue includes the requested fields of the GraphQL type Issue.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue struct {
+	Id          string                                                                                       `json:"id"`
+	Identifier  string                                                                                       `json:"identifier"`
+	Title       string                                                                                       `json:"title"`
+	Priority    float64                                                                                      `json:"priority"`
+	Estimate    float64                                                                                      `json:"estimate"`
+	Url         string                                                                                       `json:"url"`
+	CreatedAt   string                                                                                       `json:"createdAt"`
+	UpdatedAt   string                                                                                       `json:"updatedAt"`
+	CompletedAt string                                                                                       `json:"completedAt"`
+	State       PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState            `json:"state"`
+	Team        PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam                          `json:"team"`
+	Labels      PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection    `json:"labels"`
+	History     PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection `json:"history"`
+}
+
+// GetId returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Id, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetId() string { return v.Id }
+
+// GetIdentifier returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Identifier, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetIdentifier() string {
+	return v.Identifier
+}
+
+// GetTitle returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Title, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetTitle() string {
+	return v.Title
+}
+
+// GetPriority returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Priority, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetPriority() float64 {
+	return v.Priority
+}
+
+// GetEstimate returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Estimate, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetEstimate() float64 {
+	return v.Estimate
+}
+
+// GetUrl returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Url, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetUrl() string {
+	return v.Url
+}
+
+// GetCreatedAt returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.CreatedAt, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetCreatedAt() string {
+	return v.CreatedAt
+}
+
+// GetUpdatedAt returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.UpdatedAt, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetUpdatedAt() string {
+	return v.UpdatedAt
+}
+
+// GetCompletedAt returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.CompletedAt, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetCompletedAt() string {
+	return v.CompletedAt
+}
+
+// GetState returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.State, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetState() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState {
+	return v.State
+}
+
+// GetTeam returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Team, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetTeam() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam {
+	return v.Team
+}
+
+// GetLabels returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.Labels, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetLabels() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection {
+	return v.Labels
+}
+
+// GetHistory returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue.History, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssue) GetHistory() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection {
+	return v.History
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection includes the requested fields of the GraphQL type IssueHistoryConnection.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection struct {
+	Nodes    []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory `json:"nodes"`
+	PageInfo PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo            `json:"pageInfo"`
+}
+
+// GetNodes returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection) GetNodes() []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory {
+	return v.Nodes
+}
+
+// GetPageInfo returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection) GetPageInfo() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo {
+	return v.PageInfo
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory includes the requested fields of the GraphQL type IssueHistory.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory struct {
+	FromState PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState `json:"fromState"`
+	ToState   PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState   `json:"toState"`
+	CreatedAt string                                                                                                                              `json:"createdAt"`
+}
+
+// GetFromState returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory.FromState, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetFromState() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState {
+	return v.FromState
+}
+
+// GetToState returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory.ToState, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetToState() PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState {
+	return v.ToState
+}
+
+// GetCreatedAt returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory.CreatedAt, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory) GetCreatedAt() string {
+	return v.CreatedAt
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState) GetName() string {
+	return v.Name
+}
+
+// GetType returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState) GetType() string {
+	return v.Type
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// GetName returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState) GetName() string {
+	return v.Name
+}
+
+// GetType returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryToStateWorkflowState) GetType() string {
+	return v.Type
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection includes the requested fields of the GraphQL type IssueLabelConnection.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection struct {
+	Nodes []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel `json:"nodes"`
+}
+
+// GetNodes returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection) GetNodes() []PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel {
+	return v.Nodes
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel includes the requested fields of the GraphQL type IssueLabel.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel struct {
+	Name string `json:"name"`
+}
+
+// GetName returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel.Name, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel) GetName() string {
+	return v.Name
+}
+
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState includes the requested fields of the GraphQL type WorkflowState.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
 }
 
-// GetName returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Name, and is useful for accessing the field via an interface.
-func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetName() string {
+// GetName returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Name, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetName() string {
 	return v.Name
 }
 
-// GetType returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Type, and is useful for accessing the field via an interface.
-func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetType() string {
+// GetType returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState.Type, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState) GetType() string {
 	return v.Type
 }
 
-// AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam includes the requested fields of the GraphQL type Team.
-type AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam struct {
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam includes the requested fields of the GraphQL type Team.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam struct {
 	Name string `json:"name"`
 	Key  string `json:"key"`
 }
 
-// GetName returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Name, and is useful for accessing the field via an interface.
-func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetName() string {
+// GetName returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Name, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetName() string {
 	return v.Name
 }
 
-// GetKey returns AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Key, and is useful for accessing the field via an interface.
-func (v *AssignedIssuesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetKey() string {
+// GetKey returns PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam.Key, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetKey() string {
 	return v.Key
 }
 
+// PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *PeriodActivityViewerUserAssignedIssuesIssueConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
 // StateChangesResponse is returned by StateChanges on success.
 type StateChangesResponse struct {
 	Viewer StateChangesViewerUser `json:"viewer"`
@@ -166,7 +796,8 @@ func (v *StateChangesViewerUser) GetAssignedIssues() StateChangesViewerUserAssig
 
 // StateChangesViewerUserAssignedIssuesIssueConnection includes the requested fields of the GraphQL type IssueConnection.
 type StateChangesViewerUserAssignedIssuesIssueConnection struct {
-	Nodes []StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	Nodes    []StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	PageInfo StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo     `json:"pageInfo"`
 }
 
 // GetNodes returns StateChangesViewerUserAssignedIssuesIssueConnection.Nodes, and is useful for accessing the field via an interface.
@@ -174,6 +805,11 @@ func (v *StateChangesViewerUserAssignedIssuesIssueConnection) GetNodes() []State
 	return v.Nodes
 }
 
+// GetPageInfo returns StateChangesViewerUserAssignedIssuesIssueConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnection) GetPageInfo() StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo {
+	return v.PageInfo
+}
+
 // StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue includes the requested fields of the GraphQL type Issue.
 type StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue struct {
 	Id         string                                                                                     `json:"id"`
@@ -218,7 +854,8 @@ func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssue) GetHisto
 
 // StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection includes the requested fields of the GraphQL type IssueHistoryConnection.
 type StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection struct {
-	Nodes []StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory `json:"nodes"`
+	Nodes    []StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory `json:"nodes"`
+	PageInfo StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo            `json:"pageInfo"`
 }
 
 // GetNodes returns StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection.Nodes, and is useful for accessing the field via an interface.
@@ -226,6 +863,11 @@ func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIss
 	return v.Nodes
 }
 
+// GetPageInfo returns StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnection) GetPageInfo() StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo {
+	return v.PageInfo
+}
+
 // StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory includes the requested fields of the GraphQL type IssueHistory.
 type StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistory struct {
 	FromState StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionNodesIssueHistoryFromStateWorkflowState `json:"fromState"`
@@ -268,6 +910,22 @@ func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIss
 	return v.Name
 }
 
+// StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueHistoryIssueHistoryConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
 // StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam includes the requested fields of the GraphQL type Team.
 type StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam struct {
 	Name string `json:"name"`
@@ -284,6 +942,22 @@ func (v *StateChangesViewerUserAssignedIssuesIssueConnectionNodesIssueTeam) GetK
 	return v.Key
 }
 
+// StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *StateChangesViewerUserAssignedIssuesIssueConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
 // UpdatedIssuesWithDetailsResponse is returned by UpdatedIssuesWithDetails on success.
 type UpdatedIssuesWithDetailsResponse struct {
 	Viewer UpdatedIssuesWithDetailsViewerUser `json:"viewer"`
@@ -306,7 +980,8 @@ func (v *UpdatedIssuesWithDetailsViewerUser) GetAssignedIssues() UpdatedIssuesWi
 
 // UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection includes the requested fields of the GraphQL type IssueConnection.
 type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection struct {
-	Nodes []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	Nodes    []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue `json:"nodes"`
+	PageInfo UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo     `json:"pageInfo"`
 }
 
 // GetNodes returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection.Nodes, and is useful for accessing the field via an interface.
@@ -314,20 +989,27 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection) GetNod
 	return v.Nodes
 }
 
+// GetPageInfo returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnection) GetPageInfo() UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo {
+	return v.PageInfo
+}
+
 // UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue includes the requested fields of the GraphQL type Issue.
 type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue struct {
-	Id          string                                                                                             `json:"id"`
-	Identifier  string                                                                                             `json:"identifier"`
-	Title       string                                                                                             `json:"title"`
-	Description string                                                                                             `json:"description"`
-	Priority    float64                                                                                            `json:"priority"`
-	Url         string                                                                                             `json:"url"`
-	CompletedAt string                                                                                             `json:"completedAt"`
-	UpdatedAt   string                                                                                             `json:"updatedAt"`
-	State       UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState        `json:"state"`
-	Team        UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueTeam                      `json:"team"`
-	Parent      UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueParentIssue               `json:"parent"`
-	Comments    UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection `json:"comments"`
+	Id          string                                                                                              `json:"id"`
+	Identifier  string                                                                                              `json:"identifier"`
+	Title       string                                                                                              `json:"title"`
+	Description string                                                                                              `json:"description"`
+	Priority    float64                                                                                             `json:"priority"`
+	Estimate    float64                                                                                             `json:"estimate"`
+	Url         string                                                                                              `json:"url"`
+	CompletedAt string                                                                                              `json:"completedAt"`
+	UpdatedAt   string                                                                                              `json:"updatedAt"`
+	State       UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueStateWorkflowState         `json:"state"`
+	Team        UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueTeam                       `json:"team"`
+	Parent      UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueParentIssue                `json:"parent"`
+	Labels      UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection `json:"labels"`
+	Comments    UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection  `json:"comments"`
 }
 
 // GetId returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue.Id, and is useful for accessing the field via an interface.
@@ -355,6 +1037,11 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 	return v.Priority
 }
 
+// GetEstimate returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue.Estimate, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue) GetEstimate() float64 {
+	return v.Estimate
+}
+
 // GetUrl returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue.Url, and is useful for accessing the field via an interface.
 func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue) GetUrl() string {
 	return v.Url
@@ -385,6 +1072,11 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 	return v.Parent
 }
 
+// GetLabels returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue.Labels, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue) GetLabels() UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection {
+	return v.Labels
+}
+
 // GetComments returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue.Comments, and is useful for accessing the field via an interface.
 func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssue) GetComments() UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection {
 	return v.Comments
@@ -392,7 +1084,8 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 
 // UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection includes the requested fields of the GraphQL type CommentConnection.
 type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection struct {
-	Nodes []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionNodesComment `json:"nodes"`
+	Nodes    []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionNodesComment `json:"nodes"`
+	PageInfo UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo       `json:"pageInfo"`
 }
 
 // GetNodes returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection.Nodes, and is useful for accessing the field via an interface.
@@ -400,6 +1093,11 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 	return v.Nodes
 }
 
+// GetPageInfo returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnection) GetPageInfo() UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo {
+	return v.PageInfo
+}
+
 // UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionNodesComment includes the requested fields of the GraphQL type Comment.
 type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionNodesComment struct {
 	Body      string                                                                                                             `json:"body"`
@@ -432,6 +1130,42 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 	return v.Name
 }
 
+// UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueCommentsCommentConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection includes the requested fields of the GraphQL type IssueLabelConnection.
+type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection struct {
+	Nodes []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel `json:"nodes"`
+}
+
+// GetNodes returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection.Nodes, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnection) GetNodes() []UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel {
+	return v.Nodes
+}
+
+// UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel includes the requested fields of the GraphQL type IssueLabel.
+type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel struct {
+	Name string `json:"name"`
+}
+
+// GetName returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel.Name, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueLabelsIssueLabelConnectionNodesIssueLabel) GetName() string {
+	return v.Name
+}
+
 // UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueParentIssue includes the requested fields of the GraphQL type Issue.
 type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIssueParentIssue struct {
 	Id         string `json:"id"`
@@ -480,10 +1214,27 @@ func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionNodesIss
 	return v.Key
 }
 
+// UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+type UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// GetHasNextPage returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// GetEndCursor returns UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *UpdatedIssuesWithDetailsViewerUserAssignedIssuesIssueConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
 // __AssignedIssuesInput is used internally by genqlient
 type __AssignedIssuesInput struct {
 	Filter map[string]interface{} `json:"filter"`
 	First  int                    `json:"first"`
+	After  string                 `json:"after,omitempty"`
 }
 
 // GetFilter returns __AssignedIssuesInput.Filter, and is useful for accessing the field via an interface.
@@ -492,10 +1243,78 @@ func (v *__AssignedIssuesInput) GetFilter() map[string]interface{} { return v.Fi
 // GetFirst returns __AssignedIssuesInput.First, and is useful for accessing the field via an interface.
 func (v *__AssignedIssuesInput) GetFirst() int { return v.First }
 
+// GetAfter returns __AssignedIssuesInput.After, and is useful for accessing the field via an interface.
+func (v *__AssignedIssuesInput) GetAfter() string { return v.After }
+
+// __IssueByIDInput is used internally by genqlient
+type __IssueByIDInput struct {
+	Id            string `json:"id"`
+	CommentsFirst int    `json:"commentsFirst"`
+}
+
+// GetId returns __IssueByIDInput.Id, and is useful for accessing the field via an interface.
+func (v *__IssueByIDInput) GetId() string { return v.Id }
+
+// GetCommentsFirst returns __IssueByIDInput.CommentsFirst, and is useful for accessing the field via an interface.
+func (v *__IssueByIDInput) GetCommentsFirst() int { return v.CommentsFirst }
+
+// __IssueCommentsInput is used internally by genqlient
+type __IssueCommentsInput struct {
+	Id    string `json:"id"`
+	First int    `json:"first"`
+	After string `json:"after,omitempty"`
+}
+
+// GetId returns __IssueCommentsInput.Id, and is useful for accessing the field via an interface.
+func (v *__IssueCommentsInput) GetId() string { return v.Id }
+
+// GetFirst returns __IssueCommentsInput.First, and is useful for accessing the field via an interface.
+func (v *__IssueCommentsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __IssueCommentsInput.After, and is useful for accessing the field via an interface.
+func (v *__IssueCommentsInput) GetAfter() string { return v.After }
+
+// __IssueHistoryInput is used internally by genqlient
+type __IssueHistoryInput struct {
+	Id    string `json:"id"`
+	First int    `json:"first"`
+	After string `json:"after,omitempty"`
+}
+
+// GetId returns __IssueHistoryInput.Id, and is useful for accessing the field via an interface.
+func (v *__IssueHistoryInput) GetId() string { return v.Id }
+
+// GetFirst returns __IssueHistoryInput.First, and is useful for accessing the field via an interface.
+func (v *__IssueHistoryInput) GetFirst() int { return v.First }
+
+// GetAfter returns __IssueHistoryInput.After, and is useful for accessing the field via an interface.
+func (v *__IssueHistoryInput) GetAfter() string { return v.After }
+
+// __PeriodActivityInput is used internally by genqlient
+type __PeriodActivityInput struct {
+	Filter       map[string]interface{} `json:"filter"`
+	First        int                    `json:"first"`
+	After        string                 `json:"after,omitempty"`
+	HistoryFirst int                    `json:"historyFirst"`
+}
+
+// GetFilter returns __PeriodActivityInput.Filter, and is useful for accessing the field via an interface.
+func (v *__PeriodActivityInput) GetFilter() map[string]interface{} { return v.Filter }
+
+// GetFirst returns __PeriodActivityInput.First, and is useful for accessing the field via an interface.
+func (v *__PeriodActivityInput) GetFirst() int { return v.First }
+
+// GetAfter returns __PeriodActivityInput.After, and is useful for accessing the field via an interface.
+func (v *__PeriodActivityInput) GetAfter() string { return v.After }
+
+// GetHistoryFirst returns __PeriodActivityInput.HistoryFirst, and is useful for accessing the field via an interface.
+func (v *__PeriodActivityInput) GetHistoryFirst() int { return v.HistoryFirst }
+
 // __StateChangesInput is used internally by genqlient
 type __StateChangesInput struct {
 	Filter       map[string]interface{} `json:"filter"`
 	First        int                    `json:"first"`
+	After        string                 `json:"after,omitempty"`
 	HistoryFirst int                    `json:"historyFirst"`
 }
 
@@ -505,6 +1324,9 @@ func (v *__StateChangesInput) GetFilter() map[string]interface{} { return v.Filt
 // GetFirst returns __StateChangesInput.First, and is useful for accessing the field via an interface.
 func (v *__StateChangesInput) GetFirst() int { return v.First }
 
+// GetAfter returns __StateChangesInput.After, and is useful for accessing the field via an interface.
+func (v *__StateChangesInput) GetAfter() string { return v.After }
+
 // GetHistoryFirst returns __StateChangesInput.HistoryFirst, and is useful for accessing the field via an interface.
 func (v *__StateChangesInput) GetHistoryFirst() int { return v.HistoryFirst }
 
@@ -512,6 +1334,7 @@ func (v *__StateChangesInput) GetHistoryFirst() int { return v.HistoryFirst }
 type __UpdatedIssuesWithDetailsInput struct {
 	Filter        map[string]interface{} `json:"filter"`
 	First         int                    `json:"first"`
+	After         string                 `json:"after,omitempty"`
 	CommentsFirst int                    `json:"commentsFirst"`
 }
 
@@ -521,14 +1344,17 @@ func (v *__UpdatedIssuesWithDetailsInput) GetFilter() map[string]interface{} { r
 // GetFirst returns __UpdatedIssuesWithDetailsInput.First, and is useful for accessing the field via an interface.
 func (v *__UpdatedIssuesWithDetailsInput) GetFirst() int { return v.First }
 
+// GetAfter returns __UpdatedIssuesWithDetailsInput.After, and is useful for accessing the field via an interface.
+func (v *__UpdatedIssuesWithDetailsInput) GetAfter() string { return v.After }
+
 // GetCommentsFirst returns __UpdatedIssuesWithDetailsInput.CommentsFirst, and is useful for accessing the field via an interface.
 func (v *__UpdatedIssuesWithDetailsInput) GetCommentsFirst() int { return v.CommentsFirst }
 
 // The query executed by AssignedIssues.
 const AssignedIssues_Operation = `
-query AssignedIssues ($filter: IssueFilter!, $first: Int) {
+query AssignedIssues ($filter: IssueFilter!, $first: Int, $after: String) {
 	viewer {
-		assignedIssues(filter: $filter, first: $first) {
+		assignedIssues(filter: $filter, first: $first, after: $after) {
 			nodes {
 				id
 				title
@@ -550,6 +1376,10 @@ query AssignedIssues ($filter: IssueFilter!, $first: Int) {
 				updatedAt
 				completedAt
 			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
 		}
 	}
 }
@@ -560,6 +1390,7 @@ func AssignedIssues(
 	client_ graphql.Client,
 	filter map[string]interface{},
 	first int,
+	after string,
 ) (data_ *AssignedIssuesResponse, err_ error) {
 	req_ := &graphql.Request{
 		OpName: "AssignedIssues",
@@ -567,6 +1398,7 @@ func AssignedIssues(
 		Variables: &__AssignedIssuesInput{
 			Filter: filter,
 			First:  first,
+			After:  after,
 		},
 	}
 
@@ -582,11 +1414,270 @@ func AssignedIssues(
 	return data_, err_
 }
 
+// The query executed by IssueByID.
+const IssueByID_Operation = `
+query IssueByID ($id: String!, $commentsFirst: Int) {
+	issue(id: $id) {
+		id
+		identifier
+		title
+		description
+		priority
+		url
+		completedAt
+		updatedAt
+		state {
+			name
+			type
+		}
+		team {
+			name
+			key
+		}
+		labels {
+			nodes {
+				name
+			}
+		}
+		comments(first: $commentsFirst) {
+			nodes {
+				body
+				createdAt
+				user {
+					name
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+func IssueByID(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	commentsFirst int,
+) (data_ *IssueByIDResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "IssueByID",
+		Query:  IssueByID_Operation,
+		Variables: &__IssueByIDInput{
+			Id:            id,
+			CommentsFirst: commentsFirst,
+		},
+	}
+
+	data_ = &IssueByIDResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by IssueComments.
+const IssueComments_Operation = `
+query IssueComments ($id: String!, $first: Int, $after: String) {
+	issue(id: $id) {
+		comments(first: $first, after: $after) {
+			nodes {
+				body
+				createdAt
+				user {
+					name
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+func IssueComments(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	first int,
+	after string,
+) (data_ *IssueCommentsResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "IssueComments",
+		Query:  IssueComments_Operation,
+		Variables: &__IssueCommentsInput{
+			Id:    id,
+			First: first,
+			After: after,
+		},
+	}
+
+	data_ = &IssueCommentsResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by IssueHistory.
+const IssueHistory_Operation = `
+query IssueHistory ($id: String!, $first: Int, $after: String) {
+	issue(id: $id) {
+		history(first: $first, after: $after) {
+			nodes {
+				fromState {
+					name
+					type
+				}
+				toState {
+					name
+					type
+				}
+				createdAt
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+func IssueHistory(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	id string,
+	first int,
+	after string,
+) (data_ *IssueHistoryResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "IssueHistory",
+		Query:  IssueHistory_Operation,
+		Variables: &__IssueHistoryInput{
+			Id:    id,
+			First: first,
+			After: after,
+		},
+	}
+
+	data_ = &IssueHistoryResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
+// The query executed by PeriodActivity.
+const PeriodActivity_Operation = `
+query PeriodActivity ($filter: IssueFilter!, $first: Int, $after: String, $historyFirst: Int) {
+	viewer {
+		assignedIssues(filter: $filter, first: $first, after: $after) {
+			nodes {
+				id
+				identifier
+				title
+				priority
+				estimate
+				url
+				createdAt
+				updatedAt
+				completedAt
+				state {
+					name
+					type
+				}
+				team {
+					name
+					key
+				}
+				labels {
+					nodes {
+						name
+					}
+				}
+				history(first: $historyFirst) {
+					nodes {
+						fromState {
+							name
+							type
+						}
+						toState {
+							name
+							type
+						}
+						createdAt
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}
+}
+`
+
+func PeriodActivity(
+	ctx_ context.Context,
+	client_ graphql.Client,
+	filter map[string]interface{},
+	first int,
+	after string,
+	historyFirst int,
+) (data_ *PeriodActivityResponse, err_ error) {
+	req_ := &graphql.Request{
+		OpName: "PeriodActivity",
+		Query:  PeriodActivity_Operation,
+		Variables: &__PeriodActivityInput{
+			Filter:       filter,
+			First:        first,
+			After:        after,
+			HistoryFirst: historyFirst,
+		},
+	}
+
+	data_ = &PeriodActivityResponse{}
+	resp_ := &graphql.Response{Data: data_}
+
+	err_ = client_.MakeRequest(
+		ctx_,
+		req_,
+		resp_,
+	)
+
+	return data_, err_
+}
+
 // The query executed by StateChanges.
 const StateChanges_Operation = `
-query StateChanges ($filter: IssueFilter!, $first: Int, $historyFirst: Int) {
+query StateChanges ($filter: IssueFilter!, $first: Int, $after: String, $historyFirst: Int) {
 	viewer {
-		assignedIssues(filter: $filter, first: $first) {
+		assignedIssues(filter: $filter, first: $first, after: $after) {
 			nodes {
 				id
 				identifier
@@ -607,8 +1698,16 @@ query StateChanges ($filter: IssueFilter!, $first: Int, $historyFirst: Int) {
 						}
 						createdAt
 					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 				}
 			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
 		}
 	}
 }
@@ -619,6 +1718,7 @@ func StateChanges(
 	client_ graphql.Client,
 	filter map[string]interface{},
 	first int,
+	after string,
 	historyFirst int,
 ) (data_ *StateChangesResponse, err_ error) {
 	req_ := &graphql.Request{
@@ -627,6 +1727,7 @@ func StateChanges(
 		Variables: &__StateChangesInput{
 			Filter:       filter,
 			First:        first,
+			After:        after,
 			HistoryFirst: historyFirst,
 		},
 	}
@@ -645,15 +1746,16 @@ func StateChanges(
 
 // The query executed by UpdatedIssuesWithDetails.
 const UpdatedIssuesWithDetails_Operation = `
-query UpdatedIssuesWithDetails ($filter: IssueFilter!, $first: Int, $commentsFirst: Int) {
+query UpdatedIssuesWithDetails ($filter: IssueFilter!, $first: Int, $after: String, $commentsFirst: Int) {
 	viewer {
-		assignedIssues(filter: $filter, first: $first) {
+		assignedIssues(filter: $filter, first: $first, after: $after) {
 			nodes {
 				id
 				identifier
 				title
 				description
 				priority
+				estimate
 				url
 				completedAt
 				updatedAt
@@ -669,6 +1771,11 @@ query UpdatedIssuesWithDetails ($filter: IssueFilter!, $first: Int, $commentsFir
 					id
 					identifier
 				}
+				labels {
+					nodes {
+						name
+					}
+				}
 				comments(first: $commentsFirst) {
 					nodes {
 						body
@@ -677,8 +1784,16 @@ query UpdatedIssuesWithDetails ($filter: IssueFilter!, $first: Int, $commentsFir
 							name
 						}
 					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 				}
 			}
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
 		}
 	}
 }
@@ -689,6 +1804,7 @@ func UpdatedIssuesWithDetails(
 	client_ graphql.Client,
 	filter map[string]interface{},
 	first int,
+	after string,
 	commentsFirst int,
 ) (data_ *UpdatedIssuesWithDetailsResponse, err_ error) {
 	req_ := &graphql.Request{
@@ -697,6 +1813,7 @@ func UpdatedIssuesWithDetails(
 		Variables: &__UpdatedIssuesWithDetailsInput{
 			Filter:        filter,
 			First:         first,
+			After:         after,
 			CommentsFirst: commentsFirst,
 		},
 	}
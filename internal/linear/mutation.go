@@ -0,0 +1,363 @@
+package linear
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// UpdateIssueInput is the payload for issueUpdate.
+// Empty strings leave the corresponding field unchanged.
+type UpdateIssueInput struct {
+	Title       string
+	Description string
+	// StateID moves the issue to a workflow state (see ResolveStateID).
+	StateID    string
+	AssigneeID string
+	// Priority is Linear priority: 0 none, 1 urgent, 2 high, 3 medium, 4 low.
+	// Negative means unchanged.
+	Priority int
+	// Estimate is the issue estimate in team points. Negative means unchanged.
+	Estimate float64
+}
+
+// IsEmpty reports whether the input would change nothing.
+func (in *UpdateIssueInput) IsEmpty() bool {
+	return len(in.fields()) == 0
+}
+
+func (in *UpdateIssueInput) fields() map[string]any {
+	input := map[string]any{}
+	if strings.TrimSpace(in.Title) != "" {
+		input["title"] = in.Title
+	}
+	if strings.TrimSpace(in.Description) != "" {
+		input["description"] = in.Description
+	}
+	if strings.TrimSpace(in.StateID) != "" {
+		input["stateId"] = in.StateID
+	}
+	if strings.TrimSpace(in.AssigneeID) != "" {
+		input["assigneeId"] = in.AssigneeID
+	}
+	if in.Priority >= 0 {
+		input["priority"] = in.Priority
+	}
+	if in.Estimate >= 0 {
+		input["estimate"] = in.Estimate
+	}
+
+	return input
+}
+
+// UpdateIssue applies in to the issue with the given id or identifier (e.g. "LUC-153").
+func (c *Client) UpdateIssue(ctx context.Context, id string, in *UpdateIssueInput) (*Issue, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return nil, fmt.Errorf("update issue: id is required")
+	}
+	if in == nil || in.IsEmpty() {
+		return nil, fmt.Errorf("update issue %s: nothing to update", id)
+	}
+
+	resp, err := issueUpdateMutation(ctx, c.graphQLClient(), id, in.fields())
+	if err != nil {
+		return nil, fmt.Errorf("update issue %s: %w", id, err)
+	}
+	if !resp.IssueUpdate.Success {
+		return nil, fmt.Errorf("update issue %s: mutation reported success=false", id)
+	}
+
+	n := resp.IssueUpdate.Issue
+	return &Issue{
+		ID:         n.Id,
+		Title:      n.Title,
+		Identifier: n.Identifier,
+		URL:        n.Url,
+		TeamName:   n.Team.Name,
+		TeamKey:    n.Team.Key,
+		Priority:   n.Priority,
+		StateName:  n.State.Name,
+		StateType:  n.State.Type,
+	}, nil
+}
+
+// MoveIssue moves an issue to the named workflow state of its own team.
+func (c *Client) MoveIssue(ctx context.Context, identifier, stateName string) (*Issue, error) {
+	return c.moveIssue(ctx, identifier, func(ctx context.Context, teamID string) (string, error) {
+		return c.ResolveStateID(ctx, teamID, stateName)
+	})
+}
+
+// MoveIssueToStateType moves an issue to the first workflow state of its team
+// with the given type (backlog/unstarted/started/completed/canceled).
+func (c *Client) MoveIssueToStateType(ctx context.Context, identifier, stateType string) (*Issue, error) {
+	return c.moveIssue(ctx, identifier, func(ctx context.Context, teamID string) (string, error) {
+		resp, err := teamStatesQuery(ctx, c.graphQLClient(), teamID)
+		if err != nil {
+			return "", fmt.Errorf("resolve %s state: %w", stateType, err)
+		}
+		for _, n := range resp.Team.States.Nodes {
+			if n.Type == stateType && n.Id != "" {
+				return n.Id, nil
+			}
+		}
+
+		return "", fmt.Errorf("resolve %s state: not found on team", stateType)
+	})
+}
+
+func (c *Client) moveIssue(ctx context.Context, identifier string, resolve func(ctx context.Context, teamID string) (string, error)) (*Issue, error) {
+	issue, err := c.GetIssueByIdentifier(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	teamID, err := c.ResolveTeamID(ctx, issue.TeamKey)
+	if err != nil {
+		return nil, err
+	}
+	stateID, err := resolve(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.UpdateIssue(ctx, identifier, &UpdateIssueInput{StateID: stateID, Priority: -1, Estimate: -1})
+}
+
+// AddIssueLabels attaches existing workspace/team labels to an issue by name.
+// Names without a matching label are returned as missing rather than created.
+func (c *Client) AddIssueLabels(ctx context.Context, issueID string, names []string) (missing []string, err error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	resp, err := issueLabelsQuery(ctx, c.graphQLClient(), names)
+	if err != nil {
+		return nil, fmt.Errorf("resolve labels: %w", err)
+	}
+	ids := make(map[string]string, len(resp.IssueLabels.Nodes))
+	for _, n := range resp.IssueLabels.Nodes {
+		if _, ok := ids[n.Name]; !ok {
+			ids[n.Name] = n.Id
+		}
+	}
+
+	for _, name := range names {
+		id, ok := ids[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if err := issueAddLabelMutation(ctx, c.graphQLClient(), issueID, id); err != nil {
+			return missing, fmt.Errorf("add label %q to %s: %w", name, issueID, err)
+		}
+	}
+
+	return missing, nil
+}
+
+// CreateComment adds a Markdown comment to the issue with the given id or identifier.
+func (c *Client) CreateComment(ctx context.Context, issueID, body string) (*Comment, error) {
+	issueID = strings.TrimSpace(issueID)
+	if issueID == "" {
+		return nil, fmt.Errorf("create comment: issueId is required")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("create comment: body is required")
+	}
+
+	resp, err := commentCreateMutation(ctx, c.graphQLClient(), issueID, body)
+	if err != nil {
+		return nil, fmt.Errorf("create comment on %s: %w", issueID, err)
+	}
+	if !resp.CommentCreate.Success {
+		return nil, fmt.Errorf("create comment on %s: mutation reported success=false", issueID)
+	}
+
+	n := resp.CommentCreate.Comment
+	return &Comment{Body: n.Body, UserName: n.User.Name, CreatedAt: n.CreatedAt}, nil
+}
+
+// --- issueUpdate (hand-written, same style as the genqlient output) ---
+
+type issueUpdateResponse struct {
+	IssueUpdate issueUpdatePayload `json:"issueUpdate"`
+}
+
+type issueUpdatePayload struct {
+	Issue   issueCreateIssue `json:"issue"`
+	Success bool             `json:"success"`
+}
+
+type issueUpdateVars struct {
+	Input map[string]any `json:"input"`
+	ID    string         `json:"id"`
+}
+
+func issueUpdateMutation(ctx context.Context, c graphql.Client, id string, input map[string]any) (*issueUpdateResponse, error) {
+	req := &graphql.Request{
+		OpName: "IssueUpdate",
+		Query: `
+mutation IssueUpdate ($id: String!, $input: IssueUpdateInput!) {
+	issueUpdate(id: $id, input: $input) {
+		success
+		issue {
+			id
+			identifier
+			title
+			url
+			priority
+			state {
+				name
+				type
+			}
+			team {
+				name
+				key
+			}
+		}
+	}
+}
+`,
+		Variables: &issueUpdateVars{ID: id, Input: input},
+	}
+
+	data := &issueUpdateResponse{}
+	resp := &graphql.Response{Data: data}
+	err := c.MakeRequest(ctx, req, resp)
+	return data, err
+}
+
+// --- commentCreate ---
+
+type commentCreateResponse struct {
+	CommentCreate commentCreatePayload `json:"commentCreate"`
+}
+
+type commentCreatePayload struct {
+	Comment commentCreateComment `json:"comment"`
+	Success bool                 `json:"success"`
+}
+
+type commentCreateComment struct {
+	User      commentCreateUser `json:"user"`
+	Id        string            `json:"id"`
+	Body      string            `json:"body"`
+	CreatedAt string            `json:"createdAt"`
+}
+
+type commentCreateUser struct {
+	Name string `json:"name"`
+}
+
+type commentCreateVars struct {
+	Input map[string]any `json:"input"`
+}
+
+func commentCreateMutation(ctx context.Context, c graphql.Client, issueID, body string) (*commentCreateResponse, error) {
+	req := &graphql.Request{
+		OpName: "CommentCreate",
+		Query: `
+mutation CommentCreate ($input: CommentCreateInput!) {
+	commentCreate(input: $input) {
+		success
+		comment {
+			id
+			body
+			createdAt
+			user {
+				name
+			}
+		}
+	}
+}
+`,
+		Variables: &commentCreateVars{Input: map[string]any{"issueId": issueID, "body": body}},
+	}
+
+	data := &commentCreateResponse{}
+	resp := &graphql.Response{Data: data}
+	err := c.MakeRequest(ctx, req, resp)
+	return data, err
+}
+
+// --- issue labels ---
+
+type issueLabelsResponse struct {
+	IssueLabels issueLabelsConnection `json:"issueLabels"`
+}
+
+type issueLabelsConnection struct {
+	Nodes []issueLabelNode `json:"nodes"`
+}
+
+type issueLabelNode struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type issueLabelsVars struct {
+	Filter map[string]any `json:"filter"`
+}
+
+func issueLabelsQuery(ctx context.Context, c graphql.Client, names []string) (*issueLabelsResponse, error) {
+	req := &graphql.Request{
+		OpName: "IssueLabels",
+		Query: `
+query IssueLabels ($filter: IssueLabelFilter) {
+	issueLabels(filter: $filter) {
+		nodes {
+			id
+			name
+		}
+	}
+}
+`,
+		Variables: &issueLabelsVars{
+			Filter: map[string]any{"name": map[string]any{"in": names}},
+		},
+	}
+
+	data := &issueLabelsResponse{}
+	resp := &graphql.Response{Data: data}
+	err := c.MakeRequest(ctx, req, resp)
+	return data, err
+}
+
+type issueAddLabelResponse struct {
+	IssueAddLabel struct {
+		Success bool `json:"success"`
+	} `json:"issueAddLabel"`
+}
+
+type issueAddLabelVars struct {
+	ID      string `json:"id"`
+	LabelID string `json:"labelId"`
+}
+
+func issueAddLabelMutation(ctx context.Context, c graphql.Client, issueID, labelID string) error {
+	req := &graphql.Request{
+		OpName: "IssueAddLabel",
+		Query: `
+mutation IssueAddLabel ($id: String!, $labelId: String!) {
+	issueAddLabel(id: $id, labelId: $labelId) {
+		success
+	}
+}
+`,
+		Variables: &issueAddLabelVars{ID: issueID, LabelID: labelID},
+	}
+
+	data := &issueAddLabelResponse{}
+	resp := &graphql.Response{Data: data}
+	if err := c.MakeRequest(ctx, req, resp); err != nil {
+		return err
+	}
+	if !data.IssueAddLabel.Success {
+		return fmt.Errorf("mutation reported success=false")
+	}
+
+	return nil
+}
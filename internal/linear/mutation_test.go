@@ -0,0 +1,193 @@
+package linear
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIssueInputFields(t *testing.T) {
+	in := &UpdateIssueInput{Priority: -1, Estimate: -1}
+	assert.True(t, in.IsEmpty())
+
+	in = &UpdateIssueInput{Title: "t", StateID: "s", Priority: 0, Estimate: 3}
+	assert.Equal(t, map[string]any{"title": "t", "stateId": "s", "priority": 0, "estimate": float64(3)}, in.fields())
+}
+
+func TestUpdateIssue_Validation(t *testing.T) {
+	c := NewClient("key", nil)
+	_, err := c.UpdateIssue(context.Background(), "", &UpdateIssueInput{Title: "x"})
+	require.Error(t, err)
+
+	_, err = c.UpdateIssue(context.Background(), "LUC-1", &UpdateIssueInput{Priority: -1, Estimate: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to update")
+}
+
+func TestUpdateIssue_WithMockServer(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		assert.Equal(t, "IssueUpdate", req.OpName)
+		assert.Equal(t, "LUC-1", req.Variables["id"])
+		assert.Equal(t, map[string]any{"priority": float64(1)}, req.Variables["input"])
+
+		return map[string]any{"issueUpdate": map[string]any{
+			"success": true,
+			"issue": map[string]any{
+				"id": "iss-1", "identifier": "LUC-1", "title": "Task", "priority": 1,
+				"state": map[string]any{"name": "Todo", "type": "unstarted"},
+				"team":  map[string]any{"name": "Luck", "key": "LUC"},
+			},
+		}}
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	issue, err := c.UpdateIssue(context.Background(), "LUC-1", &UpdateIssueInput{Priority: 1, Estimate: -1})
+	require.NoError(t, err)
+	assert.Equal(t, "LUC-1", issue.Identifier)
+	assert.InDelta(t, 1, issue.Priority, 0)
+}
+
+func TestUpdateIssue_MutationFailure(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		return map[string]any{"issueUpdate": map[string]any{"success": false, "issue": map[string]any{}}}
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	_, err := c.UpdateIssue(context.Background(), "LUC-1", &UpdateIssueInput{Title: "x", Priority: -1, Estimate: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "success=false")
+}
+
+func TestMoveIssue_WithMockServer(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "IssueByID":
+			return map[string]any{"issue": map[string]any{
+				"id": "iss-1", "identifier": "LUC-1",
+				"team":     map[string]any{"name": "Luck", "key": "LUC"},
+				"comments": map[string]any{"nodes": []any{}},
+			}}
+		case "TeamsByKey":
+			return map[string]any{"teams": map[string]any{"nodes": []any{map[string]any{"id": "team-1", "key": "LUC"}}}}
+		case "TeamStates":
+			assert.Equal(t, "team-1", req.Variables["id"])
+			return map[string]any{"team": map[string]any{"id": "team-1", "states": map[string]any{"nodes": []any{
+				map[string]any{"id": "s-doing", "name": "In Progress", "type": "started"},
+			}}}}
+		case "IssueUpdate":
+			assert.Equal(t, map[string]any{"stateId": "s-doing"}, req.Variables["input"])
+			return map[string]any{"issueUpdate": map[string]any{"success": true, "issue": map[string]any{
+				"id": "iss-1", "identifier": "LUC-1",
+				"state": map[string]any{"name": "In Progress", "type": "started"},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	issue, err := c.MoveIssue(context.Background(), "LUC-1", "In Progress")
+	require.NoError(t, err)
+	assert.Equal(t, "In Progress", issue.StateName)
+}
+
+func TestCreateComment(t *testing.T) {
+	c := NewClient("key", nil)
+	_, err := c.CreateComment(context.Background(), "LUC-1", "  ")
+	require.Error(t, err)
+
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		assert.Equal(t, map[string]any{"issueId": "LUC-1", "body": "hello"}, req.Variables["input"])
+
+		return map[string]any{"commentCreate": map[string]any{"success": true, "comment": map[string]any{
+			"id": "c-1", "body": "hello", "createdAt": "2026-06-01T00:00:00Z", "user": map[string]any{"name": "Ann"},
+		}}}
+	})
+	defer server.Close()
+
+	c = NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	cm, err := c.CreateComment(context.Background(), "LUC-1", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "Ann", cm.UserName)
+	assert.Equal(t, "hello", cm.Body)
+}
+
+func TestMoveIssueToStateType(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "IssueByID":
+			return map[string]any{"issue": map[string]any{
+				"id": "iss-1", "identifier": "LUC-1",
+				"team":     map[string]any{"name": "Luck", "key": "LUC"},
+				"comments": map[string]any{"nodes": []any{}},
+			}}
+		case "TeamsByKey":
+			return map[string]any{"teams": map[string]any{"nodes": []any{map[string]any{"id": "team-1", "key": "LUC"}}}}
+		case "TeamStates":
+			return map[string]any{"team": map[string]any{"id": "team-1", "states": map[string]any{"nodes": []any{
+				map[string]any{"id": "s-doing", "name": "In Progress", "type": "started"},
+				map[string]any{"id": "s-done", "name": "Done", "type": "completed"},
+				map[string]any{"id": "s-shipped", "name": "Shipped", "type": "completed"},
+			}}}}
+		case "IssueUpdate":
+			assert.Equal(t, map[string]any{"stateId": "s-done"}, req.Variables["input"])
+			return map[string]any{"issueUpdate": map[string]any{"success": true, "issue": map[string]any{
+				"id": "iss-1", "identifier": "LUC-1",
+				"state": map[string]any{"name": "Done", "type": "completed"},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	issue, err := c.MoveIssueToStateType(context.Background(), "LUC-1", "completed")
+	require.NoError(t, err)
+	assert.Equal(t, "Done", issue.StateName)
+
+	_, err = c.MoveIssueToStateType(context.Background(), "LUC-1", "triage")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found on team")
+}
+
+func TestAddIssueLabels(t *testing.T) {
+	var added []string
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "IssueLabels":
+			assert.Equal(t, map[string]any{"name": map[string]any{"in": []any{"bug", "wontfix"}}}, req.Variables["filter"])
+			return map[string]any{"issueLabels": map[string]any{"nodes": []any{
+				map[string]any{"id": "l-bug", "name": "bug"},
+			}}}
+		case "IssueAddLabel":
+			assert.Equal(t, "iss-1", req.Variables["id"])
+			added = append(added, req.Variables["labelId"].(string))
+			return map[string]any{"issueAddLabel": map[string]any{"success": true}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	missing, err := c.AddIssueLabels(context.Background(), "iss-1", []string{"bug", "wontfix"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wontfix"}, missing)
+	assert.Equal(t, []string{"l-bug"}, added)
+
+	missing, err = c.AddIssueLabels(context.Background(), "iss-1", nil)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
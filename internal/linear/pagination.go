@@ -0,0 +1,170 @@
+package linear
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// Pagination defaults. Linear caps `first` at 250; 50 keeps responses small
+// while the item cap stops a runaway filter from walking the whole workspace.
+const (
+	DefaultPageSize = 50
+	DefaultMaxItems = 1000
+
+	// stateHistoryFirst is the history page fetched with each issue by
+	// GetStateChanges; recent transitions almost always fit in it.
+	stateHistoryFirst = 5
+
+	maxPageSize = 250
+)
+
+// ClientOption customizes a Client.
+type ClientOption func(*Client)
+
+// WithPageSize sets the `first:` value used for every paginated connection.
+// Values <= 0 keep DefaultPageSize; values above Linear's limit are clamped.
+func WithPageSize(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.pageSize = min(n, maxPageSize)
+		}
+	}
+}
+
+// WithMaxItems sets the hard safety cap on items collected from one
+// connection (issues, or one issue's comments/history). Values <= 0 keep
+// DefaultMaxItems.
+func WithMaxItems(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.maxItems = n
+		}
+	}
+}
+
+// first returns the configured page size, falling back to DefaultPageSize.
+func (c *Client) first() int {
+	if c.pageSize > 0 {
+		return c.pageSize
+	}
+	return DefaultPageSize
+}
+
+// limit returns the configured item cap, falling back to DefaultMaxItems.
+func (c *Client) limit() int {
+	if c.maxItems > 0 {
+		return c.maxItems
+	}
+	return DefaultMaxItems
+}
+
+// pageInfo is the cursor state of a single connection page.
+type pageInfo struct {
+	endCursor   string
+	hasNextPage bool
+}
+
+// pageFetcher returns one page of items starting after the given cursor.
+// An empty cursor requests the first page.
+type pageFetcher[T any] func(ctx context.Context, after string) ([]T, pageInfo, error)
+
+// collectPages walks a cursor-paginated connection, starting after the given
+// cursor, until hasNextPage is false or maxItems is reached. Hitting the cap is
+// logged so a truncated set is never silent.
+func collectPages[T any](ctx context.Context, label, after string, maxItems int, fetch pageFetcher[T]) ([]T, error) {
+	var all []T
+	for {
+		items, page, err := fetch(ctx, after)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if len(all) >= maxItems {
+			if len(all) > maxItems || page.hasNextPage {
+				slog.Warn("linear pagination cap reached, results truncated",
+					"connection", label, "maxItems", maxItems)
+			}
+			return all[:maxItems], nil
+		}
+		if !page.hasNextPage || page.endCursor == "" || page.endCursor == after {
+			return all, nil
+		}
+		after = page.endCursor
+	}
+}
+
+// fetchRemainingComments pages through an issue's comments after the given
+// cursor, returning at most limit items.
+func (c *Client) fetchRemainingComments(ctx context.Context, issueID, after string, limit int) ([]Comment, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	gql := c.graphQLClient()
+
+	return collectPages(ctx, "comments", after, limit, func(ctx context.Context, cursor string) ([]Comment, pageInfo, error) {
+		resp, err := IssueComments(ctx, gql, issueID, c.first(), cursor)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Issue.Comments
+		comments := make([]Comment, 0, len(conn.Nodes))
+		for _, cm := range conn.Nodes {
+			comments = append(comments, Comment{
+				Body:      cm.Body,
+				UserName:  cm.User.Name,
+				CreatedAt: cm.CreatedAt,
+			})
+		}
+
+		return comments, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: conn.PageInfo.HasNextPage}, nil
+	})
+}
+
+// historyEntry is a single state transition from an issue's history.
+type historyEntry struct {
+	fromState string
+	fromType  string
+	toState   string
+	toType    string
+	createdAt string
+}
+
+// fetchRemainingHistory pages through an issue's history after the given
+// cursor, returning at most limit entries. Linear returns history newest
+// first, so when since (RFC 3339) is set the walk stops at the first page
+// reaching entries created before it.
+func (c *Client) fetchRemainingHistory(ctx context.Context, issueID, after, since string, limit int) ([]historyEntry, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	gql := c.graphQLClient()
+
+	return collectPages(ctx, "history", after, limit, func(ctx context.Context, cursor string) ([]historyEntry, pageInfo, error) {
+		resp, err := IssueHistory(ctx, gql, issueID, c.first(), cursor)
+		if err != nil {
+			return nil, pageInfo{}, err
+		}
+		conn := resp.Issue.History
+		entries := make([]historyEntry, 0, len(conn.Nodes))
+		for _, h := range conn.Nodes {
+			entries = append(entries, historyEntry{
+				fromState: h.FromState.Name,
+				fromType:  h.FromState.Type,
+				toState:   h.ToState.Name,
+				toType:    h.ToState.Type,
+				createdAt: h.CreatedAt,
+			})
+		}
+		hasNext := conn.PageInfo.HasNextPage && !reachedSince(entries, since)
+
+		return entries, pageInfo{endCursor: conn.PageInfo.EndCursor, hasNextPage: hasNext}, nil
+	})
+}
+
+// reachedSince reports whether any entry was created before since; an empty
+// since never is.
+func reachedSince(entries []historyEntry, since string) bool {
+	return since != "" && slices.ContainsFunc(entries, func(h historyEntry) bool { return h.createdAt < since })
+}
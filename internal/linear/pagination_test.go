@@ -0,0 +1,276 @@
+package linear
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLRequest is the decoded body of a genqlient request.
+type graphQLRequest struct {
+	Variables map[string]any `json:"variables"`
+	OpName    string         `json:"operationName"`
+}
+
+func decodeGraphQLRequest(t *testing.T, r *http.Request) graphQLRequest {
+	t.Helper()
+
+	var req graphQLRequest
+	require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+	return req
+}
+
+func TestWithPageSize(t *testing.T) {
+	assert.Equal(t, 20, NewClient("k", nil, WithPageSize(20)).first())
+	assert.Equal(t, DefaultPageSize, NewClient("k", nil, WithPageSize(0)).first())
+	assert.Equal(t, maxPageSize, NewClient("k", nil, WithPageSize(10_000)).first())
+}
+
+func TestWithMaxItems(t *testing.T) {
+	assert.Equal(t, 7, NewClient("k", nil, WithMaxItems(7)).limit())
+	assert.Equal(t, DefaultMaxItems, NewClient("k", nil, WithMaxItems(-1)).limit())
+	assert.Equal(t, DefaultMaxItems, (&Client{}).limit())
+}
+
+func TestCollectPages_FollowsCursor(t *testing.T) {
+	var cursors []string
+	pages := map[string]struct {
+		items []int
+		next  string
+	}{
+		"":   {items: []int{1, 2}, next: "c1"},
+		"c1": {items: []int{3, 4}, next: "c2"},
+		"c2": {items: []int{5}},
+	}
+
+	got, err := collectPages(context.Background(), "test", "", 100, func(_ context.Context, after string) ([]int, pageInfo, error) {
+		cursors = append(cursors, after)
+		p := pages[after]
+		return p.items, pageInfo{endCursor: p.next, hasNextPage: p.next != ""}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, got)
+	assert.Equal(t, []string{"", "c1", "c2"}, cursors)
+}
+
+func TestCollectPages_StopsAtCap(t *testing.T) {
+	calls := 0
+	got, err := collectPages(context.Background(), "test", "", 3, func(_ context.Context, after string) ([]int, pageInfo, error) {
+		calls++
+		return []int{calls*10 + 1, calls*10 + 2}, pageInfo{endCursor: fmt.Sprint(calls), hasNextPage: true}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{11, 12, 21}, got)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCollectPages_StopsOnRepeatedCursor(t *testing.T) {
+	calls := 0
+	got, err := collectPages(context.Background(), "test", "", 100, func(_ context.Context, _ string) ([]int, pageInfo, error) {
+		calls++
+		return []int{calls}, pageInfo{endCursor: "same", hasNextPage: true}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, got)
+}
+
+func TestCollectPages_PropagatesError(t *testing.T) {
+	_, err := collectPages(context.Background(), "test", "", 100, func(_ context.Context, _ string) ([]int, pageInfo, error) {
+		return nil, pageInfo{}, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+}
+
+func TestGetActiveIssues_Paginates(t *testing.T) {
+	var afters []any
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		afters = append(afters, req.Variables["after"])
+		assert.InDelta(t, 2, req.Variables["first"], 0)
+
+		if req.Variables["after"] == nil {
+			resp := assignedIssuesResponse([]issueNode{
+				{Id: "1", Identifier: "ENG-1", Title: "A"},
+				{Id: "2", Identifier: "ENG-2", Title: "B"},
+			})
+			resp["viewer"].(map[string]any)["assignedIssues"].(map[string]any)["pageInfo"] = pageInfoNode{EndCursor: "cur-1", HasNextPage: true}
+			return resp
+		}
+		return assignedIssuesResponse([]issueNode{{Id: "3", Identifier: "ENG-3", Title: "C"}})
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client(), WithPageSize(2))
+	issues, err := c.GetActiveIssues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	assert.Equal(t, "ENG-3", issues[2].Identifier)
+	// First page omits the cursor entirely; the second passes endCursor.
+	assert.Equal(t, []any{nil, "cur-1"}, afters)
+}
+
+func TestGetActiveIssues_RespectsMaxItems(t *testing.T) {
+	calls := 0
+	server := mockLinearServer(t, func(r *http.Request) any {
+		calls++
+		resp := assignedIssuesResponse([]issueNode{
+			{Id: fmt.Sprint(calls), Identifier: fmt.Sprintf("ENG-%d", calls)},
+		})
+		resp["viewer"].(map[string]any)["assignedIssues"].(map[string]any)["pageInfo"] = pageInfoNode{EndCursor: fmt.Sprint(calls), HasNextPage: true}
+		return resp
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client(), WithPageSize(1), WithMaxItems(3))
+	issues, err := c.GetActiveIssues(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, issues, 3)
+	assert.Equal(t, 3, calls)
+}
+
+func TestGetUpdatedIssuesWithDetails_PaginatesComments(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "UpdatedIssuesWithDetails":
+			return updatedIssuesWithDetailsResponse([]detailIssueNode{{
+				Id: "uuid-1", Identifier: "ENG-1", Title: "Busy",
+				Comments: commentConnection{
+					Nodes:    []commentNode{{Body: "c1"}},
+					PageInfo: &pageInfoNode{EndCursor: "cm-1", HasNextPage: true},
+				},
+			}})
+		case "IssueComments":
+			assert.Equal(t, "uuid-1", req.Variables["id"])
+			if req.Variables["after"] == "cm-1" {
+				return map[string]any{"issue": map[string]any{"comments": commentConnection{
+					Nodes:    []commentNode{{Body: "c2"}},
+					PageInfo: &pageInfoNode{EndCursor: "cm-2", HasNextPage: true},
+				}}}
+			}
+			assert.Equal(t, "cm-2", req.Variables["after"])
+			return map[string]any{"issue": map[string]any{"comments": commentConnection{
+				Nodes: []commentNode{{Body: "c3", User: userNode{Name: "Ann"}}},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	details, err := c.GetUpdatedIssuesWithDetails(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Len(t, details, 1)
+	require.Len(t, details[0].Comments, 3)
+	assert.Equal(t, "c3", details[0].Comments[2].Body)
+	assert.Equal(t, "Ann", details[0].Comments[2].UserName)
+}
+
+func TestGetStateChanges_PaginatesHistory(t *testing.T) {
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "StateChanges":
+			return map[string]any{"viewer": map[string]any{"assignedIssues": map[string]any{
+				"nodes": []any{map[string]any{
+					"id": "uuid-1", "identifier": "ENG-1", "title": "Task",
+					"team": map[string]any{"name": "Eng", "key": "ENG"},
+					"history": map[string]any{
+						"nodes": []any{map[string]any{
+							"fromState": map[string]any{"name": "In Progress"},
+							"toState":   map[string]any{"name": "Done"},
+							"createdAt": "2024-06-02T10:00:00Z",
+						}},
+						"pageInfo": pageInfoNode{EndCursor: "h-1", HasNextPage: true},
+					},
+				}},
+			}}}
+		case "IssueHistory":
+			assert.Equal(t, "h-1", req.Variables["after"])
+			return map[string]any{"issue": map[string]any{"history": map[string]any{
+				"nodes": []any{map[string]any{
+					"fromState": map[string]any{"name": "Todo"},
+					"toState":   map[string]any{"name": "In Progress"},
+					"createdAt": "2024-06-01T10:00:00Z",
+				}},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	since, _ := time.Parse(time.RFC3339, "2024-06-01T00:00:00Z")
+	changes, err := c.GetStateChanges(context.Background(), since)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "Done", changes[0].ToState)
+	assert.Equal(t, "Todo", changes[1].FromState)
+}
+
+func TestGetStateChanges_StopsHistoryAtSince(t *testing.T) {
+	historyCalls := 0
+	server := mockLinearServer(t, func(r *http.Request) any {
+		req := decodeGraphQLRequest(t, r)
+		switch req.OpName {
+		case "StateChanges":
+			assert.EqualValues(t, stateHistoryFirst, req.Variables["historyFirst"])
+			return map[string]any{"viewer": map[string]any{"assignedIssues": map[string]any{
+				"nodes": []any{
+					map[string]any{
+						"id": "uuid-1", "identifier": "ENG-1", "title": "Old history",
+						"history": map[string]any{
+							"nodes": []any{
+								map[string]any{"fromState": map[string]any{"name": "Todo"}, "toState": map[string]any{"name": "Done"}, "createdAt": "2024-06-02T10:00:00Z"},
+								map[string]any{"fromState": map[string]any{"name": "Backlog"}, "toState": map[string]any{"name": "Todo"}, "createdAt": "2024-05-01T10:00:00Z"},
+							},
+							"pageInfo": pageInfoNode{EndCursor: "h-old", HasNextPage: true},
+						},
+					},
+					map[string]any{
+						"id": "uuid-2", "identifier": "ENG-2", "title": "Busy",
+						"history": map[string]any{
+							"nodes": []any{
+								map[string]any{"fromState": map[string]any{"name": "Todo"}, "toState": map[string]any{"name": "Done"}, "createdAt": "2024-06-03T10:00:00Z"},
+							},
+							"pageInfo": pageInfoNode{EndCursor: "h-2", HasNextPage: true},
+						},
+					},
+				},
+			}}}
+		case "IssueHistory":
+			historyCalls++
+			assert.Equal(t, "uuid-2", req.Variables["id"])
+			assert.Equal(t, "h-2", req.Variables["after"])
+			return map[string]any{"issue": map[string]any{"history": map[string]any{
+				"nodes": []any{
+					map[string]any{"fromState": map[string]any{"name": "Backlog"}, "toState": map[string]any{"name": "Todo"}, "createdAt": "2024-06-01T10:00:00Z"},
+					map[string]any{"fromState": map[string]any{"name": "Triage"}, "toState": map[string]any{"name": "Backlog"}, "createdAt": "2024-04-01T10:00:00Z"},
+				},
+				"pageInfo": pageInfoNode{EndCursor: "h-3", HasNextPage: true},
+			}}}
+		}
+		t.Fatalf("unexpected operation %q", req.OpName)
+		return nil
+	})
+	defer server.Close()
+
+	c := NewClientWithHTTP("test-key", nil, server.URL, server.Client())
+	since, _ := time.Parse(time.RFC3339, "2024-06-01T00:00:00Z")
+	changes, err := c.GetStateChanges(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, 1, historyCalls, "history is only paged while it is newer than since")
+	require.Len(t, changes, 3)
+	assert.Equal(t, "ENG-1", changes[0].IssueIdentifier)
+	assert.Equal(t, "Backlog", changes[2].FromState)
+}
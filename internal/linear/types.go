@@ -30,6 +30,7 @@ type StateChange struct {
 
 // IssueDetail carries full issue data (description + comments) for AI review.
 type IssueDetail struct {
+	ID               string
 	Identifier       string
 	Title            string
 	Description      string
@@ -41,8 +42,39 @@ type IssueDetail struct {
 	CompletedAt      string
 	UpdatedAt        string
 	ParentIdentifier string
+	Labels           []string
 	Comments         []Comment
 	Priority         float64
+	Estimate         float64
+}
+
+// IssueActivity is an issue together with its full workflow history,
+// used for period (weekly/monthly) reports.
+type IssueActivity struct {
+	Identifier  string
+	Title       string
+	StateName   string
+	StateType   string
+	TeamName    string
+	TeamKey     string
+	URL         string
+	CreatedAt   string
+	UpdatedAt   string
+	CompletedAt string
+	Labels      []string
+	History     []StateTransition
+	Priority    float64
+	Estimate    float64
+}
+
+// StateTransition is a single workflow state change from an issue's history.
+// Types are Linear state types (backlog/unstarted/started/completed/canceled).
+type StateTransition struct {
+	FromState string
+	FromType  string
+	ToState   string
+	ToType    string
+	CreatedAt string
 }
 
 // Comment is a comment on a Linear issue.
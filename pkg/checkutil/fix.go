@@ -0,0 +1,70 @@
+package checkutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xbpk3t/docs-alfred/pkg/fileutil"
+	"github.com/xbpk3t/docs-alfred/pkg/textutil"
+	"github.com/xbpk3t/docs-alfred/pkg/yamlutil"
+)
+
+// Fix is one mechanical correction made (or proposed, in a dry run) by a
+// check --fix.
+type Fix struct {
+	File  string `json:"file"`
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Line  int    `json:"line"`
+}
+
+// FixResult is the outcome of a check --fix run.
+type FixResult struct {
+	// Diff is the unified diff of every changed file.
+	Diff   string `json:"diff,omitempty"`
+	Fixes  []Fix  `json:"fixes"`
+	DryRun bool   `json:"dryRun"`
+}
+
+// ApplyFileEdits applies edits to file, whose content was src, and returns
+// the unified diff. Unless dryRun, the file is rewritten atomically.
+func ApplyFileEdits(file string, src []byte, edits []yamlutil.Edit, dryRun bool) (string, error) {
+	if len(edits) == 0 {
+		return "", nil
+	}
+	fixed, err := yamlutil.ApplyEdits(src, edits)
+	if err != nil {
+		return "", fmt.Errorf("fix %s: %w", file, err)
+	}
+	diff := textutil.UnifiedDiff(file, string(src), string(fixed))
+	if dryRun || diff == "" {
+		return diff, nil
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", fmt.Errorf("stat %s: %w", file, err)
+	}
+	if err := fileutil.AtomicWriteFile(file, fixed, info.Mode().Perm()); err != nil {
+		return "", fmt.Errorf("write %s: %w", file, err)
+	}
+
+	return diff, nil
+}
+
+// ReportFixes returns one line per fix, prefixed with "would fix" in a dry
+// run.
+func ReportFixes(result *FixResult) string {
+	verb := "fixed"
+	if result.DryRun {
+		verb = "would fix"
+	}
+	var b strings.Builder
+	for _, f := range result.Fixes {
+		fmt.Fprintf(&b, "%s %s:%d %s: %s -> %s\n", verb, f.File, f.Line, f.Field, f.From, f.To)
+	}
+
+	return b.String()
+}
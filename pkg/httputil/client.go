@@ -4,6 +4,7 @@ package httputil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,11 +24,17 @@ const DefaultBaseDelay = 1 * time.Second
 // DefaultMaxDelay is the maximum delay for exponential backoff.
 const DefaultMaxDelay = 30 * time.Second
 
+// ErrBodyTooLarge is returned when a response body exceeds
+// RequestOptions.MaxBodySize.
+var ErrBodyTooLarge = resty.ErrResponseBodyTooLarge
+
 // RequestOptions configures helper HTTP requests.
 type RequestOptions struct {
 	Headers    map[string]string
 	Timeout    time.Duration
 	MaxRetries int
+	// MaxBodySize caps the response body in bytes; 0 means no cap.
+	MaxBodySize int
 }
 
 // NewRestyClient creates a resty client with retry and backoff configured.
@@ -46,7 +53,8 @@ func NewRestyClient(timeout time.Duration, maxRetries int) *resty.Client {
 		SetRetryMaxWaitTime(DefaultMaxDelay).
 		AddRetryCondition(func(r *resty.Response, err error) bool {
 			if err != nil {
-				return true
+				// An oversized body will not shrink on a retry.
+				return !errors.Is(err, ErrBodyTooLarge)
 			}
 			// Retry on 5xx
 			return r.StatusCode() >= 500
@@ -60,6 +68,9 @@ func GetBytes(ctx context.Context, url string, opts RequestOptions) ([]byte, err
 	for k, v := range opts.Headers {
 		req.SetHeader(k, v)
 	}
+	if opts.MaxBodySize > 0 {
+		req.SetResponseBodyLimit(opts.MaxBodySize)
+	}
 
 	resp, err := req.Get(url)
 	if err != nil {
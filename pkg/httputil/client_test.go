@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -40,6 +42,24 @@ func TestGetBytesHTTPError(t *testing.T) {
 	assert.Contains(t, err.Error(), "bad")
 }
 
+func TestGetBytesRejectsBodyOverMaxSize(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(strings.Repeat("x", 2048)))
+	}))
+	defer server.Close()
+
+	_, err := GetBytes(context.Background(), server.URL, RequestOptions{MaxBodySize: 1024})
+
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	assert.Equal(t, int32(1), calls.Load(), "an oversized body is not retried")
+
+	body, err := GetBytes(context.Background(), server.URL, RequestOptions{MaxBodySize: 2048})
+	require.NoError(t, err)
+	assert.Len(t, body, 2048)
+}
+
 func TestPostJSONWithResult(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var payload map[string]string
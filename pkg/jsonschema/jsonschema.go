@@ -0,0 +1,86 @@
+// Package jsonschema builds JSON Schema (draft-07) documents, the dialect
+// yaml-language-server understands best.
+package jsonschema
+
+// Draft07 is the $schema URI of the documents built here.
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema node. Only the keywords the data rules need are
+// modelled.
+type Schema struct {
+	// AdditionalProperties is nil (allowed), false or a *Schema.
+	AdditionalProperties any                 `json:"additionalProperties,omitempty"`
+	Properties           map[string]*Schema  `json:"properties,omitempty"`
+	Definitions          map[string]*Schema  `json:"definitions,omitempty"`
+	Dependencies         map[string][]string `json:"dependencies,omitempty"`
+	Items                *Schema             `json:"items,omitempty"`
+	Not                  *Schema             `json:"not,omitempty"`
+	If                   *Schema             `json:"if,omitempty"`
+	Then                 *Schema             `json:"then,omitempty"`
+	Minimum              *float64            `json:"minimum,omitempty"`
+	Maximum              *float64            `json:"maximum,omitempty"`
+	MinItems             *int                `json:"minItems,omitempty"`
+	MaxItems             *int                `json:"maxItems,omitempty"`
+	// Type is a single type name or a list of them.
+	Type        any       `json:"type,omitempty"`
+	Schema      string    `json:"$schema,omitempty"`
+	Ref         string    `json:"$ref,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Pattern     string    `json:"pattern,omitempty"`
+	Required    []string  `json:"required,omitempty"`
+	Enum        []string  `json:"enum,omitempty"`
+	AnyOf       []*Schema `json:"anyOf,omitempty"`
+}
+
+// String returns a string schema.
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+// Pattern returns a string schema matching the regular expression pattern.
+func Pattern(pattern string) *Schema {
+	return &Schema{Type: "string", Pattern: pattern}
+}
+
+// Enum returns a string schema accepting only values.
+func Enum(values ...string) *Schema {
+	return &Schema{Type: "string", Enum: values}
+}
+
+// IntegerRange returns an integer schema between minimum and maximum.
+func IntegerRange(minimum, maximum float64) *Schema {
+	return &Schema{Type: "integer", Minimum: &minimum, Maximum: &maximum}
+}
+
+// Array returns an array schema of items (any item when nil).
+func Array(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// Object returns an object schema with the given properties.
+func Object(properties map[string]*Schema, required ...string) *Schema {
+	return &Schema{Type: "object", Properties: properties, Required: required}
+}
+
+// Ref returns a reference to a definition of the root document.
+func Ref(definition string) *Schema {
+	return &Schema{Ref: "#/definitions/" + definition}
+}
+
+// Never returns a schema no value satisfies, for forbidden properties.
+func Never() *Schema {
+	return &Schema{Not: &Schema{}}
+}
+
+// Any returns a schema every value satisfies.
+func Any() *Schema {
+	return &Schema{}
+}
+
+// Describe sets the description of s and returns it.
+func (s *Schema) Describe(description string) *Schema {
+	s.Description = description
+
+	return s
+}
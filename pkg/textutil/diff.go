@@ -0,0 +1,100 @@
+package textutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk.
+const diffContext = 3
+
+type diffLine struct {
+	text string
+	op   diffmatchpatch.Operation
+}
+
+// UnifiedDiff returns a unified diff of oldText and newText labelled with
+// name, or "" when they are equal.
+func UnifiedDiff(name, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	dmp := diffmatchpatch.New()
+	a, b, lineArray := dmp.DiffLinesToChars(oldText, newText)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lineArray)
+
+	var lines []diffLine
+	for _, d := range diffs {
+		for _, text := range strings.SplitAfter(d.Text, "\n") {
+			if text != "" {
+				lines = append(lines, diffLine{op: d.Type, text: strings.TrimSuffix(text, "\n")})
+			}
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", name, name)
+	for start := 0; start < len(lines); {
+		first := nextChange(lines, start)
+		if first < 0 {
+			break
+		}
+		last := first
+		for next := nextChange(lines, last+1); next >= 0 && next-last <= 2*diffContext; next = nextChange(lines, last+1) {
+			last = next
+		}
+		from, to := max(first-diffContext, 0), min(last+diffContext+1, len(lines))
+		writeHunk(&out, lines, from, to)
+		start = to
+	}
+
+	return out.String()
+}
+
+func nextChange(lines []diffLine, from int) int {
+	for i := from; i < len(lines); i++ {
+		if lines[i].op != diffmatchpatch.DiffEqual {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func writeHunk(b *strings.Builder, lines []diffLine, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, l := range lines[:from] {
+		if l.op != diffmatchpatch.DiffInsert {
+			oldStart++
+		}
+		if l.op != diffmatchpatch.DiffDelete {
+			newStart++
+		}
+	}
+	var oldCount, newCount int
+	var body strings.Builder
+	for _, l := range lines[from:to] {
+		switch l.op {
+		case diffmatchpatch.DiffEqual:
+			oldCount++
+			newCount++
+			body.WriteString(" " + l.text + "\n")
+		case diffmatchpatch.DiffDelete:
+			oldCount++
+			body.WriteString("-" + l.text + "\n")
+		case diffmatchpatch.DiffInsert:
+			newCount++
+			body.WriteString("+" + l.text + "\n")
+		}
+	}
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n%s", oldStart, oldCount, newStart, newCount, body.String())
+}
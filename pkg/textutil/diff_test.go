@@ -0,0 +1,46 @@
+package textutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Empty(t, UnifiedDiff("a.yml", "same\n", "same\n"))
+
+	var oldLines, newLines []string
+	for i := range 20 {
+		line := string(rune('a' + i))
+		oldLines = append(oldLines, line)
+		switch i {
+		case 1:
+			newLines = append(newLines, "B")
+		case 15:
+			newLines = append(newLines, line, "inserted")
+		default:
+			newLines = append(newLines, line)
+		}
+	}
+	diff := UnifiedDiff("a.yml", strings.Join(oldLines, "\n")+"\n", strings.Join(newLines, "\n")+"\n")
+
+	assert.Equal(t, `--- a.yml
++++ a.yml
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -14,6 +14,7 @@
+ n
+ o
+ p
++inserted
+ q
+ r
+ s
+`, diff)
+}
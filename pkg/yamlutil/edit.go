@@ -0,0 +1,117 @@
+package yamlutil
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+)
+
+// Edit replaces the source text Old of a token with New. Line and Column are
+// the token's 1-based position as reported by the parser.
+type Edit struct {
+	Old    string
+	New    string
+	Line   int
+	Column int
+}
+
+// ReplaceEdit returns an Edit replacing the source text of a single-line
+// scalar or mapping key, quotes included, with replacement.
+func ReplaceEdit(n ast.Node, replacement string) (Edit, bool) {
+	if n == nil {
+		return Edit{}, false
+	}
+	tk := n.GetToken()
+	if tk == nil || tk.Position == nil {
+		return Edit{}, false
+	}
+	old := strings.TrimSpace(tk.Origin)
+	if old == "" {
+		old = tk.Value
+	}
+	if old == "" || strings.Contains(old, "\n") {
+		return Edit{}, false
+	}
+
+	return Edit{Line: tk.Position.Line, Column: tk.Position.Column, Old: old, New: replacement}, true
+}
+
+// QuoteLike renders s in the quoting style of the scalar n: double, single
+// or plain.
+func QuoteLike(n *ast.StringNode, s string) string {
+	switch n.GetToken().Type {
+	case token.DoubleQuoteType:
+		return strconv.Quote(s)
+	case token.SingleQuoteType:
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	}
+
+	return s
+}
+
+// IsQuoted reports whether the scalar n is single- or double-quoted.
+func IsQuoted(n *ast.StringNode) bool {
+	typ := n.GetToken().Type
+
+	return typ == token.DoubleQuoteType || typ == token.SingleQuoteType
+}
+
+// ApplyEdits applies edits to src, leaving every other byte untouched. Each
+// Old must still be found at its position; columns count runes, with a byte
+// offset fallback.
+func ApplyEdits(src []byte, edits []Edit) ([]byte, error) {
+	lines := strings.Split(string(src), "\n")
+	edits = slices.Clone(edits)
+	// Right to left, so earlier columns on the same line stay valid.
+	slices.SortFunc(edits, func(a, b Edit) int {
+		if a.Line != b.Line {
+			return b.Line - a.Line
+		}
+
+		return b.Column - a.Column
+	})
+	for _, e := range edits {
+		if e.Line < 1 || e.Line > len(lines) {
+			return nil, fmt.Errorf("line %d out of range", e.Line)
+		}
+		line := lines[e.Line-1]
+		start, ok := editStart(line, e)
+		if !ok {
+			return nil, fmt.Errorf("line %d: %q not found at column %d", e.Line, e.Old, e.Column)
+		}
+		lines[e.Line-1] = line[:start] + e.New + line[start+len(e.Old):]
+	}
+
+	return []byte(strings.Join(lines, "\n")), nil
+}
+
+func editStart(line string, e Edit) (int, bool) {
+	col := e.Column - 1
+	if col < 0 {
+		return 0, false
+	}
+	runeStart, runes := -1, 0
+	for i := range line {
+		if runes == col {
+			runeStart = i
+
+			break
+		}
+		runes++
+	}
+	if runeStart < 0 && utf8.RuneCountInString(line) == col {
+		runeStart = len(line)
+	}
+	for _, start := range []int{runeStart, col} {
+		if start >= 0 && start <= len(line) && strings.HasPrefix(line[start:], e.Old) {
+			return start, true
+		}
+	}
+
+	return 0, false
+}
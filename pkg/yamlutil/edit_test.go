@@ -0,0 +1,50 @@
+package yamlutil
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceEditAndApplyEdits(t *testing.T) {
+	src := "# 注释\n- {name: 万历, score: 7} # x\n- name: 'a '\n  readAt: 2021/3/4\n"
+	file, err := parser.ParseBytes([]byte(src), parser.ParseComments)
+	require.NoError(t, err)
+	seq, ok := Sequence(file.Docs[0].Body)
+	require.True(t, ok)
+	flow, ok := Mapping(seq.Values[0])
+	require.True(t, ok)
+	block, ok := Mapping(seq.Values[1])
+	require.True(t, ok)
+
+	var edits []Edit
+	for _, pair := range []struct {
+		node ast.Node
+		repl string
+	}{
+		{MappingValue(flow, "score"), "5"},
+		{MappingValue(block, "readAt"), "2021-03-04"},
+		{block.Values[1].Key, "date"},
+	} {
+		edit, ok := ReplaceEdit(pair.node, pair.repl)
+		require.True(t, ok)
+		edits = append(edits, edit)
+	}
+	name, ok := MappingValue(block, "name").(*ast.StringNode)
+	require.True(t, ok)
+	assert.True(t, IsQuoted(name))
+	edit, ok := ReplaceEdit(name, QuoteLike(name, "a"))
+	require.True(t, ok)
+	assert.Equal(t, "'a '", edit.Old)
+	edits = append(edits, edit)
+
+	got, err := ApplyEdits([]byte(src), edits)
+	require.NoError(t, err)
+	assert.Equal(t, "# 注释\n- {name: 万历, score: 5} # x\n- name: 'a'\n  date: 2021-03-04\n", string(got))
+
+	_, err = ApplyEdits([]byte(src), []Edit{{Line: 2, Column: 3, Old: "nope", New: "x"}})
+	require.Error(t, err)
+}